@@ -45,6 +45,10 @@ type Config struct {
 
 	// the event sink
 	EventRecorder record.EventRecorder
+
+	// MemoryBudgetBytes is the soft memory budget for the process; 0 disables
+	// the memory budget manager.
+	MemoryBudgetBytes int64
 }
 
 type completedConfig struct {
@@ -52,6 +52,7 @@ import (
 	cloudprovider "k8s.io/cloud-provider"
 	cliflag "k8s.io/component-base/cli/flag"
 	"k8s.io/component-base/cli/globalflag"
+	"k8s.io/component-base/configstatus"
 	"k8s.io/component-base/configz"
 	"k8s.io/component-base/logs"
 	"k8s.io/component-base/term"
@@ -69,6 +70,7 @@ import (
 	"k8s.io/kubernetes/cmd/kube-controller-manager/app/config"
 	"k8s.io/kubernetes/cmd/kube-controller-manager/app/options"
 	kubectrlmgrconfig "k8s.io/kubernetes/pkg/controller/apis/config"
+	"k8s.io/kubernetes/pkg/controller/memorybudget"
 	serviceaccountcontroller "k8s.io/kubernetes/pkg/controller/serviceaccount"
 	"k8s.io/kubernetes/pkg/serviceaccount"
 )
@@ -210,6 +212,13 @@ func Run(c *config.CompletedConfig, stopCh <-chan struct{}) error {
 
 	clientBuilder, rootClientBuilder := createClientBuilders(c)
 
+	// Publish the effective configuration for drift detection.
+	if client, err := rootClientBuilder.Client("kube-controller-manager-config-status"); err != nil {
+		klog.Errorf("unable to create client for configuration status: %v", err)
+	} else if err := configstatus.Publish(context.TODO(), client, "kube-controller-manager", c.ComponentConfig); err != nil {
+		klog.Errorf("unable to publish configuration status: %v", err)
+	}
+
 	saTokenControllerInitFunc := serviceAccountTokenControllerStarter{rootClientBuilder: rootClientBuilder}.startServiceAccountTokenController
 
 	run := func(ctx context.Context, startSATokenController InitFunc, initializersFunc ControllerInitializersFunc) {
@@ -328,6 +337,11 @@ type ControllerContext struct {
 	// would become GenericInformerFactory and take a dynamic client.
 	ObjectOrMetadataInformerFactory informerfactory.InformerFactory
 
+	// MetadataInformerFactory gives access to metadata-only informers for
+	// controllers that do not need full objects, even for resources that have
+	// a typed informer.
+	MetadataInformerFactory metadatainformer.SharedInformerFactory
+
 	// ComponentConfig provides access to init options for a given controller
 	ComponentConfig kubectrlmgrconfig.KubeControllerManagerConfiguration
 
@@ -356,6 +370,10 @@ type ControllerContext struct {
 	// multiple controllers don't get into lock-step and all hammer the apiserver
 	// with list requests simultaneously.
 	ResyncPeriod func() time.Duration
+
+	// MemoryBudget is the memory budget manager that optional controllers can
+	// register shedders with, or nil if no memory budget is configured.
+	MemoryBudget *memorybudget.Manager
 }
 
 // IsControllerEnabled checks if the context's controllers enabled or not
@@ -396,7 +414,11 @@ func KnownControllers() []string {
 // ControllersDisabledByDefault is the set of controllers which is disabled by default
 var ControllersDisabledByDefault = sets.NewString(
 	"bootstrapsigner",
+	"namespacetemplate",
+	"runtimeclass-overhead",
+	"subnamespace",
 	"tokencleaner",
+	"volume-autoexpand",
 )
 
 const (
@@ -414,6 +436,8 @@ func NewControllerInitializers(loopMode ControllerLoopMode) map[string]InitFunc
 	controllers["podgc"] = startPodGCController
 	controllers["resourcequota"] = startResourceQuotaController
 	controllers["namespace"] = startNamespaceController
+	controllers["namespacetemplate"] = startNamespaceTemplateController
+	controllers["subnamespace"] = startSubnamespaceController
 	controllers["serviceaccount"] = startServiceAccountController
 	controllers["garbagecollector"] = startGarbageCollectorController
 	controllers["daemonset"] = startDaemonSetController
@@ -432,6 +456,7 @@ func NewControllerInitializers(loopMode ControllerLoopMode) map[string]InitFunc
 	controllers["tokencleaner"] = startTokenCleanerController
 	controllers["nodeipam"] = startNodeIpamController
 	controllers["nodelifecycle"] = startNodeLifecycleController
+	controllers["nodeoutofservice"] = startNodeOutOfServiceController
 	if loopMode == IncludeCloudLoops {
 		controllers["service"] = startServiceController
 		controllers["route"] = startRouteController
@@ -441,9 +466,12 @@ func NewControllerInitializers(loopMode ControllerLoopMode) map[string]InitFunc
 	controllers["persistentvolume-binder"] = startPersistentVolumeBinderController
 	controllers["attachdetach"] = startAttachDetachController
 	controllers["persistentvolume-expander"] = startVolumeExpandController
+	controllers["volume-autoexpand"] = startVolumeAutoExpandController
+	controllers["runtimeclass-overhead"] = startRuntimeClassOverheadController
 	controllers["clusterrole-aggregation"] = startClusterRoleAggregrationController
 	controllers["pvc-protection"] = startPVCProtectionController
 	controllers["pv-protection"] = startPVProtectionController
+	controllers["pvc-health"] = startPVCHealthController
 	controllers["ttl-after-finished"] = startTTLAfterFinishedController
 	controllers["root-ca-cert-publisher"] = startRootCACertPublisher
 	controllers["ephemeral-volume"] = startEphemeralVolumeController
@@ -519,10 +547,17 @@ func CreateControllerContext(s *config.CompletedConfig, rootClientBuilder, clien
 		return ControllerContext{}, err
 	}
 
+	var memoryBudget *memorybudget.Manager
+	if s.MemoryBudgetBytes > 0 {
+		memoryBudget = memorybudget.NewManager(uint64(s.MemoryBudgetBytes))
+		go memoryBudget.Run(stop)
+	}
+
 	ctx := ControllerContext{
 		ClientBuilder:                   clientBuilder,
 		InformerFactory:                 sharedInformers,
 		ObjectOrMetadataInformerFactory: informerfactory.NewInformerFactory(sharedInformers, metadataInformers),
+		MetadataInformerFactory:         metadataInformers,
 		ComponentConfig:                 s.ComponentConfig,
 		RESTMapper:                      restMapper,
 		AvailableResources:              availableResources,
@@ -530,6 +565,7 @@ func CreateControllerContext(s *config.CompletedConfig, rootClientBuilder, clien
 		LoopMode:                        loopMode,
 		InformersStarted:                make(chan struct{}),
 		ResyncPeriod:                    ResyncPeriod(s),
+		MemoryBudget:                    memoryBudget,
 	}
 	return ctx, nil
 }
@@ -683,6 +719,9 @@ func createClientBuilders(c *config.CompletedConfig) (clientBuilder clientbuilde
 			c.Client.CoreV1(),
 			metav1.NamespaceSystem)
 	} else {
+		if c.ComponentConfig.KubeCloudShared.EnforceServiceAccountCredentials {
+			klog.Fatalf("--enforce-service-account-credentials requires --use-service-account-credentials, refusing to run controllers with the shared kube-controller-manager credential")
+		}
 		clientBuilder = rootClientBuilder
 	}
 	return
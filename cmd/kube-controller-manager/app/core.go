@@ -25,6 +25,7 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"os"
 	"strings"
 	"time"
 
@@ -34,6 +35,8 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apiserver/pkg/quota/v1/generic"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	"k8s.io/apiserver/pkg/util/webhook"
+	"k8s.io/client-go/dynamic"
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/metadata"
 	restclient "k8s.io/client-go/rest"
@@ -47,24 +50,32 @@ import (
 	pkgcontroller "k8s.io/kubernetes/pkg/controller"
 	endpointcontroller "k8s.io/kubernetes/pkg/controller/endpoint"
 	"k8s.io/kubernetes/pkg/controller/garbagecollector"
+	"k8s.io/kubernetes/pkg/controller/memorybudget"
 	namespacecontroller "k8s.io/kubernetes/pkg/controller/namespace"
+	namespacetemplatecontroller "k8s.io/kubernetes/pkg/controller/namespacetemplate"
 	nodeipamcontroller "k8s.io/kubernetes/pkg/controller/nodeipam"
 	nodeipamconfig "k8s.io/kubernetes/pkg/controller/nodeipam/config"
 	"k8s.io/kubernetes/pkg/controller/nodeipam/ipam"
 	lifecyclecontroller "k8s.io/kubernetes/pkg/controller/nodelifecycle"
+	"k8s.io/kubernetes/pkg/controller/nodeoutofservice"
 	"k8s.io/kubernetes/pkg/controller/podgc"
 	replicationcontroller "k8s.io/kubernetes/pkg/controller/replication"
 	resourcequotacontroller "k8s.io/kubernetes/pkg/controller/resourcequota"
+	"k8s.io/kubernetes/pkg/controller/runtimeclass/overhead"
 	serviceaccountcontroller "k8s.io/kubernetes/pkg/controller/serviceaccount"
 	"k8s.io/kubernetes/pkg/controller/storageversiongc"
+	subnamespacecontroller "k8s.io/kubernetes/pkg/controller/subnamespace"
 	ttlcontroller "k8s.io/kubernetes/pkg/controller/ttl"
 	"k8s.io/kubernetes/pkg/controller/ttlafterfinished"
 	"k8s.io/kubernetes/pkg/controller/volume/attachdetach"
+	"k8s.io/kubernetes/pkg/controller/volume/autoexpand"
 	"k8s.io/kubernetes/pkg/controller/volume/ephemeral"
 	"k8s.io/kubernetes/pkg/controller/volume/expand"
 	persistentvolumecontroller "k8s.io/kubernetes/pkg/controller/volume/persistentvolume"
+	"k8s.io/kubernetes/pkg/controller/volume/pvchealth"
 	"k8s.io/kubernetes/pkg/controller/volume/pvcprotection"
 	"k8s.io/kubernetes/pkg/controller/volume/pvprotection"
+	"k8s.io/kubernetes/pkg/features"
 	quotainstall "k8s.io/kubernetes/pkg/quota/v1/install"
 	"k8s.io/kubernetes/pkg/volume/csimigration"
 	netutils "k8s.io/utils/net"
@@ -197,6 +208,30 @@ func startNodeLifecycleController(ctx context.Context, controllerContext Control
 	return nil, true, nil
 }
 
+func startNodeOutOfServiceController(ctx context.Context, controllerContext ControllerContext) (controller.Interface, bool, error) {
+	webhookURL := controllerContext.ComponentConfig.NodeLifecycleController.NodeOutOfServiceFencingWebhookURL
+	if len(webhookURL) == 0 {
+		// The controller must never taint nodes without a fencing check, so
+		// it stays disabled until a webhook is configured.
+		return nil, false, nil
+	}
+	outOfServiceController, err := nodeoutofservice.NewController(
+		controllerContext.InformerFactory.Core().V1().Nodes(),
+		// the out-of-service taint controller uses the existing cluster role from node-controller
+		controllerContext.ClientBuilder.ClientOrDie("node-controller"),
+		controllerContext.ComponentConfig.NodeLifecycleController.NodeOutOfServiceNotReadyThreshold.Duration,
+		nodeoutofservice.NewWebhookFenceCheck(
+			webhookURL,
+			controllerContext.ComponentConfig.NodeLifecycleController.NodeOutOfServiceFencingTimeout.Duration,
+		),
+	)
+	if err != nil {
+		return nil, true, err
+	}
+	go outOfServiceController.Run(ctx, 1)
+	return nil, true, nil
+}
+
 func startCloudNodeLifecycleController(ctx context.Context, controllerContext ControllerContext) (controller.Interface, bool, error) {
 	cloudNodeLifecycleController, err := cloudnodelifecyclecontroller.NewCloudNodeLifecycleController(
 		controllerContext.InformerFactory.Core().V1().Nodes(),
@@ -267,9 +302,44 @@ func startPersistentVolumeBinderController(ctx context.Context, controllerContex
 	if err != nil {
 		return nil, true, err
 	}
+	wipeJobTemplate, err := AttemptToLoadWipeJobTemplate(controllerContext.ComponentConfig.PersistentVolumeBinderController.PVWipeJobTemplateFile)
+	if err != nil {
+		return nil, true, fmt.Errorf("failed to load wipe job template when starting persistentvolume controller: %v", err)
+	}
+	var bindApproval *persistentvolumecontroller.BindApprovalConfig
+	if configFile := controllerContext.ComponentConfig.PersistentVolumeBinderController.PVBindApprovalWebhookConfigFile; configFile != "" {
+		approve, err := persistentvolumecontroller.NewWebhookBindApproval(configFile, webhook.DefaultRetryBackoffWithInitialDelay(500*time.Millisecond))
+		if err != nil {
+			return nil, true, fmt.Errorf("failed to create bind approval webhook when starting persistentvolume controller: %v", err)
+		}
+		bindApproval = &persistentvolumecontroller.BindApprovalConfig{
+			Approve:  approve,
+			FailOpen: controllerContext.ComponentConfig.PersistentVolumeBinderController.PVBindApprovalFailOpen,
+			CacheTTL: controllerContext.ComponentConfig.PersistentVolumeBinderController.PVBindApprovalCacheTTL.Duration,
+		}
+	}
+	var sharding *persistentvolumecontroller.ShardingConfig
+	if shardCount := controllerContext.ComponentConfig.PersistentVolumeBinderController.PVShardCount; shardCount > 0 {
+		identity := controllerContext.ComponentConfig.PersistentVolumeBinderController.PVShardIdentity
+		if identity == "" {
+			hostname, err := os.Hostname()
+			if err != nil {
+				return nil, true, fmt.Errorf("failed to determine shard identity when starting persistentvolume controller: %v", err)
+			}
+			identity = hostname
+		}
+		sharding = &persistentvolumecontroller.ShardingConfig{
+			TotalShards: int(shardCount),
+			Identity:    identity,
+		}
+	}
 	params := persistentvolumecontroller.ControllerParameters{
 		KubeClient:                controllerContext.ClientBuilder.ClientOrDie("persistent-volume-binder"),
 		SyncPeriod:                controllerContext.ComponentConfig.PersistentVolumeBinderController.PVClaimBinderSyncPeriod.Duration,
+		BinderWorkers:             int(controllerContext.ComponentConfig.PersistentVolumeBinderController.PVBinderWorkers),
+		ProvisionRetryBackoffBase: controllerContext.ComponentConfig.PersistentVolumeBinderController.PVProvisionRetryBackoffBase.Duration,
+		ProvisionRetryBackoffMax:  controllerContext.ComponentConfig.PersistentVolumeBinderController.PVProvisionRetryBackoffMax.Duration,
+		ProvisionMaxRetries:       int(controllerContext.ComponentConfig.PersistentVolumeBinderController.PVProvisionMaxRetries),
 		VolumePlugins:             plugins,
 		Cloud:                     controllerContext.Cloud,
 		ClusterName:               controllerContext.ComponentConfig.KubeCloudShared.ClusterName,
@@ -280,6 +350,12 @@ func startPersistentVolumeBinderController(ctx context.Context, controllerContex
 		NodeInformer:              controllerContext.InformerFactory.Core().V1().Nodes(),
 		EnableDynamicProvisioning: controllerContext.ComponentConfig.PersistentVolumeBinderController.VolumeConfiguration.EnableDynamicProvisioning,
 		FilteredDialOptions:       filteredDialOptions,
+		SnapshotContentClient:     dynamic.NewForConfigOrDie(controllerContext.ClientBuilder.ConfigOrDie("persistent-volume-binder")),
+		WipeJobTemplate:           wipeJobTemplate,
+		CoalesceStatusUpdates:     controllerContext.ComponentConfig.PersistentVolumeBinderController.PVCoalesceStatusUpdates,
+		Sharding:                  sharding,
+		StorageSummaryInterval:    controllerContext.ComponentConfig.PersistentVolumeBinderController.PVStorageSummaryInterval.Duration,
+		BindApproval:              bindApproval,
 	}
 	volumeController, volumeControllerErr := persistentvolumecontroller.NewController(params)
 	if volumeControllerErr != nil {
@@ -324,6 +400,7 @@ func startAttachDetachController(ctx context.Context, controllerContext Controll
 			GetDynamicPluginProber(controllerContext.ComponentConfig.PersistentVolumeBinderController.VolumeConfiguration),
 			controllerContext.ComponentConfig.AttachDetachController.DisableAttachDetachReconcilerSync,
 			controllerContext.ComponentConfig.AttachDetachController.ReconcilerSyncLoopPeriod.Duration,
+			controllerContext.ComponentConfig.AttachDetachController.MaximumParallelDetachesPerNode,
 			attachdetach.DefaultTimerConfig,
 			filteredDialOptions,
 		)
@@ -365,6 +442,38 @@ func startVolumeExpandController(ctx context.Context, controllerContext Controll
 
 }
 
+func startVolumeAutoExpandController(ctx context.Context, controllerContext ControllerContext) (controller.Interface, bool, error) {
+	client := controllerContext.ClientBuilder.ClientOrDie("volume-autoexpand-controller")
+	autoExpandController := autoexpand.NewAutoExpandController(
+		client,
+		controllerContext.InformerFactory.Core().V1().PersistentVolumeClaims(),
+		controllerContext.InformerFactory.Storage().V1().StorageClasses(),
+		autoexpand.NewSummaryStatsProvider(client, controllerContext.InformerFactory.Core().V1().Nodes().Lister()),
+		time.Minute,
+	)
+	if controllerContext.MemoryBudget != nil {
+		controllerContext.MemoryBudget.Register(memorybudget.NewFuncShedder("volume-autoexpand", autoExpandController.Pause, autoExpandController.Resume))
+	}
+	go autoExpandController.Run(ctx)
+	return nil, true, nil
+}
+
+func startRuntimeClassOverheadController(ctx context.Context, controllerContext ControllerContext) (controller.Interface, bool, error) {
+	client := controllerContext.ClientBuilder.ClientOrDie("runtimeclass-overhead-controller")
+	overheadController := overhead.NewController(
+		client,
+		controllerContext.InformerFactory.Node().V1().RuntimeClasses(),
+		overhead.NewSummarySampler(
+			client,
+			controllerContext.InformerFactory.Core().V1().Nodes().Lister(),
+			controllerContext.InformerFactory.Core().V1().Pods().Lister(),
+		),
+		time.Minute,
+	)
+	go overheadController.Run(ctx)
+	return nil, true, nil
+}
+
 func startEphemeralVolumeController(ctx context.Context, controllerContext ControllerContext) (controller.Interface, bool, error) {
 	ephemeralController, err := ephemeral.NewController(
 		controllerContext.ClientBuilder.ClientOrDie("ephemeral-volume-controller"),
@@ -384,6 +493,7 @@ func startEndpointController(ctx context.Context, controllerCtx ControllerContex
 		controllerCtx.InformerFactory.Core().V1().Endpoints(),
 		controllerCtx.ClientBuilder.ClientOrDie("endpoint-controller"),
 		controllerCtx.ComponentConfig.EndpointController.EndpointUpdatesBatchPeriod.Duration,
+		int(controllerCtx.ComponentConfig.EndpointController.EndpointSliceOnlyServiceSize),
 	).Run(ctx, int(controllerCtx.ComponentConfig.EndpointController.ConcurrentEndpointSyncs))
 	return nil, true, nil
 }
@@ -445,6 +555,29 @@ func startResourceQuotaController(ctx context.Context, controllerContext Control
 	return nil, true, nil
 }
 
+func startNamespaceTemplateController(ctx context.Context, controllerContext ControllerContext) (controller.Interface, bool, error) {
+	namespaceTemplateController := namespacetemplatecontroller.NewController(
+		controllerContext.InformerFactory.Core().V1().Namespaces(),
+		controllerContext.InformerFactory.Networking().V1().NetworkPolicies(),
+		controllerContext.InformerFactory.Core().V1().LimitRanges(),
+		controllerContext.InformerFactory.Core().V1().ResourceQuotas(),
+		controllerContext.ClientBuilder.ClientOrDie("namespace-template-controller"),
+	)
+	go namespaceTemplateController.Run(ctx, 2)
+	return nil, true, nil
+}
+
+func startSubnamespaceController(ctx context.Context, controllerContext ControllerContext) (controller.Interface, bool, error) {
+	subnamespaceController := subnamespacecontroller.NewController(
+		controllerContext.InformerFactory.Core().V1().Namespaces(),
+		controllerContext.InformerFactory.Rbac().V1().RoleBindings(),
+		controllerContext.InformerFactory.Core().V1().ResourceQuotas(),
+		controllerContext.ClientBuilder.ClientOrDie("subnamespace-controller"),
+	)
+	go subnamespaceController.Run(ctx, 2)
+	return nil, true, nil
+}
+
 func startNamespaceController(ctx context.Context, controllerContext ControllerContext) (controller.Interface, bool, error) {
 	// the namespace cleanup controller is very chatty.  It makes lots of discovery calls and then it makes lots of delete calls
 	// the ratelimiter negatively affects its speed.  Deleting 100 total items in a namespace (that's only a few of each resource
@@ -494,7 +627,7 @@ func startServiceAccountController(ctx context.Context, controllerContext Contro
 
 func startTTLController(ctx context.Context, controllerContext ControllerContext) (controller.Interface, bool, error) {
 	go ttlcontroller.NewTTLController(
-		controllerContext.InformerFactory.Core().V1().Nodes(),
+		controllerContext.MetadataInformerFactory.ForResource(v1.SchemeGroupVersion.WithResource("nodes")),
 		controllerContext.ClientBuilder.ClientOrDie("ttl-controller"),
 	).Run(ctx, 5)
 	return nil, true, nil
@@ -565,6 +698,19 @@ func startPVProtectionController(ctx context.Context, controllerContext Controll
 	return nil, true, nil
 }
 
+func startPVCHealthController(ctx context.Context, controllerContext ControllerContext) (controller.Interface, bool, error) {
+	if !utilfeature.DefaultFeatureGate.Enabled(features.CSIVolumeHealth) {
+		return nil, false, nil
+	}
+	go pvchealth.NewPVCHealthController(
+		controllerContext.InformerFactory.Core().V1().Events(),
+		controllerContext.InformerFactory.Core().V1().PersistentVolumeClaims(),
+		controllerContext.InformerFactory.Core().V1().Pods(),
+		controllerContext.ClientBuilder.ClientOrDie("pvc-health-controller"),
+	).Run(ctx, 1)
+	return nil, true, nil
+}
+
 func startTTLAfterFinishedController(ctx context.Context, controllerContext ControllerContext) (controller.Interface, bool, error) {
 	go ttlafterfinished.New(
 		controllerContext.InformerFactory.Batch().V1().Jobs(),
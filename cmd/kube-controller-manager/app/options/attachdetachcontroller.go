@@ -17,6 +17,8 @@ limitations under the License.
 package options
 
 import (
+	"fmt"
+
 	"github.com/spf13/pflag"
 
 	attachdetachconfig "k8s.io/kubernetes/pkg/controller/volume/attachdetach/config"
@@ -35,6 +37,7 @@ func (o *AttachDetachControllerOptions) AddFlags(fs *pflag.FlagSet) {
 
 	fs.BoolVar(&o.DisableAttachDetachReconcilerSync, "disable-attach-detach-reconcile-sync", false, "Disable volume attach detach reconciler sync. Disabling this may cause volumes to be mismatched with pods. Use wisely.")
 	fs.DurationVar(&o.ReconcilerSyncLoopPeriod.Duration, "attach-detach-reconcile-sync-period", o.ReconcilerSyncLoopPeriod.Duration, "The reconciler sync wait time between volume attach detach. This duration must be larger than one second, and increasing this value from the default may allow for volumes to be mismatched with pods.")
+	fs.Int32Var(&o.MaximumParallelDetachesPerNode, "maximum-parallel-detaches-per-node", o.MaximumParallelDetachesPerNode, "The maximum number of volume detach operations that may run in parallel against a single node. The default is 0, which falls back to in-tree per volume plugin defaults.")
 }
 
 // ApplyTo fills up AttachDetachController config with options.
@@ -45,6 +48,7 @@ func (o *AttachDetachControllerOptions) ApplyTo(cfg *attachdetachconfig.AttachDe
 
 	cfg.DisableAttachDetachReconcilerSync = o.DisableAttachDetachReconcilerSync
 	cfg.ReconcilerSyncLoopPeriod = o.ReconcilerSyncLoopPeriod
+	cfg.MaximumParallelDetachesPerNode = o.MaximumParallelDetachesPerNode
 
 	return nil
 }
@@ -56,5 +60,8 @@ func (o *AttachDetachControllerOptions) Validate() []error {
 	}
 
 	errs := []error{}
+	if o.MaximumParallelDetachesPerNode < 0 {
+		errs = append(errs, fmt.Errorf("--maximum-parallel-detaches-per-node must not be negative"))
+	}
 	return errs
 }
@@ -17,6 +17,8 @@ limitations under the License.
 package options
 
 import (
+	"fmt"
+
 	"github.com/spf13/pflag"
 
 	endpointconfig "k8s.io/kubernetes/pkg/controller/endpoint/config"
@@ -35,6 +37,7 @@ func (o *EndpointControllerOptions) AddFlags(fs *pflag.FlagSet) {
 
 	fs.Int32Var(&o.ConcurrentEndpointSyncs, "concurrent-endpoint-syncs", o.ConcurrentEndpointSyncs, "The number of endpoint syncing operations that will be done concurrently. Larger number = faster endpoint updating, but more CPU (and network) load")
 	fs.DurationVar(&o.EndpointUpdatesBatchPeriod.Duration, "endpoint-updates-batch-period", o.EndpointUpdatesBatchPeriod.Duration, "The length of endpoint updates batching period. Processing of pod changes will be delayed by this duration to join them with potential upcoming updates and reduce the overall number of endpoints updates. Larger number = higher endpoint programming latency, but lower number of endpoints revision generated")
+	fs.Int32Var(&o.EndpointSliceOnlyServiceSize, "endpoint-slice-only-service-size", o.EndpointSliceOnlyServiceSize, "The number of endpoints above which the endpoint controller stops maintaining the legacy Endpoints object of a service, leaving EndpointSlices as its only endpoint representation. The default value of 0 means Endpoints objects are maintained for all services.")
 }
 
 // ApplyTo fills up EndPointController config with options.
@@ -45,6 +48,7 @@ func (o *EndpointControllerOptions) ApplyTo(cfg *endpointconfig.EndpointControll
 
 	cfg.ConcurrentEndpointSyncs = o.ConcurrentEndpointSyncs
 	cfg.EndpointUpdatesBatchPeriod = o.EndpointUpdatesBatchPeriod
+	cfg.EndpointSliceOnlyServiceSize = o.EndpointSliceOnlyServiceSize
 
 	return nil
 }
@@ -56,5 +60,8 @@ func (o *EndpointControllerOptions) Validate() []error {
 	}
 
 	errs := []error{}
+	if o.EndpointSliceOnlyServiceSize < 0 {
+		errs = append(errs, fmt.Errorf("endpoint-slice-only-service-size must not be negative, but got %d", o.EndpointSliceOnlyServiceSize))
+	}
 	return errs
 }
@@ -17,6 +17,8 @@ limitations under the License.
 package options
 
 import (
+	"fmt"
+
 	"github.com/spf13/pflag"
 
 	nodelifecycleconfig "k8s.io/kubernetes/pkg/controller/nodelifecycle/config"
@@ -45,6 +47,9 @@ func (o *NodeLifecycleControllerOptions) AddFlags(fs *pflag.FlagSet) {
 	fs.Int32Var(&o.LargeClusterSizeThreshold, "large-cluster-size-threshold", 50, "Number of nodes from which NodeController treats the cluster as large for the eviction logic purposes. --secondary-node-eviction-rate is implicitly overridden to 0 for clusters this size or smaller.")
 	fs.Float32Var(&o.UnhealthyZoneThreshold, "unhealthy-zone-threshold", 0.55, "Fraction of Nodes in a zone which needs to be not Ready (minimum 3) for zone to be treated as unhealthy. ")
 	fs.BoolVar(&o.EnableTaintManager, "enable-taint-manager", o.EnableTaintManager, "WARNING: Beta feature. If set to true enables NoExecute Taints and will evict all not-tolerating Pod running on Nodes tainted with this kind of Taints.")
+	fs.StringVar(&o.NodeOutOfServiceFencingWebhookURL, "node-out-of-service-fencing-webhook-url", o.NodeOutOfServiceFencingWebhookURL, "URL of a fencing webhook consulted by the out-of-service taint controller before tainting a NotReady node with node.kubernetes.io/out-of-service. The controller only runs when this is set.")
+	fs.DurationVar(&o.NodeOutOfServiceNotReadyThreshold.Duration, "node-out-of-service-not-ready-threshold", o.NodeOutOfServiceNotReadyThreshold.Duration, "How long a node must be NotReady before the out-of-service taint controller consults the fencing webhook.")
+	fs.DurationVar(&o.NodeOutOfServiceFencingTimeout.Duration, "node-out-of-service-fencing-timeout", o.NodeOutOfServiceFencingTimeout.Duration, "Per-request timeout for calls to the fencing webhook.")
 }
 
 // ApplyTo fills up NodeLifecycleController config with options.
@@ -61,6 +66,9 @@ func (o *NodeLifecycleControllerOptions) ApplyTo(cfg *nodelifecycleconfig.NodeLi
 	cfg.SecondaryNodeEvictionRate = o.SecondaryNodeEvictionRate
 	cfg.LargeClusterSizeThreshold = o.LargeClusterSizeThreshold
 	cfg.UnhealthyZoneThreshold = o.UnhealthyZoneThreshold
+	cfg.NodeOutOfServiceFencingWebhookURL = o.NodeOutOfServiceFencingWebhookURL
+	cfg.NodeOutOfServiceNotReadyThreshold = o.NodeOutOfServiceNotReadyThreshold
+	cfg.NodeOutOfServiceFencingTimeout = o.NodeOutOfServiceFencingTimeout
 
 	return nil
 }
@@ -72,5 +80,13 @@ func (o *NodeLifecycleControllerOptions) Validate() []error {
 	}
 
 	errs := []error{}
+	if len(o.NodeOutOfServiceFencingWebhookURL) > 0 {
+		if o.NodeOutOfServiceNotReadyThreshold.Duration <= 0 {
+			errs = append(errs, fmt.Errorf("--node-out-of-service-not-ready-threshold must be greater than zero"))
+		}
+		if o.NodeOutOfServiceFencingTimeout.Duration <= 0 {
+			errs = append(errs, fmt.Errorf("--node-out-of-service-fencing-timeout must be greater than zero"))
+		}
+	}
 	return errs
 }
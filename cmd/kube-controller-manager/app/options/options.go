@@ -95,6 +95,10 @@ type KubeControllerManagerOptions struct {
 	Master                      string
 	Kubeconfig                  string
 	ShowHiddenMetricsForVersion string
+
+	// MemoryBudgetBytes is the soft memory budget for the process; 0 disables
+	// the memory budget manager.
+	MemoryBudgetBytes int64
 }
 
 // NewKubeControllerManagerOptions creates a new KubeControllerManagerOptions with a default config.
@@ -262,6 +266,7 @@ func (s *KubeControllerManagerOptions) Flags(allControllers []string, disabledBy
 	fs := fss.FlagSet("misc")
 	fs.StringVar(&s.Master, "master", s.Master, "The address of the Kubernetes API server (overrides any value in kubeconfig).")
 	fs.StringVar(&s.Kubeconfig, "kubeconfig", s.Kubeconfig, "Path to kubeconfig file with authorization and master location information.")
+	fs.Int64Var(&s.MemoryBudgetBytes, "memory-budget-bytes", s.MemoryBudgetBytes, "Soft memory budget for the process in bytes. When usage exceeds the budget, optional controllers are paused and selected informers are degraded until usage recovers. 0 disables the memory budget.")
 	utilfeature.DefaultMutableFeatureGate.AddFlag(fss.FlagSet("generic"))
 
 	return fss
@@ -361,6 +366,7 @@ func (s *KubeControllerManagerOptions) ApplyTo(c *kubecontrollerconfig.Config) e
 			return err
 		}
 	}
+	c.MemoryBudgetBytes = s.MemoryBudgetBytes
 	return nil
 }
 
@@ -399,6 +405,9 @@ func (s *KubeControllerManagerOptions) Validate(allControllers []string, disable
 	errs = append(errs, s.Authentication.Validate()...)
 	errs = append(errs, s.Authorization.Validate()...)
 	errs = append(errs, s.Metrics.Validate()...)
+	if s.MemoryBudgetBytes < 0 {
+		errs = append(errs, fmt.Errorf("--memory-budget-bytes %d can not be a negative number", s.MemoryBudgetBytes))
+	}
 
 	// TODO: validate component config, master and kubeconfig
 
@@ -125,6 +125,7 @@ var args = []string{
 	"--leader-elect-retry-period=5s",
 	"--master=192.168.4.20",
 	"--max-endpoints-per-slice=200",
+	"--maximum-parallel-detaches-per-node=4",
 	"--min-resync-period=8h",
 	"--mirroring-concurrent-service-endpoint-syncs=2",
 	"--mirroring-max-endpoints-per-subset=1000",
@@ -225,6 +226,7 @@ func TestAddFlags(t *testing.T) {
 			&attachdetachconfig.AttachDetachControllerConfiguration{
 				ReconcilerSyncLoopPeriod:          metav1.Duration{Duration: 30 * time.Second},
 				DisableAttachDetachReconcilerSync: true,
+				MaximumParallelDetachesPerNode:    4,
 			},
 		},
 		CSRSigningController: &CSRSigningControllerOptions{
@@ -335,19 +337,23 @@ func TestAddFlags(t *testing.T) {
 		},
 		NodeLifecycleController: &NodeLifecycleControllerOptions{
 			&nodelifecycleconfig.NodeLifecycleControllerConfiguration{
-				EnableTaintManager:        false,
-				NodeEvictionRate:          0.2,
-				SecondaryNodeEvictionRate: 0.05,
-				NodeMonitorGracePeriod:    metav1.Duration{Duration: 30 * time.Second},
-				NodeStartupGracePeriod:    metav1.Duration{Duration: 30 * time.Second},
-				PodEvictionTimeout:        metav1.Duration{Duration: 2 * time.Minute},
-				LargeClusterSizeThreshold: 100,
-				UnhealthyZoneThreshold:    0.6,
+				EnableTaintManager:                false,
+				NodeEvictionRate:                  0.2,
+				SecondaryNodeEvictionRate:         0.05,
+				NodeMonitorGracePeriod:            metav1.Duration{Duration: 30 * time.Second},
+				NodeStartupGracePeriod:            metav1.Duration{Duration: 30 * time.Second},
+				PodEvictionTimeout:                metav1.Duration{Duration: 2 * time.Minute},
+				LargeClusterSizeThreshold:         100,
+				UnhealthyZoneThreshold:            0.6,
+				NodeOutOfServiceNotReadyThreshold: metav1.Duration{Duration: 5 * time.Minute},
+				NodeOutOfServiceFencingTimeout:    metav1.Duration{Duration: 30 * time.Second},
 			},
 		},
 		PersistentVolumeBinderController: &PersistentVolumeBinderControllerOptions{
 			&persistentvolumeconfig.PersistentVolumeBinderControllerConfiguration{
-				PVClaimBinderSyncPeriod: metav1.Duration{Duration: 30 * time.Second},
+				PVClaimBinderSyncPeriod:    metav1.Duration{Duration: 30 * time.Second},
+				PVBinderWorkers:            1,
+				PVProvisionRetryBackoffMax: metav1.Duration{Duration: 5 * time.Minute},
 				VolumeConfiguration: persistentvolumeconfig.VolumeConfiguration{
 					EnableDynamicProvisioning:  false,
 					EnableHostPathProvisioning: true,
@@ -500,6 +506,7 @@ func TestApplyTo(t *testing.T) {
 			AttachDetachController: attachdetachconfig.AttachDetachControllerConfiguration{
 				ReconcilerSyncLoopPeriod:          metav1.Duration{Duration: 30 * time.Second},
 				DisableAttachDetachReconcilerSync: true,
+				MaximumParallelDetachesPerNode:    4,
 			},
 			CSRSigningController: csrsigningconfig.CSRSigningControllerConfiguration{
 				ClusterSigningCertFile: "/cluster-signing-cert",
@@ -578,17 +585,21 @@ func TestApplyTo(t *testing.T) {
 				NodeCIDRMaskSizeIPv6: 108,
 			},
 			NodeLifecycleController: nodelifecycleconfig.NodeLifecycleControllerConfiguration{
-				EnableTaintManager:        false,
-				NodeEvictionRate:          0.2,
-				SecondaryNodeEvictionRate: 0.05,
-				NodeMonitorGracePeriod:    metav1.Duration{Duration: 30 * time.Second},
-				NodeStartupGracePeriod:    metav1.Duration{Duration: 30 * time.Second},
-				PodEvictionTimeout:        metav1.Duration{Duration: 2 * time.Minute},
-				LargeClusterSizeThreshold: 100,
-				UnhealthyZoneThreshold:    0.6,
+				EnableTaintManager:                false,
+				NodeEvictionRate:                  0.2,
+				SecondaryNodeEvictionRate:         0.05,
+				NodeMonitorGracePeriod:            metav1.Duration{Duration: 30 * time.Second},
+				NodeStartupGracePeriod:            metav1.Duration{Duration: 30 * time.Second},
+				PodEvictionTimeout:                metav1.Duration{Duration: 2 * time.Minute},
+				LargeClusterSizeThreshold:         100,
+				UnhealthyZoneThreshold:            0.6,
+				NodeOutOfServiceNotReadyThreshold: metav1.Duration{Duration: 5 * time.Minute},
+				NodeOutOfServiceFencingTimeout:    metav1.Duration{Duration: 30 * time.Second},
 			},
 			PersistentVolumeBinderController: persistentvolumeconfig.PersistentVolumeBinderControllerConfiguration{
-				PVClaimBinderSyncPeriod: metav1.Duration{Duration: 30 * time.Second},
+				PVClaimBinderSyncPeriod:    metav1.Duration{Duration: 30 * time.Second},
+				PVBinderWorkers:            1,
+				PVProvisionRetryBackoffMax: metav1.Duration{Duration: 5 * time.Minute},
 				VolumeConfiguration: persistentvolumeconfig.VolumeConfiguration{
 					EnableDynamicProvisioning:  false,
 					EnableHostPathProvisioning: true,
@@ -38,6 +38,18 @@ func (o *PersistentVolumeBinderControllerOptions) AddFlags(fs *pflag.FlagSet) {
 	}
 
 	fs.DurationVar(&o.PVClaimBinderSyncPeriod.Duration, "pvclaimbinder-sync-period", o.PVClaimBinderSyncPeriod.Duration, "The period for syncing persistent volumes and persistent volume claims")
+	fs.Int32Var(&o.PVBinderWorkers, "pv-binder-workers", o.PVBinderWorkers, "The number of workers binding persistent volume claims concurrently. Claims are sharded across workers by namespace.")
+	fs.DurationVar(&o.PVProvisionRetryBackoffBase.Duration, "pv-provision-retry-backoff-base", o.PVProvisionRetryBackoffBase.Duration, "The initial delay between retries of failed dynamic provisioning of a claim. Retries back off exponentially with jitter up to --pv-provision-retry-backoff-max. Zero disables the backoff and retries provisioning on every sync.")
+	fs.DurationVar(&o.PVProvisionRetryBackoffMax.Duration, "pv-provision-retry-backoff-max", o.PVProvisionRetryBackoffMax.Duration, "The upper bound of the dynamic provisioning retry backoff.")
+	fs.Int32Var(&o.PVProvisionMaxRetries, "pv-provision-max-retries", o.PVProvisionMaxRetries, "The number of provisioning attempts per claim before the claim is marked with a terminal ProvisioningFailed condition. Zero means no limit. Only effective together with --pv-provision-retry-backoff-base.")
+	fs.StringVar(&o.PVWipeJobTemplateFile, "pv-wipe-job-template-file", o.PVWipeJobTemplateFile, "The file path of a batch/v1 Job manifest whose spec is used as the template for wipe jobs replacing the legacy recycler pods for volumes with the Recycle reclaim policy. If empty, the legacy recycler pods are kept.")
+	fs.BoolVar(&o.PVCoalesceStatusUpdates, "pv-coalesce-status-updates", o.PVCoalesceStatusUpdates, "Batch persistent volume and persistent volume claim status updates through a write-coalescing layer that deduplicates redundant updates, reducing the API server write load in large clusters.")
+	fs.Int32Var(&o.PVShardCount, "pv-shard-count", o.PVShardCount, "The number of shards the persistent volume and claim key space is split into. Each controller manager replica syncs only the shards it owns, coordinated through Lease objects, so the PV controller can run active in several replicas at once. Must be identical in all replicas. Zero keeps the classic single-active behavior.")
+	fs.StringVar(&o.PVShardIdentity, "pv-shard-identity", o.PVShardIdentity, "The unique name of this replica in the persistent volume shard leases, e.g. the pod name. If empty, the hostname is used. Only effective together with --pv-shard-count.")
+	fs.DurationVar(&o.PVStorageSummaryInterval.Duration, "pv-storage-summary-interval", o.PVStorageSummaryInterval.Duration, "The interval at which the controller publishes its storage summary to the pv-storage-summary ConfigMap in kube-system, so dashboards can watch one object instead of listing all volumes. Zero disables publishing.")
+	fs.StringVar(&o.PVBindApprovalWebhookConfigFile, "pv-bind-approval-webhook-config-file", o.PVBindApprovalWebhookConfigFile, "The file path of a kubeconfig file describing an external bind approval webhook consulted before binds between claims and candidate volumes are finalized. If empty, the check is disabled.")
+	fs.BoolVar(&o.PVBindApprovalFailOpen, "pv-bind-approval-fail-open", o.PVBindApprovalFailOpen, "Allow binds to proceed when the bind approval webhook cannot be reached. If false, binds are blocked until the webhook recovers. Only effective together with --pv-bind-approval-webhook-config-file.")
+	fs.DurationVar(&o.PVBindApprovalCacheTTL.Duration, "pv-bind-approval-cache-ttl", o.PVBindApprovalCacheTTL.Duration, "How long a bind approval webhook decision for a particular claim/volume pair is reused without consulting the webhook again. Zero disables caching. Only effective together with --pv-bind-approval-webhook-config-file.")
 	fs.StringVar(&o.VolumeConfiguration.PersistentVolumeRecyclerConfiguration.PodTemplateFilePathNFS, "pv-recycler-pod-template-filepath-nfs", o.VolumeConfiguration.PersistentVolumeRecyclerConfiguration.PodTemplateFilePathNFS, "The file path to a pod definition used as a template for NFS persistent volume recycling")
 	fs.Int32Var(&o.VolumeConfiguration.PersistentVolumeRecyclerConfiguration.MinimumTimeoutNFS, "pv-recycler-minimum-timeout-nfs", o.VolumeConfiguration.PersistentVolumeRecyclerConfiguration.MinimumTimeoutNFS, "The minimum ActiveDeadlineSeconds to use for an NFS Recycler pod")
 	fs.Int32Var(&o.VolumeConfiguration.PersistentVolumeRecyclerConfiguration.IncrementTimeoutNFS, "pv-recycler-increment-timeout-nfs", o.VolumeConfiguration.PersistentVolumeRecyclerConfiguration.IncrementTimeoutNFS, "the increment of time added per Gi to ActiveDeadlineSeconds for an NFS scrubber pod")
@@ -58,6 +70,18 @@ func (o *PersistentVolumeBinderControllerOptions) ApplyTo(cfg *persistentvolumec
 	}
 
 	cfg.PVClaimBinderSyncPeriod = o.PVClaimBinderSyncPeriod
+	cfg.PVBinderWorkers = o.PVBinderWorkers
+	cfg.PVProvisionRetryBackoffBase = o.PVProvisionRetryBackoffBase
+	cfg.PVProvisionRetryBackoffMax = o.PVProvisionRetryBackoffMax
+	cfg.PVProvisionMaxRetries = o.PVProvisionMaxRetries
+	cfg.PVWipeJobTemplateFile = o.PVWipeJobTemplateFile
+	cfg.PVCoalesceStatusUpdates = o.PVCoalesceStatusUpdates
+	cfg.PVShardCount = o.PVShardCount
+	cfg.PVShardIdentity = o.PVShardIdentity
+	cfg.PVStorageSummaryInterval = o.PVStorageSummaryInterval
+	cfg.PVBindApprovalWebhookConfigFile = o.PVBindApprovalWebhookConfigFile
+	cfg.PVBindApprovalFailOpen = o.PVBindApprovalFailOpen
+	cfg.PVBindApprovalCacheTTL = o.PVBindApprovalCacheTTL
 	cfg.VolumeConfiguration = o.VolumeConfiguration
 	cfg.VolumeHostCIDRDenylist = o.VolumeHostCIDRDenylist
 	cfg.VolumeHostAllowLocalLoopback = o.VolumeHostAllowLocalLoopback
@@ -75,6 +99,27 @@ func (o *PersistentVolumeBinderControllerOptions) Validate() []error {
 	if _, err := ParseVolumeHostFilters(o.VolumeHostCIDRDenylist, o.VolumeHostAllowLocalLoopback); err != nil {
 		errs = append(errs, fmt.Errorf("bad --volume-host-ip-denylist/--volume-host-allow-local-loopback %w", err))
 	}
+	if o.PVBinderWorkers < 1 {
+		errs = append(errs, fmt.Errorf("--pv-binder-workers must be greater than 0"))
+	}
+	if o.PVProvisionRetryBackoffBase.Duration < 0 {
+		errs = append(errs, fmt.Errorf("--pv-provision-retry-backoff-base must not be negative"))
+	}
+	if o.PVProvisionRetryBackoffBase.Duration > 0 && o.PVProvisionRetryBackoffMax.Duration < o.PVProvisionRetryBackoffBase.Duration {
+		errs = append(errs, fmt.Errorf("--pv-provision-retry-backoff-max must not be smaller than --pv-provision-retry-backoff-base"))
+	}
+	if o.PVProvisionMaxRetries < 0 {
+		errs = append(errs, fmt.Errorf("--pv-provision-max-retries must not be negative"))
+	}
+	if o.PVShardCount < 0 {
+		errs = append(errs, fmt.Errorf("--pv-shard-count must not be negative"))
+	}
+	if o.PVStorageSummaryInterval.Duration < 0 {
+		errs = append(errs, fmt.Errorf("--pv-storage-summary-interval must not be negative"))
+	}
+	if o.PVBindApprovalCacheTTL.Duration < 0 {
+		errs = append(errs, fmt.Errorf("--pv-bind-approval-cache-ttl must not be negative"))
+	}
 	return errs
 }
 
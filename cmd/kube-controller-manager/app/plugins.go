@@ -22,6 +22,7 @@ import (
 	// given binary target.
 
 	"fmt"
+	"io/ioutil"
 
 	"k8s.io/klog/v2"
 
@@ -44,7 +45,10 @@ import (
 	"k8s.io/kubernetes/pkg/volume/storageos"
 	volumeutil "k8s.io/kubernetes/pkg/volume/util"
 
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	"k8s.io/kubernetes/pkg/api/legacyscheme"
 	persistentvolumeconfig "k8s.io/kubernetes/pkg/controller/volume/persistentvolume/config"
 	"k8s.io/kubernetes/pkg/features"
 	"k8s.io/utils/exec"
@@ -160,3 +164,24 @@ func AttemptToLoadRecycler(path string, config *volume.VolumeConfig) error {
 	}
 	return nil
 }
+
+// AttemptToLoadWipeJobTemplate reads the batch/v1 Job manifest used as the
+// template for wipe jobs replacing the legacy recycler pods. An empty path
+// returns a nil template, keeping the legacy recycler pods.
+func AttemptToLoadWipeJobTemplate(path string) (*batchv1.JobSpec, error) {
+	if path == "" {
+		return nil, nil
+	}
+	jobDef, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file path %s: %v", path, err)
+	}
+	job := &batchv1.Job{}
+	if err := runtime.DecodeInto(legacyscheme.Codecs.UniversalDecoder(), jobDef, job); err != nil {
+		return nil, fmt.Errorf("failed decoding file %s: %v", path, err)
+	}
+	if len(job.Spec.Template.Spec.Containers) == 0 {
+		return nil, fmt.Errorf("job specification (%v): needs at least one container", path)
+	}
+	return &job.Spec, nil
+}
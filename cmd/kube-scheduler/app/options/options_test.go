@@ -754,6 +754,12 @@ profiles:
 									DefaultingType: kubeschedulerconfig.SystemDefaulting,
 								},
 							},
+							{
+								Name: "PrioritySort",
+								Args: &kubeschedulerconfig.PrioritySortArgs{
+									NonPreemptingAgingMaxBoost: 10,
+								},
+							},
 							{
 								Name: "VolumeBinding",
 								Args: &kubeschedulerconfig.VolumeBindingArgs{
@@ -982,6 +988,12 @@ profiles:
 									DefaultingType: kubeschedulerconfig.SystemDefaulting,
 								},
 							},
+							{
+								Name: "PrioritySort",
+								Args: &kubeschedulerconfig.PrioritySortArgs{
+									NonPreemptingAgingMaxBoost: 10,
+								},
+							},
 							{
 								Name: "VolumeBinding",
 								Args: &kubeschedulerconfig.VolumeBindingArgs{
@@ -44,6 +44,7 @@ import (
 	"k8s.io/client-go/tools/leaderelection"
 	cliflag "k8s.io/component-base/cli/flag"
 	"k8s.io/component-base/cli/globalflag"
+	"k8s.io/component-base/configstatus"
 	"k8s.io/component-base/configz"
 	"k8s.io/component-base/logs"
 	"k8s.io/component-base/metrics/legacyregistry"
@@ -155,6 +156,13 @@ func Run(ctx context.Context, cc *schedulerserverconfig.CompletedConfig, sched *
 		return fmt.Errorf("unable to register configz: %s", err)
 	}
 
+	// Publish the effective configuration for drift detection.
+	if cc.Client != nil {
+		if err := configstatus.Publish(ctx, cc.Client, "kube-scheduler", cc.ComponentConfig); err != nil {
+			klog.ErrorS(err, "Unable to publish configuration status")
+		}
+	}
+
 	// Prepare the event broadcaster.
 	cc.EventBroadcaster.StartRecordingToSink(ctx.Done())
 
@@ -516,6 +516,9 @@ func run(ctx context.Context, s *options.KubeletServer, kubeDeps *kubelet.Depend
 	if len(s.ShowHiddenMetricsForVersion) > 0 {
 		metrics.SetShowHidden()
 	}
+	if len(s.DebugMetricsFile) > 0 {
+		metrics.EnableDebugMetricsFromFile(s.DebugMetricsFile)
+	}
 
 	// About to get clients and such, detect standaloneMode
 	standaloneMode := true
@@ -75,6 +75,9 @@ type hollowNodeConfig struct {
 	MaxPods                 int
 	ExtendedResources       map[string]string
 	UseHostImageService     bool
+	VolumeAttachLatency     time.Duration
+	VolumeMountLatency      time.Duration
+	VolumeUnmountLatency    time.Duration
 }
 
 const (
@@ -106,6 +109,9 @@ func (c *hollowNodeConfig) addFlags(fs *pflag.FlagSet) {
 	bindableExtendedResources := cliflag.ConfigurationMap(c.ExtendedResources)
 	fs.Var(&bindableExtendedResources, "extended-resources", "Register the node with extended resources (comma separated \"<name>=<quantity>\")")
 	fs.BoolVar(&c.UseHostImageService, "use-host-image-service", true, "Set to true if the hollow-kubelet should use the host image service. If set to false the fake image service will be used")
+	fs.DurationVar(&c.VolumeAttachLatency, "volume-attach-latency", 0, "Additional delay of the first mount of every volume in hollow-kubelet, emulating volume attachment.")
+	fs.DurationVar(&c.VolumeMountLatency, "volume-mount-latency", 0, "Delay of every volume mount operation in hollow-kubelet.")
+	fs.DurationVar(&c.VolumeUnmountLatency, "volume-unmount-latency", 0, "Delay of every volume unmount operation in hollow-kubelet.")
 }
 
 func (c *hollowNodeConfig) createClientConfigFromFile() (*restclient.Config, error) {
@@ -267,6 +273,11 @@ func run(cmd *cobra.Command, config *hollowNodeConfig) error {
 			imageService,
 			runtimeService,
 			containerManager,
+			kubemark.VolumeLatencies{
+				Attach:  config.VolumeAttachLatency,
+				Mount:   config.VolumeMountLatency,
+				Unmount: config.VolumeUnmountLatency,
+			},
 		)
 		hollowKubelet.Run()
 	}
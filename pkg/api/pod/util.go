@@ -436,6 +436,8 @@ func GetValidationOptionsFromPodSpecAndMeta(podSpec, oldPodSpec *api.PodSpec, po
 		AllowOSField: utilfeature.DefaultFeatureGate.Enabled(features.IdentifyPodOS),
 		// The default sysctl value does not contain a forward slash, and in 1.24 we intend to relax this to be true by default
 		AllowSysctlRegexContainSlash: false,
+		// Tolerate pre-existing violations on unchanged fields during update
+		EnableValidationRatcheting: utilfeature.DefaultFeatureGate.Enabled(features.ValidationRatcheting),
 	}
 
 	if oldPodSpec != nil {
@@ -167,6 +167,11 @@ func validateJobSpec(spec *batch.JobSpec, fldPath *field.Path, opts apivalidatio
 			}
 		}
 	}
+	if spec.PodReplacementPolicy != nil {
+		if *spec.PodReplacementPolicy != batch.ReplaceAlways && *spec.PodReplacementPolicy != batch.ReplaceOnNodeFailure {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("podReplacementPolicy"), spec.PodReplacementPolicy, []string{string(batch.ReplaceAlways), string(batch.ReplaceOnNodeFailure)}))
+		}
+	}
 
 	allErrs = append(allErrs, apivalidation.ValidatePodTemplateSpec(&spec.Template, fldPath.Child("template"), opts)...)
 
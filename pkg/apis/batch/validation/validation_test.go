@@ -152,6 +152,20 @@ func TestValidateJob(t *testing.T) {
 				},
 			},
 		},
+		"valid OnNodeFailure pod replacement policy": {
+			job: batch.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "myjob",
+					Namespace: metav1.NamespaceDefault,
+					UID:       types.UID("1a2b3c"),
+				},
+				Spec: batch.JobSpec{
+					Selector:             validGeneratedSelector,
+					Template:             validPodTemplateSpecForGenerated,
+					PodReplacementPolicy: podReplacementPolicyPtr(batch.ReplaceOnNodeFailure),
+				},
+			},
+		},
 		"valid job tracking annotation": {
 			opts: JobValidationOptions{
 				AllowTrackingAnnotation: true,
@@ -350,6 +364,18 @@ func TestValidateJob(t *testing.T) {
 				Parallelism:    pointer.Int32Ptr(100001),
 			},
 		},
+		"spec.podReplacementPolicy: Unsupported value": {
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "myjob",
+				Namespace: metav1.NamespaceDefault,
+				UID:       types.UID("1a2b3c"),
+			},
+			Spec: batch.JobSpec{
+				Selector:             validGeneratedSelector,
+				Template:             validPodTemplateSpecForGenerated,
+				PodReplacementPolicy: podReplacementPolicyPtr("Sometimes"),
+			},
+		},
 		"metadata.annotations[batch.kubernetes.io/job-tracking]: cannot add this annotation": {
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      "myjob",
@@ -1595,3 +1621,7 @@ func TestValidateCronJobSpec(t *testing.T) {
 func completionModePtr(m batch.CompletionMode) *batch.CompletionMode {
 	return &m
 }
+
+func podReplacementPolicyPtr(p batch.PodReplacementPolicy) *batch.PodReplacementPolicy {
+	return &p
+}
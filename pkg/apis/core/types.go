@@ -1604,6 +1604,18 @@ type ServiceAccountTokenProjection struct {
 	Path string
 }
 
+// ServiceAccountIssuerProjection projects the OIDC discovery document and
+// JSON Web Key Set of the cluster's service account token issuer into the
+// volume, so that workloads can validate service account tokens without
+// calling the kube-apiserver. The kubelet keeps the projected documents
+// fresh the same way it rotates projected tokens.
+type ServiceAccountIssuerProjection struct {
+	// Path is the path relative to the mount point of the directory to
+	// project the documents into. The discovery document is written as
+	// "openid-configuration" and the key set as "jwks.json" below it.
+	Path string
+}
+
 // ProjectedVolumeSource represents a projected volume source
 type ProjectedVolumeSource struct {
 	// list of volume projections
@@ -1629,6 +1641,8 @@ type VolumeProjection struct {
 	ConfigMap *ConfigMapProjection
 	// information about the serviceAccountToken data to project
 	ServiceAccountToken *ServiceAccountTokenProjection
+	// information about the service account issuer documents to project
+	ServiceAccountIssuer *ServiceAccountIssuerProjection
 }
 
 // KeyToPath maps a string key to a path within a volume.
@@ -2430,6 +2444,11 @@ const (
 	PodReasonUnschedulable = "Unschedulable"
 	// ContainersReady indicates whether all containers in the pod are ready.
 	ContainersReady PodConditionType = "ContainersReady"
+	// PodStorageReady indicates that all of the pod's volumes have finished their
+	// initial preparation, such as fsGroup ownership changes and datasource
+	// population. It is maintained by the kubelet only for pods that list it in
+	// their readiness gates.
+	PodStorageReady PodConditionType = "StorageReady"
 )
 
 // PodCondition represents pod's condition
@@ -3345,6 +3364,26 @@ type EphemeralContainer struct {
 	TargetContainerName string
 }
 
+// PodVolumeStatus describes the observed state of a volume used by a pod.
+type PodVolumeStatus struct {
+	// Name of the volume, matching an entry in pod.spec.volumes.
+	Name string
+	// Mounted indicates that the volume has been set up on the node and is
+	// ready to be mounted into the pod's containers.
+	// +optional
+	Mounted bool
+	// ResolvedSubPaths lists the host paths that the subPath values of the
+	// pod's volume mounts for this volume resolve to. Only populated once
+	// the volume is mounted; subPathExpr mounts are resolved when their
+	// container starts and are not listed.
+	// +optional
+	ResolvedSubPaths []string
+	// Message describes the last error observed while setting up the volume
+	// or resolving a subPath, if any.
+	// +optional
+	Message string
+}
+
 // PodStatus represents information about the status of a pod. Status may trail the actual
 // state of a system.
 type PodStatus struct {
@@ -3393,6 +3432,10 @@ type PodStatus struct {
 	// This field is beta-level and available on clusters that haven't disabled the EphemeralContainers feature gate.
 	// +optional
 	EphemeralContainerStatuses []ContainerStatus
+
+	// Status of volumes used by containers in this pod, populated by the kubelet.
+	// +optional
+	VolumeStatuses []PodVolumeStatus
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
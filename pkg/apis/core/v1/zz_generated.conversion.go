@@ -1402,6 +1402,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.PodVolumeStatus)(nil), (*core.PodVolumeStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_PodVolumeStatus_To_core_PodVolumeStatus(a.(*v1.PodVolumeStatus), b.(*core.PodVolumeStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*core.PodVolumeStatus)(nil), (*v1.PodVolumeStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_core_PodVolumeStatus_To_v1_PodVolumeStatus(a.(*core.PodVolumeStatus), b.(*v1.PodVolumeStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.PortStatus)(nil), (*core.PortStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_PortStatus_To_core_PortStatus(a.(*v1.PortStatus), b.(*core.PortStatus), scope)
 	}); err != nil {
@@ -1787,6 +1797,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.ServiceAccountIssuerProjection)(nil), (*core.ServiceAccountIssuerProjection)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ServiceAccountIssuerProjection_To_core_ServiceAccountIssuerProjection(a.(*v1.ServiceAccountIssuerProjection), b.(*core.ServiceAccountIssuerProjection), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*core.ServiceAccountIssuerProjection)(nil), (*v1.ServiceAccountIssuerProjection)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_core_ServiceAccountIssuerProjection_To_v1_ServiceAccountIssuerProjection(a.(*core.ServiceAccountIssuerProjection), b.(*v1.ServiceAccountIssuerProjection), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.ServiceAccountList)(nil), (*core.ServiceAccountList)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_ServiceAccountList_To_core_ServiceAccountList(a.(*v1.ServiceAccountList), b.(*core.ServiceAccountList), scope)
 	}); err != nil {
@@ -6256,6 +6276,7 @@ func autoConvert_v1_PodStatus_To_core_PodStatus(in *v1.PodStatus, out *core.PodS
 	out.ContainerStatuses = *(*[]core.ContainerStatus)(unsafe.Pointer(&in.ContainerStatuses))
 	out.QOSClass = core.PodQOSClass(in.QOSClass)
 	out.EphemeralContainerStatuses = *(*[]core.ContainerStatus)(unsafe.Pointer(&in.EphemeralContainerStatuses))
+	out.VolumeStatuses = *(*[]core.PodVolumeStatus)(unsafe.Pointer(&in.VolumeStatuses))
 	return nil
 }
 
@@ -6272,6 +6293,7 @@ func autoConvert_core_PodStatus_To_v1_PodStatus(in *core.PodStatus, out *v1.PodS
 	out.InitContainerStatuses = *(*[]v1.ContainerStatus)(unsafe.Pointer(&in.InitContainerStatuses))
 	out.ContainerStatuses = *(*[]v1.ContainerStatus)(unsafe.Pointer(&in.ContainerStatuses))
 	out.EphemeralContainerStatuses = *(*[]v1.ContainerStatus)(unsafe.Pointer(&in.EphemeralContainerStatuses))
+	out.VolumeStatuses = *(*[]v1.PodVolumeStatus)(unsafe.Pointer(&in.VolumeStatuses))
 	return nil
 }
 
@@ -6385,6 +6407,32 @@ func autoConvert_core_PodTemplateSpec_To_v1_PodTemplateSpec(in *core.PodTemplate
 	return nil
 }
 
+func autoConvert_v1_PodVolumeStatus_To_core_PodVolumeStatus(in *v1.PodVolumeStatus, out *core.PodVolumeStatus, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Mounted = in.Mounted
+	out.ResolvedSubPaths = *(*[]string)(unsafe.Pointer(&in.ResolvedSubPaths))
+	out.Message = in.Message
+	return nil
+}
+
+// Convert_v1_PodVolumeStatus_To_core_PodVolumeStatus is an autogenerated conversion function.
+func Convert_v1_PodVolumeStatus_To_core_PodVolumeStatus(in *v1.PodVolumeStatus, out *core.PodVolumeStatus, s conversion.Scope) error {
+	return autoConvert_v1_PodVolumeStatus_To_core_PodVolumeStatus(in, out, s)
+}
+
+func autoConvert_core_PodVolumeStatus_To_v1_PodVolumeStatus(in *core.PodVolumeStatus, out *v1.PodVolumeStatus, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Mounted = in.Mounted
+	out.ResolvedSubPaths = *(*[]string)(unsafe.Pointer(&in.ResolvedSubPaths))
+	out.Message = in.Message
+	return nil
+}
+
+// Convert_core_PodVolumeStatus_To_v1_PodVolumeStatus is an autogenerated conversion function.
+func Convert_core_PodVolumeStatus_To_v1_PodVolumeStatus(in *core.PodVolumeStatus, out *v1.PodVolumeStatus, s conversion.Scope) error {
+	return autoConvert_core_PodVolumeStatus_To_v1_PodVolumeStatus(in, out, s)
+}
+
 func autoConvert_v1_PortStatus_To_core_PortStatus(in *v1.PortStatus, out *core.PortStatus, s conversion.Scope) error {
 	out.Port = in.Port
 	out.Protocol = core.Protocol(in.Protocol)
@@ -7533,6 +7581,26 @@ func Convert_core_ServiceAccount_To_v1_ServiceAccount(in *core.ServiceAccount, o
 	return autoConvert_core_ServiceAccount_To_v1_ServiceAccount(in, out, s)
 }
 
+func autoConvert_v1_ServiceAccountIssuerProjection_To_core_ServiceAccountIssuerProjection(in *v1.ServiceAccountIssuerProjection, out *core.ServiceAccountIssuerProjection, s conversion.Scope) error {
+	out.Path = in.Path
+	return nil
+}
+
+// Convert_v1_ServiceAccountIssuerProjection_To_core_ServiceAccountIssuerProjection is an autogenerated conversion function.
+func Convert_v1_ServiceAccountIssuerProjection_To_core_ServiceAccountIssuerProjection(in *v1.ServiceAccountIssuerProjection, out *core.ServiceAccountIssuerProjection, s conversion.Scope) error {
+	return autoConvert_v1_ServiceAccountIssuerProjection_To_core_ServiceAccountIssuerProjection(in, out, s)
+}
+
+func autoConvert_core_ServiceAccountIssuerProjection_To_v1_ServiceAccountIssuerProjection(in *core.ServiceAccountIssuerProjection, out *v1.ServiceAccountIssuerProjection, s conversion.Scope) error {
+	out.Path = in.Path
+	return nil
+}
+
+// Convert_core_ServiceAccountIssuerProjection_To_v1_ServiceAccountIssuerProjection is an autogenerated conversion function.
+func Convert_core_ServiceAccountIssuerProjection_To_v1_ServiceAccountIssuerProjection(in *core.ServiceAccountIssuerProjection, out *v1.ServiceAccountIssuerProjection, s conversion.Scope) error {
+	return autoConvert_core_ServiceAccountIssuerProjection_To_v1_ServiceAccountIssuerProjection(in, out, s)
+}
+
 func autoConvert_v1_ServiceAccountList_To_core_ServiceAccountList(in *v1.ServiceAccountList, out *core.ServiceAccountList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
 	out.Items = *(*[]core.ServiceAccount)(unsafe.Pointer(&in.Items))
@@ -8148,6 +8216,7 @@ func autoConvert_v1_VolumeProjection_To_core_VolumeProjection(in *v1.VolumeProje
 	} else {
 		out.ServiceAccountToken = nil
 	}
+	out.ServiceAccountIssuer = (*core.ServiceAccountIssuerProjection)(unsafe.Pointer(in.ServiceAccountIssuer))
 	return nil
 }
 
@@ -8169,6 +8238,7 @@ func autoConvert_core_VolumeProjection_To_v1_VolumeProjection(in *core.VolumePro
 	} else {
 		out.ServiceAccountToken = nil
 	}
+	out.ServiceAccountIssuer = (*v1.ServiceAccountIssuerProjection)(unsafe.Pointer(in.ServiceAccountIssuer))
 	return nil
 }
 
@@ -0,0 +1,190 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ratchetValidationErrors filters newErrs down to the errors that are provably
+// pre-existing violations of the unchanged old object. This lets validation
+// rules be tightened without breaking updates to legacy objects persisted
+// before the rule existed: a pre-existing violation on an unchanged field is
+// tolerated, while new violations are still rejected.
+//
+// An error is only considered pre-existing when
+//  1. an error with the same field path, type, bad value and detail was
+//     produced for the old object, and
+//  2. the part of the object the error points at is unchanged between the
+//     old and new object. For paths that traverse a list, the whole element
+//     at the index must be unchanged, so errors inside entries that were
+//     reordered or replaced by a different violating entry are not ratcheted.
+func ratchetValidationErrors(newObj, oldObj interface{}, newErrs, oldErrs field.ErrorList) field.ErrorList {
+	if len(newErrs) == 0 || len(oldErrs) == 0 {
+		return newErrs
+	}
+	remaining := make(field.ErrorList, 0, len(newErrs))
+	for _, err := range newErrs {
+		if !errorInList(err, oldErrs) || !unchangedAtPath(newObj, oldObj, err.Field) {
+			remaining = append(remaining, err)
+		}
+	}
+	return remaining
+}
+
+// errorInList reports whether an error with the same field path, type, bad
+// value and detail as err is present in list.
+func errorInList(err *field.Error, list field.ErrorList) bool {
+	for _, candidate := range list {
+		if err.Field == candidate.Field &&
+			err.Type == candidate.Type &&
+			err.Detail == candidate.Detail &&
+			equality.Semantic.DeepEqual(err.BadValue, candidate.BadValue) {
+			return true
+		}
+	}
+	return false
+}
+
+// unchangedAtPath reports whether the value a field path points at is
+// semantically equal in both objects. Resolution stops at the first list
+// index in the path and compares the whole element, so an error inside a
+// reordered or replaced entry is attributed to the new object. Paths that
+// cannot be resolved report false, so unattributable errors are never
+// ratcheted.
+func unchangedAtPath(newObj, oldObj interface{}, path string) bool {
+	segments, ok := splitFieldPath(path)
+	if !ok {
+		return false
+	}
+	newValue, ok := resolveFieldPath(reflect.ValueOf(newObj), segments)
+	if !ok {
+		return false
+	}
+	oldValue, ok := resolveFieldPath(reflect.ValueOf(oldObj), segments)
+	if !ok {
+		return false
+	}
+	return equality.Semantic.DeepEqual(newValue.Interface(), oldValue.Interface())
+}
+
+// fieldPathSegment is one step of a field path: either a named field of a
+// struct, or a bracketed list index or map key.
+type fieldPathSegment struct {
+	name    string
+	key     string
+	bracket bool
+}
+
+// splitFieldPath parses a rendered field.Path such as
+// "spec.containers[2].name" or "metadata.annotations[example.com/key]" into
+// its segments. Map keys may contain dots, so brackets are honored while
+// splitting.
+func splitFieldPath(path string) ([]fieldPathSegment, bool) {
+	var segments []fieldPathSegment
+	current := ""
+	flush := func() {
+		if current != "" {
+			segments = append(segments, fieldPathSegment{name: current})
+			current = ""
+		}
+	}
+	for i := 0; i < len(path); i++ {
+		switch path[i] {
+		case '.':
+			flush()
+		case '[':
+			flush()
+			end := strings.IndexByte(path[i:], ']')
+			if end < 0 {
+				return nil, false
+			}
+			segments = append(segments, fieldPathSegment{key: path[i+1 : i+end], bracket: true})
+			i += end
+		case ']':
+			return nil, false
+		default:
+			current += string(path[i])
+		}
+	}
+	flush()
+	if len(segments) == 0 {
+		return nil, false
+	}
+	return segments, true
+}
+
+// resolveFieldPath walks the segments through value. Struct fields are
+// matched case-insensitively against the names validation uses in field
+// paths, which follow the serialized form; "metadata" resolves to ObjectMeta.
+// Resolution stops at the first list index and returns the whole element.
+func resolveFieldPath(value reflect.Value, segments []fieldPathSegment) (reflect.Value, bool) {
+	for _, segment := range segments {
+		for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+			if value.IsNil() {
+				return reflect.Value{}, false
+			}
+			value = value.Elem()
+		}
+		if segment.bracket {
+			switch value.Kind() {
+			case reflect.Slice, reflect.Array:
+				index, err := strconv.Atoi(segment.key)
+				if err != nil || index < 0 || index >= value.Len() {
+					return reflect.Value{}, false
+				}
+				// Return the whole element: if it is unchanged, every error
+				// inside it is pre-existing; if not, nothing inside it may
+				// be ratcheted.
+				return value.Index(index), true
+			case reflect.Map:
+				entry := value.MapIndex(reflect.ValueOf(segment.key))
+				if !entry.IsValid() {
+					return reflect.Value{}, false
+				}
+				value = entry
+			default:
+				return reflect.Value{}, false
+			}
+			continue
+		}
+		if value.Kind() != reflect.Struct {
+			return reflect.Value{}, false
+		}
+		fieldName := segment.name
+		if strings.EqualFold(fieldName, "metadata") {
+			fieldName = "ObjectMeta"
+		}
+		fieldValue := reflect.Value{}
+		for i := 0; i < value.NumField(); i++ {
+			if strings.EqualFold(value.Type().Field(i).Name, fieldName) {
+				fieldValue = value.Field(i)
+				break
+			}
+		}
+		if !fieldValue.IsValid() {
+			return reflect.Value{}, false
+		}
+		value = fieldValue
+	}
+	return value, true
+}
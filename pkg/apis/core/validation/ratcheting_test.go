@@ -0,0 +1,145 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+type ratchetTestEntry struct {
+	Name string
+}
+
+type ratchetTestSpec struct {
+	Foo     string
+	Entries []ratchetTestEntry
+	Labels  map[string]string
+}
+
+type ratchetTestObject struct {
+	Spec ratchetTestSpec
+}
+
+func TestRatchetValidationErrors(t *testing.T) {
+	specPath := field.NewPath("spec")
+	preExisting := field.Invalid(specPath.Child("foo"), "legacy", "must be a qualified name")
+	changedValue := field.Invalid(specPath.Child("foo"), "new-bad-value", "must be a qualified name")
+	otherField := field.Required(specPath.Child("bar"), "")
+	entryName := field.Invalid(specPath.Child("entries").Index(0).Child("name"), "bad", "must be a qualified name")
+	labelValue := field.Invalid(specPath.Child("labels").Key("example.com/key"), "bad", "must be a qualified name")
+
+	legacyObject := ratchetTestObject{Spec: ratchetTestSpec{Foo: "legacy"}}
+
+	tests := []struct {
+		name           string
+		newObj, oldObj ratchetTestObject
+		newErrs        field.ErrorList
+		oldErrs        field.ErrorList
+		expect         field.ErrorList
+	}{
+		{
+			name:    "no errors pass through",
+			newObj:  legacyObject,
+			oldObj:  legacyObject,
+			newErrs: nil,
+			oldErrs: field.ErrorList{preExisting},
+			expect:  nil,
+		},
+		{
+			name:    "no old errors keeps everything",
+			newObj:  legacyObject,
+			oldObj:  legacyObject,
+			newErrs: field.ErrorList{preExisting, otherField},
+			oldErrs: nil,
+			expect:  field.ErrorList{preExisting, otherField},
+		},
+		{
+			name:    "pre-existing violation on unchanged field is tolerated",
+			newObj:  legacyObject,
+			oldObj:  legacyObject,
+			newErrs: field.ErrorList{preExisting, otherField},
+			oldErrs: field.ErrorList{preExisting},
+			expect:  field.ErrorList{otherField},
+		},
+		{
+			name:    "changing a violating field to another bad value is rejected",
+			newObj:  ratchetTestObject{Spec: ratchetTestSpec{Foo: "new-bad-value"}},
+			oldObj:  legacyObject,
+			newErrs: field.ErrorList{changedValue},
+			oldErrs: field.ErrorList{preExisting},
+			expect:  field.ErrorList{changedValue},
+		},
+		{
+			name:    "identical error on an unchanged list entry is tolerated",
+			newObj:  ratchetTestObject{Spec: ratchetTestSpec{Entries: []ratchetTestEntry{{Name: "bad"}}}},
+			oldObj:  ratchetTestObject{Spec: ratchetTestSpec{Entries: []ratchetTestEntry{{Name: "bad"}}}},
+			newErrs: field.ErrorList{entryName},
+			oldErrs: field.ErrorList{entryName},
+			expect:  field.ErrorList{},
+		},
+		{
+			name: "identical error on a reordered list entry is rejected",
+			// The entry at index 0 is a different one than the entry the old
+			// error was reported for, even though both produce the same
+			// rendered error.
+			newObj:  ratchetTestObject{Spec: ratchetTestSpec{Entries: []ratchetTestEntry{{Name: "bad"}, {Name: "good"}}}},
+			oldObj:  ratchetTestObject{Spec: ratchetTestSpec{Entries: []ratchetTestEntry{{Name: "good"}, {Name: "bad"}}}},
+			newErrs: field.ErrorList{entryName},
+			oldErrs: field.ErrorList{entryName},
+			expect:  field.ErrorList{entryName},
+		},
+		{
+			name:    "identical error on a changed list entry is rejected",
+			newObj:  ratchetTestObject{Spec: ratchetTestSpec{Entries: []ratchetTestEntry{{Name: "bad"}}}},
+			oldObj:  ratchetTestObject{Spec: ratchetTestSpec{Entries: []ratchetTestEntry{{Name: "other"}}}},
+			newErrs: field.ErrorList{entryName},
+			oldErrs: field.ErrorList{entryName},
+			expect:  field.ErrorList{entryName},
+		},
+		{
+			name:    "identical error on an unchanged map entry is tolerated",
+			newObj:  ratchetTestObject{Spec: ratchetTestSpec{Labels: map[string]string{"example.com/key": "bad"}}},
+			oldObj:  ratchetTestObject{Spec: ratchetTestSpec{Labels: map[string]string{"example.com/key": "bad"}}},
+			newErrs: field.ErrorList{labelValue},
+			oldErrs: field.ErrorList{labelValue},
+			expect:  field.ErrorList{},
+		},
+		{
+			name:    "identical error on a changed map entry is rejected",
+			newObj:  ratchetTestObject{Spec: ratchetTestSpec{Labels: map[string]string{"example.com/key": "bad"}}},
+			oldObj:  ratchetTestObject{Spec: ratchetTestSpec{Labels: map[string]string{"example.com/key": "other"}}},
+			newErrs: field.ErrorList{labelValue},
+			oldErrs: field.ErrorList{labelValue},
+			expect:  field.ErrorList{labelValue},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := ratchetValidationErrors(tc.newObj, tc.oldObj, tc.newErrs, tc.oldErrs)
+			if len(got) != len(tc.expect) {
+				t.Fatalf("expected %d errors, got %d: %v", len(tc.expect), len(got), got)
+			}
+			for i := range got {
+				if got[i].Error() != tc.expect[i].Error() {
+					t.Errorf("error %d: expected %v, got %v", i, tc.expect[i], got[i])
+				}
+			}
+		})
+	}
+}
@@ -1145,6 +1145,14 @@ func validateProjectionSources(projection *core.ProjectedVolumeSource, projectio
 				allErrs = append(allErrs, field.Required(fldPath.Child("path"), ""))
 			}
 		}
+		if projPath := srcPath.Child("serviceAccountIssuer"); source.ServiceAccountIssuer != nil {
+			numSources++
+			if source.ServiceAccountIssuer.Path == "" {
+				allErrs = append(allErrs, field.Required(projPath.Child("path"), ""))
+			} else {
+				allErrs = append(allErrs, validateLocalDescendingPath(source.ServiceAccountIssuer.Path, projPath.Child("path"))...)
+			}
+		}
 		if numSources > 1 {
 			allErrs = append(allErrs, field.Forbidden(srcPath, "may not specify more than 1 volume type"))
 		}
@@ -1645,6 +1653,8 @@ var allowedPVCTemplateObjectMetaFields = map[string]bool{
 type PersistentVolumeSpecValidationOptions struct {
 	// Allow spec to contain the "ReadWiteOncePod" access mode
 	AllowReadWriteOncePod bool
+	// Tolerate pre-existing violations on unchanged fields during update
+	EnableValidationRatcheting bool
 }
 
 // ValidatePersistentVolumeName checks that a name is appropriate for a
@@ -1659,7 +1669,8 @@ var supportedVolumeModes = sets.NewString(string(core.PersistentVolumeBlock), st
 
 func ValidationOptionsForPersistentVolume(pv, oldPv *core.PersistentVolume) PersistentVolumeSpecValidationOptions {
 	opts := PersistentVolumeSpecValidationOptions{
-		AllowReadWriteOncePod: utilfeature.DefaultFeatureGate.Enabled(features.ReadWriteOncePod),
+		AllowReadWriteOncePod:      utilfeature.DefaultFeatureGate.Enabled(features.ReadWriteOncePod),
+		EnableValidationRatcheting: utilfeature.DefaultFeatureGate.Enabled(features.ValidationRatcheting),
 	}
 	if oldPv == nil {
 		// If there's no old PV, use the options based solely on feature enablement
@@ -1973,6 +1984,11 @@ func ValidatePersistentVolume(pv *core.PersistentVolume, opts PersistentVolumeSp
 // newPv is updated with fields that cannot be changed.
 func ValidatePersistentVolumeUpdate(newPv, oldPv *core.PersistentVolume, opts PersistentVolumeSpecValidationOptions) field.ErrorList {
 	allErrs := ValidatePersistentVolume(newPv, opts)
+	if opts.EnableValidationRatcheting {
+		// Tolerate pre-existing violations on unchanged fields so that objects
+		// stored before a validation rule was tightened can still be updated.
+		allErrs = ratchetValidationErrors(newPv, oldPv, allErrs, ValidatePersistentVolume(oldPv, opts))
+	}
 
 	// if oldPV does not have ControllerExpandSecretRef then allow it to be set
 	if (oldPv.Spec.CSI != nil && oldPv.Spec.CSI.ControllerExpandSecretRef == nil) &&
@@ -2010,12 +2026,18 @@ type PersistentVolumeClaimSpecValidationOptions struct {
 	AllowReadWriteOncePod bool
 	// Allow users to recover from previously failing expansion operation
 	EnableRecoverFromExpansionFailure bool
+	// Allow assigning StorageClassName to unbound PVCs retroactively
+	EnableRetroactiveDefaultStorageClass bool
+	// Tolerate pre-existing violations on unchanged fields during update
+	EnableValidationRatcheting bool
 }
 
 func ValidationOptionsForPersistentVolumeClaim(pvc, oldPvc *core.PersistentVolumeClaim) PersistentVolumeClaimSpecValidationOptions {
 	opts := PersistentVolumeClaimSpecValidationOptions{
-		AllowReadWriteOncePod:             utilfeature.DefaultFeatureGate.Enabled(features.ReadWriteOncePod),
-		EnableRecoverFromExpansionFailure: utilfeature.DefaultFeatureGate.Enabled(features.RecoverVolumeExpansionFailure),
+		AllowReadWriteOncePod:                utilfeature.DefaultFeatureGate.Enabled(features.ReadWriteOncePod),
+		EnableRecoverFromExpansionFailure:    utilfeature.DefaultFeatureGate.Enabled(features.RecoverVolumeExpansionFailure),
+		EnableRetroactiveDefaultStorageClass: utilfeature.DefaultFeatureGate.Enabled(features.RetroactiveDefaultStorageClass),
+		EnableValidationRatcheting:           utilfeature.DefaultFeatureGate.Enabled(features.ValidationRatcheting),
 	}
 	if oldPvc == nil {
 		// If there's no old PVC, use the options based solely on feature enablement
@@ -2140,7 +2162,11 @@ func ValidatePersistentVolumeClaimSpec(spec *core.PersistentVolumeClaimSpec, fld
 // ValidatePersistentVolumeClaimUpdate validates an update to a PersistentVolumeClaim
 func ValidatePersistentVolumeClaimUpdate(newPvc, oldPvc *core.PersistentVolumeClaim, opts PersistentVolumeClaimSpecValidationOptions) field.ErrorList {
 	allErrs := ValidateObjectMetaUpdate(&newPvc.ObjectMeta, &oldPvc.ObjectMeta, field.NewPath("metadata"))
-	allErrs = append(allErrs, ValidatePersistentVolumeClaim(newPvc, opts)...)
+	newErrs := ValidatePersistentVolumeClaim(newPvc, opts)
+	if opts.EnableValidationRatcheting {
+		newErrs = ratchetValidationErrors(newPvc, oldPvc, newErrs, ValidatePersistentVolumeClaim(oldPvc, opts))
+	}
+	allErrs = append(allErrs, newErrs...)
 	newPvcClone := newPvc.DeepCopy()
 	oldPvcClone := oldPvc.DeepCopy()
 
@@ -2161,6 +2187,12 @@ func ValidatePersistentVolumeClaimUpdate(newPvc, oldPvc *core.PersistentVolumeCl
 		allErrs = append(allErrs, ValidateImmutableAnnotation(newPvc.ObjectMeta.Annotations[v1.BetaStorageClassAnnotation], oldPvc.ObjectMeta.Annotations[v1.BetaStorageClassAnnotation], v1.BetaStorageClassAnnotation, field.NewPath("metadata"))...)
 	}
 
+	if opts.EnableRetroactiveDefaultStorageClass && oldPvcClone.Spec.StorageClassName == nil && newPvcClone.Spec.StorageClassName != nil {
+		// A nil storage class may gain a value once, so the default class
+		// can be assigned retroactively.
+		oldPvcClone.Spec.StorageClassName = newPvcClone.Spec.StorageClassName // +k8s:verify-mutation:reason=clone
+	}
+
 	// lets make sure storage values are same.
 	if newPvc.Status.Phase == core.ClaimBound && newPvcClone.Spec.Resources.Requests != nil {
 		newPvcClone.Spec.Resources.Requests["storage"] = oldPvc.Spec.Resources.Requests["storage"] // +k8s:verify-mutation:reason=clone
@@ -3400,6 +3432,8 @@ type PodValidationOptions struct {
 	AllowOSField bool
 	// Allow sysctl name to contain a slash
 	AllowSysctlRegexContainSlash bool
+	// Tolerate pre-existing violations on unchanged fields during update
+	EnableValidationRatcheting bool
 }
 
 // validatePodMetadataAndSpec tests if required fields in the pod.metadata and pod.spec are set,
@@ -4262,7 +4296,11 @@ func validateSeccompAnnotationsAndFieldsMatch(annotationValue string, seccompFie
 func ValidatePodUpdate(newPod, oldPod *core.Pod, opts PodValidationOptions) field.ErrorList {
 	fldPath := field.NewPath("metadata")
 	allErrs := ValidateObjectMetaUpdate(&newPod.ObjectMeta, &oldPod.ObjectMeta, fldPath)
-	allErrs = append(allErrs, validatePodMetadataAndSpec(newPod, opts)...)
+	newErrs := validatePodMetadataAndSpec(newPod, opts)
+	if opts.EnableValidationRatcheting {
+		newErrs = ratchetValidationErrors(newPod, oldPod, newErrs, validatePodMetadataAndSpec(oldPod, opts))
+	}
+	allErrs = append(allErrs, newErrs...)
 	allErrs = append(allErrs, ValidatePodSpecificAnnotationUpdates(newPod, oldPod, fldPath.Child("annotations"), opts)...)
 	specPath := field.NewPath("spec")
 
@@ -4009,6 +4009,13 @@ func (in *PodStatus) DeepCopyInto(out *PodStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.VolumeStatuses != nil {
+		in, out := &in.VolumeStatuses, &out.VolumeStatuses
+		*out = make([]PodVolumeStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -4127,6 +4134,27 @@ func (in *PodTemplateSpec) DeepCopy() *PodTemplateSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodVolumeStatus) DeepCopyInto(out *PodVolumeStatus) {
+	*out = *in
+	if in.ResolvedSubPaths != nil {
+		in, out := &in.ResolvedSubPaths, &out.ResolvedSubPaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodVolumeStatus.
+func (in *PodVolumeStatus) DeepCopy() *PodVolumeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PodVolumeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PortStatus) DeepCopyInto(out *PortStatus) {
 	*out = *in
@@ -5217,6 +5245,22 @@ func (in *ServiceAccount) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceAccountIssuerProjection) DeepCopyInto(out *ServiceAccountIssuerProjection) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountIssuerProjection.
+func (in *ServiceAccountIssuerProjection) DeepCopy() *ServiceAccountIssuerProjection {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceAccountIssuerProjection)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceAccountList) DeepCopyInto(out *ServiceAccountList) {
 	*out = *in
@@ -5781,6 +5825,11 @@ func (in *VolumeProjection) DeepCopyInto(out *VolumeProjection) {
 		*out = new(ServiceAccountTokenProjection)
 		**out = **in
 	}
+	if in.ServiceAccountIssuer != nil {
+		in, out := &in.ServiceAccountIssuer, &out.ServiceAccountIssuer
+		*out = new(ServiceAccountIssuerProjection)
+		**out = **in
+	}
 	return
 }
 
@@ -26,6 +26,24 @@ const IsDefaultStorageClassAnnotation = "storageclass.kubernetes.io/is-default-c
 // TODO: remove Beta when no longer used
 const BetaIsDefaultStorageClassAnnotation = "storageclass.beta.kubernetes.io/is-default-class"
 
+// DeprecatedStorageClassAnnotation marks a StorageClass as deprecated. New
+// claims using the class receive an admission warning.
+const DeprecatedStorageClassAnnotation = "storageclass.kubernetes.io/deprecated"
+
+// BlockNewClaimsAnnotation escalates a StorageClass deprecation: new claims
+// using the class are rejected at admission.
+const BlockNewClaimsAnnotation = "storageclass.kubernetes.io/block-new-claims"
+
+// ReplacementClassAnnotation names the StorageClass users of a deprecated
+// class should migrate to.
+const ReplacementClassAnnotation = "storageclass.kubernetes.io/replacement-class"
+
+// IsDeprecatedAnnotation returns true if the StorageClass is marked as
+// deprecated.
+func IsDeprecatedAnnotation(obj metav1.ObjectMeta) bool {
+	return obj.Annotations[DeprecatedStorageClassAnnotation] == "true"
+}
+
 // IsDefaultAnnotationText returns a pretty Yes/No String if
 // the annotation is set
 // TODO: remove Beta when no longer needed
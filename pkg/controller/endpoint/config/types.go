@@ -33,4 +33,11 @@ type EndpointControllerConfiguration struct {
 	// in that period, they will be batched to a single endpoint update.
 	// Default 0 value means that each pod update triggers an endpoint update.
 	EndpointUpdatesBatchPeriod metav1.Duration
+
+	// EndpointSliceOnlyServiceSize is the number of endpoints above which the
+	// controller stops maintaining the legacy Endpoints object of a service,
+	// leaving EndpointSlices as its only endpoint representation.
+	// Default 0 value means that Endpoints objects are maintained for all
+	// services.
+	EndpointSliceOnlyServiceSize int32
 }
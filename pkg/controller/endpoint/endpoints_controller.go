@@ -25,6 +25,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -45,6 +46,7 @@ import (
 	api "k8s.io/kubernetes/pkg/apis/core"
 	helper "k8s.io/kubernetes/pkg/apis/core/v1/helper"
 	"k8s.io/kubernetes/pkg/controller"
+	endpointmetrics "k8s.io/kubernetes/pkg/controller/endpoint/metrics"
 	endpointutil "k8s.io/kubernetes/pkg/controller/util/endpoint"
 	utillabels "k8s.io/kubernetes/pkg/util/labels"
 	utilnet "k8s.io/utils/net"
@@ -67,11 +69,29 @@ const (
 	// endpoint resource and indicates that the number of endpoints have been truncated to
 	// maxCapacity
 	truncated = "truncated"
+
+	// LegacyEndpointsMaintained is the condition type published on a service
+	// to record whether the controller maintains a legacy Endpoints object
+	// for it. The condition is False for services above the
+	// EndpointSlice-only size threshold, whose endpoints are represented by
+	// EndpointSlices alone.
+	LegacyEndpointsMaintained = "LegacyEndpointsMaintained"
+
+	// serviceTooLargeReason is the condition reason used when the legacy
+	// Endpoints object of a service is not maintained because the service
+	// exceeds the EndpointSlice-only size threshold.
+	serviceTooLargeReason = "ServiceTooLarge"
+
+	// belowThresholdReason is the condition reason used when a service
+	// dropped back below the EndpointSlice-only size threshold and its
+	// legacy Endpoints object is maintained again.
+	belowThresholdReason = "BelowThreshold"
 )
 
 // NewEndpointController returns a new *Controller.
 func NewEndpointController(podInformer coreinformers.PodInformer, serviceInformer coreinformers.ServiceInformer,
-	endpointsInformer coreinformers.EndpointsInformer, client clientset.Interface, endpointUpdatesBatchPeriod time.Duration) *Controller {
+	endpointsInformer coreinformers.EndpointsInformer, client clientset.Interface, endpointUpdatesBatchPeriod time.Duration,
+	endpointSliceOnlyServiceSize int) *Controller {
 	broadcaster := record.NewBroadcaster()
 	broadcaster.StartStructuredLogging(0)
 	broadcaster.StartRecordingToSink(&v1core.EventSinkImpl{Interface: client.CoreV1().Events("")})
@@ -115,9 +135,12 @@ func NewEndpointController(podInformer coreinformers.PodInformer, serviceInforme
 	e.eventRecorder = recorder
 
 	e.endpointUpdatesBatchPeriod = endpointUpdatesBatchPeriod
+	e.endpointSliceOnlyServiceSize = endpointSliceOnlyServiceSize
 
 	e.serviceSelectorCache = endpointutil.NewServiceSelectorCache()
 
+	endpointmetrics.RegisterMetrics()
+
 	return e
 }
 
@@ -164,6 +187,12 @@ type Controller struct {
 
 	endpointUpdatesBatchPeriod time.Duration
 
+	// endpointSliceOnlyServiceSize is the number of endpoints above which
+	// the controller stops maintaining the legacy Endpoints object for a
+	// service, leaving EndpointSlices as its only representation. Zero
+	// keeps Endpoints for all services.
+	endpointSliceOnlyServiceSize int
+
 	// serviceSelectorCache is a cache of service selectors to avoid high CPU consumption caused by frequent calls
 	// to AsSelectorPreValidated (see #73527)
 	serviceSelectorCache *endpointutil.ServiceSelectorCache
@@ -402,6 +431,13 @@ func (e *Controller) syncService(ctx context.Context, key string) error {
 		return err
 	}
 
+	if e.endpointSliceOnlyServiceSize > 0 && len(pods) > e.endpointSliceOnlyServiceSize {
+		// The service is too large for dual-writing its endpoints; the
+		// EndpointSlices maintained by the endpointslice controller become
+		// its only representation.
+		return e.disableLegacyEndpoints(ctx, service, len(pods))
+	}
+
 	// If the user specified the older (deprecated) annotation, we have to respect it.
 	tolerateUnreadyEndpoints := service.Spec.PublishNotReadyAddresses
 
@@ -558,9 +594,56 @@ func (e *Controller) syncService(ctx context.Context, key string) error {
 
 		return err
 	}
+
+	// Clear the warning condition once a service dropped back below the
+	// EndpointSlice-only threshold. Only services that carry the condition
+	// are updated, so this does not add writes for the common case.
+	if cond := apimeta.FindStatusCondition(service.Status.Conditions, LegacyEndpointsMaintained); cond != nil && cond.Status == metav1.ConditionFalse {
+		return e.setLegacyEndpointsCondition(ctx, service, metav1.ConditionTrue, belowThresholdReason,
+			fmt.Sprintf("The service is below the EndpointSlice-only size threshold %d; its Endpoints object is maintained", e.endpointSliceOnlyServiceSize))
+	}
 	return nil
 }
 
+// disableLegacyEndpoints stops maintaining the legacy Endpoints object of a
+// service that exceeds the EndpointSlice-only size threshold: an existing
+// Endpoints object is deleted and a warning condition is published on the
+// service. The endpointslice controller keeps maintaining the service's
+// EndpointSlices, so consumers reading them are unaffected.
+func (e *Controller) disableLegacyEndpoints(ctx context.Context, service *v1.Service, endpointCount int) error {
+	endpointmetrics.LegacyEndpointsSkippedSyncs.Inc()
+	if _, err := e.endpointsLister.Endpoints(service.Namespace).Get(service.Name); err == nil {
+		if err := e.client.CoreV1().Endpoints(service.Namespace).Delete(ctx, service.Name, metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		klog.V(2).Infof("Deleted endpoints of service %s/%s: %d endpoints is above the EndpointSlice-only threshold %d", service.Namespace, service.Name, endpointCount, e.endpointSliceOnlyServiceSize)
+		e.eventRecorder.Eventf(service, v1.EventTypeWarning, "LegacyEndpointsDisabled", "Service has %d endpoints, above the EndpointSlice-only threshold %d; its Endpoints object is no longer maintained", endpointCount, e.endpointSliceOnlyServiceSize)
+		endpointmetrics.LegacyEndpointsDisabled.Inc()
+	} else if !errors.IsNotFound(err) {
+		return err
+	}
+	return e.setLegacyEndpointsCondition(ctx, service, metav1.ConditionFalse, serviceTooLargeReason,
+		fmt.Sprintf("The service has %d endpoints, above the EndpointSlice-only size threshold %d; its Endpoints object is not maintained", endpointCount, e.endpointSliceOnlyServiceSize))
+}
+
+// setLegacyEndpointsCondition publishes the LegacyEndpointsMaintained
+// condition on the service, writing its status only when the condition
+// actually changes.
+func (e *Controller) setLegacyEndpointsCondition(ctx context.Context, service *v1.Service, status metav1.ConditionStatus, reason, message string) error {
+	if cond := apimeta.FindStatusCondition(service.Status.Conditions, LegacyEndpointsMaintained); cond != nil && cond.Status == status && cond.Message == message {
+		return nil
+	}
+	updated := service.DeepCopy()
+	apimeta.SetStatusCondition(&updated.Status.Conditions, metav1.Condition{
+		Type:    LegacyEndpointsMaintained,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	})
+	_, err := e.client.CoreV1().Services(updated.Namespace).UpdateStatus(ctx, updated, metav1.UpdateOptions{})
+	return err
+}
+
 // checkLeftoverEndpoints lists all currently existing endpoints and adds their
 // service to the queue. This will detect endpoints that exist with no
 // corresponding service; these endpoints need to be deleted. We only need to
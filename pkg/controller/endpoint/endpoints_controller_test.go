@@ -27,6 +27,8 @@ import (
 	"time"
 
 	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -213,7 +215,7 @@ func newController(url string, batchPeriod time.Duration) *endpointController {
 	client := clientset.NewForConfigOrDie(&restclient.Config{Host: url, ContentConfig: restclient.ContentConfig{GroupVersion: &schema.GroupVersion{Group: "", Version: "v1"}}})
 	informerFactory := informers.NewSharedInformerFactory(client, controllerpkg.NoResyncPeriodFunc())
 	endpoints := NewEndpointController(informerFactory.Core().V1().Pods(), informerFactory.Core().V1().Services(),
-		informerFactory.Core().V1().Endpoints(), client, batchPeriod)
+		informerFactory.Core().V1().Endpoints(), client, batchPeriod, 0)
 	endpoints.podsSynced = alwaysReady
 	endpoints.servicesSynced = alwaysReady
 	endpoints.endpointsSynced = alwaysReady
@@ -234,7 +236,8 @@ func newFakeController(batchPeriod time.Duration) (*fake.Clientset, *endpointCon
 		informerFactory.Core().V1().Services(),
 		informerFactory.Core().V1().Endpoints(),
 		client,
-		batchPeriod)
+		batchPeriod,
+		0)
 
 	eController.podsSynced = alwaysReady
 	eController.servicesSynced = alwaysReady
@@ -2151,6 +2154,128 @@ func TestSyncServiceOverCapacity(t *testing.T) {
 	}
 }
 
+func TestSyncServiceEndpointSliceOnly(t *testing.T) {
+	conditionFalse := metav1.ConditionFalse
+	conditionTrue := metav1.ConditionTrue
+
+	testCases := []struct {
+		name              string
+		threshold         int
+		numPods           int
+		existingEndpoints bool
+		startingCondition *metav1.ConditionStatus
+		expectEndpoints   bool
+		expectedCondition *metav1.ConditionStatus
+	}{{
+		name:              "threshold disabled maintains endpoints of large service",
+		threshold:         0,
+		numPods:           5,
+		existingEndpoints: false,
+		expectEndpoints:   true,
+	}, {
+		name:              "below threshold maintains endpoints",
+		threshold:         10,
+		numPods:           5,
+		existingEndpoints: false,
+		expectEndpoints:   true,
+	}, {
+		name:              "above threshold deletes existing endpoints",
+		threshold:         3,
+		numPods:           5,
+		existingEndpoints: true,
+		expectEndpoints:   false,
+		expectedCondition: &conditionFalse,
+	}, {
+		name:              "above threshold does not create endpoints",
+		threshold:         3,
+		numPods:           5,
+		existingEndpoints: false,
+		expectEndpoints:   false,
+		expectedCondition: &conditionFalse,
+	}, {
+		name:              "condition cleared when back below threshold",
+		threshold:         10,
+		numPods:           5,
+		existingEndpoints: false,
+		startingCondition: &conditionFalse,
+		expectEndpoints:   true,
+		expectedCondition: &conditionTrue,
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ns := "test"
+			client, c := newFakeController(0 * time.Second)
+			c.endpointSliceOnlyServiceSize = tc.threshold
+
+			addPods(c.podStore, ns, tc.numPods, 1, 0, ipv4only)
+
+			svc := &v1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: ns},
+				Spec: v1.ServiceSpec{
+					Selector: map[string]string{"foo": "bar"},
+					Ports:    []v1.ServicePort{{Port: 80}},
+				},
+			}
+			if tc.startingCondition != nil {
+				svc.Status.Conditions = []metav1.Condition{{
+					Type:               LegacyEndpointsMaintained,
+					Status:             *tc.startingCondition,
+					Reason:             serviceTooLargeReason,
+					LastTransitionTime: metav1.Now(),
+				}}
+			}
+			c.serviceStore.Add(svc)
+			client.CoreV1().Services(ns).Create(context.TODO(), svc, metav1.CreateOptions{})
+
+			if tc.existingEndpoints {
+				endpoints := &v1.Endpoints{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            svc.Name,
+						Namespace:       ns,
+						ResourceVersion: "1",
+					},
+					Subsets: []v1.EndpointSubset{{
+						Addresses: []v1.EndpointAddress{{IP: "1.2.3.4"}},
+						Ports:     []v1.EndpointPort{{Port: 80}},
+					}},
+				}
+				c.endpointsStore.Add(endpoints)
+				client.CoreV1().Endpoints(ns).Create(context.TODO(), endpoints, metav1.CreateOptions{})
+			}
+
+			if err := c.syncService(context.TODO(), fmt.Sprintf("%s/%s", ns, svc.Name)); err != nil {
+				t.Fatalf("unexpected error syncing service: %v", err)
+			}
+
+			_, err := client.CoreV1().Endpoints(ns).Get(context.TODO(), svc.Name, metav1.GetOptions{})
+			if tc.expectEndpoints && err != nil {
+				t.Errorf("Expected endpoints to be maintained, got error: %v", err)
+			}
+			if !tc.expectEndpoints && !apierrors.IsNotFound(err) {
+				t.Errorf("Expected endpoints not to exist, got error: %v", err)
+			}
+
+			actualService, err := client.CoreV1().Services(ns).Get(context.TODO(), svc.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("unexpected error getting service: %v", err)
+			}
+			condition := apimeta.FindStatusCondition(actualService.Status.Conditions, LegacyEndpointsMaintained)
+			if tc.expectedCondition == nil {
+				if tc.startingCondition == nil && condition != nil {
+					t.Errorf("Expected no %s condition, got %v", LegacyEndpointsMaintained, condition)
+				}
+			} else {
+				if condition == nil {
+					t.Errorf("Expected %s condition with status %s, got none", LegacyEndpointsMaintained, *tc.expectedCondition)
+				} else if condition.Status != *tc.expectedCondition {
+					t.Errorf("Expected %s condition with status %s, got %s", LegacyEndpointsMaintained, *tc.expectedCondition, condition.Status)
+				}
+			}
+		})
+	}
+}
+
 func TestTruncateEndpoints(t *testing.T) {
 	testCases := []struct {
 		desc string
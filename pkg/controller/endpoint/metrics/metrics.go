@@ -0,0 +1,62 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"sync"
+
+	"k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/legacyregistry"
+)
+
+// EndpointSubsystem - subsystem name used for the Endpoints controller.
+const EndpointSubsystem = "endpoint_controller"
+
+var (
+	// LegacyEndpointsDisabled tracks the number of times the controller
+	// stopped maintaining the legacy Endpoints object of a service because
+	// the service exceeded the EndpointSlice-only size threshold.
+	LegacyEndpointsDisabled = metrics.NewCounter(
+		&metrics.CounterOpts{
+			Subsystem:      EndpointSubsystem,
+			Name:           "legacy_endpoints_disabled_total",
+			Help:           "Number of times the legacy Endpoints object of a service was dropped because the service exceeded the EndpointSlice-only size threshold",
+			StabilityLevel: metrics.ALPHA,
+		},
+	)
+	// LegacyEndpointsSkippedSyncs tracks the number of service syncs that
+	// did not write a legacy Endpoints object because the service is above
+	// the EndpointSlice-only size threshold.
+	LegacyEndpointsSkippedSyncs = metrics.NewCounter(
+		&metrics.CounterOpts{
+			Subsystem:      EndpointSubsystem,
+			Name:           "legacy_endpoints_skipped_syncs_total",
+			Help:           "Number of service syncs that skipped writing a legacy Endpoints object because the service is above the EndpointSlice-only size threshold",
+			StabilityLevel: metrics.ALPHA,
+		},
+	)
+)
+
+var registerMetrics sync.Once
+
+// RegisterMetrics registers Endpoints controller metrics.
+func RegisterMetrics() {
+	registerMetrics.Do(func() {
+		legacyregistry.MustRegister(LegacyEndpointsDisabled)
+		legacyregistry.MustRegister(LegacyEndpointsSkippedSyncs)
+	})
+}
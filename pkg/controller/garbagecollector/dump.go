@@ -254,7 +254,13 @@ type debugHTTPHandler struct {
 }
 
 func (h *debugHTTPHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
-	if req.URL.Path != "/graph" {
+	switch req.URL.Path {
+	case "/graph":
+		// handled below
+	case "/stuckdeletions":
+		h.serveStuckDeletions(w, req)
+		return
+	default:
 		http.Error(w, "", http.StatusNotFound)
 		return
 	}
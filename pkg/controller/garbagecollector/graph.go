@@ -19,6 +19,7 @@ package garbagecollector
 import (
 	"fmt"
 	"sync"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -51,8 +52,11 @@ type node struct {
 	deletingDependents     bool
 	deletingDependentsLock sync.RWMutex
 	// this records if the object's deletionTimestamp is non-nil.
-	beingDeleted     bool
-	beingDeletedLock sync.RWMutex
+	beingDeleted bool
+	// beingDeletedSince records when the graph first observed the non-nil
+	// deletionTimestamp, so stuck deletions can be reported.
+	beingDeletedSince time.Time
+	beingDeletedLock  sync.RWMutex
 	// this records if the object was constructed virtually and never observed via informer event
 	virtual     bool
 	virtualLock sync.RWMutex
@@ -68,6 +72,7 @@ func (n *node) clone() *node {
 		dependents:         make(map[*node]struct{}, len(n.dependents)),
 		deletingDependents: n.deletingDependents,
 		beingDeleted:       n.beingDeleted,
+		beingDeletedSince:  n.beingDeletedSince,
 		virtual:            n.virtual,
 		owners:             make([]metav1.OwnerReference, 0, len(n.owners)),
 	}
@@ -85,6 +90,9 @@ func (n *node) clone() *node {
 func (n *node) markBeingDeleted() {
 	n.beingDeletedLock.Lock()
 	defer n.beingDeletedLock.Unlock()
+	if !n.beingDeleted {
+		n.beingDeletedSince = time.Now()
+	}
 	n.beingDeleted = true
 }
 
@@ -94,6 +102,14 @@ func (n *node) isBeingDeleted() bool {
 	return n.beingDeleted
 }
 
+// beingDeletedAt returns when the graph first observed the object's deletion,
+// and whether the object is being deleted at all.
+func (n *node) beingDeletedAt() (time.Time, bool) {
+	n.beingDeletedLock.RLock()
+	defer n.beingDeletedLock.RUnlock()
+	return n.beingDeletedSince, n.beingDeleted
+}
+
 func (n *node) markObserved() {
 	n.virtualLock.Lock()
 	defer n.virtualLock.Unlock()
@@ -0,0 +1,102 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package garbagecollector
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// defaultStuckDeletionTTL is how long an object may be pending deletion
+// before it is reported by the /stuckdeletions debug endpoint, unless the
+// caller supplies an explicit ttl query parameter.
+const defaultStuckDeletionTTL = 5 * time.Minute
+
+// stuckDeletion describes an object whose deletion was observed longer than
+// the requested TTL ago and which still exists in the dependency graph,
+// i.e. whose finalizers have not progressed.
+type stuckDeletion struct {
+	APIVersion string    `json:"apiVersion"`
+	Kind       string    `json:"kind"`
+	Namespace  string    `json:"namespace,omitempty"`
+	Name       string    `json:"name"`
+	UID        string    `json:"uid"`
+	DeletedAt  time.Time `json:"deletionObservedAt"`
+	Age        string    `json:"age"`
+}
+
+// stuckDeletions returns the objects in the graph whose deletion was observed
+// at least olderThan ago, sorted by how long they have been pending.
+func (m *concurrentUIDToNode) stuckDeletions(olderThan time.Duration, now time.Time) []stuckDeletion {
+	m.uidToNodeLock.RLock()
+	defer m.uidToNodeLock.RUnlock()
+
+	stuck := []stuckDeletion{}
+	for _, n := range m.uidToNode {
+		since, deleting := n.beingDeletedAt()
+		if !deleting || since.IsZero() {
+			continue
+		}
+		age := now.Sub(since)
+		if age < olderThan {
+			continue
+		}
+		stuck = append(stuck, stuckDeletion{
+			APIVersion: n.identity.APIVersion,
+			Kind:       n.identity.Kind,
+			Namespace:  n.identity.Namespace,
+			Name:       n.identity.Name,
+			UID:        string(n.identity.UID),
+			DeletedAt:  since,
+			Age:        age.Truncate(time.Second).String(),
+		})
+	}
+	sort.Slice(stuck, func(i, j int) bool {
+		if !stuck[i].DeletedAt.Equal(stuck[j].DeletedAt) {
+			return stuck[i].DeletedAt.Before(stuck[j].DeletedAt)
+		}
+		return stuck[i].UID < stuck[j].UID
+	})
+	return stuck
+}
+
+// serveStuckDeletions reports objects pending deletion beyond a TTL as JSON.
+// The TTL defaults to defaultStuckDeletionTTL and can be overridden with a
+// ttl query parameter in time.ParseDuration format, e.g. ?ttl=30s.
+func (h *debugHTTPHandler) serveStuckDeletions(w http.ResponseWriter, req *http.Request) {
+	ttl := defaultStuckDeletionTTL
+	if ttlString := req.URL.Query().Get("ttl"); len(ttlString) > 0 {
+		parsed, err := time.ParseDuration(ttlString)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		ttl = parsed
+	}
+
+	stuck := h.controller.dependencyGraphBuilder.uidToNode.stuckDeletions(ttl, time.Now())
+	data, err := json.MarshalIndent(stuck, "", "  ")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Write(data)
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package garbagecollector
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestStuckDeletions(t *testing.T) {
+	now := time.Now()
+	newGraphNode := func(uid, name string, deletedAt time.Time) *node {
+		n := &node{
+			identity: objectReference{
+				OwnerReference: metav1.OwnerReference{
+					APIVersion: "v1",
+					Kind:       "Pod",
+					Name:       name,
+					UID:        types.UID(uid),
+				},
+				Namespace: "ns1",
+			},
+		}
+		if !deletedAt.IsZero() {
+			n.beingDeleted = true
+			n.beingDeletedSince = deletedAt
+		}
+		return n
+	}
+
+	uidToNode := &concurrentUIDToNode{
+		uidToNode: map[types.UID]*node{
+			"1": newGraphNode("1", "live", time.Time{}),
+			"2": newGraphNode("2", "recently-deleted", now.Add(-time.Minute)),
+			"3": newGraphNode("3", "stuck", now.Add(-time.Hour)),
+			"4": newGraphNode("4", "stuck-longer", now.Add(-2*time.Hour)),
+		},
+	}
+
+	stuck := uidToNode.stuckDeletions(5*time.Minute, now)
+	if len(stuck) != 2 {
+		t.Fatalf("expected 2 stuck deletions, got %d: %+v", len(stuck), stuck)
+	}
+	// sorted oldest first
+	if stuck[0].Name != "stuck-longer" || stuck[1].Name != "stuck" {
+		t.Errorf("expected oldest-first ordering, got %v then %v", stuck[0].Name, stuck[1].Name)
+	}
+	if stuck[0].Age != "2h0m0s" {
+		t.Errorf("unexpected age %q", stuck[0].Age)
+	}
+}
@@ -742,6 +742,10 @@ func (jm *Controller) syncJob(ctx context.Context, key string) (forget bool, rEr
 	activePods := controller.FilterActivePods(pods)
 	active := int32(len(activePods))
 	succeeded, failed := getStatus(&job, pods, uncounted, expectedRmFinalizers)
+	var applicationFailures int32
+	if onNodeFailureReplacement(&job) {
+		applicationFailures = countApplicationFailures(pods)
+	}
 	var ready *int32
 	if feature.DefaultFeatureGate.Enabled(features.JobReadyPods) {
 		ready = pointer.Int32(countReadyPods(activePods))
@@ -800,7 +804,7 @@ func (jm *Controller) syncJob(ctx context.Context, key string) (forget bool, rEr
 	} else {
 		manageJobCalled := false
 		if satisfiedExpectations && job.DeletionTimestamp == nil {
-			active, action, manageJobErr = jm.manageJob(ctx, &job, activePods, succeeded, succeededIndexes)
+			active, action, manageJobErr = jm.manageJob(ctx, &job, activePods, succeeded, succeededIndexes, applicationFailures)
 			manageJobCalled = true
 		}
 		complete := false
@@ -1314,10 +1318,49 @@ func jobSuspended(job *batch.Job) bool {
 	return job.Spec.Suspend != nil && *job.Spec.Suspend
 }
 
+// onNodeFailureReplacement returns whether the job only replaces pods whose
+// failure was caused by the node infrastructure.
+func onNodeFailureReplacement(job *batch.Job) bool {
+	return job.Spec.PodReplacementPolicy != nil && *job.Spec.PodReplacementPolicy == batch.ReplaceOnNodeFailure
+}
+
+// countApplicationFailures returns the number of failed pods whose failure is
+// attributable to the application, i.e. pods in the Failed phase with at least
+// one container that terminated with a non-zero exit code. Pods removed from
+// the API before finishing, for example by taint-based eviction or garbage
+// collection after a node removal, never reach this count and are therefore
+// replaced under any replacement policy.
+func countApplicationFailures(pods []*v1.Pod) int32 {
+	result := int32(0)
+	for _, p := range pods {
+		if isApplicationFailure(p) {
+			result++
+		}
+	}
+	return result
+}
+
+func isApplicationFailure(p *v1.Pod) bool {
+	if p.Status.Phase != v1.PodFailed {
+		return false
+	}
+	for _, stat := range p.Status.InitContainerStatuses {
+		if stat.State.Terminated != nil && stat.State.Terminated.ExitCode != 0 {
+			return true
+		}
+	}
+	for _, stat := range p.Status.ContainerStatuses {
+		if stat.State.Terminated != nil && stat.State.Terminated.ExitCode != 0 {
+			return true
+		}
+	}
+	return false
+}
+
 // manageJob is the core method responsible for managing the number of running
 // pods according to what is specified in the job.Spec.
 // Does NOT modify <activePods>.
-func (jm *Controller) manageJob(ctx context.Context, job *batch.Job, activePods []*v1.Pod, succeeded int32, succeededIndexes []interval) (int32, string, error) {
+func (jm *Controller) manageJob(ctx context.Context, job *batch.Job, activePods []*v1.Pod, succeeded int32, succeededIndexes []interval, applicationFailures int32) (int32, string, error) {
 	active := int32(len(activePods))
 	parallelism := *job.Spec.Parallelism
 	jobKey, err := controller.KeyFunc(job)
@@ -1377,6 +1420,19 @@ func (jm *Controller) manageJob(ctx context.Context, job *batch.Job, activePods
 		return active, metrics.JobSyncActionPodsDeleted, err
 	}
 
+	if onNodeFailureReplacement(job) {
+		// Pods that failed because of the application keep their slots
+		// unfilled; pods lost to the node infrastructure are deleted from the
+		// API and thus don't count as application failures, so they are
+		// replaced as usual. Only pod creation is restricted: wantActive is
+		// never lowered below the current number of active pods, so the
+		// policy cannot trigger deletions.
+		wantActive -= applicationFailures
+		if wantActive < active {
+			wantActive = active
+		}
+	}
+
 	if active < wantActive {
 		diff := wantActive - active
 		if diff > int32(MaxPodCreateDeletePerSync) {
@@ -1892,6 +1892,124 @@ func TestSyncJobComplete(t *testing.T) {
 	}
 }
 
+func TestSyncJobOnNodeFailureReplacement(t *testing.T) {
+	always := batch.ReplaceAlways
+	onNodeFailure := batch.ReplaceOnNodeFailure
+	testCases := map[string]struct {
+		policy      *batch.PodReplacementPolicy
+		parallelism int32
+
+		// pod setup. Pods lost to the node infrastructure (evicted by a
+		// taint or garbage collected after a node removal) are deleted from
+		// the API, so they are modeled by simply not adding them to the
+		// store.
+		activePods int
+		// appFailedPods are failed pods with a container that terminated
+		// with a non-zero exit code.
+		appFailedPods int
+		// infraFailedPods are failed pods without a non-zero container exit
+		// code, for example after an abrupt node shutdown.
+		infraFailedPods int
+
+		expectedCreations int32
+		expectedDeletions int32
+	}{
+		"application failures are not replaced": {
+			policy:            &onNodeFailure,
+			parallelism:       3,
+			activePods:        1,
+			appFailedPods:     1,
+			expectedCreations: 1,
+		},
+		"pods lost to the node infrastructure are replaced": {
+			policy:            &onNodeFailure,
+			parallelism:       2,
+			activePods:        1,
+			expectedCreations: 1,
+		},
+		"failed pods without a non-zero exit code are replaced": {
+			policy:            &onNodeFailure,
+			parallelism:       2,
+			activePods:        1,
+			infraFailedPods:   1,
+			expectedCreations: 1,
+		},
+		"no creations when application failures fill all remaining slots": {
+			policy:        &onNodeFailure,
+			parallelism:   2,
+			activePods:    1,
+			appFailedPods: 2,
+		},
+		"application failures do not trigger deletions": {
+			policy:            &onNodeFailure,
+			parallelism:       2,
+			activePods:        3,
+			appFailedPods:     2,
+			expectedDeletions: 1,
+		},
+		"Always policy replaces application failures": {
+			policy:            &always,
+			parallelism:       3,
+			activePods:        1,
+			appFailedPods:     1,
+			expectedCreations: 2,
+		},
+		"unset policy replaces application failures": {
+			parallelism:       3,
+			activePods:        1,
+			appFailedPods:     1,
+			expectedCreations: 2,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			clientSet := clientset.NewForConfigOrDie(&restclient.Config{Host: "", ContentConfig: restclient.ContentConfig{GroupVersion: &schema.GroupVersion{Group: "", Version: "v1"}}})
+			manager, sharedInformerFactory := newControllerFromClient(clientSet, controller.NoResyncPeriodFunc)
+			fakePodControl := controller.FakePodControl{}
+			manager.podControl = &fakePodControl
+			manager.podStoreSynced = alwaysReady
+			manager.jobStoreSynced = alwaysReady
+			manager.updateStatusHandler = func(ctx context.Context, job *batch.Job) (*batch.Job, error) {
+				return job, nil
+			}
+
+			job := newJob(tc.parallelism, -1, 6, batch.NonIndexedCompletion)
+			job.Spec.PodReplacementPolicy = tc.policy
+			sharedInformerFactory.Batch().V1().Jobs().Informer().GetIndexer().Add(job)
+			podIndexer := sharedInformerFactory.Core().V1().Pods().Informer().GetIndexer()
+			setPodsStatuses(podIndexer, job, 0, tc.activePods, 0, tc.infraFailedPods, 0)
+			for i := 0; i < tc.appFailedPods; i++ {
+				p := newPod(fmt.Sprintf("app-failed-pod-%d", i), job)
+				p.Status = v1.PodStatus{
+					Phase: v1.PodFailed,
+					ContainerStatuses: []v1.ContainerStatus{
+						{State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{ExitCode: 1}}},
+					},
+				}
+				podIndexer.Add(p)
+			}
+
+			_, err := manager.syncJob(context.TODO(), testutil.GetKey(job, t))
+			if tc.appFailedPods+tc.infraFailedPods > 0 {
+				// New failures on an unfinished job re-enqueue it after the
+				// backoff period.
+				if err == nil {
+					t.Error("Syncing jobs expected to return error when there are new failed pods and Job didn't finish")
+				}
+			} else if err != nil {
+				t.Fatalf("Unexpected error when syncing jobs: %v", err)
+			}
+			if int32(len(fakePodControl.Templates)) != tc.expectedCreations {
+				t.Errorf("Unexpected number of creates.  Expected %d, saw %d\n", tc.expectedCreations, len(fakePodControl.Templates))
+			}
+			if int32(len(fakePodControl.DeletePodName)) != tc.expectedDeletions {
+				t.Errorf("Unexpected number of deletes.  Expected %d, saw %d\n", tc.expectedDeletions, len(fakePodControl.DeletePodName))
+			}
+		})
+	}
+}
+
 func TestSyncJobDeleted(t *testing.T) {
 	clientset := clientset.NewForConfigOrDie(&restclient.Config{Host: "", ContentConfig: restclient.ContentConfig{GroupVersion: &schema.GroupVersion{Group: "", Version: "v1"}}})
 	manager, _ := newControllerFromClient(clientset, controller.NoResyncPeriodFunc)
@@ -0,0 +1,146 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package memorybudget implements a soft memory budget for the controller
+// manager. Subsystems whose work is optional register shedders with the
+// manager; when the memory held by the Go runtime exceeds the configured
+// budget the manager sheds them one at a time, and restores them once usage
+// drops well below the budget again. This keeps critical loops such as the
+// persistent volume binder and node lifecycle controller alive under memory
+// pressure instead of letting the whole process be OOM-killed.
+package memorybudget
+
+import (
+	"runtime"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// defaultCheckPeriod is how often the manager samples memory usage.
+	defaultCheckPeriod = 10 * time.Second
+
+	// restoreFractionNumerator/restoreFractionDenominator define the
+	// hysteresis threshold: a shed subsystem is only restored once memory
+	// usage drops below this fraction of the budget, so that the manager
+	// does not oscillate around the limit.
+	restoreFractionNumerator   = 3
+	restoreFractionDenominator = 4
+)
+
+// Shedder is implemented by subsystems that can temporarily reduce their
+// memory footprint, for example by pausing an optional controller or by
+// switching an informer to metadata-only mode.
+type Shedder interface {
+	// Name identifies the subsystem in log messages.
+	Name() string
+	// Shed instructs the subsystem to release memory. It must return
+	// promptly; long-running work has to happen asynchronously.
+	Shed()
+	// Restore undoes a previous Shed. It is only called after Shed.
+	Restore()
+}
+
+type funcShedder struct {
+	name    string
+	shed    func()
+	restore func()
+}
+
+func (f *funcShedder) Name() string { return f.name }
+func (f *funcShedder) Shed()        { f.shed() }
+func (f *funcShedder) Restore()     { f.restore() }
+
+// NewFuncShedder wraps a pair of functions in a Shedder.
+func NewFuncShedder(name string, shed, restore func()) Shedder {
+	return &funcShedder{name: name, shed: shed, restore: restore}
+}
+
+// Manager periodically samples the memory held by the Go runtime and sheds
+// registered subsystems while usage exceeds the budget.
+type Manager struct {
+	budgetBytes uint64
+	checkPeriod time.Duration
+
+	// usageFunc returns the current memory usage in bytes. It is a field so
+	// that tests can substitute a fake.
+	usageFunc func() uint64
+
+	mu sync.Mutex
+	// shedders are shed in registration order, so the least critical
+	// subsystems must be registered first.
+	shedders []Shedder
+	// shedCount is the number of shedders at the front of the list that are
+	// currently shed.
+	shedCount int
+}
+
+// NewManager returns a manager enforcing the given soft budget in bytes.
+func NewManager(budgetBytes uint64) *Manager {
+	return &Manager{
+		budgetBytes: budgetBytes,
+		checkPeriod: defaultCheckPeriod,
+		usageFunc:   processMemoryUsage,
+	}
+}
+
+// Register adds a shedder. Shedders are shed in registration order and
+// restored in reverse, so subsystems should be registered from least to most
+// critical. Register must not be called after Run.
+func (m *Manager) Register(s Shedder) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.shedders = append(m.shedders, s)
+}
+
+// Run samples memory usage until stopCh is closed. Anything still shed when
+// the manager stops is left shed; the process is expected to exit shortly
+// afterwards.
+func (m *Manager) Run(stopCh <-chan struct{}) {
+	klog.InfoS("Starting memory budget manager", "budgetBytes", m.budgetBytes)
+	wait.Until(m.sync, m.checkPeriod, stopCh)
+}
+
+// sync sheds or restores at most one subsystem, so that the effect of each
+// step can be observed before taking the next one.
+func (m *Manager) sync() {
+	usage := m.usageFunc()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	switch {
+	case usage > m.budgetBytes && m.shedCount < len(m.shedders):
+		s := m.shedders[m.shedCount]
+		m.shedCount++
+		klog.InfoS("Memory usage exceeds budget, shedding subsystem", "usageBytes", usage, "budgetBytes", m.budgetBytes, "subsystem", s.Name())
+		s.Shed()
+	case usage < m.budgetBytes/restoreFractionDenominator*restoreFractionNumerator && m.shedCount > 0:
+		m.shedCount--
+		s := m.shedders[m.shedCount]
+		klog.InfoS("Memory usage is back under budget, restoring subsystem", "usageBytes", usage, "budgetBytes", m.budgetBytes, "subsystem", s.Name())
+		s.Restore()
+	}
+}
+
+// processMemoryUsage approximates the resident memory held by the Go runtime.
+func processMemoryUsage() uint64 {
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	return ms.Sys - ms.HeapReleased
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package memorybudget
+
+import (
+	"testing"
+)
+
+type recordingShedder struct {
+	name string
+	shed bool
+}
+
+func (r *recordingShedder) Name() string { return r.name }
+func (r *recordingShedder) Shed()        { r.shed = true }
+func (r *recordingShedder) Restore()     { r.shed = false }
+
+func TestManagerShedsAndRestoresInOrder(t *testing.T) {
+	usage := uint64(50)
+	m := NewManager(100)
+	m.usageFunc = func() uint64 { return usage }
+
+	first := &recordingShedder{name: "first"}
+	second := &recordingShedder{name: "second"}
+	m.Register(first)
+	m.Register(second)
+
+	// Under budget: nothing happens.
+	m.sync()
+	if first.shed || second.shed {
+		t.Fatalf("no shedder should be shed while under budget")
+	}
+
+	// Over budget: shedders are shed one per sync, in registration order.
+	usage = 150
+	m.sync()
+	if !first.shed || second.shed {
+		t.Fatalf("expected only the first shedder to be shed, got first=%v second=%v", first.shed, second.shed)
+	}
+	m.sync()
+	if !first.shed || !second.shed {
+		t.Fatalf("expected both shedders to be shed, got first=%v second=%v", first.shed, second.shed)
+	}
+
+	// Further syncs over budget with nothing left to shed are no-ops.
+	m.sync()
+
+	// Usage below the budget but above the restore threshold: hysteresis
+	// keeps everything shed.
+	usage = 90
+	m.sync()
+	if !first.shed || !second.shed {
+		t.Fatalf("no shedder should be restored above the restore threshold")
+	}
+
+	// Well under budget: shedders are restored in reverse order.
+	usage = 50
+	m.sync()
+	if !first.shed || second.shed {
+		t.Fatalf("expected only the second shedder to be restored, got first=%v second=%v", first.shed, second.shed)
+	}
+	m.sync()
+	if first.shed || second.shed {
+		t.Fatalf("expected both shedders to be restored, got first=%v second=%v", first.shed, second.shed)
+	}
+
+	// Further syncs under budget with nothing shed are no-ops.
+	m.sync()
+}
+
+func TestNewFuncShedder(t *testing.T) {
+	var shed bool
+	s := NewFuncShedder("test", func() { shed = true }, func() { shed = false })
+	if s.Name() != "test" {
+		t.Errorf("unexpected name %q", s.Name())
+	}
+	s.Shed()
+	if !shed {
+		t.Error("Shed did not invoke the shed function")
+	}
+	s.Restore()
+	if shed {
+		t.Error("Restore did not invoke the restore function")
+	}
+}
@@ -0,0 +1,360 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package namespacetemplate stamps default objects into namespaces. A
+// template namespace holds the NetworkPolicies, LimitRanges and
+// ResourceQuotas that every matching namespace should start with, and the
+// template selector annotation declares which namespaces it applies to.
+// Stamped copies are create-only by default; a template object can opt into
+// drift reconciliation so that manual edits to its copies are reverted.
+package namespacetemplate
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	networkinginformers "k8s.io/client-go/informers/networking/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	networkinglisters "k8s.io/client-go/listers/networking/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// TemplateSelectorAnnotation marks a namespace as a template. Its value
+	// is a label selector; the template's objects are stamped into every
+	// namespace matching the selector.
+	TemplateSelectorAnnotation = "namespace.kubernetes.io/template-selector"
+
+	// TemplatedFromAnnotation is set on stamped copies and records the
+	// template namespace they were created from.
+	TemplatedFromAnnotation = "namespace.kubernetes.io/templated-from"
+
+	// ReconcileAnnotation opts a template object into drift reconciliation.
+	// When set to "true", edits to the stamped copies are reverted to the
+	// template's spec; without it copies are created once and then owned by
+	// the target namespace.
+	ReconcileAnnotation = "namespace.kubernetes.io/reconcile"
+)
+
+// Controller stamps template objects into namespaces matching a template's
+// selector.
+type Controller struct {
+	client clientset.Interface
+
+	nsLister         corelisters.NamespaceLister
+	nsSynced         cache.InformerSynced
+	netpolLister     networkinglisters.NetworkPolicyLister
+	netpolSynced     cache.InformerSynced
+	limitRangeLister corelisters.LimitRangeLister
+	limitRangeSynced cache.InformerSynced
+	quotaLister      corelisters.ResourceQuotaLister
+	quotaSynced      cache.InformerSynced
+
+	syncHandler func(ctx context.Context, key string) error
+	queue       workqueue.RateLimitingInterface
+}
+
+// NewController creates a new namespace template controller.
+func NewController(nsInformer coreinformers.NamespaceInformer, netpolInformer networkinginformers.NetworkPolicyInformer, limitRangeInformer coreinformers.LimitRangeInformer, quotaInformer coreinformers.ResourceQuotaInformer, client clientset.Interface) *Controller {
+	c := &Controller{
+		client:           client,
+		nsLister:         nsInformer.Lister(),
+		nsSynced:         nsInformer.Informer().HasSynced,
+		netpolLister:     netpolInformer.Lister(),
+		netpolSynced:     netpolInformer.Informer().HasSynced,
+		limitRangeLister: limitRangeInformer.Lister(),
+		limitRangeSynced: limitRangeInformer.Informer().HasSynced,
+		quotaLister:      quotaInformer.Lister(),
+		quotaSynced:      quotaInformer.Informer().HasSynced,
+
+		queue: workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "NamespaceTemplate"),
+	}
+	c.syncHandler = c.syncTemplate
+
+	nsInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			c.enqueueNamespace(obj)
+		},
+		UpdateFunc: func(old, cur interface{}) {
+			c.enqueueNamespace(cur)
+		},
+	})
+	templateObjectHandler := cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			c.enqueueTemplateObject(obj)
+		},
+		UpdateFunc: func(old, cur interface{}) {
+			c.enqueueTemplateObject(cur)
+		},
+	}
+	netpolInformer.Informer().AddEventHandler(templateObjectHandler)
+	limitRangeInformer.Informer().AddEventHandler(templateObjectHandler)
+	quotaInformer.Informer().AddEventHandler(templateObjectHandler)
+	return c
+}
+
+// enqueueNamespace queues a namespace if it is a template, and all templates
+// whose selector may match it when it is a regular namespace. The set of
+// templates is expected to be small, so re-listing them here is cheap.
+func (c *Controller) enqueueNamespace(obj interface{}) {
+	ns, ok := obj.(*v1.Namespace)
+	if !ok {
+		return
+	}
+	if _, isTemplate := ns.Annotations[TemplateSelectorAnnotation]; isTemplate {
+		c.queue.Add(ns.Name)
+		return
+	}
+	templates, err := c.nsLister.List(labels.Everything())
+	if err != nil {
+		return
+	}
+	for _, template := range templates {
+		if _, isTemplate := template.Annotations[TemplateSelectorAnnotation]; isTemplate {
+			c.queue.Add(template.Name)
+		}
+	}
+}
+
+// enqueueTemplateObject queues the namespace of a changed object when that
+// namespace is a template, so edits to template objects are re-stamped.
+func (c *Controller) enqueueTemplateObject(obj interface{}) {
+	object, ok := obj.(metav1.Object)
+	if !ok {
+		return
+	}
+	ns, err := c.nsLister.Get(object.GetNamespace())
+	if err != nil {
+		return
+	}
+	if _, isTemplate := ns.Annotations[TemplateSelectorAnnotation]; isTemplate {
+		c.queue.Add(ns.Name)
+	}
+}
+
+// Run starts the controller and blocks until the context is cancelled.
+func (c *Controller) Run(ctx context.Context, workers int) {
+	defer utilruntime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	klog.Infof("Starting namespace template controller")
+	defer klog.Infof("Shutting down namespace template controller")
+
+	if !cache.WaitForNamedCacheSync("NamespaceTemplate", ctx.Done(), c.nsSynced, c.netpolSynced, c.limitRangeSynced, c.quotaSynced) {
+		return
+	}
+
+	for i := 0; i < workers; i++ {
+		go wait.UntilWithContext(ctx, c.runWorker, time.Second)
+	}
+
+	<-ctx.Done()
+}
+
+func (c *Controller) runWorker(ctx context.Context) {
+	for c.processNextWorkItem(ctx) {
+	}
+}
+
+func (c *Controller) processNextWorkItem(ctx context.Context) bool {
+	key, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(key)
+
+	err := c.syncHandler(ctx, key.(string))
+	if err == nil {
+		c.queue.Forget(key)
+		return true
+	}
+
+	utilruntime.HandleError(fmt.Errorf("syncing template namespace %v failed: %v", key, err))
+	c.queue.AddRateLimited(key)
+
+	return true
+}
+
+// syncTemplate stamps the template namespace's objects into every namespace
+// matching its selector.
+func (c *Controller) syncTemplate(ctx context.Context, key string) error {
+	template, err := c.nsLister.Get(key)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	selectorValue, ok := template.Annotations[TemplateSelectorAnnotation]
+	if !ok {
+		return nil
+	}
+	selector, err := labels.Parse(selectorValue)
+	if err != nil {
+		// An unparseable selector cannot be retried away; surface it once
+		// and wait for the annotation to be fixed.
+		utilruntime.HandleError(fmt.Errorf("invalid template selector on namespace %s: %v", template.Name, err))
+		return nil
+	}
+
+	targets, err := c.nsLister.List(selector)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, target := range targets {
+		if target.Name == template.Name || target.Status.Phase == v1.NamespaceTerminating {
+			continue
+		}
+		if err := c.stampNamespace(ctx, template, target.Name); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// stampNamespace copies the template's objects into a single target
+// namespace.
+func (c *Controller) stampNamespace(ctx context.Context, template *v1.Namespace, target string) error {
+	netpols, err := c.netpolLister.NetworkPolicies(template.Name).List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	for _, netpol := range netpols {
+		existing, err := c.netpolLister.NetworkPolicies(target).Get(netpol.Name)
+		if apierrors.IsNotFound(err) {
+			copied := &networkingv1.NetworkPolicy{
+				ObjectMeta: stampedObjectMeta(&netpol.ObjectMeta, template.Name, target),
+				Spec:       *netpol.Spec.DeepCopy(),
+			}
+			if _, err := c.client.NetworkingV1().NetworkPolicies(target).Create(ctx, copied, metav1.CreateOptions{}); err != nil && !apierrors.IsAlreadyExists(err) {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if shouldReconcile(&netpol.ObjectMeta, &existing.ObjectMeta, template.Name) && !equality.Semantic.DeepEqual(existing.Spec, netpol.Spec) {
+			updated := existing.DeepCopy()
+			updated.Spec = *netpol.Spec.DeepCopy()
+			if _, err := c.client.NetworkingV1().NetworkPolicies(target).Update(ctx, updated, metav1.UpdateOptions{}); err != nil {
+				return err
+			}
+		}
+	}
+
+	limitRanges, err := c.limitRangeLister.LimitRanges(template.Name).List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	for _, limitRange := range limitRanges {
+		existing, err := c.limitRangeLister.LimitRanges(target).Get(limitRange.Name)
+		if apierrors.IsNotFound(err) {
+			copied := &v1.LimitRange{
+				ObjectMeta: stampedObjectMeta(&limitRange.ObjectMeta, template.Name, target),
+				Spec:       *limitRange.Spec.DeepCopy(),
+			}
+			if _, err := c.client.CoreV1().LimitRanges(target).Create(ctx, copied, metav1.CreateOptions{}); err != nil && !apierrors.IsAlreadyExists(err) {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if shouldReconcile(&limitRange.ObjectMeta, &existing.ObjectMeta, template.Name) && !equality.Semantic.DeepEqual(existing.Spec, limitRange.Spec) {
+			updated := existing.DeepCopy()
+			updated.Spec = *limitRange.Spec.DeepCopy()
+			if _, err := c.client.CoreV1().LimitRanges(target).Update(ctx, updated, metav1.UpdateOptions{}); err != nil {
+				return err
+			}
+		}
+	}
+
+	quotas, err := c.quotaLister.ResourceQuotas(template.Name).List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	for _, quota := range quotas {
+		existing, err := c.quotaLister.ResourceQuotas(target).Get(quota.Name)
+		if apierrors.IsNotFound(err) {
+			copied := &v1.ResourceQuota{
+				ObjectMeta: stampedObjectMeta(&quota.ObjectMeta, template.Name, target),
+				Spec:       *quota.Spec.DeepCopy(),
+			}
+			if _, err := c.client.CoreV1().ResourceQuotas(target).Create(ctx, copied, metav1.CreateOptions{}); err != nil && !apierrors.IsAlreadyExists(err) {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if shouldReconcile(&quota.ObjectMeta, &existing.ObjectMeta, template.Name) && !equality.Semantic.DeepEqual(existing.Spec, quota.Spec) {
+			updated := existing.DeepCopy()
+			updated.Spec = *quota.Spec.DeepCopy()
+			if _, err := c.client.CoreV1().ResourceQuotas(target).Update(ctx, updated, metav1.UpdateOptions{}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// shouldReconcile reports whether a drifted copy of the template object should
+// be reverted: the template object must opt in via the reconcile annotation,
+// and the copy must actually have been stamped from this template. Objects the
+// target namespace created itself under the same name are never touched.
+func shouldReconcile(template, existing *metav1.ObjectMeta, templateNamespace string) bool {
+	return template.Annotations[ReconcileAnnotation] == "true" &&
+		existing.Annotations[TemplatedFromAnnotation] == templateNamespace
+}
+
+// stampedObjectMeta builds the metadata for a stamped copy: same name and
+// labels as the template object, annotations plus the templated-from marker,
+// and none of the template object's identity fields.
+func stampedObjectMeta(source *metav1.ObjectMeta, templateNamespace, target string) metav1.ObjectMeta {
+	objectLabels := make(map[string]string, len(source.Labels))
+	for k, v := range source.Labels {
+		objectLabels[k] = v
+	}
+	annotations := make(map[string]string, len(source.Annotations)+1)
+	for k, v := range source.Annotations {
+		annotations[k] = v
+	}
+	annotations[TemplatedFromAnnotation] = templateNamespace
+	return metav1.ObjectMeta{
+		Name:        source.Name,
+		Namespace:   target,
+		Labels:      objectLabels,
+		Annotations: annotations,
+	}
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namespacetemplate
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+type fixture struct {
+	client  *fake.Clientset
+	c       *Controller
+	factory informers.SharedInformerFactory
+}
+
+func newFixture(t *testing.T, objects ...interface{}) *fixture {
+	var clientObjects []runtime.Object
+	for _, obj := range objects {
+		clientObjects = append(clientObjects, obj.(runtime.Object))
+	}
+	client := fake.NewSimpleClientset(clientObjects...)
+	factory := informers.NewSharedInformerFactory(client, 0)
+	c := NewController(factory.Core().V1().Namespaces(), factory.Networking().V1().NetworkPolicies(), factory.Core().V1().LimitRanges(), factory.Core().V1().ResourceQuotas(), client)
+	for _, obj := range objects {
+		var err error
+		switch o := obj.(type) {
+		case *v1.Namespace:
+			err = factory.Core().V1().Namespaces().Informer().GetStore().Add(o)
+		case *networkingv1.NetworkPolicy:
+			err = factory.Networking().V1().NetworkPolicies().Informer().GetStore().Add(o)
+		case *v1.LimitRange:
+			err = factory.Core().V1().LimitRanges().Informer().GetStore().Add(o)
+		case *v1.ResourceQuota:
+			err = factory.Core().V1().ResourceQuotas().Informer().GetStore().Add(o)
+		default:
+			t.Fatalf("unsupported object type %T", obj)
+		}
+		if err != nil {
+			t.Fatalf("failed to prime informer store: %v", err)
+		}
+	}
+	return &fixture{client: client, c: c, factory: factory}
+}
+
+func TestSyncTemplateStampsObjects(t *testing.T) {
+	template := &v1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "defaults",
+			Annotations: map[string]string{TemplateSelectorAnnotation: "tier=app"},
+		},
+	}
+	target := &v1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "app-1", Labels: map[string]string{"tier": "app"}},
+	}
+	unmatched := &v1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "system-1", Labels: map[string]string{"tier": "system"}},
+	}
+	netpol := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "default-deny", Namespace: "defaults"},
+		Spec:       networkingv1.NetworkPolicySpec{PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress}},
+	}
+	limitRange := &v1.LimitRange{
+		ObjectMeta: metav1.ObjectMeta{Name: "defaults", Namespace: "defaults"},
+		Spec: v1.LimitRangeSpec{Limits: []v1.LimitRangeItem{
+			{Type: v1.LimitTypeContainer, Default: v1.ResourceList{v1.ResourceCPU: resource.MustParse("100m")}},
+		}},
+	}
+	quota := &v1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "compute", Namespace: "defaults"},
+		Spec:       v1.ResourceQuotaSpec{Hard: v1.ResourceList{v1.ResourcePods: resource.MustParse("10")}},
+	}
+
+	f := newFixture(t, template, target, unmatched, netpol, limitRange, quota)
+	if err := f.c.syncTemplate(context.TODO(), "defaults"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	stamped, err := f.client.NetworkingV1().NetworkPolicies("app-1").Get(context.TODO(), "default-deny", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected NetworkPolicy to be stamped: %v", err)
+	}
+	if stamped.Annotations[TemplatedFromAnnotation] != "defaults" {
+		t.Errorf("expected templated-from annotation, got %v", stamped.Annotations)
+	}
+	if _, err := f.client.CoreV1().LimitRanges("app-1").Get(context.TODO(), "defaults", metav1.GetOptions{}); err != nil {
+		t.Errorf("expected LimitRange to be stamped: %v", err)
+	}
+	if _, err := f.client.CoreV1().ResourceQuotas("app-1").Get(context.TODO(), "compute", metav1.GetOptions{}); err != nil {
+		t.Errorf("expected ResourceQuota to be stamped: %v", err)
+	}
+	if _, err := f.client.CoreV1().ResourceQuotas("system-1").Get(context.TODO(), "compute", metav1.GetOptions{}); err == nil {
+		t.Errorf("expected no objects in non-matching namespace")
+	}
+}
+
+func TestSyncTemplateReconcilesDrift(t *testing.T) {
+	template := &v1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "defaults",
+			Annotations: map[string]string{TemplateSelectorAnnotation: "tier=app"},
+		},
+	}
+	target := &v1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "app-1", Labels: map[string]string{"tier": "app"}},
+	}
+	templateQuota := &v1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "compute",
+			Namespace:   "defaults",
+			Annotations: map[string]string{ReconcileAnnotation: "true"},
+		},
+		Spec: v1.ResourceQuotaSpec{Hard: v1.ResourceList{v1.ResourcePods: resource.MustParse("10")}},
+	}
+	// A previously stamped copy whose spec was edited in the target namespace.
+	drifted := &v1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "compute",
+			Namespace:   "app-1",
+			Annotations: map[string]string{TemplatedFromAnnotation: "defaults"},
+		},
+		Spec: v1.ResourceQuotaSpec{Hard: v1.ResourceList{v1.ResourcePods: resource.MustParse("100")}},
+	}
+	// An object the target namespace created itself; same name, no marker.
+	unmanaged := &v1.LimitRange{
+		ObjectMeta: metav1.ObjectMeta{Name: "defaults", Namespace: "app-1"},
+		Spec:       v1.LimitRangeSpec{},
+	}
+	templateLimitRange := &v1.LimitRange{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "defaults",
+			Namespace:   "defaults",
+			Annotations: map[string]string{ReconcileAnnotation: "true"},
+		},
+		Spec: v1.LimitRangeSpec{Limits: []v1.LimitRangeItem{{Type: v1.LimitTypeContainer}}},
+	}
+
+	f := newFixture(t, template, target, templateQuota, drifted, unmanaged, templateLimitRange)
+	if err := f.c.syncTemplate(context.TODO(), "defaults"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	reconciled, err := f.client.CoreV1().ResourceQuotas("app-1").Get(context.TODO(), "compute", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(reconciled.Spec, templateQuota.Spec) {
+		t.Errorf("expected drifted quota to be reconciled to %v, got %v", templateQuota.Spec, reconciled.Spec)
+	}
+
+	untouched, err := f.client.CoreV1().LimitRanges("app-1").Get(context.TODO(), "defaults", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(untouched.Spec, unmanaged.Spec) {
+		t.Errorf("expected unmanaged LimitRange to be left alone, got %v", untouched.Spec)
+	}
+}
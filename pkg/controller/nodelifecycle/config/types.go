@@ -44,4 +44,14 @@ type NodeLifecycleControllerConfiguration struct {
 	// Zone is treated as unhealthy in nodeEvictionRate and secondaryNodeEvictionRate when at least
 	// unhealthyZoneThreshold (no less than 3) of Nodes in the zone are NotReady
 	UnhealthyZoneThreshold float32
+	// nodeOutOfServiceFencingWebhookURL is the URL of a fencing webhook that
+	// confirms a NotReady node is powered off. When set, the out-of-service
+	// taint controller is started; when empty the controller stays disabled.
+	NodeOutOfServiceFencingWebhookURL string
+	// nodeOutOfServiceNotReadyThreshold is how long a node must be NotReady
+	// before the out-of-service taint controller consults the fencing webhook.
+	NodeOutOfServiceNotReadyThreshold metav1.Duration
+	// nodeOutOfServiceFencingTimeout is the per-request timeout for calls to
+	// the fencing webhook.
+	NodeOutOfServiceFencingTimeout metav1.Duration
 }
@@ -47,4 +47,10 @@ func RecommendedDefaultNodeLifecycleControllerConfiguration(obj *kubectrlmgrconf
 	if obj.EnableTaintManager == nil {
 		obj.EnableTaintManager = utilpointer.BoolPtr(true)
 	}
+	if obj.NodeOutOfServiceNotReadyThreshold == zero {
+		obj.NodeOutOfServiceNotReadyThreshold = metav1.Duration{Duration: 5 * time.Minute}
+	}
+	if obj.NodeOutOfServiceFencingTimeout == zero {
+		obj.NodeOutOfServiceFencingTimeout = metav1.Duration{Duration: 30 * time.Second}
+	}
 }
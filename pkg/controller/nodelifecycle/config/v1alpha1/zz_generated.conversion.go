@@ -92,6 +92,9 @@ func autoConvert_v1alpha1_NodeLifecycleControllerConfiguration_To_config_NodeLif
 	out.PodEvictionTimeout = in.PodEvictionTimeout
 	out.LargeClusterSizeThreshold = in.LargeClusterSizeThreshold
 	out.UnhealthyZoneThreshold = in.UnhealthyZoneThreshold
+	out.NodeOutOfServiceFencingWebhookURL = in.NodeOutOfServiceFencingWebhookURL
+	out.NodeOutOfServiceNotReadyThreshold = in.NodeOutOfServiceNotReadyThreshold
+	out.NodeOutOfServiceFencingTimeout = in.NodeOutOfServiceFencingTimeout
 	return nil
 }
 
@@ -106,5 +109,8 @@ func autoConvert_config_NodeLifecycleControllerConfiguration_To_v1alpha1_NodeLif
 	out.PodEvictionTimeout = in.PodEvictionTimeout
 	out.LargeClusterSizeThreshold = in.LargeClusterSizeThreshold
 	out.UnhealthyZoneThreshold = in.UnhealthyZoneThreshold
+	out.NodeOutOfServiceFencingWebhookURL = in.NodeOutOfServiceFencingWebhookURL
+	out.NodeOutOfServiceNotReadyThreshold = in.NodeOutOfServiceNotReadyThreshold
+	out.NodeOutOfServiceFencingTimeout = in.NodeOutOfServiceFencingTimeout
 	return nil
 }
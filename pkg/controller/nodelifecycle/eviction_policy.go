@@ -0,0 +1,90 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodelifecycle
+
+import (
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// EvictionPolicyRule overrides eviction pacing and the unhealthy zone
+// threshold for zones whose nodes match Selector. This allows, for example,
+// storage-heavy node pools to be drained more slowly than the global
+// --node-eviction-rate would dictate.
+type EvictionPolicyRule struct {
+	// Selector selects nodes by label. A zone adopts the rule of the first
+	// node observed in it that matches.
+	Selector labels.Selector
+	// EvictionRate replaces the global eviction rate (QPS) for matching
+	// zones while they are healthy.
+	EvictionRate float32
+	// UnhealthyZoneThreshold replaces the global unhealthy zone threshold
+	// for matching zones.
+	UnhealthyZoneThreshold float32
+}
+
+// EvictionPolicy is an ordered list of rules consulted by the node lifecycle
+// controller when a new zone is observed. The first rule whose selector
+// matches the zone's representative node wins; zones with no matching rule
+// keep the globally configured rate and threshold.
+type EvictionPolicy struct {
+	Rules []EvictionPolicyRule
+}
+
+// ruleFor returns the first rule matching the given node, or nil if no rule
+// matches.
+func (p *EvictionPolicy) ruleFor(node *v1.Node) *EvictionPolicyRule {
+	if p == nil {
+		return nil
+	}
+	for i := range p.Rules {
+		if p.Rules[i].Selector != nil && p.Rules[i].Selector.Matches(labels.Set(node.Labels)) {
+			return &p.Rules[i]
+		}
+	}
+	return nil
+}
+
+// SetEvictionPolicy configures per-nodepool eviction pacing overrides.
+// It must be called before Run.
+func (nc *Controller) SetEvictionPolicy(policy *EvictionPolicy) {
+	nc.evictionPolicy = policy
+}
+
+// evictionRateForZone returns the healthy-state eviction rate for the zone,
+// which is the policy override recorded when the zone was observed, or the
+// globally configured rate.
+func (nc *Controller) evictionRateForZone(zone string) float32 {
+	nc.evictorLock.Lock()
+	defer nc.evictorLock.Unlock()
+	if rate, ok := nc.zoneEvictionRate[zone]; ok {
+		return rate
+	}
+	return nc.evictionLimiterQPS
+}
+
+// unhealthyZoneThresholdForZone returns the unhealthy threshold for the zone,
+// which is the policy override recorded when the zone was observed, or the
+// globally configured threshold.
+func (nc *Controller) unhealthyZoneThresholdForZone(zone string) float32 {
+	nc.evictorLock.Lock()
+	defer nc.evictorLock.Unlock()
+	if threshold, ok := nc.zoneUnhealthyThreshold[zone]; ok {
+		return threshold
+	}
+	return nc.unhealthyZoneThreshold
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodelifecycle
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func TestEvictionPolicyRuleFor(t *testing.T) {
+	storagePool := labels.SelectorFromSet(labels.Set{"pool": "storage"})
+	computePool := labels.SelectorFromSet(labels.Set{"pool": "compute"})
+	policy := &EvictionPolicy{
+		Rules: []EvictionPolicyRule{
+			{Selector: storagePool, EvictionRate: 0.01, UnhealthyZoneThreshold: 0.8},
+			{Selector: computePool, EvictionRate: 0.5, UnhealthyZoneThreshold: 0.55},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		nodeLabels map[string]string
+		expectRate float32
+		expectNil  bool
+	}{
+		{
+			name:       "matches first rule",
+			nodeLabels: map[string]string{"pool": "storage"},
+			expectRate: 0.01,
+		},
+		{
+			name:       "matches second rule",
+			nodeLabels: map[string]string{"pool": "compute"},
+			expectRate: 0.5,
+		},
+		{
+			name:       "no rule matches",
+			nodeLabels: map[string]string{"pool": "other"},
+			expectNil:  true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			node := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node0", Labels: tc.nodeLabels}}
+			rule := policy.ruleFor(node)
+			if tc.expectNil {
+				if rule != nil {
+					t.Fatalf("expected no rule to match, got %+v", rule)
+				}
+				return
+			}
+			if rule == nil {
+				t.Fatalf("expected a rule to match, got none")
+			}
+			if rule.EvictionRate != tc.expectRate {
+				t.Errorf("expected eviction rate %v, got %v", tc.expectRate, rule.EvictionRate)
+			}
+		})
+	}
+
+	// a nil policy never matches
+	var nilPolicy *EvictionPolicy
+	if rule := nilPolicy.ruleFor(&v1.Node{}); rule != nil {
+		t.Errorf("expected nil policy to match nothing, got %+v", rule)
+	}
+}
+
+func TestEvictionRateForZone(t *testing.T) {
+	nc := &Controller{
+		evictionLimiterQPS:     testRateLimiterQPS,
+		unhealthyZoneThreshold: testUnhealthyThreshold,
+		zoneEvictionRate:       map[string]float32{"zone-storage": 0.01},
+		zoneUnhealthyThreshold: map[string]float32{"zone-storage": 0.8},
+	}
+
+	if rate := nc.evictionRateForZone("zone-storage"); rate != 0.01 {
+		t.Errorf("expected policy eviction rate 0.01 for overridden zone, got %v", rate)
+	}
+	if rate := nc.evictionRateForZone("zone-other"); rate != testRateLimiterQPS {
+		t.Errorf("expected global eviction rate %v for unmatched zone, got %v", testRateLimiterQPS, rate)
+	}
+	if threshold := nc.unhealthyZoneThresholdForZone("zone-storage"); threshold != 0.8 {
+		t.Errorf("expected policy threshold 0.8 for overridden zone, got %v", threshold)
+	}
+	if threshold := nc.unhealthyZoneThresholdForZone("zone-other"); threshold != testUnhealthyThreshold {
+		t.Errorf("expected global threshold %v for unmatched zone, got %v", testUnhealthyThreshold, threshold)
+	}
+}
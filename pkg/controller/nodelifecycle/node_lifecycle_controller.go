@@ -271,7 +271,7 @@ type Controller struct {
 
 	enterPartialDisruptionFunc func(nodeNum int) float32
 	enterFullDisruptionFunc    func(nodeNum int) float32
-	computeZoneStateFunc       func(nodeConditions []*v1.NodeCondition) (int, ZoneState)
+	computeZoneStateFunc       func(zone string, nodeConditions []*v1.NodeCondition) (int, ZoneState)
 
 	knownNodeSet map[string]*v1.Node
 	// per Node map storing last observed health together with a local time when it was observed.
@@ -339,6 +339,15 @@ type Controller struct {
 	largeClusterThreshold       int32
 	unhealthyZoneThreshold      float32
 
+	// evictionPolicy optionally overrides eviction pacing and unhealthy
+	// thresholds for zones whose nodes match a rule's label selector.
+	evictionPolicy *EvictionPolicy
+	// zoneEvictionRate and zoneUnhealthyThreshold record, per zone, the
+	// policy overrides resolved when the zone was first observed. Both are
+	// protected by evictorLock.
+	zoneEvictionRate       map[string]float32
+	zoneUnhealthyThreshold map[string]float32
+
 	// if set to true Controller will start TaintManager that will evict Pods from
 	// tainted nodes, if they're not tolerated.
 	runTaintManager bool
@@ -403,6 +412,8 @@ func NewNodeLifecycleController(
 		secondaryEvictionLimiterQPS: secondaryEvictionLimiterQPS,
 		largeClusterThreshold:       largeClusterThreshold,
 		unhealthyZoneThreshold:      unhealthyZoneThreshold,
+		zoneEvictionRate:            make(map[string]float32),
+		zoneUnhealthyThreshold:      make(map[string]float32),
 		runTaintManager:             runTaintManager,
 		nodeUpdateQueue:             workqueue.NewNamed("node_lifecycle_controller"),
 		podUpdateQueue:              workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "node_lifecycle_controller_pods"),
@@ -1126,7 +1137,7 @@ func (nc *Controller) handleDisruption(ctx context.Context, zoneToNodeConditions
 	allAreFullyDisrupted := true
 	for k, v := range zoneToNodeConditions {
 		zoneSize.WithLabelValues(k).Set(float64(len(v)))
-		unhealthy, newState := nc.computeZoneStateFunc(v)
+		unhealthy, newState := nc.computeZoneStateFunc(k, v)
 		zoneHealth.WithLabelValues(k).Set(float64(100*(len(v)-unhealthy)) / float64(len(v)))
 		unhealthyNodes.WithLabelValues(k).Set(float64(unhealthy))
 		if newState != stateFullDisruption {
@@ -1307,9 +1318,9 @@ func (nc *Controller) setLimiterInZone(zone string, zoneSize int, state ZoneStat
 	switch state {
 	case stateNormal:
 		if nc.runTaintManager {
-			nc.zoneNoExecuteTainter[zone].SwapLimiter(nc.evictionLimiterQPS)
+			nc.zoneNoExecuteTainter[zone].SwapLimiter(nc.evictionRateForZone(zone))
 		} else {
-			nc.zonePodEvictor[zone].SwapLimiter(nc.evictionLimiterQPS)
+			nc.zonePodEvictor[zone].SwapLimiter(nc.evictionRateForZone(zone))
 		}
 	case statePartialDisruption:
 		if nc.runTaintManager {
@@ -1386,14 +1397,22 @@ func (nc *Controller) addPodEvictorForNewZone(node *v1.Node) {
 	zone := nodetopology.GetZoneKey(node)
 	if _, found := nc.zoneStates[zone]; !found {
 		nc.zoneStates[zone] = stateInitial
+		// Resolve per-nodepool overrides from the first node observed in
+		// the zone so later limiter swaps can honor them.
+		rate := nc.evictionLimiterQPS
+		if rule := nc.evictionPolicy.ruleFor(node); rule != nil {
+			nc.zoneEvictionRate[zone] = rule.EvictionRate
+			nc.zoneUnhealthyThreshold[zone] = rule.UnhealthyZoneThreshold
+			rate = rule.EvictionRate
+		}
 		if !nc.runTaintManager {
 			nc.zonePodEvictor[zone] =
 				scheduler.NewRateLimitedTimedQueue(
-					flowcontrol.NewTokenBucketRateLimiter(nc.evictionLimiterQPS, scheduler.EvictionRateLimiterBurst))
+					flowcontrol.NewTokenBucketRateLimiter(rate, scheduler.EvictionRateLimiterBurst))
 		} else {
 			nc.zoneNoExecuteTainter[zone] =
 				scheduler.NewRateLimitedTimedQueue(
-					flowcontrol.NewTokenBucketRateLimiter(nc.evictionLimiterQPS, scheduler.EvictionRateLimiterBurst))
+					flowcontrol.NewTokenBucketRateLimiter(rate, scheduler.EvictionRateLimiterBurst))
 		}
 		// Init the metric for the new zone.
 		klog.Infof("Initializing eviction metric for zone: %v", zone)
@@ -1480,9 +1499,9 @@ func (nc *Controller) markNodeAsReachable(ctx context.Context, node *v1.Node) (b
 // ComputeZoneState returns a slice of NodeReadyConditions for all Nodes in a given zone.
 // The zone is considered:
 // - fullyDisrupted if there're no Ready Nodes,
-// - partiallyDisrupted if at least than nc.unhealthyZoneThreshold percent of Nodes are not Ready,
+// - partiallyDisrupted if at least than the zone's unhealthy threshold percent of Nodes are not Ready,
 // - normal otherwise
-func (nc *Controller) ComputeZoneState(nodeReadyConditions []*v1.NodeCondition) (int, ZoneState) {
+func (nc *Controller) ComputeZoneState(zone string, nodeReadyConditions []*v1.NodeCondition) (int, ZoneState) {
 	readyNodes := 0
 	notReadyNodes := 0
 	for i := range nodeReadyConditions {
@@ -1495,7 +1514,7 @@ func (nc *Controller) ComputeZoneState(nodeReadyConditions []*v1.NodeCondition)
 	switch {
 	case readyNodes == 0 && notReadyNodes > 0:
 		return notReadyNodes, stateFullDisruption
-	case notReadyNodes > 2 && float32(notReadyNodes)/float32(notReadyNodes+readyNodes) >= nc.unhealthyZoneThreshold:
+	case notReadyNodes > 2 && float32(notReadyNodes)/float32(notReadyNodes+readyNodes) >= nc.unhealthyZoneThresholdForZone(zone):
 		return notReadyNodes, statePartialDisruption
 	default:
 		return notReadyNodes, stateNormal
@@ -0,0 +1,77 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeoutofservice
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// fenceRequest is the JSON body POSTed to the fencing webhook.
+type fenceRequest struct {
+	NodeName string `json:"nodeName"`
+	// ProviderID is included so fencing implementations can address the
+	// machine without a separate lookup.
+	ProviderID string `json:"providerID,omitempty"`
+}
+
+// fenceResponse is the JSON body expected from the fencing webhook.
+type fenceResponse struct {
+	// Fenced must only be true when the webhook has confirmed the node is
+	// powered off.
+	Fenced bool `json:"fenced"`
+}
+
+// NewWebhookFenceCheck returns a FenceCheckFunc backed by an HTTP webhook.
+// The webhook receives a fenceRequest and must answer with a fenceResponse;
+// any non-200 status or malformed response is treated as an error, never as
+// a confirmation.
+func NewWebhookFenceCheck(url string, timeout time.Duration) FenceCheckFunc {
+	client := &http.Client{Timeout: timeout}
+	return func(ctx context.Context, node *v1.Node) (bool, error) {
+		body, err := json.Marshal(fenceRequest{NodeName: node.Name, ProviderID: node.Spec.ProviderID})
+		if err != nil {
+			return false, err
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			return false, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return false, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return false, fmt.Errorf("fencing webhook returned status %v", resp.StatusCode)
+		}
+
+		result := fenceResponse{}
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			return false, fmt.Errorf("failed to decode fencing webhook response: %v", err)
+		}
+		return result.Fenced, nil
+	}
+}
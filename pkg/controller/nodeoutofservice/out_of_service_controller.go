@@ -0,0 +1,197 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package nodeoutofservice implements an opt-in controller that applies the
+// node.kubernetes.io/out-of-service taint to nodes that have been NotReady
+// beyond a configured threshold, but only after a fencing webhook confirms
+// the node is powered off. Applying the taint allows the attach/detach
+// controller and garbage collector to release the node's workloads, which
+// unblocks StatefulSet failover without risking split-brain writes.
+package nodeoutofservice
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	informers "k8s.io/client-go/informers/core/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/controller"
+	taintutils "k8s.io/kubernetes/pkg/util/taints"
+)
+
+// FenceCheckFunc reports whether the named node has been confirmed powered
+// off (fenced). It must only return true when it is safe to assume the node
+// cannot write to shared storage anymore.
+type FenceCheckFunc func(ctx context.Context, node *v1.Node) (bool, error)
+
+// Controller applies the out-of-service taint to fenced NotReady nodes.
+type Controller struct {
+	kubeClient clientset.Interface
+
+	nodeLister listers.NodeLister
+	hasSynced  func() bool
+
+	// Nodes that need to be evaluated.
+	queue workqueue.RateLimitingInterface
+
+	// notReadyThreshold is how long a node must be NotReady before the
+	// controller considers tainting it.
+	notReadyThreshold time.Duration
+
+	// fenceCheck confirms the node is powered off before the taint is applied.
+	fenceCheck FenceCheckFunc
+
+	now func() time.Time
+}
+
+// NewController creates a new out-of-service taint controller.
+func NewController(nodeInformer informers.NodeInformer, kubeClient clientset.Interface, notReadyThreshold time.Duration, fenceCheck FenceCheckFunc) (*Controller, error) {
+	if fenceCheck == nil {
+		return nil, fmt.Errorf("a fencing check is required, refusing to taint nodes out-of-service without one")
+	}
+	c := &Controller{
+		kubeClient:        kubeClient,
+		queue:             workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "node_out_of_service"),
+		notReadyThreshold: notReadyThreshold,
+		fenceCheck:        fenceCheck,
+		now:               time.Now,
+	}
+
+	nodeInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.enqueueNode,
+		UpdateFunc: func(_, newObj interface{}) {
+			c.enqueueNode(newObj)
+		},
+	})
+	c.nodeLister = listers.NewNodeLister(nodeInformer.Informer().GetIndexer())
+	c.hasSynced = nodeInformer.Informer().HasSynced
+
+	return c, nil
+}
+
+// Run begins watching and syncing.
+func (c *Controller) Run(ctx context.Context, workers int) {
+	defer utilruntime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	klog.Infof("Starting out-of-service taint controller")
+	defer klog.Infof("Shutting down out-of-service taint controller")
+
+	if !cache.WaitForNamedCacheSync("node_out_of_service", ctx.Done(), c.hasSynced) {
+		return
+	}
+
+	for i := 0; i < workers; i++ {
+		go wait.UntilWithContext(ctx, c.worker, time.Second)
+	}
+
+	<-ctx.Done()
+}
+
+func (c *Controller) enqueueNode(obj interface{}) {
+	node, ok := obj.(*v1.Node)
+	if !ok {
+		utilruntime.HandleError(fmt.Errorf("unexpected object type: %v", obj))
+		return
+	}
+	c.queue.Add(node.Name)
+}
+
+func (c *Controller) worker(ctx context.Context) {
+	for c.processItem(ctx) {
+	}
+}
+
+func (c *Controller) processItem(ctx context.Context) bool {
+	key, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(key)
+
+	err := c.syncNode(ctx, key.(string))
+	if err == nil {
+		c.queue.Forget(key)
+		return true
+	}
+
+	utilruntime.HandleError(fmt.Errorf("error processing node %v (will retry): %v", key, err))
+	c.queue.AddRateLimited(key)
+	return true
+}
+
+func (c *Controller) syncNode(ctx context.Context, name string) error {
+	node, err := c.nodeLister.Get(name)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	taint := &v1.Taint{Key: v1.TaintNodeOutOfService, Effect: v1.TaintEffectNoExecute}
+	if taintutils.TaintExists(node.Spec.Taints, taint) {
+		return nil
+	}
+
+	notReadySince, notReady := nodeNotReadySince(node)
+	if !notReady {
+		return nil
+	}
+	if sinceNotReady := c.now().Sub(notReadySince); sinceNotReady < c.notReadyThreshold {
+		// Re-evaluate once the threshold can be met.
+		c.queue.AddAfter(name, c.notReadyThreshold-sinceNotReady)
+		return nil
+	}
+
+	fenced, err := c.fenceCheck(ctx, node)
+	if err != nil {
+		return fmt.Errorf("fencing check failed for node %v: %v", name, err)
+	}
+	if !fenced {
+		klog.V(2).Infof("Node %v is NotReady beyond threshold but fencing is not confirmed, not tainting", name)
+		return nil
+	}
+
+	klog.Infof("Node %v is NotReady since %v and confirmed fenced, applying out-of-service taint", name, notReadySince)
+	return controller.AddOrUpdateTaintOnNode(ctx, c.kubeClient, name, taint)
+}
+
+// nodeNotReadySince returns when the node's Ready condition last left True,
+// and whether the node is currently not ready. Nodes without a Ready
+// condition are treated as not ready since the condition's last heartbeat.
+func nodeNotReadySince(node *v1.Node) (time.Time, bool) {
+	for i := range node.Status.Conditions {
+		condition := &node.Status.Conditions[i]
+		if condition.Type != v1.NodeReady {
+			continue
+		}
+		if condition.Status == v1.ConditionTrue {
+			return time.Time{}, false
+		}
+		return condition.LastTransitionTime.Time, true
+	}
+	return time.Time{}, false
+}
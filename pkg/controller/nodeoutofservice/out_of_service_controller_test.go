@@ -0,0 +1,132 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeoutofservice
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	taintutils "k8s.io/kubernetes/pkg/util/taints"
+)
+
+func newNode(ready bool, notReadySince time.Time, taints []v1.Taint) *v1.Node {
+	status := v1.ConditionFalse
+	if ready {
+		status = v1.ConditionTrue
+	}
+	return &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node0"},
+		Spec:       v1.NodeSpec{Taints: taints},
+		Status: v1.NodeStatus{
+			Conditions: []v1.NodeCondition{
+				{
+					Type:               v1.NodeReady,
+					Status:             status,
+					LastTransitionTime: metav1.NewTime(notReadySince),
+				},
+			},
+		},
+	}
+}
+
+func TestSyncNode(t *testing.T) {
+	now := time.Now()
+	outOfServiceTaint := v1.Taint{Key: v1.TaintNodeOutOfService, Effect: v1.TaintEffectNoExecute}
+
+	tests := []struct {
+		name        string
+		node        *v1.Node
+		fenced      bool
+		expectTaint bool
+	}{
+		{
+			name:        "ready node is left alone",
+			node:        newNode(true, now, nil),
+			fenced:      true,
+			expectTaint: false,
+		},
+		{
+			name:        "recently not ready node is not tainted yet",
+			node:        newNode(false, now.Add(-time.Minute), nil),
+			fenced:      true,
+			expectTaint: false,
+		},
+		{
+			name:        "not ready beyond threshold but not fenced",
+			node:        newNode(false, now.Add(-time.Hour), nil),
+			fenced:      false,
+			expectTaint: false,
+		},
+		{
+			name:        "not ready beyond threshold and fenced",
+			node:        newNode(false, now.Add(-time.Hour), nil),
+			fenced:      true,
+			expectTaint: true,
+		},
+		{
+			name:        "already tainted node is left alone",
+			node:        newNode(false, now.Add(-time.Hour), []v1.Taint{outOfServiceTaint}),
+			fenced:      true,
+			expectTaint: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := fake.NewSimpleClientset(tc.node)
+			informerFactory := informers.NewSharedInformerFactory(client, 0)
+			nodeInformer := informerFactory.Core().V1().Nodes()
+			if err := nodeInformer.Informer().GetIndexer().Add(tc.node); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			fenceCheck := func(ctx context.Context, node *v1.Node) (bool, error) {
+				return tc.fenced, nil
+			}
+			c, err := NewController(nodeInformer, client, 10*time.Minute, fenceCheck)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			c.now = func() time.Time { return now }
+
+			if err := c.syncNode(context.TODO(), tc.node.Name); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			updated, err := client.CoreV1().Nodes().Get(context.TODO(), tc.node.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			hasTaint := taintutils.TaintExists(updated.Spec.Taints, &outOfServiceTaint)
+			if hasTaint != tc.expectTaint {
+				t.Errorf("expected taint presence %v, got %v (taints: %+v)", tc.expectTaint, hasTaint, updated.Spec.Taints)
+			}
+		})
+	}
+}
+
+func TestNewControllerRequiresFenceCheck(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	informerFactory := informers.NewSharedInformerFactory(client, 0)
+	if _, err := NewController(informerFactory.Core().V1().Nodes(), client, 10*time.Minute, nil); err == nil {
+		t.Errorf("expected an error constructing the controller without a fencing check")
+	}
+}
@@ -0,0 +1,252 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package overhead
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	nodev1 "k8s.io/api/node/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	nodeinformers "k8s.io/client-go/informers/node/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	v1core "k8s.io/client-go/kubernetes/typed/core/v1"
+	nodelisters "k8s.io/client-go/listers/node/v1"
+	kcache "k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// AnnOverheadAutoMeasure is the RuntimeClass annotation that opts the
+	// class into overhead auto-measurement.
+	AnnOverheadAutoMeasure = "node.kubernetes.io/overhead-auto-measure"
+	// AnnOverheadMaxCPU bounds the CPU overhead auto-measurement may set.
+	AnnOverheadMaxCPU = "node.kubernetes.io/overhead-max-cpu"
+	// AnnOverheadMaxMemory bounds the memory overhead auto-measurement may
+	// set.
+	AnnOverheadMaxMemory = "node.kubernetes.io/overhead-max-memory"
+
+	// minSampleCount is the number of sandbox overhead observations needed
+	// before a RuntimeClass overhead is updated.
+	minSampleCount = 5
+	// samplePercentile is the percentile of the observations written to the
+	// RuntimeClass, leaving headroom over the typical sandbox.
+	samplePercentile = 90
+	// hysteresisPercent suppresses updates that would change the recorded
+	// overhead by less than this percentage, to keep update churn down.
+	hysteresisPercent = 10
+)
+
+// OverheadSample is one sandbox overhead observation, computed from node
+// stats as the pod cgroup usage minus the usage of the pod's containers.
+type OverheadSample struct {
+	// CPUNanoCores is the sandbox CPU usage in nanocores.
+	CPUNanoCores int64
+	// MemoryBytes is the sandbox memory usage in bytes.
+	MemoryBytes int64
+}
+
+// Sampler supplies recent sandbox overhead observations per RuntimeClass,
+// typically collected from the kubelet Summary API.
+type Sampler interface {
+	// Refresh updates the cached samples. It is called once per sync period
+	// before the RuntimeClasses are evaluated.
+	Refresh(ctx context.Context) error
+	// GetSamples returns the recent observations for the RuntimeClass.
+	GetSamples(runtimeClassName string) []OverheadSample
+}
+
+// Controller keeps the .overhead of opted-in RuntimeClasses in line with
+// the sandbox overhead measured on the nodes, so scheduling accounting
+// stays accurate as runtimes change.
+type Controller struct {
+	kubeClient clientset.Interface
+
+	runtimeClassLister nodelisters.RuntimeClassLister
+	runtimeClassSynced kcache.InformerSynced
+
+	sampler Sampler
+
+	recorder record.EventRecorder
+
+	// syncPeriod is how often the RuntimeClasses are re-evaluated against
+	// fresh samples.
+	syncPeriod time.Duration
+}
+
+// NewController returns a controller that periodically updates the overhead
+// of RuntimeClasses carrying the auto-measure annotation.
+func NewController(
+	kubeClient clientset.Interface,
+	runtimeClassInformer nodeinformers.RuntimeClassInformer,
+	sampler Sampler,
+	syncPeriod time.Duration) *Controller {
+
+	ctrl := &Controller{
+		kubeClient:         kubeClient,
+		runtimeClassLister: runtimeClassInformer.Lister(),
+		runtimeClassSynced: runtimeClassInformer.Informer().HasSynced,
+		sampler:            sampler,
+		syncPeriod:         syncPeriod,
+	}
+
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartStructuredLogging(0)
+	eventBroadcaster.StartRecordingToSink(&v1core.EventSinkImpl{Interface: kubeClient.CoreV1().Events("")})
+	ctrl.recorder = eventBroadcaster.NewRecorder(scheme.Scheme, v1.EventSource{Component: "runtimeclass_overhead"})
+
+	return ctrl
+}
+
+// Run starts the controller and blocks until the context is cancelled.
+func (ctrl *Controller) Run(ctx context.Context) {
+	defer runtime.HandleCrash()
+
+	klog.Infof("Starting RuntimeClass overhead controller")
+	defer klog.Infof("Shutting down RuntimeClass overhead controller")
+
+	if !kcache.WaitForNamedCacheSync("RuntimeClass overhead", ctx.Done(), ctrl.runtimeClassSynced) {
+		return
+	}
+
+	wait.UntilWithContext(ctx, ctrl.reconcile, ctrl.syncPeriod)
+}
+
+// reconcile refreshes the samples and evaluates every RuntimeClass once.
+func (ctrl *Controller) reconcile(ctx context.Context) {
+	if err := ctrl.sampler.Refresh(ctx); err != nil {
+		runtime.HandleError(fmt.Errorf("refreshing sandbox overhead samples: %v", err))
+		return
+	}
+
+	classes, err := ctrl.runtimeClassLister.List(labels.Everything())
+	if err != nil {
+		runtime.HandleError(fmt.Errorf("listing RuntimeClasses: %v", err))
+		return
+	}
+	for _, class := range classes {
+		if err := ctrl.syncRuntimeClass(ctx, class); err != nil {
+			runtime.HandleError(fmt.Errorf("updating overhead of RuntimeClass %q: %v", class.Name, err))
+		}
+	}
+}
+
+// syncRuntimeClass updates the overhead of the class from the measured
+// samples, within the bounds configured on the class.
+func (ctrl *Controller) syncRuntimeClass(ctx context.Context, class *nodev1.RuntimeClass) error {
+	if class.Annotations[AnnOverheadAutoMeasure] != "true" {
+		return nil
+	}
+
+	samples := ctrl.sampler.GetSamples(class.Name)
+	if len(samples) < minSampleCount {
+		klog.V(4).Infof("RuntimeClass %q has only %d sandbox overhead samples, need %d", class.Name, len(samples), minSampleCount)
+		return nil
+	}
+
+	cpu := samplePercentileValue(samples, samplePercentile, func(s OverheadSample) int64 { return s.CPUNanoCores })
+	memory := samplePercentileValue(samples, samplePercentile, func(s OverheadSample) int64 { return s.MemoryBytes })
+
+	measuredCPU := resource.NewMilliQuantity(cpu/1000/1000, resource.DecimalSI)
+	measuredMemory := resource.NewQuantity(memory, resource.BinarySI)
+	if err := clampToBound(measuredCPU, class.Annotations[AnnOverheadMaxCPU], AnnOverheadMaxCPU, class.Name); err != nil {
+		return err
+	}
+	if err := clampToBound(measuredMemory, class.Annotations[AnnOverheadMaxMemory], AnnOverheadMaxMemory, class.Name); err != nil {
+		return err
+	}
+
+	var currentCPU, currentMemory resource.Quantity
+	if class.Overhead != nil {
+		currentCPU = class.Overhead.PodFixed[v1.ResourceCPU]
+		currentMemory = class.Overhead.PodFixed[v1.ResourceMemory]
+	}
+	if withinHysteresis(currentCPU.MilliValue(), measuredCPU.MilliValue()) &&
+		withinHysteresis(currentMemory.Value(), measuredMemory.Value()) {
+		return nil
+	}
+
+	newClass := class.DeepCopy()
+	newClass.Overhead = &nodev1.Overhead{
+		PodFixed: v1.ResourceList{
+			v1.ResourceCPU:    *measuredCPU,
+			v1.ResourceMemory: *measuredMemory,
+		},
+	}
+	if _, err := ctrl.kubeClient.NodeV1().RuntimeClasses().Update(ctx, newClass, metav1.UpdateOptions{}); err != nil {
+		return err
+	}
+	ctrl.recorder.Eventf(newClass, v1.EventTypeNormal, "OverheadUpdated",
+		"Updated overhead to cpu %s, memory %s from %d sandbox samples", measuredCPU.String(), measuredMemory.String(), len(samples))
+	klog.V(2).Infof("Updated overhead of RuntimeClass %q to cpu %s, memory %s from %d sandbox samples", class.Name, measuredCPU.String(), measuredMemory.String(), len(samples))
+	return nil
+}
+
+// samplePercentileValue returns the given percentile of the samples under
+// the given accessor.
+func samplePercentileValue(samples []OverheadSample, percentile int, value func(OverheadSample) int64) int64 {
+	values := make([]int64, 0, len(samples))
+	for _, sample := range samples {
+		values = append(values, value(sample))
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+	index := len(values)*percentile/100 - 1
+	if index < 0 {
+		index = 0
+	}
+	return values[index]
+}
+
+// clampToBound caps the quantity at the bound annotation when it is set.
+func clampToBound(quantity *resource.Quantity, bound, annotation, className string) error {
+	if bound == "" {
+		return nil
+	}
+	max, err := resource.ParseQuantity(bound)
+	if err != nil {
+		return fmt.Errorf("invalid %s annotation on RuntimeClass %q: %v", annotation, className, err)
+	}
+	if quantity.Cmp(max) > 0 {
+		*quantity = max
+	}
+	return nil
+}
+
+// withinHysteresis returns true when the measured value is close enough to
+// the current one that an update is not worth the churn.
+func withinHysteresis(current, measured int64) bool {
+	if current == measured {
+		return true
+	}
+	if current == 0 {
+		return false
+	}
+	diff := measured - current
+	if diff < 0 {
+		diff = -diff
+	}
+	return diff*100/current < hysteresisPercent
+}
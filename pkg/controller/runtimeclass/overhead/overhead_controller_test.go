@@ -0,0 +1,175 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package overhead
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	nodev1 "k8s.io/api/node/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	coretesting "k8s.io/client-go/testing"
+	"k8s.io/kubernetes/pkg/controller"
+)
+
+type fakeSampler struct {
+	samples map[string][]OverheadSample
+}
+
+func (f *fakeSampler) Refresh(ctx context.Context) error {
+	return nil
+}
+
+func (f *fakeSampler) GetSamples(runtimeClassName string) []OverheadSample {
+	return f.samples[runtimeClassName]
+}
+
+// tenSamples returns ten samples with cpu 100m..1000m and memory
+// 100Mi..1000Mi, whose 90th percentile is 900m and 900Mi.
+func tenSamples() []OverheadSample {
+	samples := make([]OverheadSample, 0, 10)
+	for i := int64(1); i <= 10; i++ {
+		samples = append(samples, OverheadSample{
+			CPUNanoCores: i * 100 * 1000 * 1000,
+			MemoryBytes:  i * 100 * 1024 * 1024,
+		})
+	}
+	return samples
+}
+
+func TestSyncRuntimeClass(t *testing.T) {
+	tests := []struct {
+		name           string
+		class          *nodev1.RuntimeClass
+		samples        []OverheadSample
+		hasError       bool
+		expectedCPU    string
+		expectedMemory string
+	}{
+		{
+			name:    "class without the auto-measure annotation is left alone",
+			class:   getFakeRuntimeClass("kata", nil, nil),
+			samples: tenSamples(),
+		},
+		{
+			name:    "too few samples leave the class alone",
+			class:   getFakeRuntimeClass("kata", map[string]string{AnnOverheadAutoMeasure: "true"}, nil),
+			samples: tenSamples()[:3],
+		},
+		{
+			name:           "enough samples set the 90th percentile overhead",
+			class:          getFakeRuntimeClass("kata", map[string]string{AnnOverheadAutoMeasure: "true"}, nil),
+			samples:        tenSamples(),
+			expectedCPU:    "900m",
+			expectedMemory: "900Mi",
+		},
+		{
+			name: "measured overhead is clamped to the bounds",
+			class: getFakeRuntimeClass("kata", map[string]string{
+				AnnOverheadAutoMeasure: "true",
+				AnnOverheadMaxCPU:      "500m",
+				AnnOverheadMaxMemory:   "256Mi",
+			}, nil),
+			samples:        tenSamples(),
+			expectedCPU:    "500m",
+			expectedMemory: "256Mi",
+		},
+		{
+			name: "measurement within hysteresis keeps the current overhead",
+			class: getFakeRuntimeClass("kata", map[string]string{AnnOverheadAutoMeasure: "true"},
+				&nodev1.Overhead{PodFixed: v1.ResourceList{
+					v1.ResourceCPU:    resource.MustParse("880m"),
+					v1.ResourceMemory: resource.MustParse("890Mi"),
+				}}),
+			samples: tenSamples(),
+		},
+		{
+			name: "invalid bound annotation is an error",
+			class: getFakeRuntimeClass("kata", map[string]string{
+				AnnOverheadAutoMeasure: "true",
+				AnnOverheadMaxCPU:      "a-lot",
+			}, nil),
+			samples:  tenSamples(),
+			hasError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeKubeClient := fake.NewSimpleClientset(tc.class)
+			informerFactory := informers.NewSharedInformerFactory(fakeKubeClient, controller.NoResyncPeriodFunc())
+			classInformer := informerFactory.Node().V1().RuntimeClasses()
+
+			ctrl := NewController(fakeKubeClient, classInformer,
+				&fakeSampler{samples: map[string][]OverheadSample{tc.class.Name: tc.samples}}, time.Minute)
+			err := ctrl.syncRuntimeClass(context.TODO(), tc.class)
+			if tc.hasError && err == nil {
+				t.Error("expected error, got none")
+			}
+			if !tc.hasError && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			var updated *nodev1.RuntimeClass
+			for _, action := range fakeKubeClient.Actions() {
+				if action.Matches("update", "runtimeclasses") {
+					updated = action.(coretesting.UpdateAction).GetObject().(*nodev1.RuntimeClass)
+				}
+			}
+			if tc.expectedCPU == "" {
+				if updated != nil {
+					t.Errorf("unexpected RuntimeClass update: %+v", updated)
+				}
+				return
+			}
+			if updated == nil {
+				t.Fatal("expected the RuntimeClass to be updated")
+			}
+			cpu := updated.Overhead.PodFixed[v1.ResourceCPU]
+			if cpu.Cmp(resource.MustParse(tc.expectedCPU)) != 0 {
+				t.Errorf("expected cpu overhead %s, got %s", tc.expectedCPU, cpu.String())
+			}
+			memory := updated.Overhead.PodFixed[v1.ResourceMemory]
+			if memory.Cmp(resource.MustParse(tc.expectedMemory)) != 0 {
+				t.Errorf("expected memory overhead %s, got %s", tc.expectedMemory, memory.String())
+			}
+		})
+	}
+}
+
+func TestSamplePercentileValue(t *testing.T) {
+	samples := tenSamples()
+	if got := samplePercentileValue(samples, 90, func(s OverheadSample) int64 { return s.CPUNanoCores }); got != 900*1000*1000 {
+		t.Errorf("expected 90th percentile of 900000000, got %d", got)
+	}
+	if got := samplePercentileValue(samples[:1], 90, func(s OverheadSample) int64 { return s.CPUNanoCores }); got != 100*1000*1000 {
+		t.Errorf("expected single-sample percentile of 100000000, got %d", got)
+	}
+}
+
+func getFakeRuntimeClass(name string, annotations map[string]string, overhead *nodev1.Overhead) *nodev1.RuntimeClass {
+	return &nodev1.RuntimeClass{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Annotations: annotations},
+		Handler:    name,
+		Overhead:   overhead,
+	}
+}
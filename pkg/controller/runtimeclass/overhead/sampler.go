@@ -0,0 +1,150 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package overhead
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	clientset "k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/klog/v2"
+	statsapi "k8s.io/kubelet/pkg/apis/stats/v1alpha1"
+)
+
+// summarySampler measures sandbox overhead from the kubelet Summary API of
+// every node, fetched through the API server node proxy. The overhead of a
+// pod is its pod-level cgroup usage minus the usage of its containers; the
+// samples are grouped by the RuntimeClass of the pod.
+type summarySampler struct {
+	kubeClient clientset.Interface
+	nodeLister corelisters.NodeLister
+	podLister  corelisters.PodLister
+
+	mutex   sync.RWMutex
+	samples map[string][]OverheadSample
+}
+
+// NewSummarySampler returns a Sampler backed by the kubelet Summary API of
+// every node.
+func NewSummarySampler(kubeClient clientset.Interface, nodeLister corelisters.NodeLister, podLister corelisters.PodLister) Sampler {
+	return &summarySampler{
+		kubeClient: kubeClient,
+		nodeLister: nodeLister,
+		podLister:  podLister,
+		samples:    map[string][]OverheadSample{},
+	}
+}
+
+// Refresh rebuilds the per-RuntimeClass samples from the Summary API of
+// every node. Nodes that fail to report contribute to the aggregate error.
+func (s *summarySampler) Refresh(ctx context.Context) error {
+	nodes, err := s.nodeLister.List(labels.Everything())
+	if err != nil {
+		return fmt.Errorf("listing nodes: %v", err)
+	}
+
+	var errs []error
+	samples := map[string][]OverheadSample{}
+	for _, node := range nodes {
+		summary, err := s.getStatsSummary(ctx, node.Name)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("getting stats summary of node %q: %v", node.Name, err))
+			continue
+		}
+		for i := range summary.Pods {
+			s.samplePod(&summary.Pods[i], samples)
+		}
+	}
+	klog.V(4).Infof("Collected sandbox overhead samples for %d RuntimeClasses from %d nodes", len(samples), len(nodes))
+
+	s.mutex.Lock()
+	s.samples = samples
+	s.mutex.Unlock()
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// GetSamples returns the observations collected for the RuntimeClass by the
+// last Refresh.
+func (s *summarySampler) GetSamples(runtimeClassName string) []OverheadSample {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.samples[runtimeClassName]
+}
+
+// samplePod computes the sandbox overhead of the pod and files it under the
+// pod's RuntimeClass. Pods without a RuntimeClass or without pod-level
+// stats are skipped.
+func (s *summarySampler) samplePod(podStats *statsapi.PodStats, samples map[string][]OverheadSample) {
+	pod, err := s.podLister.Pods(podStats.PodRef.Namespace).Get(podStats.PodRef.Name)
+	if apierrors.IsNotFound(err) {
+		return
+	}
+	if err != nil || pod.Spec.RuntimeClassName == nil || *pod.Spec.RuntimeClassName == "" {
+		return
+	}
+	if podStats.CPU == nil || podStats.CPU.UsageNanoCores == nil ||
+		podStats.Memory == nil || podStats.Memory.WorkingSetBytes == nil {
+		return
+	}
+
+	cpu := int64(*podStats.CPU.UsageNanoCores)
+	memory := int64(*podStats.Memory.WorkingSetBytes)
+	for _, containerStats := range podStats.Containers {
+		if containerStats.CPU != nil && containerStats.CPU.UsageNanoCores != nil {
+			cpu -= int64(*containerStats.CPU.UsageNanoCores)
+		}
+		if containerStats.Memory != nil && containerStats.Memory.WorkingSetBytes != nil {
+			memory -= int64(*containerStats.Memory.WorkingSetBytes)
+		}
+	}
+	if cpu < 0 {
+		cpu = 0
+	}
+	if memory < 0 {
+		memory = 0
+	}
+
+	name := *pod.Spec.RuntimeClassName
+	samples[name] = append(samples[name], OverheadSample{CPUNanoCores: cpu, MemoryBytes: memory})
+}
+
+// getStatsSummary fetches the Summary API of the node through the API
+// server node proxy.
+func (s *summarySampler) getStatsSummary(ctx context.Context, nodeName string) (*statsapi.Summary, error) {
+	data, err := s.kubeClient.CoreV1().RESTClient().Get().
+		Resource("nodes").
+		SubResource("proxy").
+		Name(nodeName).
+		Suffix("stats/summary").
+		Do(ctx).Raw()
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &statsapi.Summary{}
+	if err := json.Unmarshal(data, summary); err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package shadow implements a dry-run mode for individual controllers. A
+// controller running in shadow mode computes its intended actions as usual
+// but records them as events and metrics instead of executing them. This
+// allows a new controller version to be validated against production state
+// before it is allowed to act on it.
+package shadow
+
+import (
+	"sync"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/legacyregistry"
+	"k8s.io/klog/v2"
+)
+
+// EventReason is the reason used on events recorded for actions that were
+// suppressed by shadow mode.
+const EventReason = "ShadowedAction"
+
+var (
+	controllersLock sync.RWMutex
+	controllers     = sets.NewString()
+)
+
+// SetControllers replaces the set of controllers running in shadow mode. It
+// is called once during controller manager startup, before the controllers
+// are started.
+func SetControllers(names []string) {
+	controllersLock.Lock()
+	defer controllersLock.Unlock()
+	controllers = sets.NewString(names...)
+}
+
+// Enabled returns true if the named controller runs in shadow mode and must
+// not execute its actions.
+func Enabled(controller string) bool {
+	controllersLock.RLock()
+	defer controllersLock.RUnlock()
+	return controllers.Has(controller)
+}
+
+var (
+	registerMetrics sync.Once
+
+	shadowedActionsMetric = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Name:           "controller_shadowed_actions_total",
+			Help:           "Total number of controller actions that were computed but suppressed by shadow mode, by controller and action.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"controller", "action"},
+	)
+)
+
+// Record counts an action that a controller in shadow mode would have
+// executed. The object key identifies the acted-on object in the log line;
+// it is deliberately not a metric label to keep cardinality bounded.
+func Record(controller, action, objectKey string) {
+	registerMetrics.Do(func() {
+		legacyregistry.MustRegister(shadowedActionsMetric)
+	})
+	shadowedActionsMetric.WithLabelValues(controller, action).Inc()
+	klog.V(2).InfoS("Shadow mode: action suppressed", "controller", controller, "action", action, "objectKey", objectKey)
+}
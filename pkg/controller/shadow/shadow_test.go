@@ -0,0 +1,54 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shadow
+
+import (
+	"testing"
+)
+
+func TestEnabled(t *testing.T) {
+	defer SetControllers(nil)
+
+	if Enabled("persistentvolume-binder") {
+		t.Errorf("expected no controller to be in shadow mode by default")
+	}
+
+	SetControllers([]string{"persistentvolume-binder", "garbagecollector"})
+	if !Enabled("persistentvolume-binder") {
+		t.Errorf("expected persistentvolume-binder to be in shadow mode")
+	}
+	if !Enabled("garbagecollector") {
+		t.Errorf("expected garbagecollector to be in shadow mode")
+	}
+	if Enabled("endpoint") {
+		t.Errorf("expected endpoint not to be in shadow mode")
+	}
+
+	// SetControllers replaces the previous set.
+	SetControllers([]string{"garbagecollector"})
+	if Enabled("persistentvolume-binder") {
+		t.Errorf("expected persistentvolume-binder to have left shadow mode")
+	}
+}
+
+func TestRecord(t *testing.T) {
+	// Record must not panic and must be callable repeatedly; the metric
+	// registration is performed lazily on first use.
+	Record("persistentvolume-binder", "bind", "default/claim")
+	Record("persistentvolume-binder", "bind", "default/claim")
+	Record("garbagecollector", "delete", "default/pod")
+}
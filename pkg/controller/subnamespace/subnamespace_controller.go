@@ -0,0 +1,388 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package subnamespace implements a lightweight form of hierarchical
+// namespaces. A parent namespace declares its children through the
+// subnamespace anchor annotation; the controller creates the child
+// namespaces and propagates labels, RoleBindings and ResourceQuotas from
+// the parent so that each child starts with the parent's policy surface.
+// This provides basic hierarchy without an external project or a new
+// top-level API type.
+package subnamespace
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	rbacinformers "k8s.io/client-go/informers/rbac/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	rbaclisters "k8s.io/client-go/listers/rbac/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// SubnamespacesAnnotation anchors sub-namespaces on their parent. Its
+	// value is a comma-separated list of child short names; the controller
+	// creates each child as "<parent>-<short name>".
+	SubnamespacesAnnotation = "namespace.kubernetes.io/subnamespaces"
+
+	// ParentLabel is set on child namespaces and records the name of the
+	// parent namespace they were created from.
+	ParentLabel = "namespace.kubernetes.io/parent"
+
+	// InheritedFromAnnotation is set on objects copied into a child
+	// namespace and records the parent namespace they were copied from.
+	InheritedFromAnnotation = "namespace.kubernetes.io/inherited-from"
+
+	// DefaultStorageClassAnnotation names the StorageClass that claims in a
+	// namespace should default to. It is propagated from parent to child so
+	// that the whole hierarchy shares the parent's storage default.
+	DefaultStorageClassAnnotation = "namespace.kubernetes.io/default-storage-class"
+)
+
+// Controller creates and maintains sub-namespaces declared through the
+// subnamespace anchor annotation.
+type Controller struct {
+	client clientset.Interface
+
+	nsLister    corelisters.NamespaceLister
+	nsSynced    cache.InformerSynced
+	rbLister    rbaclisters.RoleBindingLister
+	rbSynced    cache.InformerSynced
+	quotaLister corelisters.ResourceQuotaLister
+	quotaSynced cache.InformerSynced
+
+	syncHandler func(ctx context.Context, key string) error
+	queue       workqueue.RateLimitingInterface
+}
+
+// NewController creates a new sub-namespace controller.
+func NewController(nsInformer coreinformers.NamespaceInformer, rbInformer rbacinformers.RoleBindingInformer, quotaInformer coreinformers.ResourceQuotaInformer, client clientset.Interface) *Controller {
+	c := &Controller{
+		client:      client,
+		nsLister:    nsInformer.Lister(),
+		nsSynced:    nsInformer.Informer().HasSynced,
+		rbLister:    rbInformer.Lister(),
+		rbSynced:    rbInformer.Informer().HasSynced,
+		quotaLister: quotaInformer.Lister(),
+		quotaSynced: quotaInformer.Informer().HasSynced,
+
+		queue: workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "Subnamespace"),
+	}
+	c.syncHandler = c.syncNamespace
+
+	nsInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			c.enqueueNamespace(obj)
+		},
+		UpdateFunc: func(old, cur interface{}) {
+			c.enqueueNamespace(cur)
+		},
+	})
+	rbInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			c.enqueueOwningNamespace(obj)
+		},
+		UpdateFunc: func(old, cur interface{}) {
+			c.enqueueOwningNamespace(cur)
+		},
+	})
+	quotaInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			c.enqueueOwningNamespace(obj)
+		},
+		UpdateFunc: func(old, cur interface{}) {
+			c.enqueueOwningNamespace(cur)
+		},
+	})
+	return c
+}
+
+// enqueueNamespace queues a namespace if it anchors sub-namespaces, or its
+// parent if it is itself a child.
+func (c *Controller) enqueueNamespace(obj interface{}) {
+	ns, ok := obj.(*v1.Namespace)
+	if !ok {
+		return
+	}
+	if _, anchored := ns.Annotations[SubnamespacesAnnotation]; anchored {
+		c.queue.Add(ns.Name)
+	}
+	if parent, ok := ns.Labels[ParentLabel]; ok {
+		c.queue.Add(parent)
+	}
+}
+
+// enqueueOwningNamespace queues the namespace of a namespaced object when that
+// namespace anchors sub-namespaces, so that newly created parent objects are
+// propagated to existing children.
+func (c *Controller) enqueueOwningNamespace(obj interface{}) {
+	object, err := accessor(obj)
+	if err != nil {
+		return
+	}
+	ns, err := c.nsLister.Get(object.GetNamespace())
+	if err != nil {
+		return
+	}
+	if _, anchored := ns.Annotations[SubnamespacesAnnotation]; anchored {
+		c.queue.Add(ns.Name)
+	}
+}
+
+func accessor(obj interface{}) (metav1.Object, error) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	accessor, ok := obj.(metav1.Object)
+	if !ok {
+		return nil, fmt.Errorf("object has no meta: %v", obj)
+	}
+	return accessor, nil
+}
+
+// Run starts the controller and blocks until the context is cancelled.
+func (c *Controller) Run(ctx context.Context, workers int) {
+	defer utilruntime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	klog.Infof("Starting subnamespace controller")
+	defer klog.Infof("Shutting down subnamespace controller")
+
+	if !cache.WaitForNamedCacheSync("Subnamespace", ctx.Done(), c.nsSynced, c.rbSynced, c.quotaSynced) {
+		return
+	}
+
+	for i := 0; i < workers; i++ {
+		go wait.UntilWithContext(ctx, c.runWorker, time.Second)
+	}
+
+	<-ctx.Done()
+}
+
+func (c *Controller) runWorker(ctx context.Context) {
+	for c.processNextWorkItem(ctx) {
+	}
+}
+
+func (c *Controller) processNextWorkItem(ctx context.Context) bool {
+	key, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(key)
+
+	err := c.syncHandler(ctx, key.(string))
+	if err == nil {
+		c.queue.Forget(key)
+		return true
+	}
+
+	utilruntime.HandleError(fmt.Errorf("syncing namespace %v failed: %v", key, err))
+	c.queue.AddRateLimited(key)
+
+	return true
+}
+
+// childNames parses the anchor annotation into the full names of the child
+// namespaces. Empty entries are skipped so that a trailing comma is harmless.
+func childNames(parent *v1.Namespace) []string {
+	anchor, ok := parent.Annotations[SubnamespacesAnnotation]
+	if !ok {
+		return nil
+	}
+	var names []string
+	for _, short := range strings.Split(anchor, ",") {
+		short = strings.TrimSpace(short)
+		if short == "" {
+			continue
+		}
+		names = append(names, parent.Name+"-"+short)
+	}
+	return names
+}
+
+// syncNamespace ensures that all children anchored on the given parent
+// namespace exist and carry the parent's labels, RoleBindings and
+// ResourceQuotas.
+func (c *Controller) syncNamespace(ctx context.Context, key string) error {
+	parent, err := c.nsLister.Get(key)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if parent.Status.Phase == v1.NamespaceTerminating {
+		return nil
+	}
+
+	var errs []error
+	for _, childName := range childNames(parent) {
+		if err := c.syncChild(ctx, parent, childName); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// syncChild ensures a single child namespace exists and has the parent's
+// policy objects. A pre-existing namespace that was not created from this
+// parent is left alone: adopting an unrelated namespace could leak its
+// contents to the parent's users.
+func (c *Controller) syncChild(ctx context.Context, parent *v1.Namespace, childName string) error {
+	child, err := c.nsLister.Get(childName)
+	if apierrors.IsNotFound(err) {
+		child, err = c.createChild(ctx, parent, childName)
+	}
+	if err != nil {
+		return err
+	}
+	if child.Labels[ParentLabel] != parent.Name {
+		klog.V(2).InfoS("Namespace exists but is not a child of the anchoring parent, skipping", "namespace", childName, "parent", parent.Name)
+		return nil
+	}
+	if child.Status.Phase == v1.NamespaceTerminating {
+		return nil
+	}
+
+	if err := c.propagateRoleBindings(ctx, parent, child); err != nil {
+		return err
+	}
+	return c.propagateQuotas(ctx, parent, child)
+}
+
+func (c *Controller) createChild(ctx context.Context, parent *v1.Namespace, childName string) (*v1.Namespace, error) {
+	labels := make(map[string]string, len(parent.Labels)+1)
+	for k, v := range parent.Labels {
+		labels[k] = v
+	}
+	labels[ParentLabel] = parent.Name
+	// The kubernetes.io/metadata.name label is namespace-specific and must
+	// not be inherited; the apiserver maintains it per namespace.
+	delete(labels, v1.LabelMetadataName)
+
+	annotations := map[string]string{}
+	if sc, ok := parent.Annotations[DefaultStorageClassAnnotation]; ok {
+		annotations[DefaultStorageClassAnnotation] = sc
+	}
+
+	child := &v1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        childName,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+	}
+	created, err := c.client.CoreV1().Namespaces().Create(ctx, child, metav1.CreateOptions{})
+	if apierrors.IsAlreadyExists(err) {
+		return c.client.CoreV1().Namespaces().Get(ctx, childName, metav1.GetOptions{})
+	}
+	return created, err
+}
+
+// propagateRoleBindings copies the parent's RoleBindings that the child does
+// not have yet. Copies are marked with the inherited-from annotation; existing
+// bindings in the child are never overwritten.
+func (c *Controller) propagateRoleBindings(ctx context.Context, parent, child *v1.Namespace) error {
+	bindings, err := c.rbLister.RoleBindings(parent.Name).List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	for _, binding := range bindings {
+		if _, err := c.rbLister.RoleBindings(child.Name).Get(binding.Name); err == nil {
+			continue
+		} else if !apierrors.IsNotFound(err) {
+			return err
+		}
+		copied := copyRoleBinding(binding, parent.Name, child.Name)
+		if _, err := c.client.RbacV1().RoleBindings(child.Name).Create(ctx, copied, metav1.CreateOptions{}); err != nil && !apierrors.IsAlreadyExists(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// propagateQuotas copies the parent's ResourceQuotas into the child so each
+// sub-namespace gets its own slice bounded by the same limits. As with
+// RoleBindings, existing quotas in the child are never overwritten.
+func (c *Controller) propagateQuotas(ctx context.Context, parent, child *v1.Namespace) error {
+	quotas, err := c.quotaLister.ResourceQuotas(parent.Name).List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	for _, quota := range quotas {
+		if _, err := c.quotaLister.ResourceQuotas(child.Name).Get(quota.Name); err == nil {
+			continue
+		} else if !apierrors.IsNotFound(err) {
+			return err
+		}
+		copied := &v1.ResourceQuota{
+			ObjectMeta: inheritedObjectMeta(&quota.ObjectMeta, parent.Name, child.Name),
+			Spec:       *quota.Spec.DeepCopy(),
+		}
+		if _, err := c.client.CoreV1().ResourceQuotas(child.Name).Create(ctx, copied, metav1.CreateOptions{}); err != nil && !apierrors.IsAlreadyExists(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func copyRoleBinding(binding *rbacv1.RoleBinding, parent, child string) *rbacv1.RoleBinding {
+	copied := &rbacv1.RoleBinding{
+		ObjectMeta: inheritedObjectMeta(&binding.ObjectMeta, parent, child),
+		RoleRef:    binding.RoleRef,
+	}
+	for _, subject := range binding.Subjects {
+		copied.Subjects = append(copied.Subjects, subject)
+	}
+	return copied
+}
+
+// inheritedObjectMeta builds the metadata for an object copied from parent to
+// child: same name and labels, annotations plus the inherited-from marker, and
+// none of the parent object's identity fields.
+func inheritedObjectMeta(source *metav1.ObjectMeta, parent, child string) metav1.ObjectMeta {
+	labels := make(map[string]string, len(source.Labels))
+	for k, v := range source.Labels {
+		labels[k] = v
+	}
+	annotations := make(map[string]string, len(source.Annotations)+1)
+	for k, v := range source.Annotations {
+		annotations[k] = v
+	}
+	annotations[InheritedFromAnnotation] = parent
+	return metav1.ObjectMeta{
+		Name:        source.Name,
+		Namespace:   child,
+		Labels:      labels,
+		Annotations: annotations,
+	}
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package subnamespace
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newParent(name string, annotations map[string]string) *v1.Namespace {
+	return &v1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Labels:      map[string]string{"team": "storage", v1.LabelMetadataName: name},
+			Annotations: annotations,
+		},
+	}
+}
+
+func TestChildNames(t *testing.T) {
+	tests := []struct {
+		name     string
+		anchor   string
+		expected []string
+	}{
+		{name: "no annotation"},
+		{name: "single child", anchor: "dev", expected: []string{"parent-dev"}},
+		{name: "multiple children", anchor: "dev,staging", expected: []string{"parent-dev", "parent-staging"}},
+		{name: "whitespace and trailing comma", anchor: " dev , staging ,", expected: []string{"parent-dev", "parent-staging"}},
+		{name: "empty annotation", anchor: ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			annotations := map[string]string{}
+			if test.anchor != "" || test.name == "empty annotation" {
+				annotations[SubnamespacesAnnotation] = test.anchor
+			}
+			got := childNames(newParent("parent", annotations))
+			if !reflect.DeepEqual(got, test.expected) {
+				t.Errorf("childNames() = %v, expected %v", got, test.expected)
+			}
+		})
+	}
+}
+
+func TestSyncNamespaceCreatesChildren(t *testing.T) {
+	parent := newParent("parent", map[string]string{
+		SubnamespacesAnnotation:       "dev",
+		DefaultStorageClassAnnotation: "fast",
+	})
+	binding := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "admins", Namespace: "parent"},
+		RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "admin"},
+		Subjects:   []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "storage-admins"}},
+	}
+	quota := &v1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "compute", Namespace: "parent"},
+		Spec:       v1.ResourceQuotaSpec{Hard: v1.ResourceList{v1.ResourcePods: resource.MustParse("10")}},
+	}
+
+	client := fake.NewSimpleClientset(parent, binding, quota)
+	factory := informers.NewSharedInformerFactory(client, 0)
+	c := NewController(factory.Core().V1().Namespaces(), factory.Rbac().V1().RoleBindings(), factory.Core().V1().ResourceQuotas(), client)
+	factory.Core().V1().Namespaces().Informer().GetStore().Add(parent)
+	factory.Rbac().V1().RoleBindings().Informer().GetStore().Add(binding)
+	factory.Core().V1().ResourceQuotas().Informer().GetStore().Add(quota)
+
+	if err := c.syncNamespace(context.TODO(), "parent"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	child, err := client.CoreV1().Namespaces().Get(context.TODO(), "parent-dev", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected child namespace to be created: %v", err)
+	}
+	if child.Labels[ParentLabel] != "parent" {
+		t.Errorf("expected parent label on child, got %v", child.Labels)
+	}
+	if child.Labels["team"] != "storage" {
+		t.Errorf("expected parent labels to be inherited, got %v", child.Labels)
+	}
+	if _, ok := child.Labels[v1.LabelMetadataName]; ok {
+		t.Errorf("expected metadata.name label not to be inherited, got %v", child.Labels)
+	}
+	if child.Annotations[DefaultStorageClassAnnotation] != "fast" {
+		t.Errorf("expected default storage class to be inherited, got %v", child.Annotations)
+	}
+
+	childBinding, err := client.RbacV1().RoleBindings("parent-dev").Get(context.TODO(), "admins", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected RoleBinding to be propagated: %v", err)
+	}
+	if childBinding.Annotations[InheritedFromAnnotation] != "parent" {
+		t.Errorf("expected inherited-from annotation, got %v", childBinding.Annotations)
+	}
+	if !reflect.DeepEqual(childBinding.Subjects, binding.Subjects) {
+		t.Errorf("expected subjects %v, got %v", binding.Subjects, childBinding.Subjects)
+	}
+
+	childQuota, err := client.CoreV1().ResourceQuotas("parent-dev").Get(context.TODO(), "compute", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected ResourceQuota to be propagated: %v", err)
+	}
+	if !reflect.DeepEqual(childQuota.Spec, quota.Spec) {
+		t.Errorf("expected quota spec %v, got %v", quota.Spec, childQuota.Spec)
+	}
+}
+
+func TestSyncNamespaceDoesNotAdoptExisting(t *testing.T) {
+	parent := newParent("parent", map[string]string{SubnamespacesAnnotation: "dev"})
+	// An unrelated namespace already occupies the child's name.
+	existing := &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "parent-dev"}}
+
+	client := fake.NewSimpleClientset(parent, existing)
+	factory := informers.NewSharedInformerFactory(client, 0)
+	c := NewController(factory.Core().V1().Namespaces(), factory.Rbac().V1().RoleBindings(), factory.Core().V1().ResourceQuotas(), client)
+	factory.Core().V1().Namespaces().Informer().GetStore().Add(parent)
+	factory.Core().V1().Namespaces().Informer().GetStore().Add(existing)
+
+	if err := c.syncNamespace(context.TODO(), "parent"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := client.CoreV1().Namespaces().Get(context.TODO(), "parent-dev", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := got.Labels[ParentLabel]; ok {
+		t.Errorf("expected existing namespace not to be adopted, got labels %v", got.Labels)
+	}
+}
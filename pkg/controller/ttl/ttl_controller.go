@@ -36,18 +36,17 @@ import (
 
 	"k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/json"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/strategicpatch"
 	"k8s.io/apimachinery/pkg/util/wait"
-	informers "k8s.io/client-go/informers/core/v1"
+	"k8s.io/client-go/informers"
 	clientset "k8s.io/client-go/kubernetes"
-	listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
-	"k8s.io/kubernetes/pkg/controller"
 
 	"k8s.io/klog/v2"
 )
@@ -56,8 +55,8 @@ import (
 type Controller struct {
 	kubeClient clientset.Interface
 
-	// nodeStore is a local cache of nodes.
-	nodeStore listers.NodeLister
+	// nodeLister is a local cache of node metadata.
+	nodeLister cache.GenericLister
 
 	// Nodes that need to be synced.
 	queue workqueue.RateLimitingInterface
@@ -77,8 +76,10 @@ type Controller struct {
 	boundaryStep int
 }
 
-// NewTTLController creates a new TTLController
-func NewTTLController(nodeInformer informers.NodeInformer, kubeClient clientset.Interface) *Controller {
+// NewTTLController creates a new TTLController. The controller only needs
+// node names and annotations, so nodeInformer is expected to be backed by a
+// metadata-only informer.
+func NewTTLController(nodeInformer informers.GenericInformer, kubeClient clientset.Interface) *Controller {
 	ttlc := &Controller{
 		kubeClient: kubeClient,
 		queue:      workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "ttlcontroller"),
@@ -90,7 +91,7 @@ func NewTTLController(nodeInformer informers.NodeInformer, kubeClient clientset.
 		DeleteFunc: ttlc.deleteNode,
 	})
 
-	ttlc.nodeStore = listers.NewNodeLister(nodeInformer.Informer().GetIndexer())
+	ttlc.nodeLister = nodeInformer.Lister()
 	ttlc.hasSynced = nodeInformer.Informer().HasSynced
 
 	return ttlc
@@ -132,8 +133,8 @@ func (ttlc *Controller) Run(ctx context.Context, workers int) {
 }
 
 func (ttlc *Controller) addNode(obj interface{}) {
-	node, ok := obj.(*v1.Node)
-	if !ok {
+	node, err := meta.Accessor(obj)
+	if err != nil {
 		utilruntime.HandleError(fmt.Errorf("unexpected object type: %v", obj))
 		return
 	}
@@ -151,8 +152,8 @@ func (ttlc *Controller) addNode(obj interface{}) {
 }
 
 func (ttlc *Controller) updateNode(_, newObj interface{}) {
-	node, ok := newObj.(*v1.Node)
-	if !ok {
+	node, err := meta.Accessor(newObj)
+	if err != nil {
 		utilruntime.HandleError(fmt.Errorf("unexpected object type: %v", newObj))
 		return
 	}
@@ -165,15 +166,13 @@ func (ttlc *Controller) updateNode(_, newObj interface{}) {
 }
 
 func (ttlc *Controller) deleteNode(obj interface{}) {
-	_, ok := obj.(*v1.Node)
-	if !ok {
+	if _, err := meta.Accessor(obj); err != nil {
 		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
 		if !ok {
 			utilruntime.HandleError(fmt.Errorf("unexpected object type: %v", obj))
 			return
 		}
-		_, ok = tombstone.Obj.(*v1.Node)
-		if !ok {
+		if _, err := meta.Accessor(tombstone.Obj); err != nil {
 			utilruntime.HandleError(fmt.Errorf("unexpected object types: %v", obj))
 			return
 		}
@@ -191,13 +190,9 @@ func (ttlc *Controller) deleteNode(obj interface{}) {
 	// We are not processing the node, as it no longer exists.
 }
 
-func (ttlc *Controller) enqueueNode(node *v1.Node) {
-	key, err := controller.KeyFunc(node)
-	if err != nil {
-		klog.Errorf("Couldn't get key for object %+v", node)
-		return
-	}
-	ttlc.queue.Add(key)
+func (ttlc *Controller) enqueueNode(node metav1.Object) {
+	// Nodes are cluster-scoped, so their name is also their key.
+	ttlc.queue.Add(node.GetName())
 }
 
 func (ttlc *Controller) worker(ctx context.Context) {
@@ -229,37 +224,31 @@ func (ttlc *Controller) getDesiredTTLSeconds() int {
 	return ttlc.desiredTTLSeconds
 }
 
-func getIntFromAnnotation(node *v1.Node, annotationKey string) (int, bool) {
-	if node.Annotations == nil {
-		return 0, false
-	}
-	annotationValue, ok := node.Annotations[annotationKey]
+func getIntFromAnnotation(node metav1.Object, annotationKey string) (int, bool) {
+	annotationValue, ok := node.GetAnnotations()[annotationKey]
 	if !ok {
 		return 0, false
 	}
 	intValue, err := strconv.Atoi(annotationValue)
 	if err != nil {
 		klog.Warningf("Cannot convert the value %q with annotation key %q for the node %q",
-			annotationValue, annotationKey, node.Name)
+			annotationValue, annotationKey, node.GetName())
 		return 0, false
 	}
 	return intValue, true
 }
 
-func setIntAnnotation(node *v1.Node, annotationKey string, value int) {
-	if node.Annotations == nil {
-		node.Annotations = make(map[string]string)
-	}
-	node.Annotations[annotationKey] = strconv.Itoa(value)
-}
-
-func (ttlc *Controller) patchNodeWithAnnotation(ctx context.Context, node *v1.Node, annotationKey string, value int) error {
-	oldData, err := json.Marshal(node)
+func (ttlc *Controller) patchNodeWithAnnotation(ctx context.Context, node metav1.Object, annotationKey string, value int) error {
+	oldData, err := json.Marshal(&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: node.GetName(), Annotations: node.GetAnnotations()}})
 	if err != nil {
 		return err
 	}
-	setIntAnnotation(node, annotationKey, value)
-	newData, err := json.Marshal(node)
+	newAnnotations := make(map[string]string, len(node.GetAnnotations())+1)
+	for k, v := range node.GetAnnotations() {
+		newAnnotations[k] = v
+	}
+	newAnnotations[annotationKey] = strconv.Itoa(value)
+	newData, err := json.Marshal(&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: node.GetName(), Annotations: newAnnotations}})
 	if err != nil {
 		return err
 	}
@@ -267,7 +256,7 @@ func (ttlc *Controller) patchNodeWithAnnotation(ctx context.Context, node *v1.No
 	if err != nil {
 		return err
 	}
-	_, err = ttlc.kubeClient.CoreV1().Nodes().Patch(ctx, node.Name, types.StrategicMergePatchType, patchBytes, metav1.PatchOptions{})
+	_, err = ttlc.kubeClient.CoreV1().Nodes().Patch(ctx, node.GetName(), types.StrategicMergePatchType, patchBytes, metav1.PatchOptions{})
 	if err != nil {
 		klog.V(2).InfoS("Failed to change ttl annotation for node", "node", klog.KObj(node), "err", err)
 		return err
@@ -277,13 +266,17 @@ func (ttlc *Controller) patchNodeWithAnnotation(ctx context.Context, node *v1.No
 }
 
 func (ttlc *Controller) updateNodeIfNeeded(ctx context.Context, key string) error {
-	node, err := ttlc.nodeStore.Get(key)
+	obj, err := ttlc.nodeLister.Get(key)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			return nil
 		}
 		return err
 	}
+	node, err := meta.Accessor(obj)
+	if err != nil {
+		return err
+	}
 
 	desiredTTL := ttlc.getDesiredTTLSeconds()
 	currentTTL, ok := getIntFromAnnotation(node, v1.ObjectTTLAnnotationKey)
@@ -291,5 +284,5 @@ func (ttlc *Controller) updateNodeIfNeeded(ctx context.Context, key string) erro
 		return nil
 	}
 
-	return ttlc.patchNodeWithAnnotation(ctx, node.DeepCopy(), v1.ObjectTTLAnnotationKey, desiredTTL)
+	return ttlc.patchNodeWithAnnotation(ctx, node, v1.ObjectTTLAnnotationKey, desiredTTL)
 }
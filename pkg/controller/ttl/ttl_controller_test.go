@@ -23,7 +23,6 @@ import (
 	"k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
-	listers "k8s.io/client-go/listers/core/v1"
 	core "k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
@@ -135,7 +134,7 @@ func TestUpdateNodeIfNeeded(t *testing.T) {
 		nodeStore.Add(testCase.node)
 		ttlController := &Controller{
 			kubeClient:        fakeClient,
-			nodeStore:         listers.NewNodeLister(nodeStore),
+			nodeLister:        cache.NewGenericLister(nodeStore, v1.Resource("nodes")),
 			desiredTTLSeconds: testCase.desiredTTL,
 		}
 		if err := ttlController.updateNodeIfNeeded(context.TODO(), testCase.node.Name); err != nil {
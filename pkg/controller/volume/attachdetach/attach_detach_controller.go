@@ -21,6 +21,7 @@ package attachdetach
 import (
 	"fmt"
 	"net"
+	"net/http"
 	"time"
 
 	"k8s.io/klog/v2"
@@ -102,6 +103,9 @@ var DefaultTimerConfig TimerConfig = TimerConfig{
 type AttachDetachController interface {
 	Run(stopCh <-chan struct{})
 	GetDesiredStateOfWorld() cache.DesiredStateOfWorld
+	// DebugHandler serves the desired and actual state of the world and
+	// accepts per-node force-resync triggers. See debug.go.
+	DebugHandler() http.Handler
 }
 
 // NewAttachDetachController returns a new instance of AttachDetachController.
@@ -119,6 +123,7 @@ func NewAttachDetachController(
 	prober volume.DynamicPluginProber,
 	disableReconciliationSync bool,
 	reconcilerSyncDuration time.Duration,
+	maxParallelDetachesPerNode int32,
 	timerConfig TimerConfig,
 	filteredDialOptions *proxyutil.FilteredDialOptions) (AttachDetachController, error) {
 
@@ -176,6 +181,7 @@ func NewAttachDetachController(
 		timerConfig.ReconcilerMaxWaitForUnmountDuration,
 		reconcilerSyncDuration,
 		disableReconciliationSync,
+		maxParallelDetachesPerNode,
 		adc.desiredStateOfWorld,
 		adc.actualStateOfWorld,
 		adc.attacherDetacher,
@@ -357,6 +363,7 @@ func (adc *attachDetachController) Run(stopCh <-chan struct{}) {
 	metrics.Register(adc.pvcLister,
 		adc.pvLister,
 		adc.podLister,
+		adc.csiNodeLister,
 		adc.actualStateOfWorld,
 		adc.desiredStateOfWorld,
 		&adc.volumePluginMgr,
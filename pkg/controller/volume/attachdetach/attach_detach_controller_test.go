@@ -64,6 +64,7 @@ func Test_NewAttachDetachController_Positive(t *testing.T) {
 		nil, /* prober */
 		false,
 		5*time.Second,
+		0, /* maxParallelDetachesPerNode */
 		DefaultTimerConfig,
 		nil, /* filteredDialOptions */
 	)
@@ -189,6 +190,7 @@ func attachDetachRecoveryTestCase(t *testing.T, extraPods1 []*v1.Pod, extraPods2
 		prober,
 		false,
 		1*time.Second,
+		0, /* maxParallelDetachesPerNode */
 		DefaultTimerConfig,
 		nil, /* filteredDialOptions */
 	)
@@ -461,6 +463,7 @@ func volumeAttachmentRecoveryTestCase(t *testing.T, tc vaTest) {
 		nil, /* prober */
 		false,
 		1*time.Second,
+		0, /* maxParallelDetachesPerNode */
 		DefaultTimerConfig,
 		nil, /* filteredDialOptions */
 	)
@@ -29,4 +29,8 @@ type AttachDetachControllerConfiguration struct {
 	// ReconcilerSyncLoopPeriod is the amount of time the reconciler sync states loop
 	// wait between successive executions. Is set to 5 sec by default.
 	ReconcilerSyncLoopPeriod metav1.Duration
+	// MaximumParallelDetachesPerNode is the maximum number of detach operations
+	// that may run in parallel against a single node. Is 0 by default, which
+	// falls back to in-tree per volume plugin defaults.
+	MaximumParallelDetachesPerNode int32
 }
@@ -0,0 +1,113 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package attachdetach
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// debugState is the JSON shape of the controller's caches served by the
+// debug handler.
+type debugState struct {
+	// Desired lists the volume/node pairs the controller wants attached.
+	Desired []debugDesiredVolume `json:"desired"`
+	// Actual lists the volume/node pairs the controller believes are
+	// attached.
+	Actual []debugAttachedVolume `json:"actual"`
+}
+
+type debugDesiredVolume struct {
+	VolumeName string   `json:"volumeName"`
+	NodeName   string   `json:"nodeName"`
+	Pods       []string `json:"pods,omitempty"`
+}
+
+type debugAttachedVolume struct {
+	VolumeName    string `json:"volumeName"`
+	NodeName      string `json:"nodeName"`
+	MountedByNode bool   `json:"mountedByNode"`
+}
+
+// DebugHandler serves the controller's desired and actual state of the
+// world on GET, and forces a resync of one node's attached volumes on
+// POST with a "node" query parameter. The handler performs no
+// authentication itself; it must only be installed behind the controller
+// manager's authenticated debug mux.
+func (adc *attachDetachController) DebugHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			adc.serveDebugState(w)
+		case http.MethodPost:
+			node := r.URL.Query().Get("node")
+			if node == "" {
+				http.Error(w, "query parameter \"node\" is required", http.StatusBadRequest)
+				return
+			}
+			adc.reconciler.ForceSyncNode(types.NodeName(node))
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			http.Error(w, "only GET and POST are supported", http.StatusMethodNotAllowed)
+		}
+	})
+}
+
+func (adc *attachDetachController) serveDebugState(w http.ResponseWriter) {
+	state := debugState{
+		Desired: []debugDesiredVolume{},
+		Actual:  []debugAttachedVolume{},
+	}
+	for _, volumeToAttach := range adc.desiredStateOfWorld.GetVolumesToAttach() {
+		desired := debugDesiredVolume{
+			VolumeName: string(volumeToAttach.VolumeName),
+			NodeName:   string(volumeToAttach.NodeName),
+		}
+		for _, pod := range volumeToAttach.ScheduledPods {
+			desired.Pods = append(desired.Pods, pod.Namespace+"/"+pod.Name)
+		}
+		sort.Strings(desired.Pods)
+		state.Desired = append(state.Desired, desired)
+	}
+	for _, attachedVolume := range adc.actualStateOfWorld.GetAttachedVolumes() {
+		state.Actual = append(state.Actual, debugAttachedVolume{
+			VolumeName:    string(attachedVolume.VolumeName),
+			NodeName:      string(attachedVolume.NodeName),
+			MountedByNode: attachedVolume.MountedByNode,
+		})
+	}
+	sort.Slice(state.Desired, func(i, j int) bool {
+		if state.Desired[i].NodeName != state.Desired[j].NodeName {
+			return state.Desired[i].NodeName < state.Desired[j].NodeName
+		}
+		return state.Desired[i].VolumeName < state.Desired[j].VolumeName
+	})
+	sort.Slice(state.Actual, func(i, j int) bool {
+		if state.Actual[i].NodeName != state.Actual[j].NodeName {
+			return state.Actual[i].NodeName < state.Actual[j].NodeName
+		}
+		return state.Actual[i].VolumeName < state.Actual[j].VolumeName
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(state); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package attachdetach
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/informers"
+	"k8s.io/kubernetes/pkg/controller"
+	controllervolumetesting "k8s.io/kubernetes/pkg/controller/volume/attachdetach/testing"
+)
+
+func newDebugTestController(t *testing.T) AttachDetachController {
+	t.Helper()
+	fakeKubeClient := controllervolumetesting.CreateTestClient()
+	informerFactory := informers.NewSharedInformerFactory(fakeKubeClient, controller.NoResyncPeriodFunc())
+	adc, err := NewAttachDetachController(
+		fakeKubeClient,
+		informerFactory.Core().V1().Pods(),
+		informerFactory.Core().V1().Nodes(),
+		informerFactory.Core().V1().PersistentVolumeClaims(),
+		informerFactory.Core().V1().PersistentVolumes(),
+		informerFactory.Storage().V1().CSINodes(),
+		informerFactory.Storage().V1().CSIDrivers(),
+		informerFactory.Storage().V1().VolumeAttachments(),
+		nil, /* cloud */
+		nil, /* plugins */
+		nil, /* prober */
+		false,
+		5*time.Second,
+		0, /* maxParallelDetachesPerNode */
+		DefaultTimerConfig,
+		nil, /* filteredDialOptions */
+	)
+	if err != nil {
+		t.Fatalf("failed to construct controller: %v", err)
+	}
+	return adc
+}
+
+func Test_DebugHandler_ServesState(t *testing.T) {
+	adc := newDebugTestController(t)
+
+	recorder := httptest.NewRecorder()
+	adc.DebugHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, recorder.Code)
+	}
+	var state debugState
+	if err := json.Unmarshal(recorder.Body.Bytes(), &state); err != nil {
+		t.Fatalf("cannot decode debug state: %v", err)
+	}
+	if state.Desired == nil || state.Actual == nil {
+		t.Errorf("expected empty lists rather than null, got %+v", state)
+	}
+}
+
+func Test_DebugHandler_ForceSync(t *testing.T) {
+	adc := newDebugTestController(t)
+	handler := adc.DebugHandler()
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/?node=node-1", nil))
+	if recorder.Code != http.StatusAccepted {
+		t.Errorf("expected status %d, got %d", http.StatusAccepted, recorder.Code)
+	}
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/", nil))
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d for a missing node parameter, got %d", http.StatusBadRequest, recorder.Code)
+	}
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPut, "/", nil))
+	if recorder.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected status %d for PUT, got %d", http.StatusMethodNotAllowed, recorder.Code)
+	}
+}
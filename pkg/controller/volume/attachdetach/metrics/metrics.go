@@ -17,11 +17,14 @@ limitations under the License.
 package metrics
 
 import (
+	"strings"
 	"sync"
 
+	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	corelisters "k8s.io/client-go/listers/core/v1"
+	storagelisters "k8s.io/client-go/listers/storage/v1"
 	"k8s.io/component-base/metrics"
 	"k8s.io/component-base/metrics/legacyregistry"
 	"k8s.io/klog/v2"
@@ -47,6 +50,12 @@ var (
 		[]string{"plugin_name", "state"}, nil,
 		metrics.ALPHA, "")
 
+	migratedPluginsMetricDesc = metrics.NewDesc(
+		metrics.BuildFQName("", "csi_migration", "migrated_plugins"),
+		"In-tree plugins reported as migrated to CSI per node, aggregated from the CSINode migrated-plugins annotation",
+		[]string{"node", "plugin_name"}, nil,
+		metrics.ALPHA, "")
+
 	forcedDetachMetricCounter = metrics.NewCounter(
 		&metrics.CounterOpts{
 			Name:           "attachdetach_controller_forced_detaches",
@@ -60,6 +69,7 @@ var registerMetrics sync.Once
 func Register(pvcLister corelisters.PersistentVolumeClaimLister,
 	pvLister corelisters.PersistentVolumeLister,
 	podLister corelisters.PodLister,
+	csiNodeLister storagelisters.CSINodeLister,
 	asw cache.ActualStateOfWorld,
 	dsw cache.DesiredStateOfWorld,
 	pluginMgr *volume.VolumePluginMgr,
@@ -69,6 +79,7 @@ func Register(pvcLister corelisters.PersistentVolumeClaimLister,
 		legacyregistry.CustomMustRegister(newAttachDetachStateCollector(pvcLister,
 			podLister,
 			pvLister,
+			csiNodeLister,
 			asw,
 			dsw,
 			pluginMgr,
@@ -84,6 +95,7 @@ type attachDetachStateCollector struct {
 	pvcLister                corelisters.PersistentVolumeClaimLister
 	podLister                corelisters.PodLister
 	pvLister                 corelisters.PersistentVolumeLister
+	csiNodeLister            storagelisters.CSINodeLister
 	asw                      cache.ActualStateOfWorld
 	dsw                      cache.DesiredStateOfWorld
 	volumePluginMgr          *volume.VolumePluginMgr
@@ -111,12 +123,13 @@ func newAttachDetachStateCollector(
 	pvcLister corelisters.PersistentVolumeClaimLister,
 	podLister corelisters.PodLister,
 	pvLister corelisters.PersistentVolumeLister,
+	csiNodeLister storagelisters.CSINodeLister,
 	asw cache.ActualStateOfWorld,
 	dsw cache.DesiredStateOfWorld,
 	pluginMgr *volume.VolumePluginMgr,
 	csiMigratedPluginManager csimigration.PluginManager,
 	intreeToCSITranslator csimigration.InTreeToCSITranslator) *attachDetachStateCollector {
-	return &attachDetachStateCollector{pvcLister: pvcLister, podLister: podLister, pvLister: pvLister, asw: asw, dsw: dsw, volumePluginMgr: pluginMgr, csiMigratedPluginManager: csiMigratedPluginManager, intreeToCSITranslator: intreeToCSITranslator}
+	return &attachDetachStateCollector{pvcLister: pvcLister, podLister: podLister, pvLister: pvLister, csiNodeLister: csiNodeLister, asw: asw, dsw: dsw, volumePluginMgr: pluginMgr, csiMigratedPluginManager: csiMigratedPluginManager, intreeToCSITranslator: intreeToCSITranslator}
 }
 
 // Check if our collector implements necessary collector interface
@@ -125,6 +138,7 @@ var _ metrics.StableCollector = &attachDetachStateCollector{}
 func (collector *attachDetachStateCollector) DescribeWithStability(ch chan<- *metrics.Desc) {
 	ch <- inUseVolumeMetricDesc
 	ch <- totalVolumesMetricDesc
+	ch <- migratedPluginsMetricDesc
 }
 
 func (collector *attachDetachStateCollector) CollectWithStability(ch chan<- metrics.Metric) {
@@ -149,6 +163,17 @@ func (collector *attachDetachStateCollector) CollectWithStability(ch chan<- metr
 				string(stateName))
 		}
 	}
+
+	nodePluginMap := collector.getMigratedPlugins()
+	for nodeName, pluginNames := range nodePluginMap {
+		for _, pluginName := range pluginNames {
+			ch <- metrics.NewLazyConstMetric(migratedPluginsMetricDesc,
+				metrics.GaugeValue,
+				1,
+				nodeName,
+				pluginName)
+		}
+	}
 }
 
 func (collector *attachDetachStateCollector) getVolumeInUseCount() volumeCount {
@@ -206,6 +231,27 @@ func (collector *attachDetachStateCollector) getTotalVolumesCount() volumeCount
 	return stateVolumeMap
 }
 
+// getMigratedPlugins returns the in-tree plugin names each node reports as
+// migrated to CSI, as published by the kubelet in the CSINode
+// migrated-plugins annotation.
+func (collector *attachDetachStateCollector) getMigratedPlugins() map[string][]string {
+	csiNodes, err := collector.csiNodeLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("Error getting CSINode list")
+		return nil
+	}
+
+	nodePluginMap := make(map[string][]string)
+	for _, csiNode := range csiNodes {
+		mpa := csiNode.Annotations[v1.MigratedPluginsAnnotationKey]
+		if mpa == "" {
+			continue
+		}
+		nodePluginMap[csiNode.Name] = strings.Split(mpa, ",")
+	}
+	return nodePluginMap
+}
+
 // RecordForcedDetachMetric register a forced detach metric.
 func RecordForcedDetachMetric() {
 	forcedDetachMetricCounter.Inc()
@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	v1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8stypes "k8s.io/apimachinery/pkg/types"
@@ -120,6 +121,7 @@ func TestVolumesInUseMetricCollection(t *testing.T) {
 		pvLister,
 		nil,
 		nil,
+		nil,
 		fakeVolumePluginMgr,
 		csimigration.NewPluginManager(csiTranslator, utilfeature.DefaultFeatureGate),
 		csiTranslator)
@@ -159,6 +161,7 @@ func TestTotalVolumesMetricCollection(t *testing.T) {
 		nil,
 		nil,
 		nil,
+		nil,
 		asw,
 		dsw,
 		fakeVolumePluginMgr,
@@ -190,3 +193,69 @@ func TestTotalVolumesMetricCollection(t *testing.T) {
 		t.Errorf("Expected 1 fake-plugin volume in ActualStateOfWorld, got %d", fakePluginCount)
 	}
 }
+
+func TestMigratedPluginsMetricCollection(t *testing.T) {
+	fakeClient := &fake.Clientset{}
+	fakeInformerFactory := informers.NewSharedInformerFactory(fakeClient, controller.NoResyncPeriodFunc())
+	csiNodeInformer := fakeInformerFactory.Storage().V1().CSINodes()
+
+	csiNodes := []*storagev1.CSINode{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "metric-test-host-1",
+				Annotations: map[string]string{
+					v1.MigratedPluginsAnnotationKey: "kubernetes.io/aws-ebs,kubernetes.io/gce-pd",
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "metric-test-host-2",
+				Annotations: map[string]string{
+					v1.MigratedPluginsAnnotationKey: "kubernetes.io/gce-pd",
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "metric-test-host-3",
+			},
+		},
+	}
+	for _, csiNode := range csiNodes {
+		csiNodeInformer.Informer().GetStore().Add(csiNode)
+	}
+
+	csiTranslator := csitrans.New()
+	metricCollector := newAttachDetachStateCollector(
+		nil,
+		nil,
+		nil,
+		csiNodeInformer.Lister(),
+		nil,
+		nil,
+		nil,
+		csimigration.NewPluginManager(csiTranslator, utilfeature.DefaultFeatureGate),
+		csiTranslator)
+
+	nodePluginMap := metricCollector.getMigratedPlugins()
+	if len(nodePluginMap) != 2 {
+		t.Errorf("Expected 2 nodes with migrated plugins, got %d", len(nodePluginMap))
+	}
+	expected := map[string][]string{
+		"metric-test-host-1": {"kubernetes.io/aws-ebs", "kubernetes.io/gce-pd"},
+		"metric-test-host-2": {"kubernetes.io/gce-pd"},
+	}
+	for nodeName, pluginNames := range expected {
+		gotPlugins := nodePluginMap[nodeName]
+		if len(gotPlugins) != len(pluginNames) {
+			t.Errorf("Expected %d migrated plugins on node %s, got %d", len(pluginNames), nodeName, len(gotPlugins))
+			continue
+		}
+		for i := range pluginNames {
+			if gotPlugins[i] != pluginNames[i] {
+				t.Errorf("Expected migrated plugin %s on node %s, got %s", pluginNames[i], nodeName, gotPlugins[i])
+			}
+		}
+	}
+}
@@ -53,6 +53,26 @@ type Reconciler interface {
 	// be detached are detached. If not, it will trigger attach/detach
 	// operations to rectify.
 	Run(stopCh <-chan struct{})
+
+	// ForceSyncNode verifies against the storage provider that the volumes
+	// the actual state of the world believes are attached to the given node
+	// really are, correcting the cache where they are not. It lets operators
+	// recover from cache divergence for one node without waiting for the
+	// periodic sync or restarting the controller.
+	ForceSyncNode(nodeName types.NodeName)
+}
+
+// defaultMaxParallelDetachesPerNode holds conservative in-tree defaults for
+// how many detach operations may run in parallel against a single node,
+// keyed by volume plugin name. They apply only when no limit is configured
+// explicitly. Plugins that are not listed are not limited - their backends
+// handle parallel detaches fine.
+var defaultMaxParallelDetachesPerNode = map[string]int32{
+	// Azure serializes disk operations on the VM anyway; issuing more
+	// concurrent detaches only causes API throttling.
+	"kubernetes.io/azure-disk": 1,
+	// vSphere reconfigures the VM for every detach; keep contention low.
+	"kubernetes.io/vsphere-volume": 1,
 }
 
 // NewReconciler returns a new instance of Reconciler that waits loopPeriod
@@ -64,11 +84,15 @@ type Reconciler interface {
 // anyway (to handle crashed/unavailable nodes). If during this time the volume
 // becomes used by a new pod, the detach request will be aborted and the timer
 // cleared.
+// maxDetachesPerNode is the maximum number of detach operations that may run
+// in parallel against a single node; 0 falls back to the in-tree per volume
+// plugin defaults.
 func NewReconciler(
 	loopPeriod time.Duration,
 	maxWaitForUnmountDuration time.Duration,
 	syncDuration time.Duration,
 	disableReconciliationSync bool,
+	maxDetachesPerNode int32,
 	desiredStateOfWorld cache.DesiredStateOfWorld,
 	actualStateOfWorld cache.ActualStateOfWorld,
 	attacherDetacher operationexecutor.OperationExecutor,
@@ -80,6 +104,7 @@ func NewReconciler(
 		maxWaitForUnmountDuration: maxWaitForUnmountDuration,
 		syncDuration:              syncDuration,
 		disableReconciliationSync: disableReconciliationSync,
+		maxDetachesPerNode:        maxDetachesPerNode,
 		desiredStateOfWorld:       desiredStateOfWorld,
 		actualStateOfWorld:        actualStateOfWorld,
 		attacherDetacher:          attacherDetacher,
@@ -94,6 +119,7 @@ type reconciler struct {
 	loopPeriod                time.Duration
 	maxWaitForUnmountDuration time.Duration
 	syncDuration              time.Duration
+	maxDetachesPerNode        int32
 	desiredStateOfWorld       cache.DesiredStateOfWorld
 	actualStateOfWorld        cache.ActualStateOfWorld
 	attacherDetacher          operationexecutor.OperationExecutor
@@ -141,6 +167,34 @@ func (rc *reconciler) syncStates() {
 	rc.attacherDetacher.VerifyVolumesAreAttached(volumesPerNode, rc.actualStateOfWorld)
 }
 
+func (rc *reconciler) ForceSyncNode(nodeName types.NodeName) {
+	volumesPerNode := rc.actualStateOfWorld.GetAttachedVolumesPerNode()
+	volumes, exists := volumesPerNode[nodeName]
+	if !exists {
+		klog.V(2).Infof("Forced sync requested for node %q with no attached volumes", nodeName)
+		return
+	}
+	klog.V(2).Infof("Forcing sync of %d attached volumes on node %q", len(volumes), nodeName)
+	rc.attacherDetacher.VerifyVolumesAreAttached(
+		map[types.NodeName][]operationexecutor.AttachedVolume{nodeName: volumes},
+		rc.actualStateOfWorld)
+}
+
+// maxParallelDetachesPerNode returns how many detach operations may run in
+// parallel against a single node for the plugin owning the given volume.
+// A configured limit takes precedence over the in-tree per-plugin defaults;
+// 0 means no limit.
+func (rc *reconciler) maxParallelDetachesPerNode(volumeName v1.UniqueVolumeName) int32 {
+	if rc.maxDetachesPerNode > 0 {
+		return rc.maxDetachesPerNode
+	}
+	pluginName, _, err := util.SplitUniqueName(volumeName)
+	if err != nil {
+		return 0
+	}
+	return defaultMaxParallelDetachesPerNode[pluginName]
+}
+
 // hasOutOfServiceTaint returns true if the node has out-of-service taint present
 // and `NodeOutOfServiceVolumeDetach` feature gate is enabled.
 func (rc *reconciler) hasOutOfServiceTaint(nodeName types.NodeName) (bool, error) {
@@ -158,6 +212,10 @@ func (rc *reconciler) reconcile() {
 	// Detaches are triggered before attaches so that volumes referenced by
 	// pods that are rescheduled to a different node are detached first.
 
+	// detachesInProgress counts detach operations that are running or backing
+	// off per node, so that the per-node parallelism limit can be enforced.
+	detachesInProgress := map[types.NodeName]int32{}
+
 	// Ensure volumes that should be detached are detached.
 	for _, attachedVolume := range rc.actualStateOfWorld.GetAttachedVolumes() {
 		if !rc.desiredStateOfWorld.VolumeExists(
@@ -173,15 +231,25 @@ func (rc *reconciler) reconcile() {
 			if util.IsMultiAttachAllowed(attachedVolume.VolumeSpec) {
 				if !rc.attacherDetacher.IsOperationSafeToRetry(attachedVolume.VolumeName, "" /* podName */, attachedVolume.NodeName, operationexecutor.DetachOperationName) {
 					klog.V(10).Infof("Operation for volume %q is already running or still in exponential backoff for node %q. Can't start detach", attachedVolume.VolumeName, attachedVolume.NodeName)
+					detachesInProgress[attachedVolume.NodeName]++
 					continue
 				}
 			} else {
 				if !rc.attacherDetacher.IsOperationSafeToRetry(attachedVolume.VolumeName, "" /* podName */, "" /* nodeName */, operationexecutor.DetachOperationName) {
 					klog.V(10).Infof("Operation for volume %q is already running or still in exponential backoff in the cluster. Can't start detach for %q", attachedVolume.VolumeName, attachedVolume.NodeName)
+					detachesInProgress[attachedVolume.NodeName]++
 					continue
 				}
 			}
 
+			// Don't start more detach operations against a node than its
+			// backend handles in parallel; the volume stays attached and is
+			// retried in a future reconciliation.
+			if limit := rc.maxParallelDetachesPerNode(attachedVolume.VolumeName); limit > 0 && detachesInProgress[attachedVolume.NodeName] >= limit {
+				klog.V(4).Infof("Postponing detach of volume %q from node %q: %d detach operations already in progress", attachedVolume.VolumeName, attachedVolume.NodeName, detachesInProgress[attachedVolume.NodeName])
+				continue
+			}
+
 			// Because the detach operation updates the ActualStateOfWorld before
 			// marking itself complete, it's possible for the volume to be removed
 			// from the ActualStateOfWorld between the GetAttachedVolumes() check
@@ -246,6 +314,7 @@ func (rc *reconciler) reconcile() {
 			verifySafeToDetach := !(timeout || hasOutOfServiceTaint)
 			err = rc.attacherDetacher.DetachVolume(attachedVolume.AttachedVolume, verifySafeToDetach, rc.actualStateOfWorld)
 			if err == nil {
+				detachesInProgress[attachedVolume.NodeName]++
 				if !timeout {
 					klog.InfoS("attacherDetacher.DetachVolume started", "volume", attachedVolume)
 				} else {
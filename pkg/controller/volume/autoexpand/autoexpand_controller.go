@@ -0,0 +1,326 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package autoexpand
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	storageinformers "k8s.io/client-go/informers/storage/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	v1core "k8s.io/client-go/kubernetes/typed/core/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	storagelisters "k8s.io/client-go/listers/storage/v1"
+	kcache "k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	storagehelpers "k8s.io/component-helpers/storage/volume"
+	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/controller/volume/events"
+)
+
+const (
+	// AnnAutoExpandStepSize is the StorageClass annotation that opts claims
+	// of the class into usage-driven auto-expansion. Its value is the
+	// quantity added to the claim's request on every expansion.
+	AnnAutoExpandStepSize = "volume.kubernetes.io/auto-expand-step-size"
+	// AnnAutoExpandMaxSize is the StorageClass annotation bounding the
+	// request size auto-expansion may grow a claim to. Without it the claim
+	// grows without limit.
+	AnnAutoExpandMaxSize = "volume.kubernetes.io/auto-expand-max-size"
+	// AnnAutoExpandThreshold is the StorageClass annotation holding the
+	// usage percentage above which a claim is grown. Defaults to 80.
+	AnnAutoExpandThreshold = "volume.kubernetes.io/auto-expand-threshold"
+
+	// defaultThresholdPercent is used when AnnAutoExpandThreshold is unset.
+	defaultThresholdPercent = 80
+)
+
+// VolumeStats holds the usage of a single volume as reported by the kubelet
+// stats API.
+type VolumeStats struct {
+	// UsedBytes is the number of bytes used on the volume's filesystem.
+	UsedBytes int64
+	// CapacityBytes is the size of the volume's filesystem.
+	CapacityBytes int64
+}
+
+// StatsProvider supplies per-claim volume usage, typically collected from
+// the kubelet Summary API.
+type StatsProvider interface {
+	// Refresh updates the cached stats. It is called once per sync period
+	// before claims are evaluated.
+	Refresh(ctx context.Context) error
+	// GetClaimStats returns the usage of the volume bound to the given
+	// claim, and whether usage for the claim is known.
+	GetClaimStats(namespace, name string) (VolumeStats, bool)
+}
+
+// AutoExpandController grows PVCs whose volume usage crossed the threshold
+// configured on their StorageClass.
+type AutoExpandController interface {
+	Run(ctx context.Context)
+	// Pause makes the controller skip reconciliation and drop its cached
+	// usage stats until Resume is called. The memory budget manager uses
+	// it to shed the controller under memory pressure.
+	Pause()
+	// Resume undoes a previous Pause.
+	Resume()
+}
+
+type autoExpandController struct {
+	// kubeClient is the kube API client used to update claims.
+	kubeClient clientset.Interface
+
+	// pvcLister is the shared PVC lister used to fetch and store PVC
+	// objects from the API server. It is shared with other controllers and
+	// therefore the PVC objects in its store should be treated as immutable.
+	pvcLister  corelisters.PersistentVolumeClaimLister
+	pvcsSynced kcache.InformerSynced
+
+	classLister   storagelisters.StorageClassLister
+	classesSynced kcache.InformerSynced
+
+	statsProvider StatsProvider
+
+	// recorder is used to record events in the API server
+	recorder record.EventRecorder
+
+	// syncPeriod is how often all claims are re-evaluated against fresh
+	// usage stats.
+	syncPeriod time.Duration
+
+	// pauseMutex guards paused, which is set while the controller is shed
+	// by the memory budget manager.
+	pauseMutex sync.RWMutex
+	paused     bool
+}
+
+// autoExpandPolicy is the per-StorageClass expansion policy parsed from the
+// class annotations.
+type autoExpandPolicy struct {
+	thresholdPercent int64
+	stepSize         resource.Quantity
+	// maxSize is nil when the class does not bound auto-expansion.
+	maxSize *resource.Quantity
+}
+
+// NewAutoExpandController returns a controller that periodically grows
+// claims whose usage crossed the auto-expansion threshold of their
+// StorageClass.
+func NewAutoExpandController(
+	kubeClient clientset.Interface,
+	pvcInformer coreinformers.PersistentVolumeClaimInformer,
+	classInformer storageinformers.StorageClassInformer,
+	statsProvider StatsProvider,
+	syncPeriod time.Duration) AutoExpandController {
+
+	ctrl := &autoExpandController{
+		kubeClient:    kubeClient,
+		pvcLister:     pvcInformer.Lister(),
+		pvcsSynced:    pvcInformer.Informer().HasSynced,
+		classLister:   classInformer.Lister(),
+		classesSynced: classInformer.Informer().HasSynced,
+		statsProvider: statsProvider,
+		syncPeriod:    syncPeriod,
+	}
+
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartStructuredLogging(0)
+	eventBroadcaster.StartRecordingToSink(&v1core.EventSinkImpl{Interface: kubeClient.CoreV1().Events("")})
+	ctrl.recorder = eventBroadcaster.NewRecorder(scheme.Scheme, v1.EventSource{Component: "volume_autoexpand"})
+
+	return ctrl
+}
+
+func (ctrl *autoExpandController) Run(ctx context.Context) {
+	defer runtime.HandleCrash()
+
+	klog.Infof("Starting volume auto-expand controller")
+	defer klog.Infof("Shutting down volume auto-expand controller")
+
+	if !kcache.WaitForNamedCacheSync("volume auto-expand", ctx.Done(), ctrl.pvcsSynced, ctrl.classesSynced) {
+		return
+	}
+
+	wait.UntilWithContext(ctx, ctrl.reconcile, ctrl.syncPeriod)
+}
+
+// Pause makes reconcile a no-op and drops the cached usage stats, freeing
+// the per-claim stats map while the process is under memory pressure.
+func (ctrl *autoExpandController) Pause() {
+	ctrl.pauseMutex.Lock()
+	ctrl.paused = true
+	ctrl.pauseMutex.Unlock()
+	if dropper, ok := ctrl.statsProvider.(interface{ DropCache() }); ok {
+		dropper.DropCache()
+	}
+	klog.Infof("Volume auto-expand controller paused by the memory budget")
+}
+
+// Resume lets reconcile run again; the stats are rebuilt on the next sync.
+func (ctrl *autoExpandController) Resume() {
+	ctrl.pauseMutex.Lock()
+	ctrl.paused = false
+	ctrl.pauseMutex.Unlock()
+	klog.Infof("Volume auto-expand controller resumed by the memory budget")
+}
+
+// reconcile refreshes the usage stats and evaluates every claim once.
+func (ctrl *autoExpandController) reconcile(ctx context.Context) {
+	ctrl.pauseMutex.RLock()
+	paused := ctrl.paused
+	ctrl.pauseMutex.RUnlock()
+	if paused {
+		return
+	}
+
+	if err := ctrl.statsProvider.Refresh(ctx); err != nil {
+		runtime.HandleError(fmt.Errorf("refreshing volume stats: %v", err))
+		return
+	}
+
+	claims, err := ctrl.pvcLister.List(labels.Everything())
+	if err != nil {
+		runtime.HandleError(fmt.Errorf("listing claims: %v", err))
+		return
+	}
+	for _, pvc := range claims {
+		if err := ctrl.syncClaim(ctx, pvc); err != nil {
+			runtime.HandleError(fmt.Errorf("auto-expanding claim %s/%s: %v", pvc.Namespace, pvc.Name, err))
+		}
+	}
+}
+
+// syncClaim grows the claim by its class's step size when its usage crossed
+// the threshold, up to the class's max size.
+func (ctrl *autoExpandController) syncClaim(ctx context.Context, pvc *v1.PersistentVolumeClaim) error {
+	if pvc.Status.Phase != v1.ClaimBound {
+		return nil
+	}
+
+	className := storagehelpers.GetPersistentVolumeClaimClass(pvc)
+	if className == "" {
+		return nil
+	}
+	class, err := ctrl.classLister.Get(className)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	policy, enabled, err := parseAutoExpandPolicy(class)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return nil
+	}
+	if class.AllowVolumeExpansion == nil || !*class.AllowVolumeExpansion {
+		klog.V(4).Infof("StorageClass %q requests auto-expansion but does not allow volume expansion, skipping claim %s/%s", class.Name, pvc.Namespace, pvc.Name)
+		return nil
+	}
+
+	stats, ok := ctrl.statsProvider.GetClaimStats(pvc.Namespace, pvc.Name)
+	if !ok || stats.CapacityBytes <= 0 {
+		return nil
+	}
+	usedPercent := stats.UsedBytes * 100 / stats.CapacityBytes
+	if usedPercent < policy.thresholdPercent {
+		return nil
+	}
+
+	currentRequest := pvc.Spec.Resources.Requests[v1.ResourceStorage]
+	statusCapacity := pvc.Status.Capacity[v1.ResourceStorage]
+	// An expansion is already in flight; wait for it to finish before
+	// growing the claim further.
+	if currentRequest.Cmp(statusCapacity) > 0 {
+		return nil
+	}
+	if policy.maxSize != nil && currentRequest.Cmp(*policy.maxSize) >= 0 {
+		ctrl.recorder.Eventf(pvc, v1.EventTypeWarning, events.VolumeAutoExpandLimit,
+			"Volume usage is %d%% but the claim already reached the %s limit of StorageClass %q", usedPercent, policy.maxSize.String(), class.Name)
+		return nil
+	}
+
+	newSize := currentRequest.DeepCopy()
+	newSize.Add(policy.stepSize)
+	if policy.maxSize != nil && newSize.Cmp(*policy.maxSize) > 0 {
+		newSize = policy.maxSize.DeepCopy()
+	}
+
+	newPVC := pvc.DeepCopy()
+	newPVC.Spec.Resources.Requests[v1.ResourceStorage] = newSize
+	if _, err := ctrl.kubeClient.CoreV1().PersistentVolumeClaims(newPVC.Namespace).Update(ctx, newPVC, metav1.UpdateOptions{}); err != nil {
+		return err
+	}
+	ctrl.recorder.Eventf(pvc, v1.EventTypeNormal, events.VolumeAutoExpand,
+		"Growing claim from %s to %s, volume usage is %d%%", currentRequest.String(), newSize.String(), usedPercent)
+	klog.V(2).Infof("Grew claim %s/%s from %s to %s, volume usage is %d%%", pvc.Namespace, pvc.Name, currentRequest.String(), newSize.String(), usedPercent)
+	return nil
+}
+
+// parseAutoExpandPolicy returns the expansion policy of the class and
+// whether the class opts into auto-expansion at all.
+func parseAutoExpandPolicy(class *storagev1.StorageClass) (autoExpandPolicy, bool, error) {
+	policy := autoExpandPolicy{thresholdPercent: defaultThresholdPercent}
+
+	step, ok := class.Annotations[AnnAutoExpandStepSize]
+	if !ok {
+		return policy, false, nil
+	}
+	stepSize, err := resource.ParseQuantity(step)
+	if err != nil {
+		return policy, false, fmt.Errorf("invalid %s annotation on StorageClass %q: %v", AnnAutoExpandStepSize, class.Name, err)
+	}
+	if stepSize.Sign() <= 0 {
+		return policy, false, fmt.Errorf("invalid %s annotation on StorageClass %q: step size must be positive", AnnAutoExpandStepSize, class.Name)
+	}
+	policy.stepSize = stepSize
+
+	if max, ok := class.Annotations[AnnAutoExpandMaxSize]; ok {
+		maxSize, err := resource.ParseQuantity(max)
+		if err != nil {
+			return policy, false, fmt.Errorf("invalid %s annotation on StorageClass %q: %v", AnnAutoExpandMaxSize, class.Name, err)
+		}
+		policy.maxSize = &maxSize
+	}
+
+	if threshold, ok := class.Annotations[AnnAutoExpandThreshold]; ok {
+		thresholdPercent, err := strconv.ParseInt(threshold, 10, 64)
+		if err != nil || thresholdPercent <= 0 || thresholdPercent > 100 {
+			return policy, false, fmt.Errorf("invalid %s annotation on StorageClass %q: must be a percentage between 1 and 100", AnnAutoExpandThreshold, class.Name)
+		}
+		policy.thresholdPercent = thresholdPercent
+	}
+
+	return policy, true, nil
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package autoexpand
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	coretesting "k8s.io/client-go/testing"
+	"k8s.io/kubernetes/pkg/controller"
+)
+
+type fakeStatsProvider struct {
+	stats map[string]VolumeStats
+}
+
+func (f *fakeStatsProvider) Refresh(ctx context.Context) error {
+	return nil
+}
+
+func (f *fakeStatsProvider) GetClaimStats(namespace, name string) (VolumeStats, bool) {
+	stats, ok := f.stats[namespace+"/"+name]
+	return stats, ok
+}
+
+func TestSyncClaim(t *testing.T) {
+	gig := int64(1024 * 1024 * 1024)
+
+	tests := []struct {
+		name         string
+		pvc          *v1.PersistentVolumeClaim
+		class        *storagev1.StorageClass
+		stats        map[string]VolumeStats
+		hasError     bool
+		expectedSize string
+	}{
+		{
+			name:  "usage below threshold leaves the claim alone",
+			pvc:   getFakeClaim("pvc", "gold", "1Gi", "1Gi"),
+			class: getFakeClass("gold", true, map[string]string{AnnAutoExpandStepSize: "1Gi"}),
+			stats: map[string]VolumeStats{"default/pvc": {UsedBytes: gig / 2, CapacityBytes: gig}},
+		},
+		{
+			name:         "usage above threshold grows the claim by the step size",
+			pvc:          getFakeClaim("pvc", "gold", "1Gi", "1Gi"),
+			class:        getFakeClass("gold", true, map[string]string{AnnAutoExpandStepSize: "1Gi"}),
+			stats:        map[string]VolumeStats{"default/pvc": {UsedBytes: gig * 9 / 10, CapacityBytes: gig}},
+			expectedSize: "2Gi",
+		},
+		{
+			name: "growth is clamped at the class max size",
+			pvc:  getFakeClaim("pvc", "gold", "3Gi", "3Gi"),
+			class: getFakeClass("gold", true, map[string]string{
+				AnnAutoExpandStepSize: "2Gi",
+				AnnAutoExpandMaxSize:  "4Gi",
+			}),
+			stats:        map[string]VolumeStats{"default/pvc": {UsedBytes: gig * 9 / 10, CapacityBytes: gig}},
+			expectedSize: "4Gi",
+		},
+		{
+			name: "claim at the max size is not grown",
+			pvc:  getFakeClaim("pvc", "gold", "4Gi", "4Gi"),
+			class: getFakeClass("gold", true, map[string]string{
+				AnnAutoExpandStepSize: "1Gi",
+				AnnAutoExpandMaxSize:  "4Gi",
+			}),
+			stats: map[string]VolumeStats{"default/pvc": {UsedBytes: gig * 9 / 10, CapacityBytes: gig}},
+		},
+		{
+			name: "custom threshold is honored",
+			pvc:  getFakeClaim("pvc", "gold", "1Gi", "1Gi"),
+			class: getFakeClass("gold", true, map[string]string{
+				AnnAutoExpandStepSize:  "1Gi",
+				AnnAutoExpandThreshold: "50",
+			}),
+			stats:        map[string]VolumeStats{"default/pvc": {UsedBytes: gig * 6 / 10, CapacityBytes: gig}},
+			expectedSize: "2Gi",
+		},
+		{
+			name:  "class without the step annotation opts out",
+			pvc:   getFakeClaim("pvc", "gold", "1Gi", "1Gi"),
+			class: getFakeClass("gold", true, nil),
+			stats: map[string]VolumeStats{"default/pvc": {UsedBytes: gig * 9 / 10, CapacityBytes: gig}},
+		},
+		{
+			name:  "class that disallows expansion opts out",
+			pvc:   getFakeClaim("pvc", "gold", "1Gi", "1Gi"),
+			class: getFakeClass("gold", false, map[string]string{AnnAutoExpandStepSize: "1Gi"}),
+			stats: map[string]VolumeStats{"default/pvc": {UsedBytes: gig * 9 / 10, CapacityBytes: gig}},
+		},
+		{
+			name:  "expansion in flight is not stacked",
+			pvc:   getFakeClaim("pvc", "gold", "2Gi", "1Gi"),
+			class: getFakeClass("gold", true, map[string]string{AnnAutoExpandStepSize: "1Gi"}),
+			stats: map[string]VolumeStats{"default/pvc": {UsedBytes: gig * 9 / 10, CapacityBytes: gig}},
+		},
+		{
+			name:  "claim without stats is skipped",
+			pvc:   getFakeClaim("pvc", "gold", "1Gi", "1Gi"),
+			class: getFakeClass("gold", true, map[string]string{AnnAutoExpandStepSize: "1Gi"}),
+			stats: map[string]VolumeStats{},
+		},
+		{
+			name:     "invalid step size annotation is an error",
+			pvc:      getFakeClaim("pvc", "gold", "1Gi", "1Gi"),
+			class:    getFakeClass("gold", true, map[string]string{AnnAutoExpandStepSize: "a-lot"}),
+			stats:    map[string]VolumeStats{"default/pvc": {UsedBytes: gig * 9 / 10, CapacityBytes: gig}},
+			hasError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeKubeClient := fake.NewSimpleClientset(tc.pvc)
+			informerFactory := informers.NewSharedInformerFactory(fakeKubeClient, controller.NoResyncPeriodFunc())
+			pvcInformer := informerFactory.Core().V1().PersistentVolumeClaims()
+			classInformer := informerFactory.Storage().V1().StorageClasses()
+			if err := classInformer.Informer().GetIndexer().Add(tc.class); err != nil {
+				t.Fatalf("error adding class to informer: %v", err)
+			}
+
+			ctrl := NewAutoExpandController(fakeKubeClient, pvcInformer, classInformer,
+				&fakeStatsProvider{stats: tc.stats}, time.Minute)
+			err := ctrl.(*autoExpandController).syncClaim(context.TODO(), tc.pvc)
+			if tc.hasError && err == nil {
+				t.Error("expected error, got none")
+			}
+			if !tc.hasError && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			var updated *v1.PersistentVolumeClaim
+			for _, action := range fakeKubeClient.Actions() {
+				if action.Matches("update", "persistentvolumeclaims") {
+					updated = action.(coretesting.UpdateAction).GetObject().(*v1.PersistentVolumeClaim)
+				}
+			}
+			if tc.expectedSize == "" {
+				if updated != nil {
+					t.Errorf("unexpected claim update: %+v", updated)
+				}
+				return
+			}
+			if updated == nil {
+				t.Fatal("expected the claim to be updated")
+			}
+			size := updated.Spec.Resources.Requests[v1.ResourceStorage]
+			if size.Cmp(resource.MustParse(tc.expectedSize)) != 0 {
+				t.Errorf("expected claim size %s, got %s", tc.expectedSize, size.String())
+			}
+		})
+	}
+}
+
+func getFakeClaim(name, className, requestSize, statusSize string) *v1.PersistentVolumeClaim {
+	return &v1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: v1.PersistentVolumeClaimSpec{
+			StorageClassName: &className,
+			VolumeName:       "vol-" + name,
+			Resources: v1.ResourceRequirements{
+				Requests: v1.ResourceList{v1.ResourceStorage: resource.MustParse(requestSize)},
+			},
+		},
+		Status: v1.PersistentVolumeClaimStatus{
+			Phase:    v1.ClaimBound,
+			Capacity: v1.ResourceList{v1.ResourceStorage: resource.MustParse(statusSize)},
+		},
+	}
+}
+
+func getFakeClass(name string, allowExpansion bool, annotations map[string]string) *storagev1.StorageClass {
+	return &storagev1.StorageClass{
+		ObjectMeta:           metav1.ObjectMeta{Name: name, Annotations: annotations},
+		Provisioner:          "kubernetes.io/fake",
+		AllowVolumeExpansion: &allowExpansion,
+	}
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package autoexpand
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/labels"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	clientset "k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/klog/v2"
+	statsapi "k8s.io/kubelet/pkg/apis/stats/v1alpha1"
+)
+
+// summaryStatsProvider collects volume usage from the kubelet Summary API
+// of every node, fetched through the API server node proxy.
+type summaryStatsProvider struct {
+	kubeClient clientset.Interface
+	nodeLister corelisters.NodeLister
+
+	mutex sync.RWMutex
+	stats map[string]VolumeStats
+}
+
+// NewSummaryStatsProvider returns a StatsProvider backed by the kubelet
+// Summary API of every node.
+func NewSummaryStatsProvider(kubeClient clientset.Interface, nodeLister corelisters.NodeLister) StatsProvider {
+	return &summaryStatsProvider{
+		kubeClient: kubeClient,
+		nodeLister: nodeLister,
+		stats:      map[string]VolumeStats{},
+	}
+}
+
+// Refresh rebuilds the per-claim stats from the Summary API of every node.
+// Nodes that fail to report keep their previous stats and contribute to the
+// aggregate error.
+func (p *summaryStatsProvider) Refresh(ctx context.Context) error {
+	nodes, err := p.nodeLister.List(labels.Everything())
+	if err != nil {
+		return fmt.Errorf("listing nodes: %v", err)
+	}
+
+	var errs []error
+	stats := map[string]VolumeStats{}
+	for _, node := range nodes {
+		summary, err := p.getStatsSummary(ctx, node.Name)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("getting stats summary of node %q: %v", node.Name, err))
+			continue
+		}
+		for _, podStats := range summary.Pods {
+			for _, volumeStats := range podStats.VolumeStats {
+				if volumeStats.PVCRef == nil || volumeStats.UsedBytes == nil || volumeStats.CapacityBytes == nil {
+					continue
+				}
+				key := volumeStats.PVCRef.Namespace + "/" + volumeStats.PVCRef.Name
+				stats[key] = VolumeStats{
+					UsedBytes:     int64(*volumeStats.UsedBytes),
+					CapacityBytes: int64(*volumeStats.CapacityBytes),
+				}
+			}
+		}
+	}
+	klog.V(4).Infof("Collected volume stats for %d claims from %d nodes", len(stats), len(nodes))
+
+	p.mutex.Lock()
+	// Carry stats of claims on unreachable nodes over from the previous
+	// refresh rather than forgetting them.
+	for key, previous := range p.stats {
+		if _, ok := stats[key]; !ok {
+			stats[key] = previous
+		}
+	}
+	p.stats = stats
+	p.mutex.Unlock()
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// DropCache releases the per-claim stats map. It is called when the
+// controller is shed by the memory budget manager; the stats are rebuilt by
+// the next Refresh.
+func (p *summaryStatsProvider) DropCache() {
+	p.mutex.Lock()
+	p.stats = map[string]VolumeStats{}
+	p.mutex.Unlock()
+}
+
+// GetClaimStats returns the usage of the volume bound to the given claim.
+func (p *summaryStatsProvider) GetClaimStats(namespace, name string) (VolumeStats, bool) {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	stats, ok := p.stats[namespace+"/"+name]
+	return stats, ok
+}
+
+// getStatsSummary fetches the Summary API of the node through the API
+// server node proxy.
+func (p *summaryStatsProvider) getStatsSummary(ctx context.Context, nodeName string) (*statsapi.Summary, error) {
+	data, err := p.kubeClient.CoreV1().RESTClient().Get().
+		Resource("nodes").
+		SubResource("proxy").
+		Name(nodeName).
+		Suffix("stats/summary").
+		Do(ctx).Raw()
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &statsapi.Summary{}
+	if err := json.Unmarshal(data, summary); err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
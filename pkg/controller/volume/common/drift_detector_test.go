@@ -0,0 +1,136 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+)
+
+func pv(name, resourceVersion string) *v1.PersistentVolume {
+	return &v1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: name, ResourceVersion: resourceVersion},
+	}
+}
+
+func TestDriftDetectorSpotCheck(t *testing.T) {
+	tests := []struct {
+		name          string
+		cached        []*v1.PersistentVolume
+		listed        []*v1.PersistentVolume
+		expectDrifted []string
+	}{
+		{
+			name:          "cache in sync",
+			cached:        []*v1.PersistentVolume{pv("pv-a", "1"), pv("pv-b", "2")},
+			listed:        []*v1.PersistentVolume{pv("pv-a", "1"), pv("pv-b", "2")},
+			expectDrifted: []string{},
+		},
+		{
+			name:          "object missing from cache",
+			cached:        []*v1.PersistentVolume{pv("pv-a", "1")},
+			listed:        []*v1.PersistentVolume{pv("pv-a", "1"), pv("pv-b", "2")},
+			expectDrifted: []string{"pv-b"},
+		},
+		{
+			name:          "stale resource version in cache",
+			cached:        []*v1.PersistentVolume{pv("pv-a", "1"), pv("pv-b", "2")},
+			listed:        []*v1.PersistentVolume{pv("pv-a", "3"), pv("pv-b", "2")},
+			expectDrifted: []string{"pv-a"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			store := cache.NewStore(cache.MetaNamespaceKeyFunc)
+			for _, obj := range tc.cached {
+				if err := store.Add(obj); err != nil {
+					t.Fatal(err)
+				}
+			}
+			listPage := func(ctx context.Context, options metav1.ListOptions) (runtime.Object, error) {
+				list := &v1.PersistentVolumeList{}
+				for _, obj := range tc.listed {
+					list.Items = append(list.Items, *obj)
+				}
+				return list, nil
+			}
+			drifted := []string{}
+			detector := NewDriftDetector("pv", store, listPage, func(key string) { drifted = append(drifted, key) }, 500)
+			if err := detector.spotCheck(context.TODO()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			sort.Strings(drifted)
+			if !reflect.DeepEqual(drifted, tc.expectDrifted) {
+				t.Errorf("expected drifted keys %v, got %v", tc.expectDrifted, drifted)
+			}
+		})
+	}
+}
+
+func TestDriftDetectorContinuesPagination(t *testing.T) {
+	store := cache.NewStore(cache.MetaNamespaceKeyFunc)
+	pages := map[string]*v1.PersistentVolumeList{
+		"": {
+			ListMeta: metav1.ListMeta{Continue: "page-2"},
+			Items:    []v1.PersistentVolume{*pv("pv-a", "1")},
+		},
+		"page-2": {
+			Items: []v1.PersistentVolume{*pv("pv-b", "2")},
+		},
+	}
+	requestedTokens := []string{}
+	listPage := func(ctx context.Context, options metav1.ListOptions) (runtime.Object, error) {
+		requestedTokens = append(requestedTokens, options.Continue)
+		return pages[options.Continue], nil
+	}
+	detector := NewDriftDetector("pv", store, listPage, func(string) {}, 1)
+
+	for i := 0; i < 3; i++ {
+		if err := detector.spotCheck(context.TODO()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	// the third check restarts from the beginning after the collection is
+	// exhausted
+	expected := []string{"", "page-2", ""}
+	if !reflect.DeepEqual(requestedTokens, expected) {
+		t.Errorf("expected continue tokens %v, got %v", expected, requestedTokens)
+	}
+}
+
+func TestDriftDetectorResetsExpiredContinue(t *testing.T) {
+	store := cache.NewStore(cache.MetaNamespaceKeyFunc)
+	listPage := func(ctx context.Context, options metav1.ListOptions) (runtime.Object, error) {
+		return nil, apierrors.NewResourceExpired("the provided continue parameter is too old")
+	}
+	detector := NewDriftDetector("pv", store, listPage, func(string) {}, 500)
+	detector.continueToken = "expired"
+	if err := detector.spotCheck(context.TODO()); err != nil {
+		t.Fatalf("expected expired continue to be tolerated, got %v", err)
+	}
+	if detector.continueToken != "" {
+		t.Errorf("expected continue token to be reset, got %q", detector.continueToken)
+	}
+}
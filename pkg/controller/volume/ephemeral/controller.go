@@ -19,6 +19,7 @@ package ephemeral
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"k8s.io/klog/v2"
@@ -43,6 +44,19 @@ import (
 	"k8s.io/kubernetes/pkg/controller/volume/events"
 )
 
+const (
+	// inheritPodLabelsAnnotation may be set in the metadata of a volume claim
+	// template to a comma-separated list of label keys. The values of those
+	// labels are copied from the pod to the generated PVC, which enables cost
+	// attribution and policy engines to act on ephemeral PVCs. Labels set
+	// explicitly in the template take precedence.
+	inheritPodLabelsAnnotation = "ephemeral.volume.kubernetes.io/inherit-pod-labels"
+
+	// inheritPodAnnotationsAnnotation is the equivalent of
+	// inheritPodLabelsAnnotation for annotations.
+	inheritPodAnnotationsAnnotation = "ephemeral.volume.kubernetes.io/inherit-pod-annotations"
+)
+
 // Controller creates PVCs for ephemeral inline volumes in a pod spec.
 type Controller interface {
 	Run(ctx context.Context, workers int)
@@ -261,6 +275,7 @@ func (ec *ephemeralController) handleVolume(ctx context.Context, pod *v1.Pod, vo
 
 	// Create the PVC with pod as owner.
 	isTrue := true
+	templateMeta := vol.Ephemeral.VolumeClaimTemplate.ObjectMeta
 	pvc = &v1.PersistentVolumeClaim{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: pvcName,
@@ -274,8 +289,8 @@ func (ec *ephemeralController) handleVolume(ctx context.Context, pod *v1.Pod, vo
 					BlockOwnerDeletion: &isTrue,
 				},
 			},
-			Annotations: vol.Ephemeral.VolumeClaimTemplate.Annotations,
-			Labels:      vol.Ephemeral.VolumeClaimTemplate.Labels,
+			Annotations: withInheritedPodMeta(templateMeta.Annotations, pod.Annotations, templateMeta.Annotations[inheritPodAnnotationsAnnotation]),
+			Labels:      withInheritedPodMeta(templateMeta.Labels, pod.Labels, templateMeta.Annotations[inheritPodLabelsAnnotation]),
 		},
 		Spec: vol.Ephemeral.VolumeClaimTemplate.Spec,
 	}
@@ -287,3 +302,38 @@ func (ec *ephemeralController) handleVolume(ctx context.Context, pod *v1.Pod, vo
 	}
 	return nil
 }
+
+// withInheritedPodMeta merges the labels or annotations from the volume claim
+// template with those copied from the pod. keys is the comma-separated list of
+// keys to copy, as declared in the template; keys which are missing in the pod
+// or already set in the template are skipped. The template map is returned
+// unmodified when nothing gets inherited.
+func withInheritedPodMeta(template, pod map[string]string, keys string) map[string]string {
+	if keys == "" {
+		return template
+	}
+	merged := template
+	for _, key := range strings.Split(keys, ",") {
+		key = strings.TrimSpace(key)
+		if key == "" {
+			continue
+		}
+		value, ok := pod[key]
+		if !ok {
+			continue
+		}
+		if _, ok := template[key]; ok {
+			// Explicit template entries take precedence.
+			continue
+		}
+		if len(merged) == len(template) {
+			// Copy on first write, the template must be treated as immutable.
+			merged = make(map[string]string, len(template)+1)
+			for k, v := range template {
+				merged[k] = v
+			}
+		}
+		merged[key] = value
+	}
+	return merged
+}
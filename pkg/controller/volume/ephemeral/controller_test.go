@@ -275,3 +275,53 @@ func setupMetrics() {
 	ephemeralvolumemetrics.EphemeralVolumeCreateAttempts.Reset()
 	ephemeralvolumemetrics.EphemeralVolumeCreateFailures.Reset()
 }
+
+func TestWithInheritedPodMeta(t *testing.T) {
+	tests := []struct {
+		name     string
+		template map[string]string
+		pod      map[string]string
+		keys     string
+		expected map[string]string
+	}{
+		{
+			name:     "nothing-declared",
+			template: map[string]string{"a": "1"},
+			pod:      map[string]string{"b": "2"},
+			expected: map[string]string{"a": "1"},
+		},
+		{
+			name:     "inherit",
+			template: map[string]string{"a": "1"},
+			pod:      map[string]string{"b": "2", "c": "3"},
+			keys:     "b",
+			expected: map[string]string{"a": "1", "b": "2"},
+		},
+		{
+			name:     "multiple-keys-with-spaces",
+			pod:      map[string]string{"b": "2", "c": "3"},
+			keys:     "b, c",
+			expected: map[string]string{"b": "2", "c": "3"},
+		},
+		{
+			name:     "template-takes-precedence",
+			template: map[string]string{"a": "1"},
+			pod:      map[string]string{"a": "2"},
+			keys:     "a",
+			expected: map[string]string{"a": "1"},
+		},
+		{
+			name:     "key-missing-in-pod",
+			template: map[string]string{"a": "1"},
+			pod:      map[string]string{"b": "2"},
+			keys:     "c",
+			expected: map[string]string{"a": "1"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, withInheritedPodMeta(tc.template, tc.pod, tc.keys))
+		})
+	}
+}
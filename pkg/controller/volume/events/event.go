@@ -18,18 +18,27 @@ package events
 
 // volume relevant event reasons
 const (
-	FailedBinding             = "FailedBinding"
-	VolumeMismatch            = "VolumeMismatch"
-	VolumeFailedRecycle       = "VolumeFailedRecycle"
-	VolumeRecycled            = "VolumeRecycled"
-	RecyclerPod               = "RecyclerPod"
-	VolumeDelete              = "VolumeDelete"
-	VolumeFailedDelete        = "VolumeFailedDelete"
-	ExternalProvisioning      = "ExternalProvisioning"
-	ProvisioningFailed        = "ProvisioningFailed"
-	ProvisioningCleanupFailed = "ProvisioningCleanupFailed"
-	ProvisioningSucceeded     = "ProvisioningSucceeded"
-	WaitForFirstConsumer      = "WaitForFirstConsumer"
-	WaitForPodScheduled       = "WaitForPodScheduled"
-	ExternalExpanding         = "ExternalExpanding"
+	FailedBinding              = "FailedBinding"
+	VolumeMismatch             = "VolumeMismatch"
+	VolumeFailedRecycle        = "VolumeFailedRecycle"
+	VolumeRecycled             = "VolumeRecycled"
+	RecyclerPod                = "RecyclerPod"
+	VolumeDelete               = "VolumeDelete"
+	VolumeFailedDelete         = "VolumeFailedDelete"
+	ExternalProvisioning       = "ExternalProvisioning"
+	ProvisioningFailed         = "ProvisioningFailed"
+	ProvisioningCleanupFailed  = "ProvisioningCleanupFailed"
+	ProvisioningSucceeded      = "ProvisioningSucceeded"
+	WaitForFirstConsumer       = "WaitForFirstConsumer"
+	WaitForPodScheduled        = "WaitForPodScheduled"
+	ExternalExpanding          = "ExternalExpanding"
+	DeprecatedClassInUse       = "DeprecatedStorageClassInUse"
+	VolumeAutoExpand           = "VolumeAutoExpand"
+	VolumeAutoExpandLimit      = "VolumeAutoExpandLimitReached"
+	BindingTrace               = "BindingTrace"
+	WipeJob                    = "WipeJob"
+	DeletionScheduled          = "DeletionScheduled"
+	VolumeStuckReleased        = "VolumeStuckReleased"
+	BindRollback               = "BindRollback"
+	DeletionBlockedOnSnapshots = "DeletionBlockedOnSnapshots"
 )
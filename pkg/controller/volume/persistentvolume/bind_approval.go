@@ -0,0 +1,173 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package persistentvolume
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	webhookutil "k8s.io/apiserver/pkg/util/webhook"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
+)
+
+// BindApprovalFunc is consulted by the binder before a bind between a claim
+// and a candidate volume is finalized. External placement engines (cost,
+// compliance zones) can veto the candidate; a vetoed bind is retried with the
+// next best match on a later sync.
+type BindApprovalFunc func(claim *v1.PersistentVolumeClaim, volume *v1.PersistentVolume) (approved bool, reason string, err error)
+
+// BindApprovalConfig configures an optional external bind approval webhook.
+type BindApprovalConfig struct {
+	// Approve is the decision function, typically NewWebhookBindApproval.
+	Approve BindApprovalFunc
+	// FailOpen controls what happens when the decision function returns an
+	// error: true allows the bind to proceed, false blocks it until the
+	// webhook recovers.
+	FailOpen bool
+	// CacheTTL bounds how long a decision for a particular claim/volume pair
+	// is reused without consulting the webhook again. Zero disables caching.
+	CacheTTL time.Duration
+}
+
+// bindApprovalDecision is a cached webhook answer.
+type bindApprovalDecision struct {
+	approved  bool
+	reason    string
+	expiresAt time.Time
+}
+
+type bindApprovalCacheKey struct {
+	claimUID   types.UID
+	volumeName string
+}
+
+// bindApprover evaluates and caches bind approval decisions.
+type bindApprover struct {
+	config BindApprovalConfig
+	clock  clock.Clock
+
+	mutex     sync.Mutex
+	decisions map[bindApprovalCacheKey]bindApprovalDecision
+}
+
+func newBindApprover(config BindApprovalConfig, c clock.Clock) *bindApprover {
+	return &bindApprover{
+		config:    config,
+		clock:     c,
+		decisions: map[bindApprovalCacheKey]bindApprovalDecision{},
+	}
+}
+
+// approve returns whether the bind may proceed and a human-readable reason
+// when it may not. An error is returned only in fail-closed mode when the
+// decision function fails.
+func (a *bindApprover) approve(claim *v1.PersistentVolumeClaim, volume *v1.PersistentVolume) (bool, string, error) {
+	key := bindApprovalCacheKey{claimUID: claim.UID, volumeName: volume.Name}
+	if a.config.CacheTTL > 0 {
+		a.mutex.Lock()
+		decision, ok := a.decisions[key]
+		a.mutex.Unlock()
+		if ok && a.clock.Now().Before(decision.expiresAt) {
+			return decision.approved, decision.reason, nil
+		}
+	}
+
+	approved, reason, err := a.config.Approve(claim, volume)
+	if err != nil {
+		if a.config.FailOpen {
+			klog.V(2).Infof("bind approval for claim %s/%s and volume %q failed open: %v", claim.Namespace, claim.Name, volume.Name, err)
+			return true, "", nil
+		}
+		return false, "", fmt.Errorf("bind approval for volume %q failed: %v", volume.Name, err)
+	}
+
+	if a.config.CacheTTL > 0 {
+		a.mutex.Lock()
+		a.decisions[key] = bindApprovalDecision{
+			approved:  approved,
+			reason:    reason,
+			expiresAt: a.clock.Now().Add(a.config.CacheTTL),
+		}
+		a.mutex.Unlock()
+	}
+	return approved, reason, nil
+}
+
+// bindApprovalRequest is the webhook request body.
+type bindApprovalRequest struct {
+	ClaimNamespace string    `json:"claimNamespace"`
+	ClaimName      string    `json:"claimName"`
+	ClaimUID       types.UID `json:"claimUID"`
+	VolumeName     string    `json:"volumeName"`
+	StorageClass   string    `json:"storageClass,omitempty"`
+}
+
+// bindApprovalResponse is the webhook response body.
+type bindApprovalResponse struct {
+	Approved bool   `json:"approved"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// NewWebhookBindApproval returns a BindApprovalFunc that POSTs the
+// claim/volume pair to the webhook configured in the given kubeconfig file
+// and expects a bindApprovalResponse. The generic webhook machinery supplies
+// TLS, authentication and retries with backoff.
+func NewWebhookBindApproval(kubeConfigFile string, retryBackoff wait.Backoff) (BindApprovalFunc, error) {
+	clientConfig, err := webhookutil.LoadKubeconfig(kubeConfigFile, nil)
+	if err != nil {
+		return nil, err
+	}
+	gw, err := webhookutil.NewGenericWebhook(scheme.Scheme, scheme.Codecs, clientConfig, []schema.GroupVersion{v1.SchemeGroupVersion}, retryBackoff)
+	if err != nil {
+		return nil, err
+	}
+	return func(claim *v1.PersistentVolumeClaim, volume *v1.PersistentVolume) (bool, string, error) {
+		body, err := json.Marshal(bindApprovalRequest{
+			ClaimNamespace: claim.Namespace,
+			ClaimName:      claim.Name,
+			ClaimUID:       claim.UID,
+			VolumeName:     volume.Name,
+			StorageClass:   volume.Spec.StorageClassName,
+		})
+		if err != nil {
+			return false, "", err
+		}
+		ctx := context.TODO()
+		result := gw.WithExponentialBackoff(ctx, func() rest.Result {
+			return gw.RestClient.Post().SetHeader("Content-Type", "application/json").Body(body).Do(ctx)
+		})
+		raw, err := result.Raw()
+		if err != nil {
+			return false, "", fmt.Errorf("bind approval webhook failed: %v", err)
+		}
+		decision := bindApprovalResponse{}
+		if err := json.Unmarshal(raw, &decision); err != nil {
+			return false, "", fmt.Errorf("failed to decode bind approval webhook response: %v", err)
+		}
+		return decision.Approved, decision.Reason, nil
+	}, nil
+}
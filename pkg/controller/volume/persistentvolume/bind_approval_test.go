@@ -0,0 +1,148 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package persistentvolume
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	webhookutil "k8s.io/apiserver/pkg/util/webhook"
+	testingclock "k8s.io/utils/clock/testing"
+)
+
+func approvalTestObjects() (*v1.PersistentVolumeClaim, *v1.PersistentVolume) {
+	claim := &v1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "claim1", Namespace: "ns1", UID: "uid1"},
+	}
+	volume := &v1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: "volume1"},
+		Spec:       v1.PersistentVolumeSpec{StorageClassName: "gold"},
+	}
+	return claim, volume
+}
+
+func TestBindApproverFailurePolicy(t *testing.T) {
+	claim, volume := approvalTestObjects()
+	webhookErr := fmt.Errorf("webhook unavailable")
+	failing := func(*v1.PersistentVolumeClaim, *v1.PersistentVolume) (bool, string, error) {
+		return false, "", webhookErr
+	}
+
+	approver := newBindApprover(BindApprovalConfig{Approve: failing, FailOpen: true}, testingclock.NewFakeClock(time.Now()))
+	approved, _, err := approver.approve(claim, volume)
+	if err != nil {
+		t.Errorf("fail-open: unexpected error: %v", err)
+	}
+	if !approved {
+		t.Errorf("fail-open: expected the bind to be approved")
+	}
+
+	approver = newBindApprover(BindApprovalConfig{Approve: failing, FailOpen: false}, testingclock.NewFakeClock(time.Now()))
+	approved, _, err = approver.approve(claim, volume)
+	if err == nil {
+		t.Errorf("fail-closed: expected an error")
+	}
+	if approved {
+		t.Errorf("fail-closed: expected the bind to be blocked")
+	}
+}
+
+func TestBindApproverCaching(t *testing.T) {
+	claim, volume := approvalTestObjects()
+	calls := 0
+	deny := func(*v1.PersistentVolumeClaim, *v1.PersistentVolume) (bool, string, error) {
+		calls++
+		return false, "placement engine says no", nil
+	}
+	fakeClock := testingclock.NewFakeClock(time.Now())
+	approver := newBindApprover(BindApprovalConfig{Approve: deny, CacheTTL: time.Minute}, fakeClock)
+
+	for i := 0; i < 3; i++ {
+		approved, reason, err := approver.approve(claim, volume)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if approved || reason != "placement engine says no" {
+			t.Errorf("unexpected decision: approved=%v reason=%q", approved, reason)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("expected a single webhook call within the TTL, got %d", calls)
+	}
+
+	fakeClock.Step(2 * time.Minute)
+	if _, _, err := approver.approve(claim, volume); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("expected a fresh webhook call after the TTL expired, got %d calls", calls)
+	}
+}
+
+func TestWebhookBindApproval(t *testing.T) {
+	claim, volume := approvalTestObjects()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		request := bindApprovalRequest{}
+		if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		if request.ClaimName != "claim1" || request.VolumeName != "volume1" || request.StorageClass != "gold" {
+			t.Errorf("unexpected request: %+v", request)
+		}
+		json.NewEncoder(w).Encode(bindApprovalResponse{Approved: false, Reason: "wrong zone"})
+	}))
+	defer server.Close()
+
+	kubeConfigFile := filepath.Join(t.TempDir(), "kubeconfig")
+	kubeConfig := fmt.Sprintf(`
+clusters:
+- name: approval
+  cluster:
+    server: %s
+users:
+- name: approval
+current-context: approval
+contexts:
+- name: approval
+  context:
+    cluster: approval
+    user: approval
+`, server.URL)
+	if err := os.WriteFile(kubeConfigFile, []byte(kubeConfig), 0644); err != nil {
+		t.Fatalf("failed to write kubeconfig: %v", err)
+	}
+
+	approve, err := NewWebhookBindApproval(kubeConfigFile, webhookutil.DefaultRetryBackoffWithInitialDelay(time.Millisecond))
+	if err != nil {
+		t.Fatalf("failed to create webhook bind approval: %v", err)
+	}
+	approved, reason, err := approve(claim, volume)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if approved || reason != "wrong zone" {
+		t.Errorf("unexpected decision: approved=%v reason=%q", approved, reason)
+	}
+}
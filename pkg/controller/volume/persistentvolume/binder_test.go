@@ -256,6 +256,20 @@ func TestSync(t *testing.T) {
 				newClaimArray("claim1-18", "uid1-18", "1Gi", "volume1-18", v1.ClaimBound, &classWait, volume.AnnBoundByController, volume.AnnBindCompleted)),
 			noevents, noerrors, testSyncClaim,
 		},
+		{
+			// syncClaim with a scheduler-requested rollback. Both the selected
+			// node and the rollback annotation get removed, nothing is bound.
+			"1-19 - rollback of scheduler-triggered binding",
+			novolumes,
+			novolumes,
+			claimWithAnnotation(volume.AnnBindRollback, "yes",
+				claimWithAnnotation(volume.AnnSelectedNode, "node1",
+					claimWithAnnotation(volume.AnnStorageProvisioner, "vendor/provisioner",
+						newClaimArray("claim1-19", "uid1-19", "1Gi", "", v1.ClaimPending, &classWait)))),
+			claimWithAnnotation(volume.AnnStorageProvisioner, "vendor/provisioner",
+				newClaimArray("claim1-19", "uid1-19", "1Gi", "", v1.ClaimPending, &classWait)),
+			[]string{"Normal BindRollback"}, noerrors, testSyncClaim,
+		},
 
 		// [Unit test set 2] User asked for a specific PV.
 		// Test the binding when pv.ClaimRef is already set by controller or
@@ -0,0 +1,113 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package persistentvolume
+
+import (
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// AnnCapacityPool on a static PersistentVolume references a CSIStorageCapacity
+// object ("namespace/name") describing the shared backing pool the volume is
+// carved from. The binder refuses to bind a volume when doing so would push
+// the summed capacity of bound volumes in the pool over the pool's capacity,
+// preventing oversubscription of a shared array across many static PVs.
+const AnnCapacityPool = "volume.kubernetes.io/capacity-pool"
+
+// capacityPoolForVolume returns the pool reference of a volume, or "" when
+// the volume does not participate in pool accounting.
+func capacityPoolForVolume(volume *v1.PersistentVolume) string {
+	return volume.Annotations[AnnCapacityPool]
+}
+
+// parseCapacityPoolRef splits a "namespace/name" pool reference.
+func parseCapacityPoolRef(poolRef string) (namespace, name string, err error) {
+	parts := strings.Split(poolRef, "/")
+	if len(parts) != 2 || len(parts[0]) == 0 || len(parts[1]) == 0 {
+		return "", "", fmt.Errorf("invalid capacity pool reference %q, expected \"namespace/name\"", poolRef)
+	}
+	return parts[0], parts[1], nil
+}
+
+// boundPoolUsage sums the storage capacity of bound volumes referencing
+// poolRef. Unbound volumes are not counted: a static PV that never binds does
+// not consume the backing pool.
+func boundPoolUsage(volumes []*v1.PersistentVolume, poolRef string) resource.Quantity {
+	used := resource.Quantity{}
+	for _, volume := range volumes {
+		if capacityPoolForVolume(volume) != poolRef {
+			continue
+		}
+		if volume.Status.Phase != v1.VolumeBound {
+			continue
+		}
+		if capacity, ok := volume.Spec.Capacity[v1.ResourceStorage]; ok {
+			used.Add(capacity)
+		}
+	}
+	return used
+}
+
+// poolAdmitsVolume returns whether binding volume still fits in the pool and
+// a human-readable reason when it does not.
+func poolAdmitsVolume(pool *storagev1.CSIStorageCapacity, volumes []*v1.PersistentVolume, volume *v1.PersistentVolume) (bool, string) {
+	if pool.Capacity == nil {
+		// a pool without a capacity does not constrain binds
+		return true, ""
+	}
+	used := boundPoolUsage(volumes, capacityPoolForVolume(volume))
+	if capacity, ok := volume.Spec.Capacity[v1.ResourceStorage]; ok {
+		used.Add(capacity)
+	}
+	if used.Cmp(*pool.Capacity) > 0 {
+		return false, fmt.Sprintf("binding volume %q would oversubscribe capacity pool %s/%s: %s requested of %s total", volume.Name, pool.Namespace, pool.Name, used.String(), pool.Capacity.String())
+	}
+	return true, ""
+}
+
+// checkCapacityPool enforces pool accounting for a candidate bind. It returns
+// whether the bind may proceed; pools that cannot be found block the bind, so
+// a deleted pool object fails towards not oversubscribing.
+func (ctrl *PersistentVolumeController) checkCapacityPool(volume *v1.PersistentVolume) (bool, string, error) {
+	poolRef := capacityPoolForVolume(volume)
+	if len(poolRef) == 0 || ctrl.capacityLister == nil {
+		return true, "", nil
+	}
+	namespace, name, err := parseCapacityPoolRef(poolRef)
+	if err != nil {
+		return false, err.Error(), nil
+	}
+	pool, err := ctrl.capacityLister.CSIStorageCapacities(namespace).Get(name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, fmt.Sprintf("capacity pool %s not found", poolRef), nil
+		}
+		return false, "", err
+	}
+	volumes, err := ctrl.volumeLister.List(labels.Everything())
+	if err != nil {
+		return false, "", err
+	}
+	admitted, reason := poolAdmitsVolume(pool, volumes, volume)
+	return admitted, reason, nil
+}
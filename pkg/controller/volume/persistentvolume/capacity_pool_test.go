@@ -0,0 +1,132 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package persistentvolume
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func poolVolume(name, poolRef, capacity string, phase v1.PersistentVolumePhase) *v1.PersistentVolume {
+	volume := &v1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: v1.PersistentVolumeSpec{
+			Capacity: v1.ResourceList{
+				v1.ResourceStorage: resource.MustParse(capacity),
+			},
+		},
+		Status: v1.PersistentVolumeStatus{Phase: phase},
+	}
+	if len(poolRef) > 0 {
+		volume.Annotations = map[string]string{AnnCapacityPool: poolRef}
+	}
+	return volume
+}
+
+func capacityPool(namespace, name, capacity string) *storagev1.CSIStorageCapacity {
+	quantity := resource.MustParse(capacity)
+	return &storagev1.CSIStorageCapacity{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Capacity:   &quantity,
+	}
+}
+
+func TestParseCapacityPoolRef(t *testing.T) {
+	if _, _, err := parseCapacityPoolRef("storage/pool-a"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	for _, ref := range []string{"", "pool-a", "a/b/c", "/pool-a", "storage/"} {
+		if _, _, err := parseCapacityPoolRef(ref); err == nil {
+			t.Errorf("expected an error for %q", ref)
+		}
+	}
+}
+
+func TestPoolAdmitsVolume(t *testing.T) {
+	pool := capacityPool("storage", "pool-a", "100Gi")
+	tests := []struct {
+		name        string
+		existing    []*v1.PersistentVolume
+		candidate   *v1.PersistentVolume
+		expectAdmit bool
+	}{
+		{
+			name:        "empty pool admits",
+			candidate:   poolVolume("pv-1", "storage/pool-a", "50Gi", v1.VolumeAvailable),
+			expectAdmit: true,
+		},
+		{
+			name: "fits next to bound volumes",
+			existing: []*v1.PersistentVolume{
+				poolVolume("pv-1", "storage/pool-a", "40Gi", v1.VolumeBound),
+			},
+			candidate:   poolVolume("pv-2", "storage/pool-a", "60Gi", v1.VolumeAvailable),
+			expectAdmit: true,
+		},
+		{
+			name: "oversubscription is refused",
+			existing: []*v1.PersistentVolume{
+				poolVolume("pv-1", "storage/pool-a", "80Gi", v1.VolumeBound),
+			},
+			candidate:   poolVolume("pv-2", "storage/pool-a", "30Gi", v1.VolumeAvailable),
+			expectAdmit: false,
+		},
+		{
+			name: "unbound volumes do not count",
+			existing: []*v1.PersistentVolume{
+				poolVolume("pv-1", "storage/pool-a", "80Gi", v1.VolumeAvailable),
+			},
+			candidate:   poolVolume("pv-2", "storage/pool-a", "30Gi", v1.VolumeAvailable),
+			expectAdmit: true,
+		},
+		{
+			name: "volumes in other pools do not count",
+			existing: []*v1.PersistentVolume{
+				poolVolume("pv-1", "storage/pool-b", "80Gi", v1.VolumeBound),
+				poolVolume("pv-2", "", "80Gi", v1.VolumeBound),
+			},
+			candidate:   poolVolume("pv-3", "storage/pool-a", "30Gi", v1.VolumeAvailable),
+			expectAdmit: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			volumes := append(tc.existing, tc.candidate)
+			admitted, reason := poolAdmitsVolume(pool, volumes, tc.candidate)
+			if admitted != tc.expectAdmit {
+				t.Errorf("expected admitted=%v, got %v (reason %q)", tc.expectAdmit, admitted, reason)
+			}
+			if !admitted && len(reason) == 0 {
+				t.Errorf("expected a reason for the refused bind")
+			}
+		})
+	}
+}
+
+func TestPoolWithoutCapacityAdmits(t *testing.T) {
+	pool := &storagev1.CSIStorageCapacity{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "storage", Name: "pool-a"},
+	}
+	candidate := poolVolume("pv-1", "storage/pool-a", "500Gi", v1.VolumeAvailable)
+	if admitted, _ := poolAdmitsVolume(pool, []*v1.PersistentVolume{candidate}, candidate); !admitted {
+		t.Errorf("expected a pool without capacity to admit any volume")
+	}
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package persistentvolume
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Names of the controller steps that support fault injection. They are passed
+// to the FaultInjector so a test can target a single step.
+const (
+	// StepBindVolumeToClaim covers saving the volume half of a bind.
+	StepBindVolumeToClaim = "bindVolumeToClaim"
+	// StepBindClaimToVolume covers saving the claim half of a bind.
+	StepBindClaimToVolume = "bindClaimToVolume"
+	// StepUpdateClaimStatus covers saving a claim's status phase.
+	StepUpdateClaimStatus = "updateClaimStatus"
+)
+
+// FaultInjector lets integration tests fail a specific controller step on
+// demand, before the step's API call is made. It is wired in through
+// ControllerParameters and must be nil in production; a nil injector disables
+// all injection at negligible cost.
+type FaultInjector interface {
+	// ShouldFail is called with the step name and the key of the object the
+	// step operates on ("namespace/name" for claims, the volume name for
+	// volumes). Returning a non-nil error fails the step with that error.
+	ShouldFail(step, key string) error
+}
+
+// injectFault consults the configured fault injector, if any.
+func (ctrl *PersistentVolumeController) injectFault(step, key string) error {
+	if ctrl.faultInjector == nil {
+		return nil
+	}
+	return ctrl.faultInjector.ShouldFail(step, key)
+}
+
+// CountingFaultInjector is a FaultInjector that fails a single step a fixed
+// number of times and counts how often it fired. It covers the common test
+// pattern "fail the first N attempts, then let recovery succeed".
+type CountingFaultInjector struct {
+	mutex sync.Mutex
+
+	step      string
+	remaining int
+	fired     int
+}
+
+var _ FaultInjector = &CountingFaultInjector{}
+
+// NewCountingFaultInjector returns an injector that fails the given step
+// count times.
+func NewCountingFaultInjector(step string, count int) *CountingFaultInjector {
+	return &CountingFaultInjector{step: step, remaining: count}
+}
+
+// ShouldFail implements FaultInjector.
+func (i *CountingFaultInjector) ShouldFail(step, key string) error {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	if step != i.step || i.remaining == 0 {
+		return nil
+	}
+	i.remaining--
+	i.fired++
+	return fmt.Errorf("fault injected into %s for %q", step, key)
+}
+
+// Fired returns how many times the injector failed its step.
+func (i *CountingFaultInjector) Fired() int {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	return i.fired
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package persistentvolume
+
+import (
+	"testing"
+)
+
+func TestCountingFaultInjector(t *testing.T) {
+	injector := NewCountingFaultInjector(StepBindVolumeToClaim, 2)
+
+	// Other steps are never failed.
+	if err := injector.ShouldFail(StepUpdateClaimStatus, "default/claim"); err != nil {
+		t.Errorf("unexpected error for untargeted step: %v", err)
+	}
+
+	// The targeted step fails exactly twice.
+	if err := injector.ShouldFail(StepBindVolumeToClaim, "pv-1"); err == nil {
+		t.Errorf("expected first call to fail")
+	}
+	if err := injector.ShouldFail(StepBindVolumeToClaim, "pv-1"); err == nil {
+		t.Errorf("expected second call to fail")
+	}
+	if err := injector.ShouldFail(StepBindVolumeToClaim, "pv-1"); err != nil {
+		t.Errorf("expected third call to succeed, got %v", err)
+	}
+	if fired := injector.Fired(); fired != 2 {
+		t.Errorf("expected injector to have fired twice, got %d", fired)
+	}
+}
+
+func TestInjectFaultDisabledByDefault(t *testing.T) {
+	ctrl := &PersistentVolumeController{}
+	if err := ctrl.injectFault(StepBindVolumeToClaim, "pv-1"); err != nil {
+		t.Errorf("expected nil injector to be a no-op, got %v", err)
+	}
+}
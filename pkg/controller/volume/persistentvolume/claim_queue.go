@@ -0,0 +1,182 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package persistentvolume
+
+import (
+	"container/heap"
+	"strconv"
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+)
+
+// AnnProvisioningPriority is the annotation on a PersistentVolumeClaim that
+// orders the claim in the binder work queues. Claims with a higher value are
+// processed - and therefore bound and provisioned - before claims with a
+// lower value. Claims without the annotation get priority 0. Claims with the
+// same priority are processed in FIFO order.
+const AnnProvisioningPriority = "volume.kubernetes.io/provisioning-priority"
+
+// claimProvisioningPriority returns the provisioning priority of the claim.
+// An unparseable annotation value counts as the default priority 0.
+func claimProvisioningPriority(claim *v1.PersistentVolumeClaim) int {
+	value, ok := claim.Annotations[AnnProvisioningPriority]
+	if !ok {
+		return 0
+	}
+	priority, err := strconv.Atoi(value)
+	if err != nil {
+		klog.V(4).Infof("ignoring invalid annotation %s=%q on claim %q: %v", AnnProvisioningPriority, value, claimToClaimKey(claim), err)
+		return 0
+	}
+	return priority
+}
+
+// claimQueue is a work queue that hands out claim keys ordered by their
+// provisioning priority, highest first, FIFO within one priority. It has the
+// same deduplication semantics as client-go's workqueue: a key that is
+// already queued is not queued twice, and a key added while it is being
+// processed is re-queued when its processing finishes.
+type claimQueue struct {
+	cond *sync.Cond
+
+	// items holds the queued keys ordered by priority.
+	items queuedClaims
+	// dirty maps every key that still needs processing - queued or added
+	// while being processed - to its priority.
+	dirty map[string]int
+	// processing holds the keys currently handed out to the worker.
+	processing map[string]bool
+	// seq numbers queued keys to keep FIFO order within one priority.
+	seq uint64
+
+	shuttingDown bool
+}
+
+func newClaimQueue() *claimQueue {
+	return &claimQueue{
+		cond:       sync.NewCond(&sync.Mutex{}),
+		dirty:      map[string]int{},
+		processing: map[string]bool{},
+	}
+}
+
+// Add queues the key with the given priority. Adding a key that is already
+// queued is a no-op, the key keeps its original priority and position.
+func (q *claimQueue) Add(key string, priority int) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	if q.shuttingDown {
+		return
+	}
+	if _, ok := q.dirty[key]; ok {
+		return
+	}
+	q.dirty[key] = priority
+	if q.processing[key] {
+		// The key is re-queued when its current processing finishes.
+		return
+	}
+	q.push(key, priority)
+}
+
+// Get blocks until a key is available or the queue is shut down and returns
+// the queued key with the highest priority. The second return value is true
+// if the queue is shutting down.
+func (q *claimQueue) Get() (string, bool) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	for len(q.items) == 0 && !q.shuttingDown {
+		q.cond.Wait()
+	}
+	if len(q.items) == 0 {
+		return "", true
+	}
+	item := heap.Pop(&q.items).(*queuedClaim)
+	delete(q.dirty, item.key)
+	q.processing[item.key] = true
+	return item.key, false
+}
+
+// Done marks the key as processed and re-queues it if it was added again
+// while it was being processed.
+func (q *claimQueue) Done(key string) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	delete(q.processing, key)
+	if priority, ok := q.dirty[key]; ok {
+		q.push(key, priority)
+	}
+}
+
+// Len returns the number of queued keys, not counting keys currently being
+// processed.
+func (q *claimQueue) Len() int {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	return len(q.items)
+}
+
+// ShutDown causes pending and future Get calls to return immediately.
+func (q *claimQueue) ShutDown() {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	q.shuttingDown = true
+	q.cond.Broadcast()
+}
+
+// push must be called with the lock held.
+func (q *claimQueue) push(key string, priority int) {
+	heap.Push(&q.items, &queuedClaim{key: key, priority: priority, seq: q.seq})
+	q.seq++
+	q.cond.Signal()
+}
+
+type queuedClaim struct {
+	key      string
+	priority int
+	seq      uint64
+}
+
+// queuedClaims is a heap yielding the highest priority claim first and
+// keeping FIFO order of claims with equal priorities.
+type queuedClaims []*queuedClaim
+
+func (c queuedClaims) Len() int { return len(c) }
+
+func (c queuedClaims) Less(i, j int) bool {
+	if c[i].priority != c[j].priority {
+		return c[i].priority > c[j].priority
+	}
+	return c[i].seq < c[j].seq
+}
+
+func (c queuedClaims) Swap(i, j int) { c[i], c[j] = c[j], c[i] }
+
+func (c *queuedClaims) Push(item interface{}) {
+	*c = append(*c, item.(*queuedClaim))
+}
+
+func (c *queuedClaims) Pop() interface{} {
+	old := *c
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	*c = old[:n-1]
+	return item
+}
@@ -26,6 +26,59 @@ type PersistentVolumeBinderControllerConfiguration struct {
 	// pvClaimBinderSyncPeriod is the period for syncing persistent volumes
 	// and persistent volume claims.
 	PVClaimBinderSyncPeriod metav1.Duration
+	// pvBinderWorkers is the number of workers binding persistent volume
+	// claims concurrently. Claims are sharded across workers, so each claim
+	// is always handled by the same worker. Values below 1 are treated as 1.
+	PVBinderWorkers int32
+	// pvProvisionRetryBackoffBase is the initial delay between retries of
+	// failed dynamic provisioning of a claim. Retries back off exponentially
+	// with jitter up to pvProvisionRetryBackoffMax. Zero disables the
+	// backoff and retries provisioning on every sync.
+	PVProvisionRetryBackoffBase metav1.Duration
+	// pvProvisionRetryBackoffMax is the upper bound of the provisioning
+	// retry backoff.
+	PVProvisionRetryBackoffMax metav1.Duration
+	// pvProvisionMaxRetries is the number of provisioning attempts per claim
+	// before the claim is marked with a terminal ProvisioningFailed
+	// condition. Zero means no limit. Only effective with the backoff
+	// enabled.
+	PVProvisionMaxRetries int32
+	// pvWipeJobTemplateFile is the path of a batch/v1 Job manifest whose
+	// spec is used as the template for wipe jobs that replace the legacy
+	// recycler pods for volumes with the Recycle reclaim policy. Empty
+	// keeps the legacy recycler pods.
+	PVWipeJobTemplateFile string
+	// pvCoalesceStatusUpdates makes the controller batch PV and PVC status
+	// updates through a write-coalescing layer that deduplicates redundant
+	// updates, reducing the API server write load in large clusters.
+	PVCoalesceStatusUpdates bool
+	// pvShardCount splits the volume and claim key space into this many
+	// shards and makes this replica sync only the shards it owns,
+	// coordinated through Lease objects, so the PV controller can run
+	// active in several manager replicas at once. It must be identical in
+	// all replicas. Zero keeps the classic single-active behavior.
+	PVShardCount int32
+	// pvShardIdentity uniquely names this replica in the shard leases,
+	// e.g. the pod name. Empty defaults to the hostname.
+	PVShardIdentity string
+	// pvStorageSummaryInterval is the interval at which the controller
+	// publishes its storage summary to the pv-storage-summary ConfigMap in
+	// kube-system, so dashboards can watch one object instead of listing
+	// all volumes. Zero disables publishing.
+	PVStorageSummaryInterval metav1.Duration
+	// pvBindApprovalWebhookConfigFile is the path of a kubeconfig file
+	// describing an external bind approval webhook consulted before binds
+	// between claims and candidate volumes are finalized. Empty disables
+	// the check.
+	PVBindApprovalWebhookConfigFile string
+	// pvBindApprovalFailOpen allows binds to proceed when the bind
+	// approval webhook cannot be reached; false blocks them until the
+	// webhook recovers.
+	PVBindApprovalFailOpen bool
+	// pvBindApprovalCacheTTL bounds how long a webhook decision for a
+	// particular claim/volume pair is reused without consulting the
+	// webhook again. Zero disables caching.
+	PVBindApprovalCacheTTL metav1.Duration
 	// volumeConfiguration holds configuration for volume related features.
 	VolumeConfiguration VolumeConfiguration
 	// VolumeHostCIDRDenylist is a list of CIDRs that should not be reachable by the
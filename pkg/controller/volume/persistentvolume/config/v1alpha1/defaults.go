@@ -39,6 +39,17 @@ func RecommendedDefaultPersistentVolumeBinderControllerConfiguration(obj *kubect
 		obj.PVClaimBinderSyncPeriod = metav1.Duration{Duration: 15 * time.Second}
 	}
 
+	if obj.PVBinderWorkers == 0 {
+		obj.PVBinderWorkers = 1
+	}
+
+	// The provisioning retry backoff is disabled by default; only its upper
+	// bound is defaulted so that enabling the backoff with just the base
+	// duration gives a sensible retry schedule.
+	if obj.PVProvisionRetryBackoffMax == zero {
+		obj.PVProvisionRetryBackoffMax = metav1.Duration{Duration: 5 * time.Minute}
+	}
+
 	if obj.VolumeHostAllowLocalLoopback == nil {
 		trueValue := true
 		obj.VolumeHostAllowLocalLoopback = &trueValue
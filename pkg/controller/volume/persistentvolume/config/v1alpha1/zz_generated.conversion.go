@@ -105,6 +105,18 @@ func Convert_v1_GroupResource_To_v1alpha1_GroupResource(in *v1.GroupResource, ou
 
 func autoConvert_v1alpha1_PersistentVolumeBinderControllerConfiguration_To_config_PersistentVolumeBinderControllerConfiguration(in *v1alpha1.PersistentVolumeBinderControllerConfiguration, out *config.PersistentVolumeBinderControllerConfiguration, s conversion.Scope) error {
 	out.PVClaimBinderSyncPeriod = in.PVClaimBinderSyncPeriod
+	out.PVBinderWorkers = in.PVBinderWorkers
+	out.PVProvisionRetryBackoffBase = in.PVProvisionRetryBackoffBase
+	out.PVProvisionRetryBackoffMax = in.PVProvisionRetryBackoffMax
+	out.PVProvisionMaxRetries = in.PVProvisionMaxRetries
+	out.PVWipeJobTemplateFile = in.PVWipeJobTemplateFile
+	out.PVCoalesceStatusUpdates = in.PVCoalesceStatusUpdates
+	out.PVShardCount = in.PVShardCount
+	out.PVShardIdentity = in.PVShardIdentity
+	out.PVStorageSummaryInterval = in.PVStorageSummaryInterval
+	out.PVBindApprovalWebhookConfigFile = in.PVBindApprovalWebhookConfigFile
+	out.PVBindApprovalFailOpen = in.PVBindApprovalFailOpen
+	out.PVBindApprovalCacheTTL = in.PVBindApprovalCacheTTL
 	if err := Convert_v1alpha1_VolumeConfiguration_To_config_VolumeConfiguration(&in.VolumeConfiguration, &out.VolumeConfiguration, s); err != nil {
 		return err
 	}
@@ -117,6 +129,18 @@ func autoConvert_v1alpha1_PersistentVolumeBinderControllerConfiguration_To_confi
 
 func autoConvert_config_PersistentVolumeBinderControllerConfiguration_To_v1alpha1_PersistentVolumeBinderControllerConfiguration(in *config.PersistentVolumeBinderControllerConfiguration, out *v1alpha1.PersistentVolumeBinderControllerConfiguration, s conversion.Scope) error {
 	out.PVClaimBinderSyncPeriod = in.PVClaimBinderSyncPeriod
+	out.PVBinderWorkers = in.PVBinderWorkers
+	out.PVProvisionRetryBackoffBase = in.PVProvisionRetryBackoffBase
+	out.PVProvisionRetryBackoffMax = in.PVProvisionRetryBackoffMax
+	out.PVProvisionMaxRetries = in.PVProvisionMaxRetries
+	out.PVWipeJobTemplateFile = in.PVWipeJobTemplateFile
+	out.PVCoalesceStatusUpdates = in.PVCoalesceStatusUpdates
+	out.PVShardCount = in.PVShardCount
+	out.PVShardIdentity = in.PVShardIdentity
+	out.PVStorageSummaryInterval = in.PVStorageSummaryInterval
+	out.PVBindApprovalWebhookConfigFile = in.PVBindApprovalWebhookConfigFile
+	out.PVBindApprovalFailOpen = in.PVBindApprovalFailOpen
+	out.PVBindApprovalCacheTTL = in.PVBindApprovalCacheTTL
 	if err := Convert_config_VolumeConfiguration_To_v1alpha1_VolumeConfiguration(&in.VolumeConfiguration, &out.VolumeConfiguration, s); err != nil {
 		return err
 	}
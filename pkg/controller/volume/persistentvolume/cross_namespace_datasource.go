@@ -0,0 +1,96 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package persistentvolume
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// AnnCrossNamespaceDataSource on a claim names a PersistentVolumeClaim
+	// in another namespace, in "namespace/name" form, to clone the new
+	// volume from. The claim's DataSourceRef cannot carry a namespace, so
+	// the annotation is the carrier until the API grows one.
+	AnnCrossNamespaceDataSource = "volume.kubernetes.io/cross-namespace-data-source"
+
+	// AnnAllowCloneFromNamespaces on a source claim grants namespaces
+	// permission to clone from it, as a comma-separated list of namespace
+	// names or "*" for any namespace. Without the grant a cross-namespace
+	// clone of the claim is refused.
+	AnnAllowCloneFromNamespaces = "volume.kubernetes.io/allow-clone-from-namespaces"
+)
+
+// resolveCrossNamespaceDataSource resolves the cross-namespace data source
+// annotation of the claim, if any, and checks that the source claim grants
+// the claim's namespace permission to clone from it. It returns (nil, nil)
+// when the claim has no cross-namespace data source, and an error when the
+// source is missing, unauthorized or the annotation is malformed. The clone
+// itself is performed by the provisioner; the controller only authorizes it.
+func (ctrl *PersistentVolumeController) resolveCrossNamespaceDataSource(ctx context.Context, claim *v1.PersistentVolumeClaim) (*v1.PersistentVolumeClaim, error) {
+	source, ok := claim.Annotations[AnnCrossNamespaceDataSource]
+	if !ok {
+		return nil, nil
+	}
+	sourceNamespace, sourceName, err := parseCrossNamespaceDataSource(source)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %v", AnnCrossNamespaceDataSource, err)
+	}
+	if sourceNamespace == claim.Namespace {
+		// A same-namespace source needs no grant; the regular DataSourceRef
+		// field should be used instead, but don't refuse it.
+		return ctrl.kubeClient.CoreV1().PersistentVolumeClaims(sourceNamespace).Get(ctx, sourceName, metav1.GetOptions{})
+	}
+	sourceClaim, err := ctrl.kubeClient.CoreV1().PersistentVolumeClaims(sourceNamespace).Get(ctx, sourceName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("cannot get cross-namespace data source %q: %v", source, err)
+	}
+	if !cloneGrantedToNamespace(sourceClaim, claim.Namespace) {
+		return nil, fmt.Errorf("cross-namespace data source %q does not grant cloning to namespace %q", source, claim.Namespace)
+	}
+	return sourceClaim, nil
+}
+
+// parseCrossNamespaceDataSource splits a "namespace/name" data source
+// reference.
+func parseCrossNamespaceDataSource(source string) (namespace, name string, err error) {
+	parts := strings.Split(source, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("expected \"namespace/name\", got %q", source)
+	}
+	return parts[0], parts[1], nil
+}
+
+// cloneGrantedToNamespace returns true when the source claim grants the
+// given namespace permission to clone from it.
+func cloneGrantedToNamespace(sourceClaim *v1.PersistentVolumeClaim, namespace string) bool {
+	grant, ok := sourceClaim.Annotations[AnnAllowCloneFromNamespaces]
+	if !ok {
+		return false
+	}
+	for _, granted := range strings.Split(grant, ",") {
+		granted = strings.TrimSpace(granted)
+		if granted == "*" || granted == namespace {
+			return true
+		}
+	}
+	return false
+}
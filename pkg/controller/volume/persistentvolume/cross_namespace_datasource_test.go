@@ -0,0 +1,141 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package persistentvolume
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newSourceClaim(namespace, name, grant string) *v1.PersistentVolumeClaim {
+	claim := &v1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: v1.PersistentVolumeClaimSpec{
+			AccessModes: []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce},
+			Resources: v1.ResourceRequirements{
+				Requests: v1.ResourceList{
+					v1.ResourceName(v1.ResourceStorage): resource.MustParse("1Gi"),
+				},
+			},
+		},
+		Status: v1.PersistentVolumeClaimStatus{
+			Phase: v1.ClaimBound,
+		},
+	}
+	if grant != "" {
+		claim.Annotations = map[string]string{AnnAllowCloneFromNamespaces: grant}
+	}
+	return claim
+}
+
+func TestResolveCrossNamespaceDataSource(t *testing.T) {
+	classGold := "gold"
+	tests := []struct {
+		name           string
+		sourceClaim    *v1.PersistentVolumeClaim
+		annotation     string
+		expectedSource bool
+		hasError       bool
+	}{
+		{
+			name: "claim without the annotation resolves to no source",
+		},
+		{
+			name:           "grant listing the namespace authorizes the clone",
+			sourceClaim:    newSourceClaim("golden", "base-image", "default,staging"),
+			annotation:     "golden/base-image",
+			expectedSource: true,
+		},
+		{
+			name:           "wildcard grant authorizes any namespace",
+			sourceClaim:    newSourceClaim("golden", "base-image", "*"),
+			annotation:     "golden/base-image",
+			expectedSource: true,
+		},
+		{
+			name:        "grant not listing the namespace refuses the clone",
+			sourceClaim: newSourceClaim("golden", "base-image", "staging"),
+			annotation:  "golden/base-image",
+			hasError:    true,
+		},
+		{
+			name:        "source without a grant refuses the clone",
+			sourceClaim: newSourceClaim("golden", "base-image", ""),
+			annotation:  "golden/base-image",
+			hasError:    true,
+		},
+		{
+			name:       "missing source is an error",
+			annotation: "golden/base-image",
+			hasError:   true,
+		},
+		{
+			name:       "malformed annotation is an error",
+			annotation: "base-image",
+			hasError:   true,
+		},
+		{
+			name:           "same-namespace source needs no grant",
+			sourceClaim:    newSourceClaim(testNamespace, "base-image", ""),
+			annotation:     testNamespace + "/base-image",
+			expectedSource: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			objects := []runtime.Object{}
+			if tc.sourceClaim != nil {
+				objects = append(objects, tc.sourceClaim)
+			}
+			fakeKubeClient := fake.NewSimpleClientset(objects...)
+			ctrl, err := newTestController(fakeKubeClient, nil, false)
+			if err != nil {
+				t.Fatalf("failed to construct controller: %v", err)
+			}
+
+			annotations := []string{}
+			claim := newClaim("claim-1", "uid-1", "1Gi", "", v1.ClaimPending, &classGold, annotations...)
+			if tc.annotation != "" {
+				claim.Annotations = map[string]string{AnnCrossNamespaceDataSource: tc.annotation}
+			}
+
+			source, err := ctrl.resolveCrossNamespaceDataSource(context.TODO(), claim)
+			if tc.hasError && err == nil {
+				t.Error("expected error, got none")
+			}
+			if !tc.hasError && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if tc.expectedSource && source == nil {
+				t.Error("expected a source claim, got none")
+			}
+			if !tc.expectedSource && source != nil {
+				t.Errorf("unexpected source claim: %+v", source)
+			}
+		})
+	}
+}
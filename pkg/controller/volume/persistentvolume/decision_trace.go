@@ -0,0 +1,128 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package persistentvolume
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/controller/volume/events"
+	"k8s.io/kubernetes/pkg/controller/volume/persistentvolume/matcher"
+)
+
+const (
+	// AnnBindingTrace is the claim annotation the controller writes the
+	// verbose binding decision trace to when decision tracing is enabled.
+	AnnBindingTrace = "volume.kubernetes.io/binding-trace"
+
+	// maxTraceEventVolumes caps how many per-volume reasons make it into
+	// the event summary; the full trace goes to the annotation.
+	maxTraceEventVolumes = 5
+)
+
+// volumeRejection records why a single volume could not satisfy a claim.
+type volumeRejection struct {
+	volumeName string
+	reason     string
+}
+
+// recordBindingTrace explains a failed volume search for the claim when
+// decision tracing is enabled: it emits an event with a truncated
+// per-volume summary and writes the full trace to the AnnBindingTrace
+// annotation of the claim.
+func (ctrl *PersistentVolumeController) recordBindingTrace(ctx context.Context, claim *v1.PersistentVolumeClaim) {
+	if !ctrl.enableDecisionTrace {
+		return
+	}
+	rejections := ctrl.traceVolumeRejections(claim)
+	if len(rejections) == 0 {
+		// No candidate volumes exist at all; the regular FailedBinding
+		// event already says so.
+		return
+	}
+
+	ctrl.eventRecorder.Event(claim, v1.EventTypeNormal, events.BindingTrace, summarizeRejections(rejections))
+
+	trace := formatRejections(rejections)
+	if claim.Annotations[AnnBindingTrace] == trace {
+		return
+	}
+	claimClone := claim.DeepCopy()
+	metav1.SetMetaDataAnnotation(&claimClone.ObjectMeta, AnnBindingTrace, trace)
+	if _, err := ctrl.kubeClient.CoreV1().PersistentVolumeClaims(claimClone.Namespace).Update(ctx, claimClone, metav1.UpdateOptions{}); err != nil {
+		// The trace is best effort debugging aid; the next sync retries.
+		klog.V(4).Infof("failed to update binding trace annotation of claim %q: %v", claimToClaimKey(claim), err)
+	}
+}
+
+// traceVolumeRejections evaluates every known volume against the claim and
+// returns why each was rejected, sorted by volume name. It mirrors the
+// checks FindMatchingVolume performs, broken out per volume.
+func (ctrl *PersistentVolumeController) traceVolumeRejections(claim *v1.PersistentVolumeClaim) []volumeRejection {
+	var rejections []volumeRejection
+	for _, obj := range ctrl.volumes.store.List() {
+		volume, ok := obj.(*v1.PersistentVolume)
+		if !ok {
+			continue
+		}
+		if reason := rejectionReason(claim, volume); reason != "" {
+			rejections = append(rejections, volumeRejection{volumeName: volume.Name, reason: reason})
+		}
+	}
+	sort.Slice(rejections, func(i, j int) bool { return rejections[i].volumeName < rejections[j].volumeName })
+	return rejections
+}
+
+// rejectionReason returns why the volume cannot satisfy the claim, or an
+// empty string when it could. The controller has no node in hand here, so
+// node affinity is not part of the trace.
+func rejectionReason(claim *v1.PersistentVolumeClaim, volume *v1.PersistentVolume) string {
+	return matcher.RejectionReason(claim, volume, nil)
+}
+
+// summarizeRejections renders the rejections as a single-line event
+// message, truncated to maxTraceEventVolumes volumes.
+func summarizeRejections(rejections []volumeRejection) string {
+	shown := rejections
+	if len(shown) > maxTraceEventVolumes {
+		shown = shown[:maxTraceEventVolumes]
+	}
+	parts := make([]string, 0, len(shown))
+	for _, rejection := range shown {
+		parts = append(parts, fmt.Sprintf("%s: %s", rejection.volumeName, rejection.reason))
+	}
+	summary := fmt.Sprintf("evaluated %d volumes: %s", len(rejections), strings.Join(parts, "; "))
+	if len(rejections) > maxTraceEventVolumes {
+		summary += fmt.Sprintf(" (%d more)", len(rejections)-maxTraceEventVolumes)
+	}
+	return summary
+}
+
+// formatRejections renders the full trace for the annotation, one volume
+// per line.
+func formatRejections(rejections []volumeRejection) string {
+	lines := make([]string, 0, len(rejections))
+	for _, rejection := range rejections {
+		lines = append(lines, fmt.Sprintf("%s: %s", rejection.volumeName, rejection.reason))
+	}
+	return strings.Join(lines, "\n")
+}
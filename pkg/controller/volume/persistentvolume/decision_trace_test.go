@@ -0,0 +1,136 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package persistentvolume
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Test single call to rejectionReason, no real controller is needed.
+func TestRejectionReason(t *testing.T) {
+	classEmpty := ""
+	block := v1.PersistentVolumeBlock
+
+	tests := []struct {
+		name           string
+		volume         *v1.PersistentVolume
+		claim          *v1.PersistentVolumeClaim
+		expectedReason string
+	}{
+		{
+			name:           "volume bound to another claim",
+			volume:         newVolume("volume-1", "1Gi", "uid-other", "claim-other", v1.VolumeBound, v1.PersistentVolumeReclaimRetain, classEmpty),
+			claim:          newClaim("claim-1", "uid-1", "1Gi", "", v1.ClaimPending, &classEmpty),
+			expectedReason: `bound to another claim "default/claim-other"`,
+		},
+		{
+			name:           "unbound volume not yet available",
+			volume:         newVolume("volume-1", "1Gi", "", "", v1.VolumePending, v1.PersistentVolumeReclaimRetain, classEmpty),
+			claim:          newClaim("claim-1", "uid-1", "1Gi", "", v1.ClaimPending, &classEmpty),
+			expectedReason: "volume is Pending",
+		},
+		{
+			name:           "storage class mismatch",
+			volume:         newVolume("volume-1", "1Gi", "", "", v1.VolumeAvailable, v1.PersistentVolumeReclaimRetain, "gold"),
+			claim:          newClaim("claim-1", "uid-1", "1Gi", "", v1.ClaimPending, &classEmpty),
+			expectedReason: `storage class "gold" does not match the requested ""`,
+		},
+		{
+			name: "volume mode mismatch",
+			volume: func() *v1.PersistentVolume {
+				volume := newVolume("volume-1", "1Gi", "", "", v1.VolumeAvailable, v1.PersistentVolumeReclaimRetain, classEmpty)
+				volume.Spec.VolumeMode = &block
+				return volume
+			}(),
+			claim:          newClaim("claim-1", "uid-1", "1Gi", "", v1.ClaimPending, &classEmpty),
+			expectedReason: "volume mode does not match the requested mode",
+		},
+		{
+			name:   "unsupported access mode",
+			volume: newVolume("volume-1", "1Gi", "", "", v1.VolumeAvailable, v1.PersistentVolumeReclaimRetain, classEmpty),
+			claim: func() *v1.PersistentVolumeClaim {
+				claim := newClaim("claim-1", "uid-1", "1Gi", "", v1.ClaimPending, &classEmpty)
+				claim.Spec.AccessModes = []v1.PersistentVolumeAccessMode{v1.ReadWriteMany}
+				return claim
+			}(),
+			expectedReason: "volume does not support the requested access modes",
+		},
+		{
+			name:           "volume too small",
+			volume:         newVolume("volume-1", "1Gi", "", "", v1.VolumeAvailable, v1.PersistentVolumeReclaimRetain, classEmpty),
+			claim:          newClaim("claim-1", "uid-1", "10Gi", "", v1.ClaimPending, &classEmpty),
+			expectedReason: "capacity 1Gi is smaller than the requested 10Gi",
+		},
+		{
+			name:   "selector does not match volume labels",
+			volume: newVolume("volume-1", "1Gi", "", "", v1.VolumeAvailable, v1.PersistentVolumeReclaimRetain, classEmpty),
+			claim: func() *v1.PersistentVolumeClaim {
+				claim := newClaim("claim-1", "uid-1", "1Gi", "", v1.ClaimPending, &classEmpty)
+				claim.Spec.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "gold"}}
+				return claim
+			}(),
+			expectedReason: "volume labels do not match the claim selector",
+		},
+		{
+			name:           "matching volume has no rejection reason",
+			volume:         newVolume("volume-1", "1Gi", "", "", v1.VolumeAvailable, v1.PersistentVolumeReclaimRetain, classEmpty),
+			claim:          newClaim("claim-1", "uid-1", "1Gi", "", v1.ClaimPending, &classEmpty),
+			expectedReason: "",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			reason := rejectionReason(tc.claim, tc.volume)
+			if reason != tc.expectedReason {
+				t.Errorf("expected reason %q, got %q", tc.expectedReason, reason)
+			}
+		})
+	}
+}
+
+func TestSummarizeRejections(t *testing.T) {
+	var rejections []volumeRejection
+	for i := 1; i <= maxTraceEventVolumes+2; i++ {
+		rejections = append(rejections, volumeRejection{
+			volumeName: fmt.Sprintf("volume-%d", i),
+			reason:     "volume is Pending",
+		})
+	}
+
+	summary := summarizeRejections(rejections[:2])
+	expected := "evaluated 2 volumes: volume-1: volume is Pending; volume-2: volume is Pending"
+	if summary != expected {
+		t.Errorf("expected summary %q, got %q", expected, summary)
+	}
+
+	summary = summarizeRejections(rejections)
+	if !strings.HasPrefix(summary, fmt.Sprintf("evaluated %d volumes:", len(rejections))) {
+		t.Errorf("expected summary to count all rejections, got %q", summary)
+	}
+	if !strings.HasSuffix(summary, "(2 more)") {
+		t.Errorf("expected truncated summary to note the omitted volumes, got %q", summary)
+	}
+	if strings.Contains(summary, fmt.Sprintf("volume-%d:", maxTraceEventVolumes+1)) {
+		t.Errorf("expected summary to omit volumes over the cap, got %q", summary)
+	}
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package persistentvolume
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	storage "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	storagehelpers "k8s.io/component-helpers/storage/volume"
+	"k8s.io/klog/v2"
+	storageutil "k8s.io/kubernetes/pkg/apis/storage/v1/util"
+)
+
+// assignDefaultStorageClass retroactively sets the cluster's default
+// StorageClass on an unbound claim that was created without one, so a
+// default class created after the claim still applies to it. Claims with an
+// explicit class (including the empty string, which opts out of dynamic
+// provisioning) and bound claims are left alone. The updated claim is
+// returned; without a default class the claim is returned unchanged.
+func (ctrl *PersistentVolumeController) assignDefaultStorageClass(ctx context.Context, claim *v1.PersistentVolumeClaim) (*v1.PersistentVolumeClaim, error) {
+	if claim.Spec.StorageClassName != nil || storagehelpers.GetPersistentVolumeClaimClass(claim) != "" {
+		return claim, nil
+	}
+	if claim.Status.Phase == v1.ClaimBound || claim.Spec.VolumeName != "" {
+		// Bound claims keep whatever they were bound with.
+		return claim, nil
+	}
+
+	class, err := ctrl.defaultStorageClass()
+	if err != nil {
+		return claim, err
+	}
+	if class == nil {
+		return claim, nil
+	}
+
+	klog.V(4).Infof("claim %q: retroactively assigning default StorageClass %q", claimToClaimKey(claim), class.Name)
+	claimClone := claim.DeepCopy()
+	claimClone.Spec.StorageClassName = &class.Name
+	newClaim, err := ctrl.kubeClient.CoreV1().PersistentVolumeClaims(claim.Namespace).Update(ctx, claimClone, metav1.UpdateOptions{})
+	if err != nil {
+		return claim, err
+	}
+	if _, err = ctrl.storeClaimUpdate(newClaim); err != nil {
+		return newClaim, err
+	}
+	return newClaim, nil
+}
+
+// defaultStorageClass returns the cluster's default StorageClass, nil when
+// none is marked as default, and an error when several are — the same
+// ambiguity the DefaultStorageClass admission plugin refuses.
+func (ctrl *PersistentVolumeController) defaultStorageClass() (*storage.StorageClass, error) {
+	classes, err := ctrl.classLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	var defaultClasses []*storage.StorageClass
+	for _, class := range classes {
+		if storageutil.IsDefaultAnnotation(class.ObjectMeta) {
+			defaultClasses = append(defaultClasses, class)
+		}
+	}
+	switch len(defaultClasses) {
+	case 0:
+		return nil, nil
+	case 1:
+		return defaultClasses[0], nil
+	default:
+		return nil, fmt.Errorf("%d default StorageClasses were found", len(defaultClasses))
+	}
+}
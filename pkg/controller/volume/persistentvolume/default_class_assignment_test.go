@@ -0,0 +1,122 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package persistentvolume
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	storageutil "k8s.io/kubernetes/pkg/apis/storage/v1/util"
+	"k8s.io/kubernetes/pkg/controller"
+)
+
+func newDefaultClassTestController(t *testing.T, claim *v1.PersistentVolumeClaim, classes ...*storagev1.StorageClass) *PersistentVolumeController {
+	t.Helper()
+	client := fake.NewSimpleClientset(claim)
+	informerFactory := informers.NewSharedInformerFactory(client, controller.NoResyncPeriodFunc())
+	ctrl, err := newTestController(client, informerFactory, false)
+	if err != nil {
+		t.Fatalf("Construct PersistentVolume controller failed: %v", err)
+	}
+	for _, class := range classes {
+		if err := informerFactory.Storage().V1().StorageClasses().Informer().GetIndexer().Add(class); err != nil {
+			t.Fatalf("cannot add StorageClass to informer: %v", err)
+		}
+	}
+	return ctrl
+}
+
+func defaultClass(name string) *storagev1.StorageClass {
+	return &storagev1.StorageClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Annotations: map[string]string{storageutil.IsDefaultStorageClassAnnotation: "true"},
+		},
+	}
+}
+
+func TestAssignDefaultStorageClass(t *testing.T) {
+	explicitEmpty := ""
+
+	tests := []struct {
+		name          string
+		claim         *v1.PersistentVolumeClaim
+		classes       []*storagev1.StorageClass
+		expectError   bool
+		expectedClass *string
+	}{
+		{
+			name:          "class-less claim gets the default",
+			claim:         newClaim("claim-retro", "uid-retro", "1Gi", "", v1.ClaimPending, nil),
+			classes:       []*storagev1.StorageClass{defaultClass("gold")},
+			expectedClass: func() *string { s := "gold"; return &s }(),
+		},
+		{
+			name:          "no default leaves the claim alone",
+			claim:         newClaim("claim-retro", "uid-retro", "1Gi", "", v1.ClaimPending, nil),
+			expectedClass: nil,
+		},
+		{
+			name:          "explicit empty class opts out",
+			claim:         newClaim("claim-retro", "uid-retro", "1Gi", "", v1.ClaimPending, &explicitEmpty),
+			classes:       []*storagev1.StorageClass{defaultClass("gold")},
+			expectedClass: &explicitEmpty,
+		},
+		{
+			name:        "several defaults are an error",
+			claim:       newClaim("claim-retro", "uid-retro", "1Gi", "", v1.ClaimPending, nil),
+			classes:     []*storagev1.StorageClass{defaultClass("gold"), defaultClass("silver")},
+			expectError: true,
+		},
+		{
+			name:          "bound claim is left alone",
+			claim:         newClaim("claim-retro", "uid-retro", "1Gi", "volume-retro", v1.ClaimBound, nil),
+			classes:       []*storagev1.StorageClass{defaultClass("gold")},
+			expectedClass: nil,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := newDefaultClassTestController(t, test.claim, test.classes...)
+			newClaim, err := ctrl.assignDefaultStorageClass(context.TODO(), test.claim)
+			if test.expectError {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("assignDefaultStorageClass failed: %v", err)
+			}
+			switch {
+			case test.expectedClass == nil:
+				if newClaim.Spec.StorageClassName != nil {
+					t.Errorf("expected no class, got %q", *newClaim.Spec.StorageClassName)
+				}
+			case newClaim.Spec.StorageClassName == nil:
+				t.Errorf("expected class %q, got nil", *test.expectedClass)
+			case *newClaim.Spec.StorageClassName != *test.expectedClass:
+				t.Errorf("expected class %q, got %q", *test.expectedClass, *newClaim.Spec.StorageClassName)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package persistentvolume
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	storagehelpers "k8s.io/component-helpers/storage/volume"
+	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/controller/volume/events"
+)
+
+// AnnScheduledDeletionTime on a Released volume records, in RFC 3339 form,
+// the earliest time the deleter may run. The controller stamps it when a
+// delete grace period applies; PersistentVolumeStatus has no field for it,
+// so the annotation is the published contract.
+const AnnScheduledDeletionTime = "volume.kubernetes.io/scheduled-deletion-time"
+
+// classParamReleasedDeleteGracePeriod is the StorageClass parameter that
+// overrides the controller-wide ReleasedDeleteGracePeriod for volumes of
+// that class. Its value is a Go duration, e.g. "24h".
+const classParamReleasedDeleteGracePeriod = "releasedDeleteGracePeriod"
+
+// deletionGracePeriodForVolume returns the grace period to apply before
+// deleting the Released volume: the volume's StorageClass parameter when one
+// is set and parses, the controller-wide default otherwise.
+func (ctrl *PersistentVolumeController) deletionGracePeriodForVolume(volume *v1.PersistentVolume) time.Duration {
+	period := ctrl.releasedDeleteGracePeriod
+	className := storagehelpers.GetPersistentVolumeClass(volume)
+	if className == "" {
+		return period
+	}
+	class, err := ctrl.classLister.Get(className)
+	if err != nil {
+		// No class means no override; the controller-wide default applies.
+		return period
+	}
+	value, ok := class.Parameters[classParamReleasedDeleteGracePeriod]
+	if !ok {
+		return period
+	}
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		klog.V(2).Infof("ignoring invalid %s parameter %q of StorageClass %q: %v", classParamReleasedDeleteGracePeriod, value, className, err)
+		return period
+	}
+	return parsed
+}
+
+// delayVolumeDeletion returns true when the Released volume's deletion has
+// to wait for its grace period. On the first call it stamps the scheduled
+// deletion time on the volume; subsequent periodic syncs retry the reclaim
+// until the deadline passes.
+func (ctrl *PersistentVolumeController) delayVolumeDeletion(volume *v1.PersistentVolume) (bool, error) {
+	period := ctrl.deletionGracePeriodForVolume(volume)
+	if period <= 0 {
+		return false, nil
+	}
+
+	scheduled, ok := volume.Annotations[AnnScheduledDeletionTime]
+	if !ok {
+		deadline := time.Now().Add(period).UTC().Format(time.RFC3339)
+		volumeClone := volume.DeepCopy()
+		metav1.SetMetaDataAnnotation(&volumeClone.ObjectMeta, AnnScheduledDeletionTime, deadline)
+		newVol, err := ctrl.kubeClient.CoreV1().PersistentVolumes().Update(context.TODO(), volumeClone, metav1.UpdateOptions{})
+		if err != nil {
+			return true, err
+		}
+		if _, err = ctrl.storeVolumeUpdate(newVol); err != nil {
+			return true, err
+		}
+		klog.V(2).Infof("volume %q deletion scheduled for %s", volume.Name, deadline)
+		ctrl.eventRecorder.Event(volume, v1.EventTypeNormal, events.DeletionScheduled, fmt.Sprintf("Volume deletion scheduled for %s", deadline))
+		return true, nil
+	}
+
+	deadline, err := time.Parse(time.RFC3339, scheduled)
+	if err != nil {
+		// An unparseable annotation must not protect the volume forever.
+		klog.V(2).Infof("ignoring invalid %s annotation %q on volume %q: %v", AnnScheduledDeletionTime, scheduled, volume.Name, err)
+		return false, nil
+	}
+	if time.Now().Before(deadline) {
+		klog.V(4).Infof("volume %q is in its deletion grace period until %s", volume.Name, scheduled)
+		return true, nil
+	}
+	return false, nil
+}
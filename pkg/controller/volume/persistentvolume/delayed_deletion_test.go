@@ -0,0 +1,133 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package persistentvolume
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/kubernetes/pkg/controller"
+)
+
+func newDelayedDeletionTestController(t *testing.T, defaultPeriod time.Duration, classes ...*storagev1.StorageClass) (*PersistentVolumeController, *fake.Clientset) {
+	t.Helper()
+	client := fake.NewSimpleClientset()
+	informerFactory := informers.NewSharedInformerFactory(client, controller.NoResyncPeriodFunc())
+	ctrl, err := newTestController(client, informerFactory, false)
+	if err != nil {
+		t.Fatalf("Construct PersistentVolume controller failed: %v", err)
+	}
+	ctrl.releasedDeleteGracePeriod = defaultPeriod
+	for _, class := range classes {
+		if err := informerFactory.Storage().V1().StorageClasses().Informer().GetIndexer().Add(class); err != nil {
+			t.Fatalf("cannot add StorageClass to informer: %v", err)
+		}
+	}
+	return ctrl, client
+}
+
+func TestDeletionGracePeriodForVolume(t *testing.T) {
+	goldClass := &storagev1.StorageClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "gold"},
+		Parameters: map[string]string{classParamReleasedDeleteGracePeriod: "30m"},
+	}
+	brokenClass := &storagev1.StorageClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "broken"},
+		Parameters: map[string]string{classParamReleasedDeleteGracePeriod: "soon"},
+	}
+	ctrl, _ := newDelayedDeletionTestController(t, time.Hour, goldClass, brokenClass)
+
+	tests := []struct {
+		name     string
+		class    string
+		expected time.Duration
+	}{
+		{"controller default without class", "", time.Hour},
+		{"class parameter overrides default", "gold", 30 * time.Minute},
+		{"invalid class parameter falls back to default", "broken", time.Hour},
+		{"unknown class falls back to default", "silver", time.Hour},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			volume := newVolume("volume-grace", "1Gi", "uid-grace", "claim-grace", v1.VolumeReleased, v1.PersistentVolumeReclaimDelete, test.class)
+			if period := ctrl.deletionGracePeriodForVolume(volume); period != test.expected {
+				t.Errorf("expected grace period %v, got %v", test.expected, period)
+			}
+		})
+	}
+}
+
+func TestDelayVolumeDeletion(t *testing.T) {
+	past := time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)
+	future := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+
+	tests := []struct {
+		name            string
+		period          time.Duration
+		annotations     map[string]string
+		expectedDelayed bool
+		expectStamp     bool
+	}{
+		{"zero grace period deletes immediately", 0, nil, false, false},
+		{"first sync stamps the deadline and waits", time.Hour, nil, true, true},
+		{"deadline in the future waits", time.Hour, map[string]string{AnnScheduledDeletionTime: future}, true, false},
+		{"deadline in the past deletes", time.Hour, map[string]string{AnnScheduledDeletionTime: past}, false, false},
+		{"invalid deadline deletes", time.Hour, map[string]string{AnnScheduledDeletionTime: "yesterday"}, false, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl, client := newDelayedDeletionTestController(t, test.period)
+			volume := newVolume("volume-delayed", "1Gi", "uid-delayed", "claim-delayed", v1.VolumeReleased, v1.PersistentVolumeReclaimDelete, "")
+			volume.Annotations = test.annotations
+			if _, err := client.CoreV1().PersistentVolumes().Create(context.TODO(), volume, metav1.CreateOptions{}); err != nil {
+				t.Fatalf("cannot create volume: %v", err)
+			}
+
+			delayed, err := ctrl.delayVolumeDeletion(volume)
+			if err != nil {
+				t.Fatalf("delayVolumeDeletion failed: %v", err)
+			}
+			if delayed != test.expectedDelayed {
+				t.Errorf("expected delayed=%v, got %v", test.expectedDelayed, delayed)
+			}
+
+			stored, err := client.CoreV1().PersistentVolumes().Get(context.TODO(), volume.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("cannot get volume: %v", err)
+			}
+			scheduled, stamped := stored.Annotations[AnnScheduledDeletionTime]
+			if test.expectStamp {
+				if !stamped {
+					t.Fatalf("expected %s annotation on the volume", AnnScheduledDeletionTime)
+				}
+				deadline, err := time.Parse(time.RFC3339, scheduled)
+				if err != nil {
+					t.Fatalf("cannot parse scheduled deletion time %q: %v", scheduled, err)
+				}
+				if remaining := time.Until(deadline); remaining <= 0 || remaining > test.period {
+					t.Errorf("scheduled deletion time %q is not within the grace period", scheduled)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package persistentvolume
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	storagehelpers "k8s.io/component-helpers/storage/volume"
+	"k8s.io/klog/v2"
+	storageutil "k8s.io/kubernetes/pkg/apis/storage/util"
+	"k8s.io/kubernetes/pkg/controller/volume/events"
+)
+
+// reportedClaimsPerClass caps how many claim names a single migration report
+// event carries; the total count is always reported.
+const reportedClaimsPerClass = 10
+
+// reportDeprecatedClassUsage emits a migration report for every deprecated
+// StorageClass that still has claims using it: an event on the class with the
+// claim count and a sample of claim names. Together with the admission-side
+// enforcement, this lets admins track a storage tier retirement to
+// completion; the class can be removed once its report is empty.
+func (ctrl *PersistentVolumeController) reportDeprecatedClassUsage() {
+	classes, err := ctrl.classLister.List(labels.Everything())
+	if err != nil {
+		klog.Warningf("cannot list storage classes: %s", err)
+		return
+	}
+	deprecated := map[string]bool{}
+	for _, class := range classes {
+		if storageutil.IsDeprecatedAnnotation(class.ObjectMeta) {
+			deprecated[class.Name] = true
+		}
+	}
+	if len(deprecated) == 0 {
+		return
+	}
+
+	claims, err := ctrl.claimLister.List(labels.Everything())
+	if err != nil {
+		klog.Warningf("cannot list claims: %s", err)
+		return
+	}
+	usage := map[string][]string{}
+	for _, claim := range claims {
+		className := storagehelpers.GetPersistentVolumeClaimClass(claim)
+		if deprecated[className] {
+			usage[className] = append(usage[className], claimToClaimKey(claim))
+		}
+	}
+
+	for _, class := range classes {
+		claimKeys := usage[class.Name]
+		if len(claimKeys) == 0 {
+			continue
+		}
+		sort.Strings(claimKeys)
+		sample := claimKeys
+		if len(sample) > reportedClaimsPerClass {
+			sample = sample[:reportedClaimsPerClass]
+		}
+		msg := fmt.Sprintf("%d claim(s) still use deprecated StorageClass %q: %s", len(claimKeys), class.Name, strings.Join(sample, ", "))
+		if len(claimKeys) > len(sample) {
+			msg += ", ..."
+		}
+		klog.V(2).Infof("storage class migration report: %s", msg)
+		ctrl.eventRecorder.Event(class, v1.EventTypeWarning, events.DeprecatedClassInUse, msg)
+	}
+}
@@ -445,6 +445,15 @@ func volumesWithFinalizers(pvs []*v1.PersistentVolume, finalizers []string) []*v
 	return result
 }
 
+func claimsWithFinalizers(claims []*v1.PersistentVolumeClaim, finalizers []string) []*v1.PersistentVolumeClaim {
+	result := []*v1.PersistentVolumeClaim{}
+	for _, claim := range claims {
+		claim.SetFinalizers(finalizers)
+		result = append(result, claim)
+	}
+	return result
+}
+
 // newClaim returns a new claim with given attributes
 func newClaim(name, claimUID, capacity, boundToVolume string, phase v1.PersistentVolumeClaimPhase, class *string, annotations ...string) *v1.PersistentVolumeClaim {
 	fs := v1.PersistentVolumeFilesystem
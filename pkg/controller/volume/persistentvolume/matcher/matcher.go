@@ -0,0 +1,48 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package matcher exposes the volume binder's claim-to-volume matching as a
+// pure API, so the scheduler's volumebinding plugin, kubectl diagnostics
+// and integration tests can share one implementation instead of
+// re-deriving the matching rules.
+package matcher
+
+import (
+	v1 "k8s.io/api/core/v1"
+	storagehelpers "k8s.io/component-helpers/storage/volume"
+)
+
+// FindBestMatch returns the smallest volume among volumes that satisfies
+// the claim's size, access modes, selector, storage class, volume mode and,
+// when node is not nil, the volume's node affinity. It returns nil when no
+// volume matches. The function is pure: it inspects only its arguments and
+// never mutates them.
+func FindBestMatch(claim *v1.PersistentVolumeClaim, volumes []*v1.PersistentVolume, node *v1.Node) (*v1.PersistentVolume, error) {
+	return storagehelpers.FindMatchingVolume(claim, volumes, node, nil, false)
+}
+
+// RejectionReason returns why the volume cannot satisfy the claim, or an
+// empty string when it could. The checks mirror FindBestMatch, broken out
+// per volume and ordered to surface the most actionable mismatch first.
+// When node is nil, node affinity is not checked.
+func RejectionReason(claim *v1.PersistentVolumeClaim, volume *v1.PersistentVolume, node *v1.Node) string {
+	for _, check := range storagehelpers.CheckVolumeBinding(claim, volume, node) {
+		if !check.Passed {
+			return check.Reason
+		}
+	}
+	return ""
+}
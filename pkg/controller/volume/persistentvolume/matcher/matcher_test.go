@@ -0,0 +1,145 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package matcher
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func makeVolume(name, capacity string) *v1.PersistentVolume {
+	return &v1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: v1.PersistentVolumeSpec{
+			Capacity: v1.ResourceList{
+				v1.ResourceStorage: resource.MustParse(capacity),
+			},
+			AccessModes: []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce},
+		},
+		Status: v1.PersistentVolumeStatus{Phase: v1.VolumeAvailable},
+	}
+}
+
+func makeClaim(capacity string) *v1.PersistentVolumeClaim {
+	return &v1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "claim-1", Namespace: "default"},
+		Spec: v1.PersistentVolumeClaimSpec{
+			AccessModes: []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce},
+			Resources: v1.ResourceRequirements{
+				Requests: v1.ResourceList{
+					v1.ResourceStorage: resource.MustParse(capacity),
+				},
+			},
+		},
+	}
+}
+
+func withNodeAffinity(volume *v1.PersistentVolume, key, value string) *v1.PersistentVolume {
+	volume.Spec.NodeAffinity = &v1.VolumeNodeAffinity{
+		Required: &v1.NodeSelector{
+			NodeSelectorTerms: []v1.NodeSelectorTerm{{
+				MatchExpressions: []v1.NodeSelectorRequirement{{
+					Key:      key,
+					Operator: v1.NodeSelectorOpIn,
+					Values:   []string{value},
+				}},
+			}},
+		},
+	}
+	return volume
+}
+
+func TestFindBestMatch(t *testing.T) {
+	node := &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "node-1",
+			Labels: map[string]string{"topology.kubernetes.io/zone": "zone-a"},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		claim         *v1.PersistentVolumeClaim
+		volumes       []*v1.PersistentVolume
+		node          *v1.Node
+		expectedMatch string
+	}{
+		{
+			name:          "smallest sufficient volume wins",
+			claim:         makeClaim("5Gi"),
+			volumes:       []*v1.PersistentVolume{makeVolume("pv-large", "100Gi"), makeVolume("pv-small", "10Gi")},
+			expectedMatch: "pv-small",
+		},
+		{
+			name:    "no volume is large enough",
+			claim:   makeClaim("50Gi"),
+			volumes: []*v1.PersistentVolume{makeVolume("pv-small", "10Gi")},
+		},
+		{
+			name:  "node affinity excludes volumes from other zones",
+			claim: makeClaim("5Gi"),
+			volumes: []*v1.PersistentVolume{
+				withNodeAffinity(makeVolume("pv-zone-b", "10Gi"), "topology.kubernetes.io/zone", "zone-b"),
+				withNodeAffinity(makeVolume("pv-zone-a", "10Gi"), "topology.kubernetes.io/zone", "zone-a"),
+			},
+			node:          node,
+			expectedMatch: "pv-zone-a",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			volume, err := FindBestMatch(tc.claim, tc.volumes, tc.node)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.expectedMatch == "" {
+				if volume != nil {
+					t.Errorf("expected no match, got %q", volume.Name)
+				}
+				return
+			}
+			if volume == nil {
+				t.Fatalf("expected to match %q, got no volume", tc.expectedMatch)
+			}
+			if volume.Name != tc.expectedMatch {
+				t.Errorf("expected to match %q, got %q", tc.expectedMatch, volume.Name)
+			}
+		})
+	}
+}
+
+func TestRejectionReasonNodeAffinity(t *testing.T) {
+	claim := makeClaim("5Gi")
+	volume := withNodeAffinity(makeVolume("pv-1", "10Gi"), "topology.kubernetes.io/zone", "zone-b")
+	node := &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "node-1",
+			Labels: map[string]string{"topology.kubernetes.io/zone": "zone-a"},
+		},
+	}
+
+	if reason := RejectionReason(claim, volume, nil); reason != "" {
+		t.Errorf("expected no rejection without a node, got %q", reason)
+	}
+	if reason := RejectionReason(claim, volume, node); reason == "" {
+		t.Error("expected a node affinity rejection, got none")
+	}
+}
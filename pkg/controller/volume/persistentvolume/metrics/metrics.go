@@ -32,17 +32,32 @@ const (
 	pvControllerSubsystem = "pv_collector"
 
 	// Metric names.
-	totalPVKey    = "total_pv_count"
-	boundPVKey    = "bound_pv_count"
-	unboundPVKey  = "unbound_pv_count"
-	boundPVCKey   = "bound_pvc_count"
-	unboundPVCKey = "unbound_pvc_count"
+	totalPVKey               = "total_pv_count"
+	boundPVKey               = "bound_pv_count"
+	unboundPVKey             = "unbound_pv_count"
+	boundPVCKey              = "bound_pvc_count"
+	unboundPVCKey            = "unbound_pvc_count"
+	claimBindDurationKey     = "claim_bind_duration_seconds"
+	volumeReclaimDurationKey = "volume_reclaim_duration_seconds"
+	claimQueueDepthKey       = "claim_queue_depth"
+	volumeQueueDepthKey      = "volume_queue_depth"
+	provisionThrottledKey    = "provision_throttled_total"
+	pvPhaseCountKey          = "pv_phase_count"
+	phaseTransitionsKey      = "pv_phase_transitions_total"
+	releasedStuckKey         = "released_pv_stuck_count"
 
 	// Label names.
-	namespaceLabel    = "namespace"
-	storageClassLabel = "storage_class"
-	pluginNameLabel   = "plugin_name"
-	volumeModeLabel   = "volume_mode"
+	phaseLabel         = "phase"
+	namespaceLabel     = "namespace"
+	storageClassLabel  = "storage_class"
+	pluginNameLabel    = "plugin_name"
+	volumeModeLabel    = "volume_mode"
+	outcomeLabel       = "outcome"
+	reclaimPolicyLabel = "reclaim_policy"
+
+	// Outcomes of reclaiming a Released volume.
+	ReclaimOutcomeAvailable = "available"
+	ReclaimOutcomeDeleted   = "deleted"
 
 	// String to use when plugin name cannot be determined
 	pluginNameNotAvailable = "N/A"
@@ -65,6 +80,36 @@ func Register(pvLister PVLister, pvcLister PVCLister, pluginMgr *volume.VolumePl
 	registerMetrics.Do(func() {
 		legacyregistry.CustomMustRegister(newPVAndPVCCountCollector(pvLister, pvcLister, pluginMgr))
 		legacyregistry.MustRegister(volumeOperationErrorsMetric)
+		legacyregistry.MustRegister(claimBindDurationMetric)
+		legacyregistry.MustRegister(volumeReclaimDurationMetric)
+		legacyregistry.MustRegister(provisionThrottledMetric)
+		legacyregistry.MustRegister(phaseTransitionsMetric)
+	})
+}
+
+var registerQueueDepthMetrics sync.Once
+
+// RegisterQueueDepthMetrics registers gauges reporting the current depth of
+// the controller's claim and volume work queues. The depth functions are
+// called at scrape time.
+func RegisterQueueDepthMetrics(claimQueueDepth, volumeQueueDepth func() int) {
+	registerQueueDepthMetrics.Do(func() {
+		legacyregistry.RawMustRegister(metrics.NewGaugeFunc(
+			&metrics.GaugeOpts{
+				Subsystem:      pvControllerSubsystem,
+				Name:           claimQueueDepthKey,
+				Help:           "Number of claims waiting in the persistent volume controller work queues",
+				StabilityLevel: metrics.ALPHA,
+			},
+			func() float64 { return float64(claimQueueDepth()) }))
+		legacyregistry.RawMustRegister(metrics.NewGaugeFunc(
+			&metrics.GaugeOpts{
+				Subsystem:      pvControllerSubsystem,
+				Name:           volumeQueueDepthKey,
+				Help:           "Number of volumes waiting in the persistent volume controller work queue",
+				StabilityLevel: metrics.ALPHA,
+			},
+			func() float64 { return float64(volumeQueueDepth()) }))
 	})
 }
 
@@ -103,6 +148,16 @@ var (
 		"Gauge measuring number of persistent volume currently unbound",
 		[]string{storageClassLabel}, nil,
 		metrics.ALPHA, "")
+	pvPhaseCountDesc = metrics.NewDesc(
+		metrics.BuildFQName("", pvControllerSubsystem, pvPhaseCountKey),
+		"Gauge measuring number of persistent volumes, partitioned by phase and storage class",
+		[]string{phaseLabel, storageClassLabel}, nil,
+		metrics.ALPHA, "")
+	releasedStuckPVCountDesc = metrics.NewDesc(
+		metrics.BuildFQName("", pvControllerSubsystem, releasedStuckKey),
+		"Gauge measuring number of persistent volumes stuck in phase Released for longer than the configured threshold, partitioned by plugin and reclaim policy",
+		[]string{pluginNameLabel, reclaimPolicyLabel}, nil,
+		metrics.ALPHA, "")
 
 	boundPVCCountDesc = metrics.NewDesc(
 		metrics.BuildFQName("", pvControllerSubsystem, boundPVCKey),
@@ -122,6 +177,42 @@ var (
 			StabilityLevel: metrics.ALPHA,
 		},
 		[]string{"plugin_name", "operation_name"})
+
+	claimBindDurationMetric = metrics.NewHistogram(
+		&metrics.HistogramOpts{
+			Subsystem:      pvControllerSubsystem,
+			Name:           claimBindDurationKey,
+			Help:           "Time between creation of a persistent volume claim and the claim reaching phase Bound, in seconds",
+			Buckets:        metrics.ExponentialBuckets(0.1, 2, 15),
+			StabilityLevel: metrics.ALPHA,
+		})
+	volumeReclaimDurationMetric = metrics.NewHistogramVec(
+		&metrics.HistogramOpts{
+			Subsystem:      pvControllerSubsystem,
+			Name:           volumeReclaimDurationKey,
+			Help:           "Time between a persistent volume entering phase Released and it being reclaimed, in seconds, partitioned by whether it became Available again or was deleted",
+			Buckets:        metrics.ExponentialBuckets(0.1, 2, 15),
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{outcomeLabel})
+
+	provisionThrottledMetric = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem:      pvControllerSubsystem,
+			Name:           provisionThrottledKey,
+			Help:           "Total number of provisioning attempts deferred by the per-provisioner rate limiter, partitioned by provisioner name",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{pluginNameLabel})
+
+	phaseTransitionsMetric = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem:      pvControllerSubsystem,
+			Name:           phaseTransitionsKey,
+			Help:           "Total number of persistent volume phase transitions observed by the controller, partitioned by the phase entered and storage class",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{phaseLabel, storageClassLabel})
 )
 
 // volumeCount counts by PluginName and VolumeMode.
@@ -142,6 +233,8 @@ func (collector *pvAndPVCCountCollector) DescribeWithStability(ch chan<- *metric
 	ch <- unboundPVCountDesc
 	ch <- boundPVCCountDesc
 	ch <- unboundPVCCountDesc
+	ch <- pvPhaseCountDesc
+	ch <- releasedStuckPVCountDesc
 }
 
 func (collector *pvAndPVCCountCollector) CollectWithStability(ch chan<- metrics.Metric) {
@@ -161,6 +254,9 @@ func (collector *pvAndPVCCountCollector) getPVPluginName(pv *v1.PersistentVolume
 func (collector *pvAndPVCCountCollector) pvCollect(ch chan<- metrics.Metric) {
 	boundNumberByStorageClass := make(map[string]int)
 	unboundNumberByStorageClass := make(map[string]int)
+	numberByPhaseAndClass := make(map[string]map[string]int)
+	stuckNumberByPluginAndPolicy := make(map[string]map[string]int)
+	stuckThreshold := getReleasedStuckThreshold()
 	totalCount := make(volumeCount)
 	for _, pvObj := range collector.pvLister.List() {
 		pv, ok := pvObj.(*v1.PersistentVolume)
@@ -169,11 +265,25 @@ func (collector *pvAndPVCCountCollector) pvCollect(ch chan<- metrics.Metric) {
 		}
 		pluginName := collector.getPVPluginName(pv)
 		totalCount.add(pluginName, string(*pv.Spec.VolumeMode))
+		phase := string(pv.Status.Phase)
+		if numberByPhaseAndClass[phase] == nil {
+			numberByPhaseAndClass[phase] = map[string]int{}
+		}
+		numberByPhaseAndClass[phase][pv.Spec.StorageClassName]++
 		if pv.Status.Phase == v1.VolumeBound {
 			boundNumberByStorageClass[pv.Spec.StorageClassName]++
 		} else {
 			unboundNumberByStorageClass[pv.Spec.StorageClassName]++
 		}
+		if stuckThreshold > 0 &&
+			pv.Status.Phase == v1.VolumeReleased &&
+			time.Since(ReleasedSince(pv.Name)) >= stuckThreshold {
+			policy := string(pv.Spec.PersistentVolumeReclaimPolicy)
+			if stuckNumberByPluginAndPolicy[pluginName] == nil {
+				stuckNumberByPluginAndPolicy[pluginName] = map[string]int{}
+			}
+			stuckNumberByPluginAndPolicy[pluginName][policy]++
+		}
 	}
 	for storageClassName, number := range boundNumberByStorageClass {
 		ch <- metrics.NewLazyConstMetric(
@@ -199,6 +309,26 @@ func (collector *pvAndPVCCountCollector) pvCollect(ch chan<- metrics.Metric) {
 				volumeMode)
 		}
 	}
+	for phase, numberByClass := range numberByPhaseAndClass {
+		for storageClassName, number := range numberByClass {
+			ch <- metrics.NewLazyConstMetric(
+				pvPhaseCountDesc,
+				metrics.GaugeValue,
+				float64(number),
+				phase,
+				storageClassName)
+		}
+	}
+	for pluginName, numberByPolicy := range stuckNumberByPluginAndPolicy {
+		for policy, number := range numberByPolicy {
+			ch <- metrics.NewLazyConstMetric(
+				releasedStuckPVCountDesc,
+				metrics.GaugeValue,
+				float64(number),
+				pluginName,
+				policy)
+		}
+	}
 }
 
 func (collector *pvAndPVCCountCollector) pvcCollect(ch chan<- metrics.Metric) {
@@ -238,6 +368,114 @@ func RecordVolumeOperationErrorMetric(pluginName, opName string) {
 		pluginName = "N/A"
 	}
 	volumeOperationErrorsMetric.WithLabelValues(pluginName, opName).Inc()
+
+	operationErrorCountsLock.Lock()
+	defer operationErrorCountsLock.Unlock()
+	operationErrorCounts[opName]++
+}
+
+var (
+	operationErrorCountsLock sync.Mutex
+	// operationErrorCounts aggregates failed operations by operation name so
+	// the counts can be reported in the controller's storage summary without
+	// scraping the prometheus registry.
+	operationErrorCounts = map[string]int64{}
+)
+
+// OperationErrorCounts returns a copy of the cumulative failed operation
+// counts by operation name. Nil is returned when no operation has failed, so
+// callers can embed the result directly in a JSON response.
+func OperationErrorCounts() map[string]int64 {
+	operationErrorCountsLock.Lock()
+	defer operationErrorCountsLock.Unlock()
+	if len(operationErrorCounts) == 0 {
+		return nil
+	}
+	counts := make(map[string]int64, len(operationErrorCounts))
+	for opName, count := range operationErrorCounts {
+		counts[opName] = count
+	}
+	return counts
+}
+
+// RecordClaimBindDuration observes how long it took a claim to reach phase
+// Bound, measured from its creation timestamp. It is called once per claim,
+// when the controller transitions the claim to Bound.
+func RecordClaimBindDuration(seconds float64) {
+	claimBindDurationMetric.Observe(seconds)
+}
+
+// releasedTimestamps remembers when each volume was observed entering phase
+// Released, keyed by volume name, so the reclaim duration can be computed
+// when the volume becomes Available again or is deleted.
+var releasedTimestamps sync.Map // [string]time.Time
+
+// RecordVolumeReleased remembers the time the given volume entered phase
+// Released.
+func RecordVolumeReleased(volumeName string) {
+	releasedTimestamps.LoadOrStore(volumeName, time.Now())
+}
+
+// ReleasedSince returns the time the given volume was first observed in phase
+// Released. Volumes released before a controller restart are observed when
+// they are first asked about, so their age is counted from that point.
+func ReleasedSince(volumeName string) time.Time {
+	obj, _ := releasedTimestamps.LoadOrStore(volumeName, time.Now())
+	startTs, ok := obj.(time.Time)
+	if !ok {
+		return time.Now()
+	}
+	return startTs
+}
+
+var (
+	releasedStuckThresholdLock sync.Mutex
+	// releasedStuckThreshold is how long a volume may stay in phase Released
+	// before it is counted in the released_pv_stuck_count gauge. Zero
+	// disables the gauge.
+	releasedStuckThreshold time.Duration
+)
+
+// SetReleasedStuckThreshold configures how long a Released volume may linger
+// before it is reported as stuck.
+func SetReleasedStuckThreshold(threshold time.Duration) {
+	releasedStuckThresholdLock.Lock()
+	defer releasedStuckThresholdLock.Unlock()
+	releasedStuckThreshold = threshold
+}
+
+func getReleasedStuckThreshold() time.Duration {
+	releasedStuckThresholdLock.Lock()
+	defer releasedStuckThresholdLock.Unlock()
+	return releasedStuckThreshold
+}
+
+// RecordVolumeReclaimed observes the time between the volume entering phase
+// Released and now, with the given outcome. It is a no-op for volumes whose
+// release was not observed, e.g. volumes released before a controller
+// restart.
+func RecordVolumeReclaimed(volumeName, outcome string) {
+	obj, exists := releasedTimestamps.LoadAndDelete(volumeName)
+	if !exists {
+		return
+	}
+	startTs, ok := obj.(time.Time)
+	if !ok {
+		return
+	}
+	volumeReclaimDurationMetric.WithLabelValues(outcome).Observe(time.Since(startTs).Seconds())
+}
+
+// RecordProvisionThrottled counts a provisioning attempt deferred by the
+// per-provisioner rate limiter.
+func RecordProvisionThrottled(provisionerName string) {
+	provisionThrottledMetric.WithLabelValues(provisionerName).Inc()
+}
+
+// RecordVolumePhaseTransition counts a volume entering the given phase, so
+// dashboards can derive transition rates without listing volumes.
+func RecordVolumePhaseTransition(phase v1.PersistentVolumePhase, storageClass string) {
+	phaseTransitionsMetric.WithLabelValues(string(phase), storageClass).Inc()
 }
 
 // operationTimestamp stores the start time of an operation by a plugin
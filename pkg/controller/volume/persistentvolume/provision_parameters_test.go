@@ -0,0 +1,114 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package persistentvolume
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	storage "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestClaimProvisionerParameters(t *testing.T) {
+	tests := []struct {
+		name             string
+		claimAnnotations map[string]string
+		classAnnotations map[string]string
+		classParameters  map[string]string
+		expectParameters map[string]string
+		expectError      bool
+	}{
+		{
+			name:             "no claim parameters",
+			classParameters:  map[string]string{"type": "ssd"},
+			expectParameters: map[string]string{"type": "ssd"},
+		},
+		{
+			name: "allowlisted claim parameter is merged",
+			claimAnnotations: map[string]string{
+				AnnProvisionerParameterPrefix + "iops": "4000",
+			},
+			classAnnotations: map[string]string{
+				AnnAllowedClaimParameters: "iops,throughput",
+			},
+			classParameters:  map[string]string{"type": "ssd"},
+			expectParameters: map[string]string{"type": "ssd", "iops": "4000"},
+		},
+		{
+			name: "allowlisted claim parameter overrides class default",
+			claimAnnotations: map[string]string{
+				AnnProvisionerParameterPrefix + "iops": "4000",
+			},
+			classAnnotations: map[string]string{
+				AnnAllowedClaimParameters: " iops ",
+			},
+			classParameters:  map[string]string{"iops": "1000"},
+			expectParameters: map[string]string{"iops": "4000"},
+		},
+		{
+			name: "parameter not on allowlist fails",
+			claimAnnotations: map[string]string{
+				AnnProvisionerParameterPrefix + "secretRef": "oops",
+			},
+			classAnnotations: map[string]string{
+				AnnAllowedClaimParameters: "iops",
+			},
+			expectError: true,
+		},
+		{
+			name: "no allowlist means no claim parameters",
+			claimAnnotations: map[string]string{
+				AnnProvisionerParameterPrefix + "iops": "4000",
+			},
+			expectError: true,
+		},
+		{
+			name: "unrelated annotations are ignored",
+			claimAnnotations: map[string]string{
+				"some.other/annotation": "value",
+			},
+			classParameters:  map[string]string{"type": "ssd"},
+			expectParameters: map[string]string{"type": "ssd"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			claim := &v1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: "claim1", Namespace: "ns1", Annotations: tc.claimAnnotations},
+			}
+			class := &storage.StorageClass{
+				ObjectMeta: metav1.ObjectMeta{Name: "gold", Annotations: tc.classAnnotations},
+				Parameters: tc.classParameters,
+			}
+			parameters, err := claimProvisionerParameters(claim, class)
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected an error, got parameters %v", parameters)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(parameters, tc.expectParameters) {
+				t.Errorf("expected parameters %v, got %v", tc.expectParameters, parameters)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package persistentvolume
+
+import (
+	"sync"
+
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+// provisionRateLimiter throttles provisioning calls per provisioner, so a
+// burst of claims for one storage backend cannot exhaust the cloud
+// provider's API quota. Each provisioner gets its own token bucket,
+// created lazily on first use.
+type provisionRateLimiter struct {
+	qps   float32
+	burst int
+
+	mutex    sync.Mutex
+	limiters map[string]flowcontrol.RateLimiter
+}
+
+// newProvisionRateLimiter returns a rate limiter giving every provisioner
+// qps provisioning calls per second with the given burst.
+func newProvisionRateLimiter(qps float32, burst int) *provisionRateLimiter {
+	return &provisionRateLimiter{
+		qps:      qps,
+		burst:    burst,
+		limiters: map[string]flowcontrol.RateLimiter{},
+	}
+}
+
+// tryAccept takes a token from the provisioner's bucket and reports
+// whether a provisioning call may proceed now.
+func (l *provisionRateLimiter) tryAccept(provisionerName string) bool {
+	l.mutex.Lock()
+	limiter, ok := l.limiters[provisionerName]
+	if !ok {
+		limiter = flowcontrol.NewTokenBucketRateLimiter(l.qps, l.burst)
+		l.limiters[provisionerName] = limiter
+	}
+	l.mutex.Unlock()
+	return limiter.TryAccept()
+}
@@ -0,0 +1,39 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package persistentvolume
+
+import (
+	"testing"
+)
+
+func TestProvisionRateLimiterThrottlesPerProvisioner(t *testing.T) {
+	limiter := newProvisionRateLimiter(0.001, 2)
+
+	for i := 0; i < 2; i++ {
+		if !limiter.tryAccept("kubernetes.io/mock-provisioner") {
+			t.Fatalf("expected call %d to be within the burst", i+1)
+		}
+	}
+	if limiter.tryAccept("kubernetes.io/mock-provisioner") {
+		t.Error("expected the call over the burst to be throttled")
+	}
+
+	// A different provisioner has its own bucket.
+	if !limiter.tryAccept("kubernetes.io/other-provisioner") {
+		t.Error("expected another provisioner to be unaffected by the throttle")
+	}
+}
@@ -19,17 +19,21 @@ package persistentvolume
 import (
 	"context"
 	"errors"
+	"testing"
+	"time"
+
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
 	featuregatetesting "k8s.io/component-base/featuregate/testing"
 	"k8s.io/kubernetes/pkg/features"
-	"testing"
 
 	v1 "k8s.io/api/core/v1"
 	storage "k8s.io/api/storage/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
 	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/flowcontrol"
 	"k8s.io/component-helpers/storage/volume"
 	api "k8s.io/kubernetes/pkg/apis/core"
 	pvtesting "k8s.io/kubernetes/pkg/controller/volume/persistentvolume/testing"
@@ -292,7 +296,11 @@ func TestProvisionSync(t *testing.T) {
 			novolumes,
 			novolumes,
 			newClaimArray("claim11-10", "uid11-10", "1Gi", "", v1.ClaimPending, &classGold),
-			newClaimArray("claim11-10", "uid11-10", "1Gi", "", v1.ClaimPending, &classGold, volume.AnnStorageProvisioner, volume.AnnBetaStorageProvisioner),
+			// The asset could not be cleaned up, so the claim keeps the
+			// provisioning protection finalizer.
+			claimsWithFinalizers(
+				newClaimArray("claim11-10", "uid11-10", "1Gi", "", v1.ClaimPending, &classGold, volume.AnnStorageProvisioner, volume.AnnBetaStorageProvisioner),
+				[]string{ProvisioningProtectionFinalizer}),
 			[]string{"Warning ProvisioningFailed", "Warning ProvisioningCleanupFailed"},
 			[]pvtesting.ReactorError{
 				// Inject error to five kubeclient.PersistentVolumes.Create()
@@ -313,7 +321,11 @@ func TestProvisionSync(t *testing.T) {
 			novolumes,
 			novolumes,
 			newClaimArray("claim11-11", "uid11-11", "1Gi", "", v1.ClaimPending, &classGold),
-			newClaimArray("claim11-11", "uid11-11", "1Gi", "", v1.ClaimPending, &classGold, volume.AnnStorageProvisioner, volume.AnnBetaStorageProvisioner),
+			// The asset could not be cleaned up, so the claim keeps the
+			// provisioning protection finalizer.
+			claimsWithFinalizers(
+				newClaimArray("claim11-11", "uid11-11", "1Gi", "", v1.ClaimPending, &classGold, volume.AnnStorageProvisioner, volume.AnnBetaStorageProvisioner),
+				[]string{ProvisioningProtectionFinalizer}),
 			[]string{"Warning ProvisioningFailed", "Warning ProvisioningCleanupFailed"},
 			[]pvtesting.ReactorError{
 				// Inject error to five kubeclient.PersistentVolumes.Create()
@@ -343,7 +355,12 @@ func TestProvisionSync(t *testing.T) {
 			novolumes,
 			novolumes,
 			newClaimArray("claim11-12", "uid11-12", "1Gi", "", v1.ClaimPending, &classGold),
-			newClaimArray("claim11-12", "uid11-12", "1Gi", "", v1.ClaimPending, &classGold, volume.AnnStorageProvisioner, volume.AnnBetaStorageProvisioner),
+			// The first delete attempt fails and the controller gives up
+			// without confirming the asset gone, so the claim keeps the
+			// provisioning protection finalizer.
+			claimsWithFinalizers(
+				newClaimArray("claim11-12", "uid11-12", "1Gi", "", v1.ClaimPending, &classGold, volume.AnnStorageProvisioner, volume.AnnBetaStorageProvisioner),
+				[]string{ProvisioningProtectionFinalizer}),
 			[]string{"Warning ProvisioningFailed"},
 			[]pvtesting.ReactorError{
 				// Inject error to five kubeclient.PersistentVolumes.Create()
@@ -663,3 +680,52 @@ func TestDisablingDynamicProvisioner(t *testing.T) {
 		t.Errorf("Expected nil return but got %v", retVal)
 	}
 }
+
+// Test that a claim whose provisioning failed up to the retry limit gets the
+// terminal ProvisioningFailed condition instead of another retry, and that
+// the failure history can be cleared again.
+func TestProvisionRetryLimit(t *testing.T) {
+	claim := newClaim("claim-backoff", "uid-backoff", "1Gi", "", v1.ClaimPending, &storageClasses[0].Name)
+	client := fake.NewSimpleClientset(claim)
+	ctrl, err := newTestController(client, nil, true)
+	if err != nil {
+		t.Fatalf("Construct PersistentVolume controller failed: %v", err)
+	}
+	ctrl.provisionBackoff = flowcontrol.NewBackOff(time.Minute, 10*time.Minute)
+	ctrl.provisionMaxRetries = 2
+	ctrl.provisionFailures = map[string]int{}
+
+	claimKey := claimToClaimKey(claim)
+	ctrl.recordProvisionFailure(claimKey)
+	if count := ctrl.provisionFailureCount(claimKey); count != 1 {
+		t.Errorf("expected 1 recorded failure, got %d", count)
+	}
+	if !ctrl.provisionBackoff.IsInBackOffSinceUpdate(claimKey, ctrl.provisionBackoff.Clock.Now()) {
+		t.Errorf("expected claim to be in backoff after a failure")
+	}
+
+	// The second failure reaches the limit; the next provisionClaim call must
+	// mark the claim instead of scheduling another attempt.
+	ctrl.recordProvisionFailure(claimKey)
+	if err := ctrl.provisionClaim(context.TODO(), claim); err != nil {
+		t.Fatalf("provisionClaim failed: %v", err)
+	}
+	updated, err := client.CoreV1().PersistentVolumeClaims(claim.Namespace).Get(context.TODO(), claim.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get claim: %v", err)
+	}
+	found := false
+	for _, condition := range updated.Status.Conditions {
+		if condition.Type == PVCProvisioningFailedCondition && condition.Status == v1.ConditionTrue {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected terminal %s condition on the claim, got %+v", PVCProvisioningFailedCondition, updated.Status.Conditions)
+	}
+
+	ctrl.clearProvisionFailure(claimKey)
+	if count := ctrl.provisionFailureCount(claimKey); count != 0 {
+		t.Errorf("expected failure count to be cleared, got %d", count)
+	}
+}
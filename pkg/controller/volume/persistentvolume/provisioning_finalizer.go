@@ -0,0 +1,81 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package persistentvolume
+
+import (
+	"context"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/util/slice"
+)
+
+// ProvisioningProtectionFinalizer protects a claim whose volume is being
+// provisioned. It is added before the plugin is asked to create the storage
+// asset and removed once the asset is owned by a PV object or confirmed
+// deleted at the plugin level, so that deleting the claim mid-provision
+// cannot leak the asset.
+const ProvisioningProtectionFinalizer = "kubernetes.io/pvc-provisioning-protection"
+
+// addProvisioningFinalizer adds ProvisioningProtectionFinalizer to the claim
+// and returns the updated claim. It is a no-op when the finalizer is already
+// present.
+func (ctrl *PersistentVolumeController) addProvisioningFinalizer(ctx context.Context, claim *v1.PersistentVolumeClaim) (*v1.PersistentVolumeClaim, error) {
+	if slice.ContainsString(claim.Finalizers, ProvisioningProtectionFinalizer, nil) {
+		return claim, nil
+	}
+
+	// The claim from method args can be pointing to watcher cache. We must not
+	// modify these, therefore create a copy.
+	claimClone := claim.DeepCopy()
+	claimClone.Finalizers = append(claimClone.Finalizers, ProvisioningProtectionFinalizer)
+	newClaim, err := ctrl.kubeClient.CoreV1().PersistentVolumeClaims(claim.Namespace).Update(ctx, claimClone, metav1.UpdateOptions{})
+	if err != nil {
+		return claim, err
+	}
+	_, err = ctrl.storeClaimUpdate(newClaim)
+	if err != nil {
+		return newClaim, err
+	}
+	klog.V(4).Infof("claim %q protected while its volume is provisioned", claimToClaimKey(claim))
+	return newClaim, nil
+}
+
+// removeProvisioningFinalizer removes ProvisioningProtectionFinalizer from
+// the claim. It is a no-op when the finalizer is not present. Errors are
+// returned so callers can log them; the next provisioning attempt for the
+// claim removes a leftover finalizer via the idempotency check at the top of
+// provisionClaimOperation.
+func (ctrl *PersistentVolumeController) removeProvisioningFinalizer(ctx context.Context, claim *v1.PersistentVolumeClaim) error {
+	if !slice.ContainsString(claim.Finalizers, ProvisioningProtectionFinalizer, nil) {
+		return nil
+	}
+
+	claimClone := claim.DeepCopy()
+	claimClone.Finalizers = slice.RemoveString(claimClone.Finalizers, ProvisioningProtectionFinalizer, nil)
+	newClaim, err := ctrl.kubeClient.CoreV1().PersistentVolumeClaims(claim.Namespace).Update(ctx, claimClone, metav1.UpdateOptions{})
+	if err != nil {
+		return err
+	}
+	_, err = ctrl.storeClaimUpdate(newClaim)
+	if err != nil {
+		return err
+	}
+	klog.V(4).Infof("claim %q unprotected, provisioning finished", claimToClaimKey(claim))
+	return nil
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package persistentvolume
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/kubernetes/pkg/util/slice"
+)
+
+// TestProvisioningFinalizer verifies that the provisioning protection
+// finalizer is added to and removed from the claim through the API and that
+// both helpers are idempotent.
+func TestProvisioningFinalizer(t *testing.T) {
+	claim := newClaim("claim-finalizer", "uid-finalizer", "1Gi", "", v1.ClaimPending, nil)
+	client := fake.NewSimpleClientset(claim)
+	ctrl, err := newTestController(client, nil, false)
+	if err != nil {
+		t.Fatalf("Construct PersistentVolume controller failed: %v", err)
+	}
+
+	updated, err := ctrl.addProvisioningFinalizer(context.TODO(), claim)
+	if err != nil {
+		t.Fatalf("adding the finalizer failed: %v", err)
+	}
+	if !slice.ContainsString(updated.Finalizers, ProvisioningProtectionFinalizer, nil) {
+		t.Errorf("expected finalizer %q on the claim, got %v", ProvisioningProtectionFinalizer, updated.Finalizers)
+	}
+
+	// A second add must not duplicate the finalizer.
+	again, err := ctrl.addProvisioningFinalizer(context.TODO(), updated)
+	if err != nil {
+		t.Fatalf("re-adding the finalizer failed: %v", err)
+	}
+	if len(again.Finalizers) != len(updated.Finalizers) {
+		t.Errorf("expected the finalizer to be added once, got %v", again.Finalizers)
+	}
+
+	if err := ctrl.removeProvisioningFinalizer(context.TODO(), again); err != nil {
+		t.Fatalf("removing the finalizer failed: %v", err)
+	}
+	stored, err := client.CoreV1().PersistentVolumeClaims(testNamespace).Get(context.TODO(), claim.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("getting the claim failed: %v", err)
+	}
+	if slice.ContainsString(stored.Finalizers, ProvisioningProtectionFinalizer, nil) {
+		t.Errorf("expected finalizer %q to be removed, got %v", ProvisioningProtectionFinalizer, stored.Finalizers)
+	}
+
+	// Removing from a claim without the finalizer is a no-op.
+	if err := ctrl.removeProvisioningFinalizer(context.TODO(), stored); err != nil {
+		t.Errorf("removing an absent finalizer failed: %v", err)
+	}
+}
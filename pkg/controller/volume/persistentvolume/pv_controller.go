@@ -21,18 +21,21 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"sync"
 	"time"
 
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
 	"k8s.io/kubernetes/pkg/features"
 	"k8s.io/kubernetes/pkg/util/slice"
 
+	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
 	storage "k8s.io/api/storage/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/dynamic"
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	corelisters "k8s.io/client-go/listers/core/v1"
@@ -40,10 +43,12 @@ import (
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	ref "k8s.io/client-go/tools/reference"
+	"k8s.io/client-go/util/flowcontrol"
 	"k8s.io/client-go/util/workqueue"
 	cloudprovider "k8s.io/cloud-provider"
 	volerr "k8s.io/cloud-provider/volume/errors"
 	storagehelpers "k8s.io/component-helpers/storage/volume"
+	"k8s.io/kubernetes/pkg/controller/shadow"
 	"k8s.io/kubernetes/pkg/controller/volume/common"
 	"k8s.io/kubernetes/pkg/controller/volume/events"
 	"k8s.io/kubernetes/pkg/controller/volume/persistentvolume/metrics"
@@ -132,6 +137,21 @@ const CloudVolumeCreatedForClaimNameTag = "kubernetes.io/created-for/pvc/name"
 // with name of appropriate Kubernetes persistent volume .
 const CloudVolumeCreatedForVolumeNameTag = "kubernetes.io/created-for/pv/name"
 
+// shadowControllerName is the controller name under which the PV binder
+// can be put into shadow mode; it matches the controller name used by the
+// kube-controller-manager.
+const shadowControllerName = "persistentvolume-binder"
+
+// PVCProvisioningFailedCondition is set on a claim when dynamic provisioning
+// has been retried up to the configured limit and the controller gave up. The
+// condition is terminal; such claims are not retried anymore.
+const PVCProvisioningFailedCondition v1.PersistentVolumeClaimConditionType = "ProvisioningFailed"
+
+// provisionBackoffJitterFactor spreads out provisioning retries of claims
+// that failed at the same time, so an external provisioner recovering from
+// an outage is not hit by all of them at once.
+const provisionBackoffJitterFactor = 1.0
+
 // Number of retries when we create a PV object for a provisioned volume.
 const createProvisionedPVRetryCount = 5
 
@@ -158,12 +178,19 @@ type PersistentVolumeController struct {
 	claimLister        corelisters.PersistentVolumeClaimLister
 	claimListerSynced  cache.InformerSynced
 	classLister        storagelisters.StorageClassLister
-	classListerSynced  cache.InformerSynced
-	podLister          corelisters.PodLister
-	podListerSynced    cache.InformerSynced
-	podIndexer         cache.Indexer
-	NodeLister         corelisters.NodeLister
-	NodeListerSynced   cache.InformerSynced
+	// capacityLister, if non-nil, enables capacity pool accounting for
+	// static volumes annotated with AnnCapacityPool.
+	capacityLister    storagelisters.CSIStorageCapacityLister
+	classListerSynced cache.InformerSynced
+	podLister         corelisters.PodLister
+	podListerSynced   cache.InformerSynced
+	podIndexer        cache.Indexer
+	// onDemandPodLookup makes findPodsByPVCKey list pods from the API
+	// server instead of the informer cache; podLister and podIndexer are
+	// nil in this mode.
+	onDemandPodLookup bool
+	NodeLister        corelisters.NodeLister
+	NodeListerSynced  cache.InformerSynced
 
 	kubeClient                clientset.Interface
 	eventRecorder             record.EventRecorder
@@ -172,6 +199,58 @@ type PersistentVolumeController struct {
 	enableDynamicProvisioning bool
 	clusterName               string
 	resyncPeriod              time.Duration
+	dryRun                    bool
+	enableDecisionTrace       bool
+
+	// statusCoalescer batches PV and PVC status updates when the controller
+	// was created with CoalesceStatusUpdates, nil otherwise.
+	statusCoalescer *statusCoalescer
+
+	// wipeJobTemplate, when set, replaces the legacy recycler pods with
+	// jobs instantiated from this template for the Recycle reclaim policy.
+	wipeJobTemplate *batchv1.JobSpec
+
+	// provisionRateLimiter throttles provisioning calls per provisioner
+	// when the controller was created with a provisioning rate, nil
+	// otherwise.
+	provisionRateLimiter *provisionRateLimiter
+
+	// shardOwner restricts this replica to its shard of the volumes and
+	// claims when the controller was created with a ShardingConfig, nil
+	// otherwise.
+	shardOwner *shardOwner
+
+	// releasedDeleteGracePeriod keeps Released volumes with reclaim policy
+	// Delete around for this long before the deleter runs. Zero means
+	// delete immediately; a StorageClass parameter can override it per
+	// class.
+	releasedDeleteGracePeriod time.Duration
+
+	// snapshotContentClient reads the external VolumeSnapshotContent
+	// objects when the deleter checks for dependent snapshots, nil when the
+	// check is unavailable.
+	snapshotContentClient dynamic.Interface
+
+	// snapshotAwareDeletion makes the deleter hold the deletion of volumes
+	// that VolumeSnapshotContent objects still reference; a StorageClass
+	// parameter can override it per class.
+	snapshotAwareDeletion bool
+
+	// storageSummaryInterval is how often the storage summary ConfigMap is
+	// refreshed; zero disables publishing.
+	storageSummaryInterval time.Duration
+
+	// volumeNameTemplate names dynamically provisioned volumes; empty keeps
+	// the pvc-<uid> default. A StorageClass can override it through the
+	// volumeNameTemplate parameter.
+	volumeNameTemplate string
+
+	// releasedStuckThreshold is how long a volume may stay in phase Released
+	// before it is reported as stuck; zero disables the reporting.
+	// releasedStuckWarned remembers which volumes were already warned about,
+	// keyed by volume name, and is touched only by the monitor goroutine.
+	releasedStuckThreshold time.Duration
+	releasedStuckWarned    map[string]bool
 
 	// Cache of the last known version of volumes and claims. This cache is
 	// thread safe as long as the volumes/claims there are not modified, they
@@ -202,7 +281,13 @@ type PersistentVolumeController struct {
 	// version errors in API server and other checks in this controller),
 	// however overall speed of multi-worker controller would be lower than if
 	// it runs single thread only.
-	claimQueue  *workqueue.Type
+	// claimQueues holds the sharded claim work queues. Claims are
+	// partitioned by a hash of their namespace so that one worker per shard
+	// can bind claims in parallel while each claim is still processed by
+	// exactly one worker. With a single binder worker this degenerates to
+	// the classic single "claims" queue. Within each shard, claims are
+	// ordered by the priority in their AnnProvisioningPriority annotation.
+	claimQueues []*claimQueue
 	volumeQueue *workqueue.Type
 
 	// Map of scheduled/running operations.
@@ -212,6 +297,28 @@ type PersistentVolumeController struct {
 	// Not used when set to nil.
 	preOperationHook func(operationName string)
 
+	// bindApprover, if non-nil, is consulted before a bind between a claim
+	// and a matched volume is finalized.
+	bindApprover *bindApprover
+
+	// For testing only: fault injector consulted before selected sync steps.
+	// Not used when set to nil.
+	faultInjector FaultInjector
+
+	// provisionBackoff, if non-nil, throttles retries of failed dynamic
+	// provisioning per claim with exponential backoff and jitter. Nil keeps
+	// the classic behavior of retrying on every sync.
+	provisionBackoff *flowcontrol.Backoff
+	// provisionMaxRetries limits how many times provisioning of a claim is
+	// retried before the claim gets a terminal ProvisioningFailed condition.
+	// Zero means no limit. Only effective with provisionBackoff set.
+	provisionMaxRetries int
+
+	// provisionFailures counts consecutive provisioning failures per claim
+	// key. Guarded by provisionFailuresLock.
+	provisionFailuresLock sync.Mutex
+	provisionFailures     map[string]int
+
 	createProvisionedPVRetryCount int
 	createProvisionedPVInterval   time.Duration
 
@@ -251,6 +358,10 @@ type PersistentVolumeController struct {
 // For easier readability, it was split into syncUnboundClaim and syncBoundClaim
 // methods.
 func (ctrl *PersistentVolumeController) syncClaim(ctx context.Context, claim *v1.PersistentVolumeClaim) error {
+	if ctrl.shardOwner != nil && !ctrl.shardOwner.owns(string(claim.UID)) {
+		klog.V(5).Infof("synchronizing PersistentVolumeClaim[%s]: owned by another shard, skipping", claimToClaimKey(claim))
+		return nil
+	}
 	klog.V(4).Infof("synchronizing PersistentVolumeClaim[%s]: %s", claimToClaimKey(claim), getClaimStatusForLogging(claim))
 
 	// Set correct "migrated-to" annotations on PVC and update in API server if
@@ -263,6 +374,16 @@ func (ctrl *PersistentVolumeController) syncClaim(ctx context.Context, claim *v1
 	}
 	claim = newClaim
 
+	if utilfeature.DefaultFeatureGate.Enabled(features.RetroactiveDefaultStorageClass) {
+		newClaim, err := ctrl.assignDefaultStorageClass(ctx, claim)
+		if err != nil {
+			// Nothing was saved; we will fall back into the same
+			// condition in the next call to this method
+			return err
+		}
+		claim = newClaim
+	}
+
 	if !metav1.HasAnnotation(claim.ObjectMeta, storagehelpers.AnnBindCompleted) {
 		return ctrl.syncUnboundClaim(ctx, claim)
 	} else {
@@ -333,6 +454,12 @@ func (ctrl *PersistentVolumeController) syncUnboundClaim(ctx context.Context, cl
 	// This is a new PVC that has not completed binding
 	// OBSERVATION: pvc is "Pending"
 	if claim.Spec.VolumeName == "" {
+		// The scheduler asked for the binding of this claim to be rolled
+		// back because the pod-wide binding transaction failed. Undo the
+		// node selection before provisioning goes any further.
+		if metav1.HasAnnotation(claim.ObjectMeta, storagehelpers.AnnBindRollback) {
+			return ctrl.rollbackBinding(claim)
+		}
 		// User did not care which PV they get.
 		delayBinding, err := storagehelpers.IsDelayBindingMode(claim, ctrl.classLister)
 		if err != nil {
@@ -347,8 +474,17 @@ func (ctrl *PersistentVolumeController) syncUnboundClaim(ctx context.Context, cl
 		}
 		if volume == nil {
 			klog.V(4).Infof("synchronizing unbound PersistentVolumeClaim[%s]: no volume found", claimToClaimKey(claim))
+			// Claims carrying a restore ID adopt a matching restored
+			// volume before any provisioning is considered.
+			if restored := ctrl.findRestoredVolume(claim); restored != nil {
+				klog.V(4).Infof("synchronizing unbound PersistentVolumeClaim[%s]: adopting restored volume %q", claimToClaimKey(claim), restored.Name)
+				return ctrl.bind(restored, claim)
+			}
 			// No PV could be found
 			// OBSERVATION: pvc is "Pending", will retry
+			if !delayBinding {
+				ctrl.recordBindingTrace(ctx, claim)
+			}
 			switch {
 			case delayBinding && !storagehelpers.IsDelayBindingProvisioning(claim):
 				if err = ctrl.emitEventForUnboundDelayBindingClaim(claim); err != nil {
@@ -374,6 +510,44 @@ func (ctrl *PersistentVolumeController) syncUnboundClaim(ctx context.Context, cl
 			// OBSERVATION: pvc is "Pending", pv is "Available"
 			claimKey := claimToClaimKey(claim)
 			klog.V(4).Infof("synchronizing unbound PersistentVolumeClaim[%s]: volume %q found: %s", claimKey, volume.Name, getVolumeStatusForLogging(volume))
+			if ctrl.bindApprover != nil {
+				approved, reason, err := ctrl.bindApprover.approve(claim, volume)
+				if err != nil {
+					return err
+				}
+				if !approved {
+					klog.V(4).Infof("synchronizing unbound PersistentVolumeClaim[%s]: bind to volume %q vetoed by approval webhook: %s", claimKey, volume.Name, reason)
+					ctrl.eventRecorder.Event(claim, v1.EventTypeWarning, events.FailedBinding, fmt.Sprintf("bind to volume %q denied by approval webhook: %s", volume.Name, reason))
+					// Mark the claim as Pending and retry with the next
+					// periodic syncClaim.
+					if _, err = ctrl.updateClaimStatus(claim, v1.ClaimPending, nil); err != nil {
+						return err
+					}
+					return nil
+				}
+			}
+			admitted, reason, err := ctrl.checkCapacityPool(volume)
+			if err != nil {
+				return err
+			}
+			if !admitted {
+				klog.V(4).Infof("synchronizing unbound PersistentVolumeClaim[%s]: %s", claimKey, reason)
+				ctrl.eventRecorder.Event(claim, v1.EventTypeWarning, events.FailedBinding, reason)
+				if _, err = ctrl.updateClaimStatus(claim, v1.ClaimPending, nil); err != nil {
+					return err
+				}
+				return nil
+			}
+			if ctrl.isDryRun() {
+				ctrl.eventRecorder.Event(claim, v1.EventTypeNormal, shadow.EventReason, fmt.Sprintf("shadow mode: would bind to volume %q", volume.Name))
+				shadow.Record(shadowControllerName, "bind", claimKey)
+				// Keep the claim Pending; the bind is recomputed and
+				// suppressed again on the next periodic syncClaim.
+				if _, err = ctrl.updateClaimStatus(claim, v1.ClaimPending, nil); err != nil {
+					return err
+				}
+				return nil
+			}
 			if err = ctrl.bind(volume, claim); err != nil {
 				// On any error saving the volume or the claim, subsequent
 				// syncClaim will finish the binding.
@@ -539,6 +713,10 @@ func (ctrl *PersistentVolumeController) syncBoundClaim(claim *v1.PersistentVolum
 // created, updated or periodically synced. We do not differentiate between
 // these events.
 func (ctrl *PersistentVolumeController) syncVolume(ctx context.Context, volume *v1.PersistentVolume) error {
+	if ctrl.shardOwner != nil && !ctrl.shardOwner.owns(string(volume.UID)) {
+		klog.V(5).Infof("synchronizing PersistentVolume[%s]: owned by another shard, skipping", volume.Name)
+		return nil
+	}
 	klog.V(4).Infof("synchronizing PersistentVolume[%s]: %s", volume.Name, getVolumeStatusForLogging(volume))
 	// Set correct "migrated-to" annotations and modify finalizers on PV and update in API server if
 	// necessary
@@ -691,7 +869,7 @@ func (ctrl *PersistentVolumeController) syncVolume(ctx context.Context, volume *
 			// This speeds up binding of provisioned volumes - provisioner saves
 			// only the new PV and it expects that next syncClaim will bind the
 			// claim to it.
-			ctrl.claimQueue.Add(claimToClaimKey(claim))
+			ctrl.claimQueueForKey(claimToClaimKey(claim)).Add(claimToClaimKey(claim), claimProvisioningPriority(claim))
 			return nil
 		} else if claim.Spec.VolumeName == volume.Name {
 			// Volume is bound to a claim properly, update status if necessary
@@ -755,12 +933,17 @@ func (ctrl *PersistentVolumeController) syncVolume(ctx context.Context, volume *
 
 // updateClaimStatus saves new claim.Status to API server.
 // Parameters:
-//  claim - claim to update
-//  phase - phase to set
-//  volume - volume which Capacity is set into claim.Status.Capacity
+//
+//	claim - claim to update
+//	phase - phase to set
+//	volume - volume which Capacity is set into claim.Status.Capacity
 func (ctrl *PersistentVolumeController) updateClaimStatus(claim *v1.PersistentVolumeClaim, phase v1.PersistentVolumeClaimPhase, volume *v1.PersistentVolume) (*v1.PersistentVolumeClaim, error) {
 	klog.V(4).Infof("updating PersistentVolumeClaim[%s] status: set phase %s", claimToClaimKey(claim), phase)
 
+	if err := ctrl.injectFault(StepUpdateClaimStatus, claimToClaimKey(claim)); err != nil {
+		return nil, err
+	}
+
 	dirty := false
 
 	claimClone := claim.DeepCopy()
@@ -821,6 +1004,17 @@ func (ctrl *PersistentVolumeController) updateClaimStatus(claim *v1.PersistentVo
 		return claim, nil
 	}
 
+	if ctrl.statusCoalescer != nil {
+		// Let the coalescer batch the update; continue the sync with the
+		// local copy and pick up the applied object from the informer.
+		ctrl.statusCoalescer.enqueueClaimStatus(claimClone)
+		if phase == v1.ClaimBound && claim.Status.Phase != phase {
+			metrics.RecordClaimBindDuration(time.Since(claim.CreationTimestamp.Time).Seconds())
+		}
+		klog.V(2).Infof("claim %q entered phase %q", claimToClaimKey(claim), phase)
+		return claimClone, nil
+	}
+
 	newClaim, err := ctrl.kubeClient.CoreV1().PersistentVolumeClaims(claimClone.Namespace).UpdateStatus(context.TODO(), claimClone, metav1.UpdateOptions{})
 	if err != nil {
 		klog.V(4).Infof("updating PersistentVolumeClaim[%s] status: set phase %s failed: %v", claimToClaimKey(claim), phase, err)
@@ -831,6 +1025,9 @@ func (ctrl *PersistentVolumeController) updateClaimStatus(claim *v1.PersistentVo
 		klog.V(4).Infof("updating PersistentVolumeClaim[%s] status: cannot update internal cache: %v", claimToClaimKey(claim), err)
 		return newClaim, err
 	}
+	if phase == v1.ClaimBound && claim.Status.Phase != phase {
+		metrics.RecordClaimBindDuration(time.Since(claim.CreationTimestamp.Time).Seconds())
+	}
 	klog.V(2).Infof("claim %q entered phase %q", claimToClaimKey(claim), phase)
 	return newClaim, nil
 }
@@ -839,10 +1036,11 @@ func (ctrl *PersistentVolumeController) updateClaimStatus(claim *v1.PersistentVo
 // given event on the claim. It saves the status and emits the event only when
 // the status has actually changed from the version saved in API server.
 // Parameters:
-//   claim - claim to update
-//   phase - phase to set
-//   volume - volume which Capacity is set into claim.Status.Capacity
-//   eventtype, reason, message - event to send, see EventRecorder.Event()
+//
+//	claim - claim to update
+//	phase - phase to set
+//	volume - volume which Capacity is set into claim.Status.Capacity
+//	eventtype, reason, message - event to send, see EventRecorder.Event()
 func (ctrl *PersistentVolumeController) updateClaimStatusWithEvent(claim *v1.PersistentVolumeClaim, phase v1.PersistentVolumeClaimPhase, volume *v1.PersistentVolume, eventtype, reason, message string) (*v1.PersistentVolumeClaim, error) {
 	klog.V(4).Infof("updating updateClaimStatusWithEvent[%s]: set phase %s", claimToClaimKey(claim), phase)
 	if claim.Status.Phase == phase {
@@ -877,6 +1075,21 @@ func (ctrl *PersistentVolumeController) updateVolumePhase(volume *v1.PersistentV
 	volumeClone.Status.Phase = phase
 	volumeClone.Status.Message = message
 
+	if ctrl.statusCoalescer != nil {
+		// Let the coalescer batch the update; continue the sync with the
+		// local copy and pick up the applied object from the informer.
+		ctrl.statusCoalescer.enqueueVolumePhase(volume.Name, phase, message)
+		switch phase {
+		case v1.VolumeReleased:
+			metrics.RecordVolumeReleased(volume.Name)
+		case v1.VolumeAvailable:
+			metrics.RecordVolumeReclaimed(volume.Name, metrics.ReclaimOutcomeAvailable)
+		}
+		metrics.RecordVolumePhaseTransition(phase, storagehelpers.GetPersistentVolumeClass(volume))
+		klog.V(2).Infof("volume %q entered phase %q", volume.Name, phase)
+		return volumeClone, nil
+	}
+
 	newVol, err := ctrl.kubeClient.CoreV1().PersistentVolumes().UpdateStatus(context.TODO(), volumeClone, metav1.UpdateOptions{})
 	if err != nil {
 		klog.V(4).Infof("updating PersistentVolume[%s]: set phase %s failed: %v", volume.Name, phase, err)
@@ -887,6 +1100,13 @@ func (ctrl *PersistentVolumeController) updateVolumePhase(volume *v1.PersistentV
 		klog.V(4).Infof("updating PersistentVolume[%s]: cannot update internal cache: %v", volume.Name, err)
 		return newVol, err
 	}
+	switch phase {
+	case v1.VolumeReleased:
+		metrics.RecordVolumeReleased(volume.Name)
+	case v1.VolumeAvailable:
+		metrics.RecordVolumeReclaimed(volume.Name, metrics.ReclaimOutcomeAvailable)
+	}
+	metrics.RecordVolumePhaseTransition(phase, storagehelpers.GetPersistentVolumeClass(volume))
 	klog.V(2).Infof("volume %q entered phase %q", volume.Name, phase)
 	return newVol, err
 }
@@ -920,6 +1140,10 @@ func (ctrl *PersistentVolumeController) updateVolumePhaseWithEvent(volume *v1.Pe
 func (ctrl *PersistentVolumeController) bindVolumeToClaim(volume *v1.PersistentVolume, claim *v1.PersistentVolumeClaim) (*v1.PersistentVolume, error) {
 	klog.V(4).Infof("updating PersistentVolume[%s]: binding to %q", volume.Name, claimToClaimKey(claim))
 
+	if err := ctrl.injectFault(StepBindVolumeToClaim, volume.Name); err != nil {
+		return nil, err
+	}
+
 	volumeClone, dirty, err := storagehelpers.GetBindVolumeToClaim(volume, claim)
 	if err != nil {
 		return nil, err
@@ -960,6 +1184,10 @@ func (ctrl *PersistentVolumeController) updateBindVolumeToClaim(volumeClone *v1.
 func (ctrl *PersistentVolumeController) bindClaimToVolume(claim *v1.PersistentVolumeClaim, volume *v1.PersistentVolume) (*v1.PersistentVolumeClaim, error) {
 	klog.V(4).Infof("updating PersistentVolumeClaim[%s]: binding to %q", claimToClaimKey(claim), volume.Name)
 
+	if err := ctrl.injectFault(StepBindClaimToVolume, claimToClaimKey(claim)); err != nil {
+		return nil, err
+	}
+
 	dirty := false
 
 	// Check if the claim was already bound (either by controller or by user)
@@ -1117,6 +1345,13 @@ func (ctrl *PersistentVolumeController) reclaimVolume(volume *v1.PersistentVolum
 
 	case v1.PersistentVolumeReclaimDelete:
 		klog.V(4).Infof("reclaimVolume[%s]: policy is Delete", volume.Name)
+		if delayed, err := ctrl.delayVolumeDeletion(volume); err != nil {
+			return err
+		} else if delayed {
+			// The grace period has not passed yet; the periodic sync
+			// retries the reclaim.
+			return nil
+		}
 		opName := fmt.Sprintf("delete-%s[%s]", volume.Name, string(volume.UID))
 		// create a start timestamp entry in cache for deletion operation if no one exists with
 		// key = volume.Name, pluginName = provisionerName, operation = "delete"
@@ -1191,6 +1426,17 @@ func (ctrl *PersistentVolumeController) recycleVolumeOperation(volume *v1.Persis
 	// saving.
 	volume = newVolume
 
+	if ctrl.isDryRun() {
+		ctrl.eventRecorder.Event(volume, v1.EventTypeNormal, shadow.EventReason, "shadow mode: would recycle volume")
+		shadow.Record(shadowControllerName, "recycle", volume.Name)
+		return
+	}
+
+	if ctrl.wipeJobTemplate != nil {
+		ctrl.recycleVolumeByJob(context.TODO(), volume)
+		return
+	}
+
 	// Find a plugin.
 	spec := vol.NewSpecFromPersistentVolume(volume, false)
 	plugin, err := ctrl.volumePluginMgr.FindRecyclablePluginBySpec(spec)
@@ -1265,6 +1511,22 @@ func (ctrl *PersistentVolumeController) deleteVolumeOperation(volume *v1.Persist
 		klog.V(3).Infof("volume %q no longer needs deletion, skipping", volume.Name)
 		return "", nil
 	}
+	if ctrl.isDryRun() {
+		ctrl.eventRecorder.Event(volume, v1.EventTypeNormal, shadow.EventReason, "shadow mode: would delete volume")
+		shadow.Record(shadowControllerName, "delete", volume.Name)
+		return "", nil
+	}
+
+	// Check for dependent snapshot contents before invoking the plugin's
+	// deleter; deleting the volume would orphan snapshots taken from it.
+	if held, err := ctrl.holdDeletionForSnapshots(newVolume); err != nil {
+		klog.V(3).Infof("error checking snapshot contents of volume %q: %v", volume.Name, err)
+		return "", err
+	} else if held {
+		// The periodic sync retries the deletion until the snapshot
+		// contents are gone.
+		return "", nil
+	}
 
 	pluginName, deleted, err := ctrl.doDeleteVolume(volume)
 	if err != nil {
@@ -1306,6 +1568,13 @@ func (ctrl *PersistentVolumeController) deleteVolumeOperation(volume *v1.Persist
 	return pluginName, nil
 }
 
+// isDryRun returns true if the controller must not execute its decisions,
+// either because it was created with the DryRun parameter or because it was
+// put into shadow mode.
+func (ctrl *PersistentVolumeController) isDryRun() bool {
+	return ctrl.dryRun || shadow.Enabled(shadowControllerName)
+}
+
 // isVolumeReleased returns true if given volume is released and can be recycled
 // or deleted, based on its retain policy. I.e. the volume is bound to a claim
 // and the claim does not exist or exists and is bound to different volume.
@@ -1355,6 +1624,9 @@ func (ctrl *PersistentVolumeController) isVolumeReleased(volume *v1.PersistentVo
 }
 
 func (ctrl *PersistentVolumeController) findPodsByPVCKey(key string) ([]*v1.Pod, error) {
+	if ctrl.onDemandPodLookup {
+		return ctrl.findPodsByPVCKeyOnDemand(key)
+	}
 	pods := []*v1.Pod{}
 	objs, err := ctrl.podIndexer.ByIndex(common.PodPVCIndex, key)
 	if err != nil {
@@ -1370,6 +1642,37 @@ func (ctrl *PersistentVolumeController) findPodsByPVCKey(key string) ([]*v1.Pod,
 	return pods, err
 }
 
+// findPodsByPVCKeyOnDemand lists the pods of the claim's namespace from the
+// API server and filters them with the same index function the informer
+// cache would use. It is used when the controller runs without a full pod
+// informer cache.
+func (ctrl *PersistentVolumeController) findPodsByPVCKeyOnDemand(key string) ([]*v1.Pod, error) {
+	namespace, _, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return nil, err
+	}
+	podList, err := ctrl.kubeClient.CoreV1().Pods(namespace).List(context.TODO(), metav1.ListOptions{ResourceVersion: "0"})
+	if err != nil {
+		return nil, err
+	}
+	indexFunc := common.PodPVCIndexFunc()
+	pods := []*v1.Pod{}
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		keys, err := indexFunc(pod)
+		if err != nil {
+			return nil, err
+		}
+		for _, podKey := range keys {
+			if podKey == key {
+				pods = append(pods, pod)
+				break
+			}
+		}
+	}
+	return pods, nil
+}
+
 // isVolumeUsed returns list of active pods that use given PV.
 func (ctrl *PersistentVolumeController) isVolumeUsed(pv *v1.PersistentVolume) ([]string, bool, error) {
 	if pv.Spec.ClaimRef == nil {
@@ -1492,8 +1795,25 @@ func (ctrl *PersistentVolumeController) provisionClaim(ctx context.Context, clai
 	if !ctrl.enableDynamicProvisioning {
 		return nil
 	}
-	klog.V(4).Infof("provisionClaim[%s]: started", claimToClaimKey(claim))
-	opName := fmt.Sprintf("provision-%s[%s]", claimToClaimKey(claim), string(claim.UID))
+	claimKey := claimToClaimKey(claim)
+	klog.V(4).Infof("provisionClaim[%s]: started", claimKey)
+	if ctrl.provisionBackoff != nil {
+		if ctrl.provisionMaxRetries > 0 && ctrl.provisionFailureCount(claimKey) >= ctrl.provisionMaxRetries {
+			return ctrl.markProvisioningFailed(claim)
+		}
+		if ctrl.provisionBackoff.IsInBackOffSinceUpdate(claimKey, ctrl.provisionBackoff.Clock.Now()) {
+			klog.V(4).Infof("provisionClaim[%s]: backing off after %d failed attempts", claimKey, ctrl.provisionFailureCount(claimKey))
+			return nil
+		}
+	}
+	if _, err := ctrl.resolveCrossNamespaceDataSource(ctx, claim); err != nil {
+		ctrl.eventRecorder.Event(claim, v1.EventTypeWarning, events.ProvisioningFailed, err.Error())
+		klog.Errorf("error resolving cross-namespace data source for claim %s: %v", claimKey, err)
+		// The claim stays Pending; the controller retries the resolution in
+		// every syncUnboundClaim() call, like other provisioning failures.
+		return nil
+	}
+	opName := fmt.Sprintf("provision-%s[%s]", claimKey, string(claim.UID))
 	plugin, storageClass, err := ctrl.findProvisionablePlugin(claim)
 	// findProvisionablePlugin does not return err for external provisioners
 	if err != nil {
@@ -1504,10 +1824,24 @@ func (ctrl *PersistentVolumeController) provisionClaim(ctx context.Context, clai
 		// retain the original behavior of returning nil from provisionClaim call
 		return nil
 	}
+	if ctrl.isDryRun() {
+		ctrl.eventRecorder.Event(claim, v1.EventTypeNormal, shadow.EventReason, fmt.Sprintf("shadow mode: would provision a volume using provisioner %q", ctrl.getProvisionerName(plugin, storageClass)))
+		shadow.Record(shadowControllerName, "provision", claimKey)
+		return nil
+	}
+	if ctrl.provisionRateLimiter != nil {
+		provisionerName := ctrl.getProvisionerName(plugin, storageClass)
+		if !ctrl.provisionRateLimiter.tryAccept(provisionerName) {
+			metrics.RecordProvisionThrottled(provisionerName)
+			klog.V(4).Infof("provisionClaim[%s]: provisioner %q is over its provisioning rate, deferring", claimKey, provisionerName)
+			// The claim stays Pending; the controller retries in every
+			// syncUnboundClaim() call until a token is available.
+			return nil
+		}
+	}
 	ctrl.scheduleOperation(opName, func() error {
 		// create a start timestamp entry in cache for provision operation if no one exists with
 		// key = claimKey, pluginName = provisionerName, operation = "provision"
-		claimKey := claimToClaimKey(claim)
 		ctrl.operationTimestamps.AddIfNotExist(claimKey, ctrl.getProvisionerName(plugin, storageClass), "provision")
 		var err error
 		if plugin == nil {
@@ -1519,12 +1853,79 @@ func (ctrl *PersistentVolumeController) provisionClaim(ctx context.Context, clai
 		// timestamp entry will remain in cache until a success binding has happened
 		if err != nil {
 			metrics.RecordMetric(claimKey, &ctrl.operationTimestamps, err)
+			ctrl.recordProvisionFailure(claimKey)
+		} else {
+			ctrl.clearProvisionFailure(claimKey)
 		}
 		return err
 	})
 	return nil
 }
 
+// provisionFailureCount returns the number of consecutive failed provisioning
+// attempts for the given claim key.
+func (ctrl *PersistentVolumeController) provisionFailureCount(claimKey string) int {
+	ctrl.provisionFailuresLock.Lock()
+	defer ctrl.provisionFailuresLock.Unlock()
+	return ctrl.provisionFailures[claimKey]
+}
+
+// recordProvisionFailure counts a failed provisioning attempt of a claim and
+// schedules the earliest allowed retry.
+func (ctrl *PersistentVolumeController) recordProvisionFailure(claimKey string) {
+	if ctrl.provisionBackoff == nil {
+		return
+	}
+	ctrl.provisionBackoff.Next(claimKey, ctrl.provisionBackoff.Clock.Now())
+	ctrl.provisionFailuresLock.Lock()
+	defer ctrl.provisionFailuresLock.Unlock()
+	ctrl.provisionFailures[claimKey]++
+}
+
+// clearProvisionFailure forgets the provisioning failure history of a claim,
+// either because provisioning succeeded or because the claim was deleted.
+func (ctrl *PersistentVolumeController) clearProvisionFailure(claimKey string) {
+	if ctrl.provisionBackoff == nil {
+		return
+	}
+	ctrl.provisionBackoff.DeleteEntry(claimKey)
+	ctrl.provisionFailuresLock.Lock()
+	defer ctrl.provisionFailuresLock.Unlock()
+	delete(ctrl.provisionFailures, claimKey)
+}
+
+// markProvisioningFailed puts the terminal ProvisioningFailed condition on a
+// claim whose provisioning retry limit has been exhausted. The claim stays
+// Pending and is not retried anymore; the user has to delete and recreate it.
+func (ctrl *PersistentVolumeController) markProvisioningFailed(claim *v1.PersistentVolumeClaim) error {
+	for _, condition := range claim.Status.Conditions {
+		if condition.Type == PVCProvisioningFailedCondition && condition.Status == v1.ConditionTrue {
+			// The condition is already set, nothing to do.
+			return nil
+		}
+	}
+	message := fmt.Sprintf("provisioning failed %d times, giving up", ctrl.provisionMaxRetries)
+	klog.V(2).Infof("marking claim %q with terminal %s condition: %s", claimToClaimKey(claim), PVCProvisioningFailedCondition, message)
+
+	claimClone := claim.DeepCopy()
+	claimClone.Status.Conditions = append(claimClone.Status.Conditions, v1.PersistentVolumeClaimCondition{
+		Type:               PVCProvisioningFailedCondition,
+		Status:             v1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             "MaxRetriesExceeded",
+		Message:            message,
+	})
+	newClaim, err := ctrl.kubeClient.CoreV1().PersistentVolumeClaims(claimClone.Namespace).UpdateStatus(context.TODO(), claimClone, metav1.UpdateOptions{})
+	if err != nil {
+		return err
+	}
+	if _, err = ctrl.storeClaimUpdate(newClaim); err != nil {
+		return err
+	}
+	ctrl.eventRecorder.Event(newClaim, v1.EventTypeWarning, events.ProvisioningFailed, message)
+	return nil
+}
+
 // provisionClaimOperation provisions a volume. This method is running in
 // standalone goroutine and already has all necessary locks.
 func (ctrl *PersistentVolumeController) provisionClaimOperation(
@@ -1565,16 +1966,34 @@ func (ctrl *PersistentVolumeController) provisionClaimOperation(
 	//  the locks. Check that PV (with deterministic name) hasn't been provisioned
 	//  yet.
 
-	pvName := ctrl.getProvisionedVolumeNameForClaim(claim)
+	pvName, err := ctrl.getProvisionedVolumeNameForClaim(claim, storageClass)
+	if err != nil {
+		strerr := fmt.Sprintf("error generating volume name for claim %q: %v", claimToClaimKey(claim), err)
+		klog.V(2).Infof(strerr)
+		ctrl.eventRecorder.Event(claim, v1.EventTypeWarning, events.ProvisioningFailed, strerr)
+		return pluginName, err
+	}
 	volume, err := ctrl.kubeClient.CoreV1().PersistentVolumes().Get(context.TODO(), pvName, metav1.GetOptions{})
 	if err != nil && !apierrors.IsNotFound(err) {
 		klog.V(3).Infof("error reading persistent volume %q: %v", pvName, err)
 		return pluginName, err
 	}
 	if err == nil && volume != nil {
-		// Volume has been already provisioned, nothing to do.
+		if !storagehelpers.IsVolumeBoundToClaim(volume, claim) {
+			// A templated name collided with a volume provisioned for a
+			// different claim. Fail loudly instead of adopting it.
+			strerr := fmt.Sprintf("volume name %q generated for claim %q is already taken by a volume provisioned for a different claim", pvName, claimToClaimKey(claim))
+			klog.V(2).Infof(strerr)
+			ctrl.eventRecorder.Event(claim, v1.EventTypeWarning, events.ProvisioningFailed, strerr)
+			return pluginName, fmt.Errorf(strerr)
+		}
+		// Volume has been already provisioned, nothing to do. Drop a
+		// protection finalizer possibly left behind by a previous attempt.
 		klog.V(4).Infof("provisionClaimOperation [%s]: volume already exists, skipping", claimToClaimKey(claim))
-		return pluginName, err
+		if err := ctrl.removeProvisioningFinalizer(ctx, claim); err != nil {
+			klog.V(3).Infof("error removing provisioning protection finalizer from claim %q: %v", claimToClaimKey(claim), err)
+		}
+		return pluginName, nil
 	}
 
 	// Prepare a claimRef to the claim early (to fail before a volume is
@@ -1591,14 +2010,39 @@ func (ctrl *PersistentVolumeController) provisionClaimOperation(
 	tags[CloudVolumeCreatedForClaimNameTag] = claim.Name
 	tags[CloudVolumeCreatedForVolumeNameTag] = pvName
 
+	parameters, err := claimProvisionerParameters(claim, storageClass)
+	if err != nil {
+		klog.V(2).Infof("provisioning for claim %q failed: %v", claimToClaimKey(claim), err)
+		ctrl.eventRecorder.Event(claim, v1.EventTypeWarning, events.ProvisioningFailed, err.Error())
+		return pluginName, err
+	}
+	if _, found := parameters[VolumeNameTemplateParameter]; found {
+		// The volume name template is consumed by this controller; do not
+		// leak it to the provisioner plugin. Copy the map first, it may be
+		// shared with the informer's copy of the StorageClass.
+		stripped := make(map[string]string, len(parameters))
+		for key, value := range parameters {
+			stripped[key] = value
+		}
+		delete(stripped, VolumeNameTemplateParameter)
+		parameters = stripped
+	}
+
+	reclaimPolicy, err := ctrl.reclaimPolicyForClaim(ctx, claim, storageClass)
+	if err != nil {
+		klog.V(2).Infof("provisioning for claim %q failed: %v", claimToClaimKey(claim), err)
+		ctrl.eventRecorder.Event(claim, v1.EventTypeWarning, events.ProvisioningFailed, err.Error())
+		return pluginName, err
+	}
+
 	options := vol.VolumeOptions{
-		PersistentVolumeReclaimPolicy: *storageClass.ReclaimPolicy,
+		PersistentVolumeReclaimPolicy: reclaimPolicy,
 		MountOptions:                  storageClass.MountOptions,
 		CloudTags:                     &tags,
 		ClusterName:                   ctrl.clusterName,
 		PVName:                        pvName,
 		PVC:                           claim,
-		Parameters:                    storageClass.Parameters,
+		Parameters:                    parameters,
 	}
 
 	// Refuse to provision if the plugin doesn't support mount options, creation
@@ -1631,6 +2075,16 @@ func (ctrl *PersistentVolumeController) provisionClaimOperation(
 	}
 	allowedTopologies := storageClass.AllowedTopologies
 
+	// Protect the claim before the storage asset exists; a claim deleted
+	// mid-provision could otherwise leak the asset.
+	newClaim, err = ctrl.addProvisioningFinalizer(ctx, claim)
+	if err != nil {
+		// Save failed, the controller will retry in the next sync
+		klog.V(2).Infof("error saving claim %s: %v", claimToClaimKey(claim), err)
+		return pluginName, err
+	}
+	claim = newClaim
+
 	opComplete := util.OperationCompleteHook(plugin.GetPluginName(), "volume_provision")
 	volume, err = provisioner.Provision(selectedNode, allowedTopologies)
 	opComplete(volumetypes.CompleteFuncParam{Err: &err})
@@ -1640,6 +2094,11 @@ func (ctrl *PersistentVolumeController) provisionClaimOperation(
 		// rescheduleProvisioning here when the underlying provisioning actually failed.
 		ctrl.rescheduleProvisioning(claim)
 
+		// The plugin created nothing, the claim no longer needs protection.
+		if err := ctrl.removeProvisioningFinalizer(ctx, claim); err != nil {
+			klog.V(3).Infof("error removing provisioning protection finalizer from claim %q: %v", claimToClaimKey(claim), err)
+		}
+
 		strerr := fmt.Sprintf("Failed to provision volume with StorageClass %q: %v", storageClass.Name, err)
 		klog.V(2).Infof("failed to provision volume for claim %q with StorageClass %q: %v", claimToClaimKey(claim), storageClass.Name, err)
 		ctrl.eventRecorder.Event(claim, v1.EventTypeWarning, events.ProvisioningFailed, strerr)
@@ -1704,8 +2163,14 @@ func (ctrl *PersistentVolumeController) provisionClaimOperation(
 
 		var deleteErr error
 		var deleted bool
+		// Whether any attempt confirmed the asset gone at the plugin level;
+		// bookkeeping errors after that must not keep the claim protected.
+		assetDeleted := false
 		for i := 0; i < ctrl.createProvisionedPVRetryCount; i++ {
 			_, deleted, deleteErr = ctrl.doDeleteVolume(volume)
+			if deleted {
+				assetDeleted = true
+			}
 			if deleteErr == nil && deleted {
 				// Delete succeeded
 				klog.V(4).Infof("provisionClaimOperation [%s]: cleaning volume %s succeeded", claimToClaimKey(claim), volume.Name)
@@ -1723,17 +2188,29 @@ func (ctrl *PersistentVolumeController) provisionClaimOperation(
 			time.Sleep(ctrl.createProvisionedPVInterval)
 		}
 
-		if deleteErr != nil {
+		if deleteErr != nil && !assetDeleted {
 			// Delete failed several times. There is an orphaned volume and there
-			// is nothing we can do about it.
+			// is nothing we can do about it. Keep the protection finalizer on
+			// the claim so the asset stays discoverable through it.
 			strerr := fmt.Sprintf("Error cleaning provisioned volume for claim %s: %v. Please delete manually.", claimToClaimKey(claim), deleteErr)
 			klog.V(2).Info(strerr)
 			ctrl.eventRecorder.Event(claim, v1.EventTypeWarning, events.ProvisioningCleanupFailed, strerr)
+		} else if assetDeleted {
+			// The asset is confirmed deleted at the plugin level, the claim
+			// no longer needs protection.
+			if err := ctrl.removeProvisioningFinalizer(ctx, claim); err != nil {
+				klog.V(3).Infof("error removing provisioning protection finalizer from claim %q: %v", claimToClaimKey(claim), err)
+			}
 		}
 	} else {
 		klog.V(2).Infof("volume %q provisioned for claim %q", volume.Name, claimToClaimKey(claim))
 		msg := fmt.Sprintf("Successfully provisioned volume %s using %s", volume.Name, plugin.GetPluginName())
 		ctrl.eventRecorder.Event(claim, v1.EventTypeNormal, events.ProvisioningSucceeded, msg)
+		// The PV object now owns the asset's lifecycle, the claim no longer
+		// needs protection.
+		if err := ctrl.removeProvisioningFinalizer(ctx, claim); err != nil {
+			klog.V(3).Infof("error removing provisioning protection finalizer from claim %q: %v", claimToClaimKey(claim), err)
+		}
 	}
 	return pluginName, nil
 }
@@ -1799,10 +2276,44 @@ func (ctrl *PersistentVolumeController) rescheduleProvisioning(claim *v1.Persist
 	}
 }
 
+// rollbackBinding undoes the scheduler-triggered binding of an unbound claim
+// after the pod-wide binding transaction failed. It removes the selected node
+// annotation together with the rollback request, so that provisioning stops
+// and the claim waits for its next consumer.
+func (ctrl *PersistentVolumeController) rollbackBinding(claim *v1.PersistentVolumeClaim) error {
+	klog.V(4).Infof("synchronizing unbound PersistentVolumeClaim[%s]: rolling back binding on scheduler request", claimToClaimKey(claim))
+
+	// The claim from method args can be pointing to watcher cache. We must not
+	// modify these, therefore create a copy.
+	newClaim := claim.DeepCopy()
+	delete(newClaim.Annotations, storagehelpers.AnnSelectedNode)
+	delete(newClaim.Annotations, storagehelpers.AnnBindRollback)
+	newClaim, err := ctrl.kubeClient.CoreV1().PersistentVolumeClaims(newClaim.Namespace).Update(context.TODO(), newClaim, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("rolling back binding of claim %q: %w", claimToClaimKey(claim), err)
+	}
+	if _, err := ctrl.storeClaimUpdate(newClaim); err != nil {
+		// We will get an "claim updated" event soon, this is not a big error
+		klog.V(4).Infof("Updating PersistentVolumeClaim %q: cannot update internal cache: %v", claimToClaimKey(newClaim), err)
+	}
+	ctrl.eventRecorder.Event(newClaim, v1.EventTypeNormal, events.BindRollback, "binding rolled back after the pod's volume binding transaction failed")
+	return nil
+}
+
 // getProvisionedVolumeNameForClaim returns PV.Name for the provisioned volume.
-// The name must be unique.
-func (ctrl *PersistentVolumeController) getProvisionedVolumeNameForClaim(claim *v1.PersistentVolumeClaim) string {
-	return "pvc-" + string(claim.UID)
+// The name must be unique and deterministic, so that a provisioning retry
+// finds the volume created by a previous attempt. A volume name template from
+// the StorageClass parameters takes precedence over the controller-wide one;
+// with no template configured the name is "pvc-" + claim UID.
+func (ctrl *PersistentVolumeController) getProvisionedVolumeNameForClaim(claim *v1.PersistentVolumeClaim, storageClass *storage.StorageClass) (string, error) {
+	template := ctrl.volumeNameTemplate
+	if classTemplate, found := storageClass.Parameters[VolumeNameTemplateParameter]; found {
+		template = classTemplate
+	}
+	if template == "" {
+		return "pvc-" + string(claim.UID), nil
+	}
+	return expandVolumeNameTemplate(template, claim)
 }
 
 // scheduleOperation starts given asynchronous operation on given volume. It
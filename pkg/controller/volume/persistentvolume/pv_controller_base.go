@@ -19,10 +19,12 @@ package persistentvolume
 import (
 	"context"
 	"fmt"
+	"hash/fnv"
 	"strconv"
 	"strings"
 	"time"
 
+	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -31,6 +33,7 @@ import (
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	"k8s.io/client-go/dynamic"
 	coreinformers "k8s.io/client-go/informers/core/v1"
 	storageinformers "k8s.io/client-go/informers/storage/v1"
 	clientset "k8s.io/client-go/kubernetes"
@@ -39,6 +42,7 @@ import (
 	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/flowcontrol"
 	"k8s.io/client-go/util/workqueue"
 	cloudprovider "k8s.io/cloud-provider"
 	storagehelpers "k8s.io/component-helpers/storage/volume"
@@ -52,6 +56,7 @@ import (
 	"k8s.io/kubernetes/pkg/util/slice"
 	vol "k8s.io/kubernetes/pkg/volume"
 	"k8s.io/kubernetes/pkg/volume/csimigration"
+	"k8s.io/utils/clock"
 
 	"k8s.io/klog/v2"
 )
@@ -63,19 +68,120 @@ import (
 // ControllerParameters contains arguments for creation of a new
 // PersistentVolume controller.
 type ControllerParameters struct {
-	KubeClient                clientset.Interface
-	SyncPeriod                time.Duration
-	VolumePlugins             []vol.VolumePlugin
-	Cloud                     cloudprovider.Interface
-	ClusterName               string
-	VolumeInformer            coreinformers.PersistentVolumeInformer
-	ClaimInformer             coreinformers.PersistentVolumeClaimInformer
-	ClassInformer             storageinformers.StorageClassInformer
-	PodInformer               coreinformers.PodInformer
+	KubeClient     clientset.Interface
+	SyncPeriod     time.Duration
+	VolumePlugins  []vol.VolumePlugin
+	Cloud          cloudprovider.Interface
+	ClusterName    string
+	VolumeInformer coreinformers.PersistentVolumeInformer
+	ClaimInformer  coreinformers.PersistentVolumeClaimInformer
+	ClassInformer  storageinformers.StorageClassInformer
+	// CapacityInformer optionally enables capacity pool accounting for
+	// static volumes. Nil disables the check.
+	CapacityInformer storageinformers.CSIStorageCapacityInformer
+	// PodInformer supplies the pods referencing a claim on the recycle and
+	// delayed-binding paths. It may be nil when OnDemandPodLookup is set.
+	PodInformer coreinformers.PodInformer
+	// OnDemandPodLookup makes the controller list the pods of a claim's
+	// namespace from the API server when it needs them instead of indexing
+	// a full pod informer cache. Embedders that do not share a pod informer
+	// with other controllers can use this to avoid caching every pod in the
+	// cluster; pod lookups only happen on the rare recycle and
+	// delayed-binding paths.
+	OnDemandPodLookup         bool
 	NodeInformer              coreinformers.NodeInformer
 	EventRecorder             record.EventRecorder
 	EnableDynamicProvisioning bool
 	FilteredDialOptions       *proxyutil.FilteredDialOptions
+	// BindApproval optionally configures an external webhook consulted
+	// before binds are finalized. Nil disables the check.
+	BindApproval *BindApprovalConfig
+	// FaultInjector, for testing only, fails selected sync steps on demand.
+	// Must be nil in production.
+	FaultInjector FaultInjector
+	// BinderWorkers is the number of workers binding claims in parallel.
+	// Values below 1 are treated as 1.
+	BinderWorkers int
+	// ProvisionRetryBackoffBase and ProvisionRetryBackoffMax configure a
+	// per-claim exponential backoff with jitter between failed dynamic
+	// provisioning attempts. A zero base disables the backoff and keeps the
+	// classic behavior of retrying on every sync.
+	ProvisionRetryBackoffBase time.Duration
+	ProvisionRetryBackoffMax  time.Duration
+	// ProvisionMaxRetries limits the number of provisioning attempts per
+	// claim before a terminal ProvisioningFailed condition is set on it.
+	// Zero means no limit. Only effective with the backoff enabled.
+	ProvisionMaxRetries int
+	// DryRun makes the controller compute its bind, provision and delete
+	// decisions and record them as events and metrics without mutating API
+	// objects or storage assets. It is equivalent to putting the controller
+	// into shadow mode (see pkg/controller/shadow).
+	DryRun bool
+	// EnableDecisionTrace makes the controller additionally write the full
+	// binding decision trace to the AnnBindingTrace annotation of claims for
+	// which no matching volume was found. The truncated event summary is
+	// always emitted.
+	EnableDecisionTrace bool
+	// CoalesceStatusUpdates makes the controller batch PV and PVC status
+	// updates through a write-coalescing layer that deduplicates redundant
+	// updates and applies them with server-side apply, reducing the API
+	// server write load in large clusters.
+	CoalesceStatusUpdates bool
+	// ProvisionRateQPS and ProvisionRateBurst configure a token-bucket
+	// rate limiter on provisioning calls, applied per provisioner, so a
+	// burst of claims cannot exhaust a cloud provider's API quota.
+	// Throttled attempts are counted in the provision_throttled_total
+	// metric and retried on the next sync. A zero QPS disables the
+	// limiter.
+	ProvisionRateQPS   float32
+	ProvisionRateBurst int
+	// WipeJobTemplate, when set, replaces the legacy recycler pods with
+	// jobs instantiated from this template for volumes with the Recycle
+	// reclaim policy. The template controls the wiper image, resource
+	// limits, tolerations and TTL; the controller injects the volume to
+	// wipe as the pod volume named "vol" and marks the volume Available
+	// only once the job completed.
+	WipeJobTemplate *batchv1.JobSpec
+	// Sharding, when set, makes this replica sync only the volumes and
+	// claims hashing into the shards it owns, coordinated through Lease
+	// objects, so the PV controller can run active in several manager
+	// replicas at once. Nil keeps the classic single-active behavior.
+	Sharding *ShardingConfig
+	// ReleasedDeleteGracePeriod keeps Released volumes with reclaim policy
+	// Delete around for this long before the deleter runs, giving users a
+	// window to rescue data. The scheduled deletion time is published on
+	// the volume, and a StorageClass can override the period through the
+	// releasedDeleteGracePeriod parameter. Zero deletes immediately.
+	ReleasedDeleteGracePeriod time.Duration
+	// StorageSummaryInterval, when positive, makes the controller publish
+	// its storage summary to the pv-storage-summary ConfigMap in
+	// kube-system at this interval, so dashboards can watch one object
+	// instead of listing all volumes. Zero disables publishing.
+	StorageSummaryInterval time.Duration
+	// ReleasedStuckThreshold, when positive, makes the controller report
+	// volumes that stay in phase Released for longer than this as stuck: a
+	// warning event is emitted on the volume and the volume is counted in
+	// the released_pv_stuck_count gauge, partitioned by plugin and reclaim
+	// policy. Zero disables the reporting.
+	ReleasedStuckThreshold time.Duration
+	// VolumeNameTemplate, when set, is used to name dynamically provisioned
+	// volumes instead of the opaque pvc-<uid> default. The template may use
+	// the {namespace} and {pvcname} placeholders, plus {hash}, a short
+	// deterministic hash of the claim UID that keeps names unique. A
+	// StorageClass can override the template through the volumeNameTemplate
+	// parameter.
+	VolumeNameTemplate string
+	// SnapshotContentClient, when set, lets the deleter check for
+	// VolumeSnapshotContent objects (an external CRD) that still reference
+	// a volume before invoking a plugin's Delete. Nil disables the check
+	// entirely.
+	SnapshotContentClient dynamic.Interface
+	// SnapshotAwareDeletion makes the deleter hold the deletion of any
+	// volume that snapshot contents still reference, with a warning event
+	// naming them, until they are gone. A StorageClass can override it per
+	// class through the snapshotAwareDeletion parameter. Requires
+	// SnapshotContentClient.
+	SnapshotAwareDeletion bool
 }
 
 // NewController creates a new PersistentVolume controller
@@ -96,13 +202,40 @@ func NewController(p ControllerParameters) (*PersistentVolumeController, error)
 		runningOperations:             goroutinemap.NewGoRoutineMap(true /* exponentialBackOffOnError */),
 		cloud:                         p.Cloud,
 		enableDynamicProvisioning:     p.EnableDynamicProvisioning,
+		dryRun:                        p.DryRun,
+		enableDecisionTrace:           p.EnableDecisionTrace,
 		clusterName:                   p.ClusterName,
 		createProvisionedPVRetryCount: createProvisionedPVRetryCount,
 		createProvisionedPVInterval:   createProvisionedPVInterval,
-		claimQueue:                    workqueue.NewNamed("claims"),
+		claimQueues:                   newClaimQueues(p.BinderWorkers),
 		volumeQueue:                   workqueue.NewNamed("volumes"),
 		resyncPeriod:                  p.SyncPeriod,
 		operationTimestamps:           metrics.NewOperationStartTimeCache(),
+		wipeJobTemplate:               p.WipeJobTemplate,
+		releasedDeleteGracePeriod:     p.ReleasedDeleteGracePeriod,
+		snapshotContentClient:         p.SnapshotContentClient,
+		snapshotAwareDeletion:         p.SnapshotAwareDeletion,
+		storageSummaryInterval:        p.StorageSummaryInterval,
+		releasedStuckThreshold:        p.ReleasedStuckThreshold,
+		volumeNameTemplate:            p.VolumeNameTemplate,
+	}
+	if p.CoalesceStatusUpdates {
+		controller.statusCoalescer = newStatusCoalescer(p.KubeClient, statusFlushInterval)
+	}
+	if p.BindApproval != nil {
+		controller.bindApprover = newBindApprover(*p.BindApproval, clock.RealClock{})
+	}
+	controller.faultInjector = p.FaultInjector
+	if p.ProvisionRateQPS > 0 {
+		controller.provisionRateLimiter = newProvisionRateLimiter(p.ProvisionRateQPS, p.ProvisionRateBurst)
+	}
+	if p.Sharding != nil && p.Sharding.TotalShards > 1 {
+		controller.shardOwner = newShardOwner(p.KubeClient, *p.Sharding)
+	}
+	if p.ProvisionRetryBackoffBase > 0 {
+		controller.provisionBackoff = flowcontrol.NewBackOffWithJitter(p.ProvisionRetryBackoffBase, p.ProvisionRetryBackoffMax, provisionBackoffJitterFactor)
+		controller.provisionMaxRetries = p.ProvisionMaxRetries
+		controller.provisionFailures = map[string]int{}
 	}
 
 	// Prober is nil because PV is not aware of Flexvolume.
@@ -122,9 +255,9 @@ func NewController(p ControllerParameters) (*PersistentVolumeController, error)
 
 	p.ClaimInformer.Informer().AddEventHandler(
 		cache.ResourceEventHandlerFuncs{
-			AddFunc:    func(obj interface{}) { controller.enqueueWork(controller.claimQueue, obj) },
-			UpdateFunc: func(oldObj, newObj interface{}) { controller.enqueueWork(controller.claimQueue, newObj) },
-			DeleteFunc: func(obj interface{}) { controller.enqueueWork(controller.claimQueue, obj) },
+			AddFunc:    func(obj interface{}) { controller.enqueueClaimWork(obj) },
+			UpdateFunc: func(oldObj, newObj interface{}) { controller.enqueueClaimWork(newObj) },
+			DeleteFunc: func(obj interface{}) { controller.enqueueClaimWork(obj) },
 		},
 	)
 	controller.claimLister = p.ClaimInformer.Lister()
@@ -132,18 +265,24 @@ func NewController(p ControllerParameters) (*PersistentVolumeController, error)
 
 	controller.classLister = p.ClassInformer.Lister()
 	controller.classListerSynced = p.ClassInformer.Informer().HasSynced
-	controller.podLister = p.PodInformer.Lister()
-	controller.podIndexer = p.PodInformer.Informer().GetIndexer()
-	controller.podListerSynced = p.PodInformer.Informer().HasSynced
+	if p.CapacityInformer != nil {
+		controller.capacityLister = p.CapacityInformer.Lister()
+	}
+	controller.onDemandPodLookup = p.OnDemandPodLookup
+	if !p.OnDemandPodLookup {
+		controller.podLister = p.PodInformer.Lister()
+		controller.podIndexer = p.PodInformer.Informer().GetIndexer()
+		controller.podListerSynced = p.PodInformer.Informer().HasSynced
+
+		// This custom indexer will index pods by its PVC keys. Then we don't need
+		// to iterate all pods every time to find pods which reference given PVC.
+		if err := common.AddPodPVCIndexerIfNotPresent(controller.podIndexer); err != nil {
+			return nil, fmt.Errorf("could not initialize attach detach controller: %w", err)
+		}
+	}
 	controller.NodeLister = p.NodeInformer.Lister()
 	controller.NodeListerSynced = p.NodeInformer.Informer().HasSynced
 
-	// This custom indexer will index pods by its PVC keys. Then we don't need
-	// to iterate all pods every time to find pods which reference given PVC.
-	if err := common.AddPodPVCIndexerIfNotPresent(controller.podIndexer); err != nil {
-		return nil, fmt.Errorf("could not initialize attach detach controller: %w", err)
-	}
-
 	csiTranslator := csitrans.New()
 	controller.translator = csiTranslator
 	controller.csiMigratedPluginManager = csimigration.NewPluginManager(csiTranslator, utilfeature.DefaultFeatureGate)
@@ -182,7 +321,7 @@ func (ctrl *PersistentVolumeController) initializeCaches(volumeLister corelister
 	klog.V(4).Infof("controller initialized")
 }
 
-// enqueueWork adds volume or claim to given work queue.
+// enqueueWork adds volume to given work queue.
 func (ctrl *PersistentVolumeController) enqueueWork(queue workqueue.Interface, obj interface{}) {
 	// Beware of "xxx deleted" events
 	if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok && unknown.Obj != nil {
@@ -197,6 +336,55 @@ func (ctrl *PersistentVolumeController) enqueueWork(queue workqueue.Interface, o
 	queue.Add(objName)
 }
 
+// enqueueClaimWork adds a claim to the work queue of its shard, ordered by
+// the claim's provisioning priority.
+func (ctrl *PersistentVolumeController) enqueueClaimWork(obj interface{}) {
+	// Beware of "xxx deleted" events
+	if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok && unknown.Obj != nil {
+		obj = unknown.Obj
+	}
+	objName, err := controller.KeyFunc(obj)
+	if err != nil {
+		klog.Errorf("failed to get key from object: %v", err)
+		return
+	}
+	priority := 0
+	if claim, ok := obj.(*v1.PersistentVolumeClaim); ok {
+		priority = claimProvisioningPriority(claim)
+	}
+	klog.V(5).Infof("enqueued %q for sync", objName)
+	ctrl.claimQueueForKey(objName).Add(objName, priority)
+}
+
+// newClaimQueues creates the sharded claim work queues.
+func newClaimQueues(workers int) []*claimQueue {
+	if workers < 1 {
+		workers = 1
+	}
+	queues := make([]*claimQueue, workers)
+	for i := range queues {
+		queues[i] = newClaimQueue()
+	}
+	return queues
+}
+
+// claimQueueForKey returns the work queue of the shard responsible for the
+// given claim key. Claims are partitioned by a hash of their namespace, so
+// all enqueue sites - some of which only have the key, not the claim - agree
+// on the shard and a claim is never processed by two workers at once.
+func (ctrl *PersistentVolumeController) claimQueueForKey(key string) *claimQueue {
+	if len(ctrl.claimQueues) == 1 {
+		return ctrl.claimQueues[0]
+	}
+	namespace := key
+	if idx := strings.IndexByte(key, '/'); idx >= 0 {
+		namespace = key[:idx]
+	}
+	h := fnv.New32a()
+	h.Write([]byte(namespace))
+	return ctrl.claimQueues[int(h.Sum32())%len(ctrl.claimQueues)]
+}
+
 func (ctrl *PersistentVolumeController) storeVolumeUpdate(volume interface{}) (bool, error) {
 	return storeObjectUpdate(ctrl.volumes.store, volume, "volume")
 }
@@ -241,6 +429,9 @@ func (ctrl *PersistentVolumeController) deleteVolume(volume *v1.PersistentVolume
 	// the following calls will be a no-op if there is nothing for this volume in the cache
 	// end of timestamp cache entry lifecycle, "RecordMetric" will do the clean
 	metrics.RecordMetric(volume.Name, &ctrl.operationTimestamps, nil)
+	// record how long the volume stayed Released; a no-op for volumes that
+	// were not Released when they were deleted
+	metrics.RecordVolumeReclaimed(volume.Name, metrics.ReclaimOutcomeDeleted)
 
 	if volume.Spec.ClaimRef == nil {
 		return
@@ -250,7 +441,10 @@ func (ctrl *PersistentVolumeController) deleteVolume(volume *v1.PersistentVolume
 	// waiting until the next sync period for its Lost status.
 	claimKey := claimrefToClaimKey(volume.Spec.ClaimRef)
 	klog.V(5).Infof("deleteVolume[%s]: scheduling sync of claim %q", volume.Name, claimKey)
-	ctrl.claimQueue.Add(claimKey)
+	// Only the claim key is known here, so the claim gets the default
+	// priority. The claim is synced anyway, just possibly after
+	// higher-priority claims.
+	ctrl.claimQueueForKey(claimKey).Add(claimKey, 0)
 }
 
 // updateClaim runs in worker thread and handles "claim added",
@@ -288,6 +482,8 @@ func (ctrl *PersistentVolumeController) deleteClaim(claim *v1.PersistentVolumeCl
 	// clean any possible unfinished provision start timestamp from cache
 	// Unit test [5-8] [5-9]
 	ctrl.operationTimestamps.Delete(claimKey)
+	// forget any provisioning failure history of the claim
+	ctrl.clearProvisionFailure(claimKey)
 
 	volumeName := claim.Spec.VolumeName
 	if volumeName == "" {
@@ -305,23 +501,54 @@ func (ctrl *PersistentVolumeController) deleteClaim(claim *v1.PersistentVolumeCl
 // Run starts all of this controller's control loops
 func (ctrl *PersistentVolumeController) Run(ctx context.Context) {
 	defer utilruntime.HandleCrash()
-	defer ctrl.claimQueue.ShutDown()
+	for _, queue := range ctrl.claimQueues {
+		defer queue.ShutDown()
+	}
 	defer ctrl.volumeQueue.ShutDown()
 
 	klog.Infof("Starting persistent volume controller")
 	defer klog.Infof("Shutting down persistent volume controller")
 
-	if !cache.WaitForNamedCacheSync("persistent volume", ctx.Done(), ctrl.volumeListerSynced, ctrl.claimListerSynced, ctrl.classListerSynced, ctrl.podListerSynced, ctrl.NodeListerSynced) {
+	caches := []cache.InformerSynced{ctrl.volumeListerSynced, ctrl.claimListerSynced, ctrl.classListerSynced, ctrl.NodeListerSynced}
+	if ctrl.podListerSynced != nil {
+		caches = append(caches, ctrl.podListerSynced)
+	}
+	if !cache.WaitForNamedCacheSync("persistent volume", ctx.Done(), caches...) {
 		return
 	}
 
 	ctrl.initializeCaches(ctrl.volumeLister, ctrl.claimLister)
 
 	go wait.Until(ctrl.resync, ctrl.resyncPeriod, ctx.Done())
+	if ctrl.statusCoalescer != nil {
+		go ctrl.statusCoalescer.run(ctx)
+	}
+	if ctrl.shardOwner != nil {
+		go ctrl.shardOwner.run(ctx)
+	}
+	if ctrl.storageSummaryInterval > 0 {
+		go wait.Until(func() { ctrl.publishStorageSummary(ctx) }, ctrl.storageSummaryInterval, ctx.Done())
+	}
+	if ctrl.releasedStuckThreshold > 0 {
+		metrics.SetReleasedStuckThreshold(ctrl.releasedStuckThreshold)
+		go wait.Until(ctrl.checkStuckReleasedVolumes, releasedStuckCheckInterval, ctx.Done())
+	}
 	go wait.UntilWithContext(ctx, ctrl.volumeWorker, time.Second)
-	go wait.UntilWithContext(ctx, ctrl.claimWorker, time.Second)
+	for _, queue := range ctrl.claimQueues {
+		queue := queue
+		go wait.UntilWithContext(ctx, func(ctx context.Context) { ctrl.claimWorker(ctx, queue) }, time.Second)
+	}
 
 	metrics.Register(ctrl.volumes.store, ctrl.claims, &ctrl.volumePluginMgr)
+	metrics.RegisterQueueDepthMetrics(
+		func() int {
+			depth := 0
+			for _, queue := range ctrl.claimQueues {
+				depth += queue.Len()
+			}
+			return depth
+		},
+		ctrl.volumeQueue.Len)
 
 	<-ctx.Done()
 }
@@ -541,16 +768,15 @@ func (ctrl *PersistentVolumeController) volumeWorker(ctx context.Context) {
 	}
 }
 
-// claimWorker processes items from claimQueue. It must run only once,
-// syncClaim is not reentrant.
-func (ctrl *PersistentVolumeController) claimWorker(ctx context.Context) {
+// claimWorker processes items from one claim queue shard. It must run only
+// once per shard, syncClaim is not reentrant.
+func (ctrl *PersistentVolumeController) claimWorker(ctx context.Context, queue *claimQueue) {
 	workFunc := func() bool {
-		keyObj, quit := ctrl.claimQueue.Get()
+		key, quit := queue.Get()
 		if quit {
 			return true
 		}
-		defer ctrl.claimQueue.Done(keyObj)
-		key := keyObj.(string)
+		defer queue.Done(key)
 		klog.V(5).Infof("claimWorker[%s]", key)
 
 		namespace, name, err := cache.SplitMetaNamespaceKey(key)
@@ -604,13 +830,15 @@ func (ctrl *PersistentVolumeController) claimWorker(ctx context.Context) {
 func (ctrl *PersistentVolumeController) resync() {
 	klog.V(4).Infof("resyncing PV controller")
 
+	ctrl.reportDeprecatedClassUsage()
+
 	pvcs, err := ctrl.claimLister.List(labels.NewSelector())
 	if err != nil {
 		klog.Warningf("cannot list claims: %s", err)
 		return
 	}
 	for _, pvc := range pvcs {
-		ctrl.enqueueWork(ctrl.claimQueue, pvc)
+		ctrl.enqueueClaimWork(pvc)
 	}
 
 	pvs, err := ctrl.volumeLister.List(labels.NewSelector())
@@ -778,3 +778,74 @@ func TestModifyDeletionFinalizers(t *testing.T) {
 		})
 	}
 }
+
+func TestClaimQueueSharding(t *testing.T) {
+	if queues := newClaimQueues(0); len(queues) != 1 {
+		t.Errorf("expected 0 workers to fall back to a single queue, got %d", len(queues))
+	}
+	ctrl := &PersistentVolumeController{claimQueues: newClaimQueues(4)}
+	if len(ctrl.claimQueues) != 4 {
+		t.Fatalf("expected 4 queues, got %d", len(ctrl.claimQueues))
+	}
+	// Claims in the same namespace always map to the same shard, no matter
+	// what the claim name is.
+	queue := ctrl.claimQueueForKey("ns1/claim-a")
+	if got := ctrl.claimQueueForKey("ns1/claim-b"); got != queue {
+		t.Errorf("claims in the same namespace mapped to different shards")
+	}
+	// The shard choice is deterministic across calls.
+	if got := ctrl.claimQueueForKey("ns1/claim-a"); got != queue {
+		t.Errorf("shard choice is not deterministic")
+	}
+}
+
+func TestClaimQueuePriorityOrdering(t *testing.T) {
+	queue := newClaimQueue()
+	queue.Add("ns1/bulk-1", 0)
+	queue.Add("ns1/bulk-2", 0)
+	queue.Add("ns1/system", 10)
+	// A key that is already queued is not queued twice.
+	queue.Add("ns1/bulk-1", 0)
+
+	expected := []string{"ns1/system", "ns1/bulk-1", "ns1/bulk-2"}
+	for _, want := range expected {
+		got, quit := queue.Get()
+		if quit {
+			t.Fatalf("queue unexpectedly shut down")
+		}
+		if got != want {
+			t.Errorf("expected key %q, got %q", want, got)
+		}
+		queue.Done(got)
+	}
+	if length := queue.Len(); length != 0 {
+		t.Errorf("expected empty queue, got %d keys", length)
+	}
+
+	// A key added while it is being processed is re-queued on Done.
+	queue.Add("ns1/bulk-1", 0)
+	key, _ := queue.Get()
+	queue.Add("ns1/bulk-1", 0)
+	if length := queue.Len(); length != 0 {
+		t.Errorf("expected key being processed not to be queued, got %d keys", length)
+	}
+	queue.Done(key)
+	if length := queue.Len(); length != 1 {
+		t.Errorf("expected key to be re-queued after Done, got %d keys", length)
+	}
+}
+
+func TestClaimProvisioningPriority(t *testing.T) {
+	claim := newClaim("claim-prio", "uid-prio", "1Gi", "", v1.ClaimPending, nil)
+	if got := claimProvisioningPriority(claim); got != 0 {
+		t.Errorf("expected default priority 0, got %d", got)
+	}
+	claim.Annotations = map[string]string{AnnProvisioningPriority: "100"}
+	if got := claimProvisioningPriority(claim); got != 100 {
+		t.Errorf("expected priority 100, got %d", got)
+	}
+	claim.Annotations[AnnProvisioningPriority] = "not-a-number"
+	if got := claimProvisioningPriority(claim); got != 0 {
+		t.Errorf("expected invalid annotation to count as priority 0, got %d", got)
+	}
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package persistentvolume
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	storage "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+// AnnReclaimPolicyOverride on a Namespace overrides the reclaim policy of
+// volumes dynamically provisioned for claims in that namespace, regardless
+// of the StorageClass default. Valid values are "Retain" and "Delete".
+// Multi-tenant operators use it to force Retain for namespaces whose data
+// must survive claim deletion.
+const AnnReclaimPolicyOverride = "volume.kubernetes.io/reclaim-policy-override"
+
+// reclaimPolicyForClaim returns the reclaim policy to apply to a volume
+// provisioned for the claim: the namespace override when one is set, the
+// StorageClass default otherwise.
+func (ctrl *PersistentVolumeController) reclaimPolicyForClaim(ctx context.Context, claim *v1.PersistentVolumeClaim, storageClass *storage.StorageClass) (v1.PersistentVolumeReclaimPolicy, error) {
+	namespace, err := ctrl.kubeClient.CoreV1().Namespaces().Get(ctx, claim.Namespace, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		// No namespace object means no override; don't block provisioning.
+		return *storageClass.ReclaimPolicy, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("cannot get namespace %q to check for a reclaim policy override: %v", claim.Namespace, err)
+	}
+	override, ok := namespace.Annotations[AnnReclaimPolicyOverride]
+	if !ok {
+		return *storageClass.ReclaimPolicy, nil
+	}
+	switch policy := v1.PersistentVolumeReclaimPolicy(override); policy {
+	case v1.PersistentVolumeReclaimRetain, v1.PersistentVolumeReclaimDelete:
+		if policy != *storageClass.ReclaimPolicy {
+			klog.V(4).Infof("claim %q: namespace overrides reclaim policy %q of StorageClass %q with %q", claimToClaimKey(claim), *storageClass.ReclaimPolicy, storageClass.Name, policy)
+		}
+		return policy, nil
+	default:
+		return "", fmt.Errorf("invalid %s annotation on namespace %q: %q is not a valid reclaim policy", AnnReclaimPolicyOverride, claim.Namespace, override)
+	}
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package persistentvolume
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	storage "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestReclaimPolicyForClaim(t *testing.T) {
+	classGold := "gold"
+	deletePolicy := v1.PersistentVolumeReclaimDelete
+
+	tests := []struct {
+		name           string
+		annotation     string
+		expectedPolicy v1.PersistentVolumeReclaimPolicy
+		hasError       bool
+	}{
+		{
+			name:           "no override uses the class default",
+			expectedPolicy: v1.PersistentVolumeReclaimDelete,
+		},
+		{
+			name:           "namespace forces Retain",
+			annotation:     string(v1.PersistentVolumeReclaimRetain),
+			expectedPolicy: v1.PersistentVolumeReclaimRetain,
+		},
+		{
+			name:           "override matching the class default is a no-op",
+			annotation:     string(v1.PersistentVolumeReclaimDelete),
+			expectedPolicy: v1.PersistentVolumeReclaimDelete,
+		},
+		{
+			name:       "invalid override is an error",
+			annotation: "Recycle",
+			hasError:   true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			namespace := &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testNamespace}}
+			if tc.annotation != "" {
+				namespace.Annotations = map[string]string{AnnReclaimPolicyOverride: tc.annotation}
+			}
+			fakeKubeClient := fake.NewSimpleClientset(namespace)
+			ctrl, err := newTestController(fakeKubeClient, nil, false)
+			if err != nil {
+				t.Fatalf("failed to construct controller: %v", err)
+			}
+			storageClass := &storage.StorageClass{
+				ObjectMeta:    metav1.ObjectMeta{Name: classGold},
+				ReclaimPolicy: &deletePolicy,
+			}
+			claim := newClaim("claim-1", "uid-1", "1Gi", "", v1.ClaimPending, &classGold)
+
+			policy, err := ctrl.reclaimPolicyForClaim(context.TODO(), claim, storageClass)
+			if tc.hasError {
+				if err == nil {
+					t.Error("expected error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if policy != tc.expectedPolicy {
+				t.Errorf("expected reclaim policy %q, got %q", tc.expectedPolicy, policy)
+			}
+		})
+	}
+}
+
+func TestReclaimPolicyForClaimWithoutNamespaceObject(t *testing.T) {
+	classGold := "gold"
+	deletePolicy := v1.PersistentVolumeReclaimDelete
+	ctrl, err := newTestController(fake.NewSimpleClientset(), nil, false)
+	if err != nil {
+		t.Fatalf("failed to construct controller: %v", err)
+	}
+	storageClass := &storage.StorageClass{
+		ObjectMeta:    metav1.ObjectMeta{Name: classGold},
+		ReclaimPolicy: &deletePolicy,
+	}
+	claim := newClaim("claim-1", "uid-1", "1Gi", "", v1.ClaimPending, &classGold)
+
+	policy, err := ctrl.reclaimPolicyForClaim(context.TODO(), claim, storageClass)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if policy != v1.PersistentVolumeReclaimDelete {
+		t.Errorf("expected the class default, got %q", policy)
+	}
+}
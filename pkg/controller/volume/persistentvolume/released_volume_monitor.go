@@ -0,0 +1,63 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package persistentvolume
+
+import (
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/controller/volume/events"
+	"k8s.io/kubernetes/pkg/controller/volume/persistentvolume/metrics"
+)
+
+// releasedStuckCheckInterval is how often the controller scans for volumes
+// stuck in phase Released.
+const releasedStuckCheckInterval = time.Minute
+
+// checkStuckReleasedVolumes emits a warning event on every volume that has
+// been in phase Released for longer than the configured threshold, so leaked
+// capacity surfaces in `kubectl describe pv` and event-based alerting without
+// scripting against the API. Each volume is warned once per stay in Released;
+// leaving the phase arms the warning again. The method runs on a single
+// goroutine, so releasedStuckWarned needs no locking.
+func (ctrl *PersistentVolumeController) checkStuckReleasedVolumes() {
+	volumes, err := ctrl.volumeLister.List(labels.Everything())
+	if err != nil {
+		klog.V(2).Infof("cannot list volumes to check for stuck Released volumes: %v", err)
+		return
+	}
+	if ctrl.releasedStuckWarned == nil {
+		ctrl.releasedStuckWarned = map[string]bool{}
+	}
+	for _, volume := range volumes {
+		if volume.Status.Phase != v1.VolumeReleased {
+			delete(ctrl.releasedStuckWarned, volume.Name)
+			continue
+		}
+		age := time.Since(metrics.ReleasedSince(volume.Name))
+		if age < ctrl.releasedStuckThreshold || ctrl.releasedStuckWarned[volume.Name] {
+			continue
+		}
+		ctrl.eventRecorder.Event(volume, v1.EventTypeWarning, events.VolumeStuckReleased,
+			fmt.Sprintf("Volume has been in phase Released for %v (reclaim policy %s); its storage may be leaked",
+				age.Round(time.Second), volume.Spec.PersistentVolumeReclaimPolicy))
+		ctrl.releasedStuckWarned[volume.Name] = true
+	}
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package persistentvolume
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/kubernetes/pkg/controller"
+	"k8s.io/kubernetes/pkg/controller/volume/events"
+)
+
+func TestCheckStuckReleasedVolumes(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	informerFactory := informers.NewSharedInformerFactory(client, controller.NoResyncPeriodFunc())
+	ctrl, err := newTestController(client, informerFactory, false)
+	if err != nil {
+		t.Fatalf("failed to construct controller: %v", err)
+	}
+	ctrl.releasedStuckThreshold = time.Nanosecond
+
+	volume := newVolume("volume-stuck", "1Gi", "uid-stuck", "claim-stuck", v1.VolumeReleased, v1.PersistentVolumeReclaimDelete, "")
+	volumeIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	if err := volumeIndexer.Add(volume); err != nil {
+		t.Fatalf("failed to add volume: %v", err)
+	}
+	ctrl.volumeLister = corelisters.NewPersistentVolumeLister(volumeIndexer)
+
+	recorder := ctrl.eventRecorder.(*record.FakeRecorder)
+	nextEvent := func() string {
+		select {
+		case event := <-recorder.Events:
+			return event
+		default:
+			return ""
+		}
+	}
+
+	// Give the volume a measurable stay in phase Released.
+	time.Sleep(10 * time.Millisecond)
+
+	// The first check warns about the stuck volume.
+	ctrl.checkStuckReleasedVolumes()
+	if event := nextEvent(); !strings.Contains(event, events.VolumeStuckReleased) {
+		t.Errorf("expected a %s event, got %q", events.VolumeStuckReleased, event)
+	}
+
+	// A second check must not warn again about the same stay.
+	ctrl.checkStuckReleasedVolumes()
+	if event := nextEvent(); event != "" {
+		t.Errorf("expected no event on a repeated check, got %q", event)
+	}
+
+	// Leaving phase Released arms the warning again.
+	availableVolume := volume.DeepCopy()
+	availableVolume.Status.Phase = v1.VolumeAvailable
+	if err := volumeIndexer.Update(availableVolume); err != nil {
+		t.Fatalf("failed to update volume: %v", err)
+	}
+	ctrl.checkStuckReleasedVolumes()
+	if event := nextEvent(); event != "" {
+		t.Errorf("expected no event for an Available volume, got %q", event)
+	}
+	if err := volumeIndexer.Update(volume); err != nil {
+		t.Fatalf("failed to update volume: %v", err)
+	}
+	ctrl.checkStuckReleasedVolumes()
+	if event := nextEvent(); !strings.Contains(event, events.VolumeStuckReleased) {
+		t.Errorf("expected a %s event after the volume was released again, got %q", events.VolumeStuckReleased, event)
+	}
+}
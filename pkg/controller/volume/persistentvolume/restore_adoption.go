@@ -0,0 +1,97 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package persistentvolume
+
+import (
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	storagehelpers "k8s.io/component-helpers/storage/volume"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// LabelRestoreID on a persistent volume marks it as created out of
+	// band by backup restore or DR tooling, carrying the restore run it
+	// belongs to. It is a label so restored volumes stay selectable.
+	LabelRestoreID = "volume.kubernetes.io/restore-id"
+
+	// AnnRestoreID on a claim makes the controller adopt an Available
+	// volume labeled with the same restore ID instead of provisioning a
+	// new one. The volume must still satisfy the claim's size, access
+	// modes and volume mode; the storage class is deliberately not
+	// compared, see restoreRejectionReason.
+	AnnRestoreID = "volume.kubernetes.io/restore-id"
+)
+
+// findRestoredVolume returns an Available volume labeled with the claim's
+// restore ID that satisfies the claim, or nil when the claim carries no
+// restore ID or no such volume exists. Candidates are tried in name order
+// for deterministic adoption.
+func (ctrl *PersistentVolumeController) findRestoredVolume(claim *v1.PersistentVolumeClaim) *v1.PersistentVolume {
+	restoreID := claim.Annotations[AnnRestoreID]
+	if restoreID == "" {
+		return nil
+	}
+
+	var candidates []*v1.PersistentVolume
+	for _, obj := range ctrl.volumes.store.List() {
+		volume, ok := obj.(*v1.PersistentVolume)
+		if !ok {
+			continue
+		}
+		if volume.Labels[LabelRestoreID] != restoreID {
+			continue
+		}
+		if reason := restoreRejectionReason(claim, volume); reason != "" {
+			klog.V(4).Infof("restored volume %q does not fit claim %q: %s", volume.Name, claimToClaimKey(claim), reason)
+			continue
+		}
+		candidates = append(candidates, volume)
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].Name < candidates[j].Name })
+	return candidates[0]
+}
+
+// restoreRejectionReason returns why a restored volume cannot be adopted
+// by the claim, or an empty string when it can. Unlike the regular
+// matcher, the storage class is deliberately not compared: the restore ID
+// is authoritative, and restore tooling often cannot reproduce the class
+// of the original volume.
+func restoreRejectionReason(claim *v1.PersistentVolumeClaim, volume *v1.PersistentVolume) string {
+	if volume.Spec.ClaimRef != nil && !storagehelpers.IsVolumeBoundToClaim(volume, claim) {
+		return "bound to another claim"
+	}
+	if volume.Status.Phase != v1.VolumeAvailable {
+		return "volume is not Available"
+	}
+	if storagehelpers.CheckVolumeModeMismatches(&claim.Spec, &volume.Spec) {
+		return "volume mode does not match the requested mode"
+	}
+	if !storagehelpers.CheckAccessModes(claim, volume) {
+		return "volume does not support the requested access modes"
+	}
+	volumeSize := volume.Spec.Capacity[v1.ResourceStorage]
+	requestedSize := claim.Spec.Resources.Requests[v1.ResourceStorage]
+	if volumeSize.Cmp(requestedSize) < 0 {
+		return "capacity is smaller than requested"
+	}
+	return ""
+}
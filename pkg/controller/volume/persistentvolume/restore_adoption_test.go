@@ -0,0 +1,106 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package persistentvolume
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newRestoredVolume(name, capacity, restoreID string) *v1.PersistentVolume {
+	volume := newVolume(name, capacity, "", "", v1.VolumeAvailable, v1.PersistentVolumeReclaimRetain, "")
+	volume.Labels = map[string]string{LabelRestoreID: restoreID}
+	return volume
+}
+
+func TestFindRestoredVolume(t *testing.T) {
+	classGold := "gold"
+
+	tests := []struct {
+		name           string
+		restoreID      string
+		volumes        []*v1.PersistentVolume
+		expectedVolume string
+	}{
+		{
+			name:           "claim without restore ID adopts nothing",
+			volumes:        []*v1.PersistentVolume{newRestoredVolume("volume-1", "10Gi", "restore-1")},
+			expectedVolume: "",
+		},
+		{
+			name:           "matching restore ID is adopted",
+			restoreID:      "restore-1",
+			volumes:        []*v1.PersistentVolume{newRestoredVolume("volume-1", "10Gi", "restore-1")},
+			expectedVolume: "volume-1",
+		},
+		{
+			name:           "other restore IDs are ignored",
+			restoreID:      "restore-1",
+			volumes:        []*v1.PersistentVolume{newRestoredVolume("volume-1", "10Gi", "restore-2")},
+			expectedVolume: "",
+		},
+		{
+			name:           "too small restored volume is rejected",
+			restoreID:      "restore-1",
+			volumes:        []*v1.PersistentVolume{newRestoredVolume("volume-1", "500Mi", "restore-1")},
+			expectedVolume: "",
+		},
+		{
+			name:      "first candidate by name wins",
+			restoreID: "restore-1",
+			volumes: []*v1.PersistentVolume{
+				newRestoredVolume("volume-b", "10Gi", "restore-1"),
+				newRestoredVolume("volume-a", "10Gi", "restore-1"),
+			},
+			expectedVolume: "volume-a",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl, err := newTestController(fake.NewSimpleClientset(), nil, false)
+			if err != nil {
+				t.Fatalf("failed to construct controller: %v", err)
+			}
+			for _, volume := range tc.volumes {
+				ctrl.volumes.store.Add(volume)
+			}
+			// The claim requests a class the restored volumes do not have;
+			// restore adoption must not compare classes.
+			claim := newClaim("claim-1", "uid-1", "1Gi", "", v1.ClaimPending, &classGold)
+			if tc.restoreID != "" {
+				claim.Annotations = map[string]string{AnnRestoreID: tc.restoreID}
+			}
+
+			restored := ctrl.findRestoredVolume(claim)
+			if tc.expectedVolume == "" {
+				if restored != nil {
+					t.Errorf("expected no adoption, got %q", restored.Name)
+				}
+				return
+			}
+			if restored == nil {
+				t.Fatalf("expected to adopt %q, got nothing", tc.expectedVolume)
+			}
+			if restored.Name != tc.expectedVolume {
+				t.Errorf("expected to adopt %q, got %q", tc.expectedVolume, restored.Name)
+			}
+		})
+	}
+}
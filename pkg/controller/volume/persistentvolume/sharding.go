@@ -0,0 +1,207 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package persistentvolume
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// shardLeaseNamePrefix prefixes the Lease objects coordinating shard
+	// ownership; the shard number is appended.
+	shardLeaseNamePrefix = "pv-controller-shard-"
+
+	// defaultShardLeaseDuration is how long a shard stays owned without a
+	// renewal before another replica may take it over.
+	defaultShardLeaseDuration = 15 * time.Second
+)
+
+// ShardingConfig makes the PV controller run active in several manager
+// replicas at once, each owning a deterministic shard of the volumes and
+// claims. Objects hash to shards by UID, and shard ownership is
+// coordinated through Lease objects so shards fail over when a replica
+// dies.
+type ShardingConfig struct {
+	// TotalShards is the number of shards the key space is split into.
+	// It must be identical in all replicas.
+	TotalShards int
+	// Identity uniquely names this replica in the shard leases, e.g. the
+	// pod name.
+	Identity string
+	// LeaseNamespace is where the shard leases live. Defaults to
+	// kube-system.
+	LeaseNamespace string
+	// LeaseDuration is how long an unrenewed shard lease is honored
+	// before another replica takes the shard over. Defaults to 15s.
+	LeaseDuration time.Duration
+}
+
+// shardOwner tracks which shards this replica currently owns. Ownership
+// is refreshed in the background; lookups only read the local state, so
+// the sync paths never wait on the API server.
+type shardOwner struct {
+	kubeClient     clientset.Interface
+	totalShards    int
+	identity       string
+	leaseNamespace string
+	leaseDuration  time.Duration
+
+	mutex sync.RWMutex
+	owned map[int]bool
+}
+
+func newShardOwner(kubeClient clientset.Interface, config ShardingConfig) *shardOwner {
+	if config.LeaseNamespace == "" {
+		config.LeaseNamespace = metav1.NamespaceSystem
+	}
+	if config.LeaseDuration == 0 {
+		config.LeaseDuration = defaultShardLeaseDuration
+	}
+	return &shardOwner{
+		kubeClient:     kubeClient,
+		totalShards:    config.TotalShards,
+		identity:       config.Identity,
+		leaseNamespace: config.LeaseNamespace,
+		leaseDuration:  config.LeaseDuration,
+		owned:          map[int]bool{},
+	}
+}
+
+// run refreshes shard ownership until the context is cancelled. Leases
+// are renewed at a third of their duration, the same ratio the leader
+// election defaults use.
+func (s *shardOwner) run(ctx context.Context) {
+	wait.UntilWithContext(ctx, s.sync, s.leaseDuration/3)
+}
+
+// sync tries to acquire or renew every shard lease and records the
+// outcome. Failures leave the previous local state in place: a transient
+// API error must not make the replica drop work it still holds the lease
+// for.
+func (s *shardOwner) sync(ctx context.Context) {
+	for shard := 0; shard < s.totalShards; shard++ {
+		owned, err := s.tryAcquireShard(ctx, shard)
+		if err != nil {
+			klog.V(3).Infof("cannot acquire shard %d: %v", shard, err)
+			continue
+		}
+		s.mutex.Lock()
+		if owned != s.owned[shard] {
+			klog.V(2).Infof("shard %d ownership changed: owned=%v", shard, owned)
+		}
+		s.owned[shard] = owned
+		s.mutex.Unlock()
+	}
+}
+
+// tryAcquireShard acquires the lease of one shard when it is free or
+// expired, renews it when this replica holds it, and reports whether the
+// shard is owned afterwards.
+func (s *shardOwner) tryAcquireShard(ctx context.Context, shard int) (bool, error) {
+	leases := s.kubeClient.CoordinationV1().Leases(s.leaseNamespace)
+	name := fmt.Sprintf("%s%d", shardLeaseNamePrefix, shard)
+	now := metav1.NewMicroTime(time.Now())
+	durationSeconds := int32(s.leaseDuration / time.Second)
+
+	lease, err := leases.Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		lease = &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: s.leaseNamespace},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       &s.identity,
+				LeaseDurationSeconds: &durationSeconds,
+				AcquireTime:          &now,
+				RenewTime:            &now,
+			},
+		}
+		if _, err := leases.Create(ctx, lease, metav1.CreateOptions{}); err != nil {
+			if apierrors.IsAlreadyExists(err) {
+				// Another replica won the race for this shard.
+				return false, nil
+			}
+			return false, err
+		}
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	holder := ""
+	if lease.Spec.HolderIdentity != nil {
+		holder = *lease.Spec.HolderIdentity
+	}
+	if holder != s.identity && holder != "" && !leaseExpired(lease, now.Time) {
+		return false, nil
+	}
+
+	// The lease is ours, free, or expired: take it.
+	clone := lease.DeepCopy()
+	clone.Spec.HolderIdentity = &s.identity
+	clone.Spec.LeaseDurationSeconds = &durationSeconds
+	clone.Spec.RenewTime = &now
+	if holder != s.identity {
+		clone.Spec.AcquireTime = &now
+	}
+	if _, err := leases.Update(ctx, clone, metav1.UpdateOptions{}); err != nil {
+		if apierrors.IsConflict(err) {
+			// Another replica updated the lease first.
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// leaseExpired reports whether the lease's renew time is older than its
+// duration.
+func leaseExpired(lease *coordinationv1.Lease, now time.Time) bool {
+	if lease.Spec.RenewTime == nil || lease.Spec.LeaseDurationSeconds == nil {
+		return true
+	}
+	expiry := lease.Spec.RenewTime.Add(time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second)
+	return expiry.Before(now)
+}
+
+// owns reports whether the object with the given UID falls into a shard
+// this replica currently owns.
+func (s *shardOwner) owns(uid string) bool {
+	shard := shardForKey(uid, s.totalShards)
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.owned[shard]
+}
+
+// shardForKey deterministically maps a key to a shard. Every replica must
+// compute the same mapping, so this may never change for a given shard
+// count.
+func shardForKey(key string, totalShards int) int {
+	hash := fnv.New32a()
+	hash.Write([]byte(key))
+	return int(hash.Sum32() % uint32(totalShards))
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package persistentvolume
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestShardForKeyIsDeterministic(t *testing.T) {
+	shard := shardForKey("uid-1", 4)
+	for i := 0; i < 10; i++ {
+		if got := shardForKey("uid-1", 4); got != shard {
+			t.Fatalf("expected a stable shard, got %d and %d", shard, got)
+		}
+	}
+	if shard < 0 || shard >= 4 {
+		t.Errorf("expected a shard in [0,4), got %d", shard)
+	}
+}
+
+func TestShardOwnerAcquiresFreeShards(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	owner := newShardOwner(client, ShardingConfig{TotalShards: 2, Identity: "replica-a"})
+
+	owner.sync(context.TODO())
+
+	for shard := 0; shard < 2; shard++ {
+		owner.mutex.RLock()
+		owned := owner.owned[shard]
+		owner.mutex.RUnlock()
+		if !owned {
+			t.Errorf("expected shard %d to be owned after syncing against an empty cluster", shard)
+		}
+	}
+	lease, err := client.CoordinationV1().Leases(metav1.NamespaceSystem).Get(context.TODO(), "pv-controller-shard-0", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected the shard lease to be created: %v", err)
+	}
+	if lease.Spec.HolderIdentity == nil || *lease.Spec.HolderIdentity != "replica-a" {
+		t.Errorf("expected replica-a to hold the lease, got %v", lease.Spec.HolderIdentity)
+	}
+}
+
+func TestShardOwnerRespectsUnexpiredLeases(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	other := newShardOwner(client, ShardingConfig{TotalShards: 1, Identity: "replica-a"})
+	other.sync(context.TODO())
+
+	owner := newShardOwner(client, ShardingConfig{TotalShards: 1, Identity: "replica-b"})
+	owner.sync(context.TODO())
+
+	owner.mutex.RLock()
+	owned := owner.owned[0]
+	owner.mutex.RUnlock()
+	if owned {
+		t.Error("expected replica-b not to steal an unexpired lease")
+	}
+	if owner.owns("uid-1") {
+		t.Error("expected owns to reflect the lost shard")
+	}
+}
+
+func TestShardOwnerTakesOverExpiredLeases(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	other := newShardOwner(client, ShardingConfig{TotalShards: 1, Identity: "replica-a"})
+	other.sync(context.TODO())
+
+	// Age the lease past its duration.
+	lease, err := client.CoordinationV1().Leases(metav1.NamespaceSystem).Get(context.TODO(), "pv-controller-shard-0", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expired := metav1.NewMicroTime(time.Now().Add(-time.Minute))
+	lease.Spec.RenewTime = &expired
+	if _, err := client.CoordinationV1().Leases(metav1.NamespaceSystem).Update(context.TODO(), lease, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	owner := newShardOwner(client, ShardingConfig{TotalShards: 1, Identity: "replica-b"})
+	owner.sync(context.TODO())
+
+	owner.mutex.RLock()
+	owned := owner.owned[0]
+	owner.mutex.RUnlock()
+	if !owned {
+		t.Error("expected replica-b to take over the expired lease")
+	}
+	lease, err = client.CoordinationV1().Leases(metav1.NamespaceSystem).Get(context.TODO(), "pv-controller-shard-0", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if lease.Spec.HolderIdentity == nil || *lease.Spec.HolderIdentity != "replica-b" {
+		t.Errorf("expected replica-b to hold the lease, got %v", lease.Spec.HolderIdentity)
+	}
+}
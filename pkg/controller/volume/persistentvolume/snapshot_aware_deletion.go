@@ -0,0 +1,122 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package persistentvolume
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	storagehelpers "k8s.io/component-helpers/storage/volume"
+	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/controller/volume/events"
+)
+
+// classParamSnapshotAwareDeletion is the StorageClass parameter that
+// overrides the controller-wide SnapshotAwareDeletion setting for volumes of
+// that class. Its value is "true" or "false".
+const classParamSnapshotAwareDeletion = "snapshotAwareDeletion"
+
+// volumeSnapshotContentResource identifies the VolumeSnapshotContent objects
+// maintained by the external snapshot controller. The API is a CRD, so the
+// controller reads it through a dynamic client instead of a typed lister.
+var volumeSnapshotContentResource = schema.GroupVersionResource{
+	Group:    "snapshot.storage.k8s.io",
+	Version:  "v1",
+	Resource: "volumesnapshotcontents",
+}
+
+// snapshotAwareDeletionForVolume returns whether the deleter has to check for
+// dependent snapshot contents before deleting the volume: the volume's
+// StorageClass parameter when one is set and parses, the controller-wide
+// default otherwise.
+func (ctrl *PersistentVolumeController) snapshotAwareDeletionForVolume(volume *v1.PersistentVolume) bool {
+	enabled := ctrl.snapshotAwareDeletion
+	className := storagehelpers.GetPersistentVolumeClass(volume)
+	if className == "" {
+		return enabled
+	}
+	class, err := ctrl.classLister.Get(className)
+	if err != nil {
+		// No class means no override; the controller-wide default applies.
+		return enabled
+	}
+	value, ok := class.Parameters[classParamSnapshotAwareDeletion]
+	if !ok {
+		return enabled
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		klog.V(2).Infof("ignoring invalid %s parameter %q of StorageClass %q: %v", classParamSnapshotAwareDeletion, value, className, err)
+		return enabled
+	}
+	return parsed
+}
+
+// dependentSnapshotContents returns the names of the VolumeSnapshotContent
+// objects that reference the volume. Only CSI volumes can be referenced: a
+// snapshot content points at its source volume through the CSI driver name
+// and volume handle.
+func (ctrl *PersistentVolumeController) dependentSnapshotContents(volume *v1.PersistentVolume) ([]string, error) {
+	if volume.Spec.CSI == nil {
+		return nil, nil
+	}
+	list, err := ctrl.snapshotContentClient.Resource(volumeSnapshotContentResource).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	var contents []string
+	for _, item := range list.Items {
+		driver, _, err := unstructured.NestedString(item.Object, "spec", "driver")
+		if err != nil || driver != volume.Spec.CSI.Driver {
+			continue
+		}
+		volumeHandle, _, err := unstructured.NestedString(item.Object, "spec", "source", "volumeHandle")
+		if err != nil || volumeHandle == "" || volumeHandle != volume.Spec.CSI.VolumeHandle {
+			continue
+		}
+		contents = append(contents, item.GetName())
+	}
+	sort.Strings(contents)
+	return contents, nil
+}
+
+// holdDeletionForSnapshots returns true when the volume's deletion has to
+// wait for dependent snapshot contents to be removed. It emits an event
+// naming the blocking contents; the periodic sync retries the deletion until
+// they are gone.
+func (ctrl *PersistentVolumeController) holdDeletionForSnapshots(volume *v1.PersistentVolume) (bool, error) {
+	if ctrl.snapshotContentClient == nil || !ctrl.snapshotAwareDeletionForVolume(volume) {
+		return false, nil
+	}
+	contents, err := ctrl.dependentSnapshotContents(volume)
+	if err != nil {
+		return false, fmt.Errorf("listing snapshot contents of volume %q: %v", volume.Name, err)
+	}
+	if len(contents) == 0 {
+		return false, nil
+	}
+	klog.V(2).Infof("volume %q deletion is on hold: referenced by snapshot contents %s", volume.Name, strings.Join(contents, ", "))
+	ctrl.eventRecorder.Event(volume, v1.EventTypeWarning, events.DeletionBlockedOnSnapshots, fmt.Sprintf("Volume deletion is on hold until VolumeSnapshotContent objects referencing it are removed: %s", strings.Join(contents, ", ")))
+	return true, nil
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package persistentvolume
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/kubernetes/pkg/controller"
+)
+
+func newSnapshotAwareTestController(t *testing.T, defaultEnabled bool, contents []runtime.Object, classes ...*storagev1.StorageClass) *PersistentVolumeController {
+	t.Helper()
+	client := fake.NewSimpleClientset()
+	informerFactory := informers.NewSharedInformerFactory(client, controller.NoResyncPeriodFunc())
+	ctrl, err := newTestController(client, informerFactory, false)
+	if err != nil {
+		t.Fatalf("Construct PersistentVolume controller failed: %v", err)
+	}
+	ctrl.snapshotAwareDeletion = defaultEnabled
+	ctrl.snapshotContentClient = dynamicfake.NewSimpleDynamicClientWithCustomListKinds(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{volumeSnapshotContentResource: "VolumeSnapshotContentList"},
+		contents...)
+	for _, class := range classes {
+		if err := informerFactory.Storage().V1().StorageClasses().Informer().GetIndexer().Add(class); err != nil {
+			t.Fatalf("cannot add StorageClass to informer: %v", err)
+		}
+	}
+	return ctrl
+}
+
+func newSnapshotContent(name, driver, volumeHandle string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "snapshot.storage.k8s.io/v1",
+			"kind":       "VolumeSnapshotContent",
+			"metadata": map[string]interface{}{
+				"name": name,
+			},
+			"spec": map[string]interface{}{
+				"driver": driver,
+				"source": map[string]interface{}{
+					"volumeHandle": volumeHandle,
+				},
+			},
+		},
+	}
+}
+
+func newCSIVolume(name, driver, volumeHandle, class string) *v1.PersistentVolume {
+	volume := newVolume(name, "1Gi", "uid-"+name, "claim-"+name, v1.VolumeReleased, v1.PersistentVolumeReclaimDelete, class)
+	volume.Spec.PersistentVolumeSource = v1.PersistentVolumeSource{
+		CSI: &v1.CSIPersistentVolumeSource{
+			Driver:       driver,
+			VolumeHandle: volumeHandle,
+		},
+	}
+	return volume
+}
+
+func TestSnapshotAwareDeletionForVolume(t *testing.T) {
+	onClass := &storagev1.StorageClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "on"},
+		Parameters: map[string]string{classParamSnapshotAwareDeletion: "true"},
+	}
+	offClass := &storagev1.StorageClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "off"},
+		Parameters: map[string]string{classParamSnapshotAwareDeletion: "false"},
+	}
+	brokenClass := &storagev1.StorageClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "broken"},
+		Parameters: map[string]string{classParamSnapshotAwareDeletion: "maybe"},
+	}
+
+	tests := []struct {
+		name           string
+		defaultEnabled bool
+		class          string
+		expected       bool
+	}{
+		{"controller default off without class", false, "", false},
+		{"controller default on without class", true, "", true},
+		{"class parameter enables the check", false, "on", true},
+		{"class parameter disables the check", true, "off", false},
+		{"invalid class parameter falls back to default", false, "broken", false},
+		{"unknown class falls back to default", true, "silver", true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := newSnapshotAwareTestController(t, test.defaultEnabled, nil, onClass, offClass, brokenClass)
+			volume := newCSIVolume("volume-snap", "csi.example.com", "handle-1", test.class)
+			if enabled := ctrl.snapshotAwareDeletionForVolume(volume); enabled != test.expected {
+				t.Errorf("expected snapshot aware deletion %v, got %v", test.expected, enabled)
+			}
+		})
+	}
+}
+
+func TestHoldDeletionForSnapshots(t *testing.T) {
+	tests := []struct {
+		name         string
+		enabled      bool
+		volume       *v1.PersistentVolume
+		contents     []runtime.Object
+		expectedHeld bool
+	}{
+		{
+			name:         "check disabled ignores matching content",
+			enabled:      false,
+			volume:       newCSIVolume("volume-1", "csi.example.com", "handle-1", ""),
+			contents:     []runtime.Object{newSnapshotContent("content-1", "csi.example.com", "handle-1")},
+			expectedHeld: false,
+		},
+		{
+			name:         "matching content holds deletion",
+			enabled:      true,
+			volume:       newCSIVolume("volume-2", "csi.example.com", "handle-2", ""),
+			contents:     []runtime.Object{newSnapshotContent("content-2", "csi.example.com", "handle-2")},
+			expectedHeld: true,
+		},
+		{
+			name:         "content of another volume does not hold deletion",
+			enabled:      true,
+			volume:       newCSIVolume("volume-3", "csi.example.com", "handle-3", ""),
+			contents:     []runtime.Object{newSnapshotContent("content-3", "csi.example.com", "other-handle")},
+			expectedHeld: false,
+		},
+		{
+			name:         "content of another driver does not hold deletion",
+			enabled:      true,
+			volume:       newCSIVolume("volume-4", "csi.example.com", "handle-4", ""),
+			contents:     []runtime.Object{newSnapshotContent("content-4", "other.example.com", "handle-4")},
+			expectedHeld: false,
+		},
+		{
+			name:         "non-CSI volume is never held",
+			enabled:      true,
+			volume:       newVolume("volume-5", "1Gi", "uid-5", "claim-5", v1.VolumeReleased, v1.PersistentVolumeReclaimDelete, ""),
+			contents:     []runtime.Object{newSnapshotContent("content-5", "csi.example.com", "handle-5")},
+			expectedHeld: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := newSnapshotAwareTestController(t, test.enabled, test.contents)
+			held, err := ctrl.holdDeletionForSnapshots(test.volume)
+			if err != nil {
+				t.Fatalf("holdDeletionForSnapshots failed: %v", err)
+			}
+			if held != test.expectedHeld {
+				t.Errorf("expected held=%v, got %v", test.expectedHeld, held)
+			}
+		})
+	}
+}
+
+func TestHoldDeletionForSnapshotsWithoutClient(t *testing.T) {
+	ctrl := newSnapshotAwareTestController(t, true, nil)
+	ctrl.snapshotContentClient = nil
+	volume := newCSIVolume("volume-no-client", "csi.example.com", "handle-1", "")
+	held, err := ctrl.holdDeletionForSnapshots(volume)
+	if err != nil {
+		t.Fatalf("holdDeletionForSnapshots failed: %v", err)
+	}
+	if held {
+		t.Error("expected deletion to proceed without a snapshot content client")
+	}
+}
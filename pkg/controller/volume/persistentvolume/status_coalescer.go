@@ -0,0 +1,194 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package persistentvolume
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	corev1apply "k8s.io/client-go/applyconfigurations/core/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// statusFieldManager owns the status fields the coalescer applies.
+	statusFieldManager = "persistentvolume-binder"
+
+	// statusFlushInterval is how often pending status updates are flushed to
+	// the API server.
+	statusFlushInterval = 100 * time.Millisecond
+)
+
+// pendingVolumeStatus is the desired status of one volume.
+type pendingVolumeStatus struct {
+	phase   v1.PersistentVolumePhase
+	message string
+}
+
+// pendingClaimStatus is the desired status of one claim.
+type pendingClaimStatus struct {
+	namespace   string
+	name        string
+	phase       v1.PersistentVolumeClaimPhase
+	accessModes []v1.PersistentVolumeAccessMode
+	capacity    v1.ResourceList
+}
+
+// statusCoalescer batches PV and PVC status updates. Instead of one
+// UPDATE call per status change, syncs enqueue the desired status and a
+// background loop applies it with server-side apply; updates superseded or
+// repeated within one flush interval collapse into a single PATCH. This
+// keeps status churn from dominating the API server write load in large
+// clusters.
+type statusCoalescer struct {
+	kubeClient    clientset.Interface
+	flushInterval time.Duration
+
+	mutex sync.Mutex
+	// volumes and claims hold the desired statuses not yet applied, keyed
+	// by volume name and by claim "namespace/name" respectively.
+	volumes map[string]pendingVolumeStatus
+	claims  map[string]pendingClaimStatus
+	// appliedVolumes and appliedClaims remember the last applied statuses
+	// to drop redundant re-enqueues.
+	appliedVolumes map[string]pendingVolumeStatus
+	appliedClaims  map[string]pendingClaimStatus
+}
+
+func newStatusCoalescer(kubeClient clientset.Interface, flushInterval time.Duration) *statusCoalescer {
+	return &statusCoalescer{
+		kubeClient:     kubeClient,
+		flushInterval:  flushInterval,
+		volumes:        map[string]pendingVolumeStatus{},
+		claims:         map[string]pendingClaimStatus{},
+		appliedVolumes: map[string]pendingVolumeStatus{},
+		appliedClaims:  map[string]pendingClaimStatus{},
+	}
+}
+
+// run flushes pending status updates until the context is cancelled.
+func (c *statusCoalescer) run(ctx context.Context) {
+	wait.UntilWithContext(ctx, c.flush, c.flushInterval)
+}
+
+// enqueueVolumePhase records the desired phase and message of the volume,
+// replacing any not yet applied status for it.
+func (c *statusCoalescer) enqueueVolumePhase(volumeName string, phase v1.PersistentVolumePhase, message string) {
+	desired := pendingVolumeStatus{phase: phase, message: message}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if _, pending := c.volumes[volumeName]; !pending && c.appliedVolumes[volumeName] == desired {
+		return
+	}
+	c.volumes[volumeName] = desired
+}
+
+// enqueueClaimStatus records the desired status of the claim, replacing any
+// not yet applied status for it.
+func (c *statusCoalescer) enqueueClaimStatus(claim *v1.PersistentVolumeClaim) {
+	desired := pendingClaimStatus{
+		namespace:   claim.Namespace,
+		name:        claim.Name,
+		phase:       claim.Status.Phase,
+		accessModes: claim.Status.AccessModes,
+		capacity:    claim.Status.Capacity,
+	}
+	key := claimToClaimKey(claim)
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if _, pending := c.claims[key]; !pending && reflect.DeepEqual(c.appliedClaims[key], desired) {
+		return
+	}
+	c.claims[key] = desired
+}
+
+// flush applies all pending statuses with server-side apply. Failed updates
+// are kept for the next flush unless they were superseded in the meantime.
+func (c *statusCoalescer) flush(ctx context.Context) {
+	c.mutex.Lock()
+	volumes := c.volumes
+	claims := c.claims
+	c.volumes = map[string]pendingVolumeStatus{}
+	c.claims = map[string]pendingClaimStatus{}
+	c.mutex.Unlock()
+
+	for volumeName, desired := range volumes {
+		applyConfig := corev1apply.PersistentVolume(volumeName).
+			WithStatus(corev1apply.PersistentVolumeStatus().
+				WithPhase(desired.phase).
+				WithMessage(desired.message))
+		_, err := c.kubeClient.CoreV1().PersistentVolumes().ApplyStatus(ctx, applyConfig, metav1.ApplyOptions{FieldManager: statusFieldManager, Force: true})
+		if err != nil {
+			klog.V(4).Infof("coalesced status update of volume %q failed, will retry: %v", volumeName, err)
+			c.requeueVolume(volumeName, desired)
+			continue
+		}
+		c.markVolumeApplied(volumeName, desired)
+	}
+
+	for key, desired := range claims {
+		status := corev1apply.PersistentVolumeClaimStatus().WithPhase(desired.phase)
+		if len(desired.accessModes) > 0 {
+			status = status.WithAccessModes(desired.accessModes...)
+		}
+		if desired.capacity != nil {
+			status = status.WithCapacity(desired.capacity)
+		}
+		applyConfig := corev1apply.PersistentVolumeClaim(desired.name, desired.namespace).WithStatus(status)
+		_, err := c.kubeClient.CoreV1().PersistentVolumeClaims(desired.namespace).ApplyStatus(ctx, applyConfig, metav1.ApplyOptions{FieldManager: statusFieldManager, Force: true})
+		if err != nil {
+			klog.V(4).Infof("coalesced status update of claim %q failed, will retry: %v", key, err)
+			c.requeueClaim(key, desired)
+			continue
+		}
+		c.markClaimApplied(key, desired)
+	}
+}
+
+func (c *statusCoalescer) requeueVolume(volumeName string, desired pendingVolumeStatus) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if _, pending := c.volumes[volumeName]; !pending {
+		c.volumes[volumeName] = desired
+	}
+}
+
+func (c *statusCoalescer) requeueClaim(key string, desired pendingClaimStatus) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if _, pending := c.claims[key]; !pending {
+		c.claims[key] = desired
+	}
+}
+
+func (c *statusCoalescer) markVolumeApplied(volumeName string, desired pendingVolumeStatus) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.appliedVolumes[volumeName] = desired
+}
+
+func (c *statusCoalescer) markClaimApplied(key string, desired pendingClaimStatus) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.appliedClaims[key] = desired
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package persistentvolume
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	coretesting "k8s.io/client-go/testing"
+)
+
+// patchedPhases decodes the phases from the recorded status patch actions
+// for the given resource.
+func patchedPhases(t *testing.T, actions []coretesting.Action, resource string) []string {
+	t.Helper()
+	var phases []string
+	for _, action := range actions {
+		patchAction, ok := action.(coretesting.PatchAction)
+		if !ok || !action.Matches("patch", resource) {
+			continue
+		}
+		patched := struct {
+			Status struct {
+				Phase string `json:"phase"`
+			} `json:"status"`
+		}{}
+		if err := json.Unmarshal(patchAction.GetPatch(), &patched); err != nil {
+			t.Fatalf("cannot decode patch: %v", err)
+		}
+		phases = append(phases, patched.Status.Phase)
+	}
+	return phases
+}
+
+func TestCoalescerDeduplicatesVolumeUpdates(t *testing.T) {
+	fakeKubeClient := fake.NewSimpleClientset()
+	fakeKubeClient.PrependReactor("patch", "persistentvolumes", func(action coretesting.Action) (bool, runtime.Object, error) {
+		return true, &v1.PersistentVolume{}, nil
+	})
+	coalescer := newStatusCoalescer(fakeKubeClient, time.Millisecond)
+
+	// Two enqueues within one flush interval collapse into one PATCH with
+	// the latest status.
+	coalescer.enqueueVolumePhase("volume-1", v1.VolumeAvailable, "")
+	coalescer.enqueueVolumePhase("volume-1", v1.VolumeBound, "")
+	coalescer.flush(context.TODO())
+
+	phases := patchedPhases(t, fakeKubeClient.Actions(), "persistentvolumes")
+	if len(phases) != 1 || phases[0] != string(v1.VolumeBound) {
+		t.Fatalf("expected a single patch to phase Bound, got %v", phases)
+	}
+
+	// Re-enqueueing the applied status is a no-op.
+	coalescer.enqueueVolumePhase("volume-1", v1.VolumeBound, "")
+	coalescer.flush(context.TODO())
+	if phases := patchedPhases(t, fakeKubeClient.Actions(), "persistentvolumes"); len(phases) != 1 {
+		t.Errorf("expected no patch for an already applied status, got %v", phases)
+	}
+
+	// A new phase is applied again.
+	coalescer.enqueueVolumePhase("volume-1", v1.VolumeReleased, "")
+	coalescer.flush(context.TODO())
+	phases = patchedPhases(t, fakeKubeClient.Actions(), "persistentvolumes")
+	if len(phases) != 2 || phases[1] != string(v1.VolumeReleased) {
+		t.Errorf("expected a second patch to phase Released, got %v", phases)
+	}
+}
+
+func TestCoalescerDeduplicatesClaimUpdates(t *testing.T) {
+	fakeKubeClient := fake.NewSimpleClientset()
+	fakeKubeClient.PrependReactor("patch", "persistentvolumeclaims", func(action coretesting.Action) (bool, runtime.Object, error) {
+		return true, &v1.PersistentVolumeClaim{}, nil
+	})
+	coalescer := newStatusCoalescer(fakeKubeClient, time.Millisecond)
+	classEmpty := ""
+
+	coalescer.enqueueClaimStatus(newClaim("claim-1", "uid-1", "1Gi", "", v1.ClaimPending, &classEmpty))
+	coalescer.enqueueClaimStatus(newClaim("claim-1", "uid-1", "1Gi", "volume-1", v1.ClaimBound, &classEmpty))
+	coalescer.flush(context.TODO())
+
+	phases := patchedPhases(t, fakeKubeClient.Actions(), "persistentvolumeclaims")
+	if len(phases) != 1 || phases[0] != string(v1.ClaimBound) {
+		t.Fatalf("expected a single patch to phase Bound, got %v", phases)
+	}
+
+	coalescer.enqueueClaimStatus(newClaim("claim-1", "uid-1", "1Gi", "volume-1", v1.ClaimBound, &classEmpty))
+	coalescer.flush(context.TODO())
+	if phases := patchedPhases(t, fakeKubeClient.Actions(), "persistentvolumeclaims"); len(phases) != 1 {
+		t.Errorf("expected no patch for an already applied status, got %v", phases)
+	}
+}
+
+func TestCoalescerRetriesFailedUpdates(t *testing.T) {
+	fakeKubeClient := fake.NewSimpleClientset()
+	failures := 1
+	fakeKubeClient.PrependReactor("patch", "persistentvolumes", func(action coretesting.Action) (bool, runtime.Object, error) {
+		if failures > 0 {
+			failures--
+			return true, nil, errors.New("conflict")
+		}
+		return true, &v1.PersistentVolume{}, nil
+	})
+	coalescer := newStatusCoalescer(fakeKubeClient, time.Millisecond)
+
+	coalescer.enqueueVolumePhase("volume-1", v1.VolumeAvailable, "")
+	coalescer.flush(context.TODO())
+	coalescer.flush(context.TODO())
+
+	phases := patchedPhases(t, fakeKubeClient.Actions(), "persistentvolumes")
+	if len(phases) != 2 || phases[1] != string(v1.VolumeAvailable) {
+		t.Errorf("expected the failed patch to be retried on the next flush, got %v", phases)
+	}
+}
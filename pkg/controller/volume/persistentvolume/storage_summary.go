@@ -0,0 +1,104 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package persistentvolume
+
+import (
+	"encoding/json"
+	"net/http"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	storagehelpers "k8s.io/component-helpers/storage/volume"
+
+	"k8s.io/kubernetes/pkg/controller/volume/persistentvolume/metrics"
+)
+
+// StorageSummary is an aggregated view of the cluster's storage objects as
+// seen by the persistent volume controller. It is cheap to produce from the
+// controller's caches, so dashboards can poll it instead of listing every
+// volume and claim through the apiserver.
+type StorageSummary struct {
+	// Time is when the summary was produced.
+	Time metav1.Time `json:"time"`
+	// VolumesByPhase counts persistent volumes by their phase.
+	VolumesByPhase map[string]int `json:"volumesByPhase"`
+	// VolumesByClass counts persistent volumes by their storage class; volumes
+	// without a class are counted under the empty key.
+	VolumesByClass map[string]int `json:"volumesByClass"`
+	// PendingClaimsByNamespace counts claims in phase Pending per namespace.
+	PendingClaimsByNamespace map[string]int `json:"pendingClaimsByNamespace,omitempty"`
+	// QueuedClaims and QueuedVolumes are the controller's current work queue
+	// depths, an indicator of binding operations still in flight.
+	QueuedClaims  int `json:"queuedClaims"`
+	QueuedVolumes int `json:"queuedVolumes"`
+	// OperationErrors is the cumulative number of failed volume operations
+	// (provisioning, deletion) by operation name since the controller
+	// started.
+	OperationErrors map[string]int64 `json:"operationErrors,omitempty"`
+}
+
+// StorageSummary builds a summary from the controller's informer caches and
+// work queues.
+func (ctrl *PersistentVolumeController) StorageSummary() (*StorageSummary, error) {
+	volumes, err := ctrl.volumeLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	claims, err := ctrl.claimLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &StorageSummary{
+		Time:            metav1.Now(),
+		VolumesByPhase:  map[string]int{},
+		VolumesByClass:  map[string]int{},
+		QueuedVolumes:   ctrl.volumeQueue.Len(),
+		OperationErrors: metrics.OperationErrorCounts(),
+	}
+	for _, queue := range ctrl.claimQueues {
+		summary.QueuedClaims += queue.Len()
+	}
+	for _, volume := range volumes {
+		summary.VolumesByPhase[string(volume.Status.Phase)]++
+		summary.VolumesByClass[storagehelpers.GetPersistentVolumeClass(volume)]++
+	}
+	for _, claim := range claims {
+		if claim.Status.Phase == v1.ClaimPending {
+			if summary.PendingClaimsByNamespace == nil {
+				summary.PendingClaimsByNamespace = map[string]int{}
+			}
+			summary.PendingClaimsByNamespace[claim.Namespace]++
+		}
+	}
+	return summary, nil
+}
+
+// ServeStorageSummary writes the storage summary as JSON. It is intended to be
+// installed on the controller manager's debug mux.
+func (ctrl *PersistentVolumeController) ServeStorageSummary(w http.ResponseWriter, req *http.Request) {
+	summary, err := ctrl.StorageSummary()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(summary); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package persistentvolume
+
+import (
+	"context"
+	"encoding/json"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// StorageSummaryConfigMapName is the ConfigMap in kube-system the
+	// controller keeps up to date with its storage summary when summary
+	// publishing is enabled. Dashboards can watch this single object
+	// instead of listing every volume each scrape.
+	StorageSummaryConfigMapName = "pv-storage-summary"
+	// StorageSummaryConfigMapKey is the data key holding the summary JSON.
+	StorageSummaryConfigMapKey = "summary"
+)
+
+// publishStorageSummary writes the current storage summary to the
+// kube-system ConfigMap, creating it on first use. Errors are logged; the
+// next publishing interval retries.
+func (ctrl *PersistentVolumeController) publishStorageSummary(ctx context.Context) {
+	summary, err := ctrl.StorageSummary()
+	if err != nil {
+		klog.V(2).Infof("cannot build storage summary: %v", err)
+		return
+	}
+	payload, err := json.Marshal(summary)
+	if err != nil {
+		klog.V(2).Infof("cannot encode storage summary: %v", err)
+		return
+	}
+
+	configMap := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      StorageSummaryConfigMapName,
+			Namespace: metav1.NamespaceSystem,
+		},
+		Data: map[string]string{StorageSummaryConfigMapKey: string(payload)},
+	}
+	_, err = ctrl.kubeClient.CoreV1().ConfigMaps(metav1.NamespaceSystem).Update(ctx, configMap, metav1.UpdateOptions{})
+	if apierrors.IsNotFound(err) {
+		_, err = ctrl.kubeClient.CoreV1().ConfigMaps(metav1.NamespaceSystem).Create(ctx, configMap, metav1.CreateOptions{})
+	}
+	if err != nil {
+		klog.V(2).Infof("cannot publish storage summary: %v", err)
+		return
+	}
+	klog.V(4).Infof("storage summary published to %s/%s", metav1.NamespaceSystem, StorageSummaryConfigMapName)
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package persistentvolume
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/kubernetes/pkg/controller"
+)
+
+func TestPublishStorageSummary(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	informerFactory := informers.NewSharedInformerFactory(client, controller.NoResyncPeriodFunc())
+	ctrl, err := newTestController(client, informerFactory, true)
+	if err != nil {
+		t.Fatalf("failed to construct controller: %v", err)
+	}
+
+	volumeIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	if err := volumeIndexer.Add(&v1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: "pv-bound"},
+		Status:     v1.PersistentVolumeStatus{Phase: v1.VolumeBound},
+	}); err != nil {
+		t.Fatalf("failed to add volume: %v", err)
+	}
+	ctrl.volumeLister = corelisters.NewPersistentVolumeLister(volumeIndexer)
+	ctrl.claimLister = corelisters.NewPersistentVolumeClaimLister(cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{}))
+
+	// First publish must create the ConfigMap.
+	ctrl.publishStorageSummary(context.TODO())
+	configMap, err := client.CoreV1().ConfigMaps(metav1.NamespaceSystem).Get(context.TODO(), StorageSummaryConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected ConfigMap to be created: %v", err)
+	}
+	var summary StorageSummary
+	if err := json.Unmarshal([]byte(configMap.Data[StorageSummaryConfigMapKey]), &summary); err != nil {
+		t.Fatalf("cannot decode published summary: %v", err)
+	}
+	if got := summary.VolumesByPhase[string(v1.VolumeBound)]; got != 1 {
+		t.Errorf("expected 1 bound volume in published summary, got %d", got)
+	}
+
+	// A second publish updates the existing ConfigMap in place.
+	if err := volumeIndexer.Add(&v1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: "pv-available"},
+		Status:     v1.PersistentVolumeStatus{Phase: v1.VolumeAvailable},
+	}); err != nil {
+		t.Fatalf("failed to add volume: %v", err)
+	}
+	ctrl.publishStorageSummary(context.TODO())
+	configMap, err = client.CoreV1().ConfigMaps(metav1.NamespaceSystem).Get(context.TODO(), StorageSummaryConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected ConfigMap to survive a second publish: %v", err)
+	}
+	if err := json.Unmarshal([]byte(configMap.Data[StorageSummaryConfigMapKey]), &summary); err != nil {
+		t.Fatalf("cannot decode published summary: %v", err)
+	}
+	if got := summary.VolumesByPhase[string(v1.VolumeAvailable)]; got != 1 {
+		t.Errorf("expected 1 available volume in published summary, got %d", got)
+	}
+}
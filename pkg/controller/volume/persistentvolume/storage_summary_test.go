@@ -0,0 +1,111 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package persistentvolume
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/kubernetes/pkg/controller"
+)
+
+func TestStorageSummary(t *testing.T) {
+	client := &fake.Clientset{}
+	informerFactory := informers.NewSharedInformerFactory(client, controller.NoResyncPeriodFunc())
+	ctrl, err := newTestController(client, informerFactory, true)
+	if err != nil {
+		t.Fatalf("failed to construct controller: %v", err)
+	}
+
+	volumeIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	volumes := []*v1.PersistentVolume{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "pv-bound"},
+			Spec:       v1.PersistentVolumeSpec{StorageClassName: "fast"},
+			Status:     v1.PersistentVolumeStatus{Phase: v1.VolumeBound},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "pv-bound-2"},
+			Spec:       v1.PersistentVolumeSpec{StorageClassName: "fast"},
+			Status:     v1.PersistentVolumeStatus{Phase: v1.VolumeBound},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "pv-available"},
+			Status:     v1.PersistentVolumeStatus{Phase: v1.VolumeAvailable},
+		},
+	}
+	for _, volume := range volumes {
+		if err := volumeIndexer.Add(volume); err != nil {
+			t.Fatalf("failed to add volume: %v", err)
+		}
+	}
+	ctrl.volumeLister = corelisters.NewPersistentVolumeLister(volumeIndexer)
+
+	claimIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	claims := []*v1.PersistentVolumeClaim{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "claim-pending", Namespace: "ns1"},
+			Status:     v1.PersistentVolumeClaimStatus{Phase: v1.ClaimPending},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "claim-bound", Namespace: "ns1"},
+			Status:     v1.PersistentVolumeClaimStatus{Phase: v1.ClaimBound},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "claim-pending", Namespace: "ns2"},
+			Status:     v1.PersistentVolumeClaimStatus{Phase: v1.ClaimPending},
+		},
+	}
+	for _, claim := range claims {
+		if err := claimIndexer.Add(claim); err != nil {
+			t.Fatalf("failed to add claim: %v", err)
+		}
+	}
+	ctrl.claimLister = corelisters.NewPersistentVolumeClaimLister(claimIndexer)
+
+	summary, err := ctrl.StorageSummary()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := summary.VolumesByPhase[string(v1.VolumeBound)]; got != 2 {
+		t.Errorf("expected 2 bound volumes, got %d", got)
+	}
+	if got := summary.VolumesByPhase[string(v1.VolumeAvailable)]; got != 1 {
+		t.Errorf("expected 1 available volume, got %d", got)
+	}
+	if got := summary.VolumesByClass["fast"]; got != 2 {
+		t.Errorf("expected 2 volumes of class fast, got %d", got)
+	}
+	if got := summary.VolumesByClass[""]; got != 1 {
+		t.Errorf("expected 1 volume without class, got %d", got)
+	}
+	if got := summary.PendingClaimsByNamespace["ns1"]; got != 1 {
+		t.Errorf("expected 1 pending claim in ns1, got %d", got)
+	}
+	if got := summary.PendingClaimsByNamespace["ns2"]; got != 1 {
+		t.Errorf("expected 1 pending claim in ns2, got %d", got)
+	}
+	if _, ok := summary.PendingClaimsByNamespace["ns3"]; ok {
+		t.Errorf("unexpected entry for namespace without pending claims")
+	}
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package persistentvolume
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// VolumeNameTemplateParameter is the StorageClass parameter that overrides
+// the controller-wide volume name template for dynamically provisioned
+// volumes. It is consumed by the PV controller and stripped from the
+// parameters handed to the provisioner plugin.
+const VolumeNameTemplateParameter = "volumeNameTemplate"
+
+// expandVolumeNameTemplate renders a volume name template for the given
+// claim. The template may use the {namespace} and {pvcname} placeholders,
+// plus {hash}, a short deterministic hash of the claim UID that keeps names
+// unique across claims with the same namespace and name. The result must be
+// a valid volume name.
+func expandVolumeNameTemplate(template string, claim *v1.PersistentVolumeClaim) (string, error) {
+	replacer := strings.NewReplacer(
+		"{namespace}", claim.Namespace,
+		"{pvcname}", claim.Name,
+		"{hash}", claimUIDHash(claim),
+	)
+	name := replacer.Replace(template)
+	if strings.ContainsAny(name, "{}") {
+		return "", fmt.Errorf("volume name template %q contains an unknown placeholder; supported placeholders are {namespace}, {pvcname} and {hash}", template)
+	}
+	if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+		return "", fmt.Errorf("name %q expanded from volume name template %q is not a valid volume name: %s", name, template, strings.Join(errs, "; "))
+	}
+	return name, nil
+}
+
+// claimUIDHash returns a short deterministic hash of the claim UID. It is
+// stable across provisioning retries, so the "volume already provisioned"
+// check keeps working with templated names.
+func claimUIDHash(claim *v1.PersistentVolumeClaim) string {
+	hasher := fnv.New32a()
+	hasher.Write([]byte(claim.UID))
+	return fmt.Sprintf("%08x", hasher.Sum32())
+}
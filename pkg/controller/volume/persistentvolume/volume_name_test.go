@@ -0,0 +1,104 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package persistentvolume
+
+import (
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestExpandVolumeNameTemplate(t *testing.T) {
+	claim := &v1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "data",
+			UID:       types.UID("uid-1"),
+		},
+	}
+
+	tests := []struct {
+		name        string
+		template    string
+		expectName  string
+		expectError bool
+	}{
+		{
+			name:       "all placeholders",
+			template:   "{namespace}-{pvcname}-{hash}",
+			expectName: "default-data-" + claimUIDHash(claim),
+		},
+		{
+			name:       "no placeholders",
+			template:   "static-name",
+			expectName: "static-name",
+		},
+		{
+			name:        "unknown placeholder",
+			template:    "{namespace}-{pvname}",
+			expectError: true,
+		},
+		{
+			name:        "invalid expanded name",
+			template:    "{pvcname}-",
+			expectError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			name, err := expandVolumeNameTemplate(test.template, claim)
+			if test.expectError {
+				if err == nil {
+					t.Fatalf("expected an error, got name %q", name)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if name != test.expectName {
+				t.Errorf("expected name %q, got %q", test.expectName, name)
+			}
+		})
+	}
+}
+
+func TestClaimUIDHashDeterministic(t *testing.T) {
+	claim := &v1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "data",
+			UID:       types.UID("uid-1"),
+		},
+	}
+	hash := claimUIDHash(claim)
+	if len(hash) != 8 || strings.ToLower(hash) != hash {
+		t.Errorf("expected a short lowercase hash, got %q", hash)
+	}
+	if again := claimUIDHash(claim); again != hash {
+		t.Errorf("hash is not deterministic: %q vs %q", hash, again)
+	}
+	other := claim.DeepCopy()
+	other.UID = types.UID("uid-2")
+	if claimUIDHash(other) == hash {
+		t.Errorf("claims with different UIDs produced the same hash %q", hash)
+	}
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package persistentvolume
+
+import (
+	"context"
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/controller/volume/events"
+)
+
+const (
+	// wipeJobNamespace is where wipe jobs run, matching the namespace the
+	// legacy recycler pods were created in.
+	wipeJobNamespace = metav1.NamespaceDefault
+
+	// wipeJobVolumeName is the pod volume the controller injects into the
+	// wipe job; the template's containers mount it to scrub the data.
+	wipeJobVolumeName = "vol"
+
+	// wipeJobVolumeLabel on a wipe job names the volume it is wiping.
+	wipeJobVolumeLabel = "volume.kubernetes.io/wipe-target"
+)
+
+// recycleVolumeByJob reclaims a released volume through the configured
+// wipe job instead of a recycler pod. The operation is level triggered:
+// it creates the job when missing, waits for it across syncs and only
+// makes the volume Available once the job completed.
+func (ctrl *PersistentVolumeController) recycleVolumeByJob(ctx context.Context, volume *v1.PersistentVolume) {
+	done, err := ctrl.ensureWipeJob(ctx, volume)
+	if err != nil {
+		strerr := fmt.Sprintf("Wipe job failed: %s", err)
+		if _, err = ctrl.updateVolumePhaseWithEvent(volume, v1.VolumeFailed, v1.EventTypeWarning, events.VolumeFailedRecycle, strerr); err != nil {
+			klog.V(4).Infof("recycleVolumeByJob [%s]: failed to mark volume as failed: %v", volume.Name, err)
+		}
+		// Despite the volume being Failed, the controller will retry
+		// recycling the volume in every syncVolume() call.
+		return
+	}
+	if !done {
+		klog.V(4).Infof("recycleVolumeByJob [%s]: waiting for wipe job to complete", volume.Name)
+		return
+	}
+
+	// The job did its work; clean it up unless a TTL in the template
+	// already takes care of that.
+	if ctrl.wipeJobTemplate.TTLSecondsAfterFinished == nil {
+		propagation := metav1.DeletePropagationBackground
+		if err := ctrl.kubeClient.BatchV1().Jobs(wipeJobNamespace).Delete(ctx, wipeJobName(volume), metav1.DeleteOptions{PropagationPolicy: &propagation}); err != nil && !apierrors.IsNotFound(err) {
+			klog.V(3).Infof("recycleVolumeByJob [%s]: failed to delete finished wipe job: %v", volume.Name, err)
+		}
+	}
+
+	klog.V(2).Infof("volume %q recycled", volume.Name)
+	ctrl.eventRecorder.Event(volume, v1.EventTypeNormal, events.VolumeRecycled, "Volume recycled by wipe job")
+	if err = ctrl.unbindVolume(volume); err != nil {
+		klog.V(3).Infof("recycleVolumeByJob [%s]: failed to make recycled volume 'Available' (%v), we will recycle the volume again", volume.Name, err)
+	}
+}
+
+// ensureWipeJob creates the wipe job for the volume when it does not exist
+// yet and reports whether the job completed. A failed job is deleted and
+// an error returned, so the volume is marked Failed and the next sync
+// starts a fresh job.
+func (ctrl *PersistentVolumeController) ensureWipeJob(ctx context.Context, volume *v1.PersistentVolume) (bool, error) {
+	jobs := ctrl.kubeClient.BatchV1().Jobs(wipeJobNamespace)
+	job, err := jobs.Get(ctx, wipeJobName(volume), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		job, err = newWipeJob(ctrl.wipeJobTemplate, volume)
+		if err != nil {
+			return false, err
+		}
+		if _, err := jobs.Create(ctx, job, metav1.CreateOptions{}); err != nil && !apierrors.IsAlreadyExists(err) {
+			return false, fmt.Errorf("cannot create wipe job: %v", err)
+		}
+		ctrl.eventRecorder.Eventf(volume, v1.EventTypeNormal, events.WipeJob, "Started wipe job %s/%s", wipeJobNamespace, wipeJobName(volume))
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("cannot read wipe job: %v", err)
+	}
+
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != v1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobComplete:
+			return true, nil
+		case batchv1.JobFailed:
+			propagation := metav1.DeletePropagationBackground
+			if err := jobs.Delete(ctx, job.Name, metav1.DeleteOptions{PropagationPolicy: &propagation}); err != nil && !apierrors.IsNotFound(err) {
+				klog.V(3).Infof("failed to delete failed wipe job %q: %v", job.Name, err)
+			}
+			return false, fmt.Errorf("wipe job failed: %s", condition.Message)
+		}
+	}
+	return false, nil
+}
+
+// newWipeJob instantiates the wipe job template for the volume, injecting
+// the volume to wipe as the pod volume named wipeJobVolumeName. The
+// template controls everything else: image, resource limits, tolerations
+// and TTL.
+func newWipeJob(template *batchv1.JobSpec, volume *v1.PersistentVolume) (*batchv1.Job, error) {
+	source, err := wipeJobVolumeSource(volume)
+	if err != nil {
+		return nil, err
+	}
+	spec := template.DeepCopy()
+	spec.Template.Spec.Volumes = append(spec.Template.Spec.Volumes, v1.Volume{
+		Name:         wipeJobVolumeName,
+		VolumeSource: *source,
+	})
+	if spec.Template.Spec.RestartPolicy == "" {
+		spec.Template.Spec.RestartPolicy = v1.RestartPolicyNever
+	}
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      wipeJobName(volume),
+			Namespace: wipeJobNamespace,
+			Labels:    map[string]string{wipeJobVolumeLabel: volume.Name},
+		},
+		Spec: *spec,
+	}, nil
+}
+
+// wipeJobVolumeSource converts the persistent volume's source into a pod
+// volume source the wipe job can mount. Only sources the legacy recycler
+// supported can be wiped.
+func wipeJobVolumeSource(volume *v1.PersistentVolume) (*v1.VolumeSource, error) {
+	switch {
+	case volume.Spec.HostPath != nil:
+		return &v1.VolumeSource{HostPath: &v1.HostPathVolumeSource{Path: volume.Spec.HostPath.Path}}, nil
+	case volume.Spec.NFS != nil:
+		nfs := *volume.Spec.NFS
+		return &v1.VolumeSource{NFS: &nfs}, nil
+	default:
+		return nil, fmt.Errorf("volume source of %q cannot be wiped by a job", volume.Name)
+	}
+}
+
+func wipeJobName(volume *v1.PersistentVolume) string {
+	return "wipe-" + volume.Name
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package persistentvolume
+
+import (
+	"context"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func wipeJobTemplate() *batchv1.JobSpec {
+	return &batchv1.JobSpec{
+		Template: v1.PodTemplateSpec{
+			Spec: v1.PodSpec{
+				Containers: []v1.Container{{
+					Name:         "wiper",
+					Image:        "registry.k8s.io/wiper:latest",
+					VolumeMounts: []v1.VolumeMount{{Name: wipeJobVolumeName, MountPath: "/scrub"}},
+				}},
+			},
+		},
+	}
+}
+
+func newWipeableVolume(name string) *v1.PersistentVolume {
+	volume := newVolume(name, "1Gi", "uid-1", "claim-1", v1.VolumeReleased, v1.PersistentVolumeReclaimRecycle, "")
+	volume.Spec.PersistentVolumeSource = v1.PersistentVolumeSource{
+		HostPath: &v1.HostPathVolumeSource{Path: "/tmp/data"},
+	}
+	return volume
+}
+
+func newWipeTestController(t *testing.T, objects ...runtime.Object) (*PersistentVolumeController, *fake.Clientset) {
+	t.Helper()
+	fakeKubeClient := fake.NewSimpleClientset(objects...)
+	ctrl, err := newTestController(fakeKubeClient, nil, false)
+	if err != nil {
+		t.Fatalf("failed to construct controller: %v", err)
+	}
+	ctrl.wipeJobTemplate = wipeJobTemplate()
+	return ctrl, fakeKubeClient
+}
+
+func TestEnsureWipeJobCreatesJob(t *testing.T) {
+	volume := newWipeableVolume("volume-1")
+	ctrl, fakeKubeClient := newWipeTestController(t)
+
+	done, err := ctrl.ensureWipeJob(context.TODO(), volume)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if done {
+		t.Error("expected the job to be pending right after creation")
+	}
+
+	job, err := fakeKubeClient.BatchV1().Jobs(wipeJobNamespace).Get(context.TODO(), "wipe-volume-1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected the wipe job to be created: %v", err)
+	}
+	if job.Labels[wipeJobVolumeLabel] != "volume-1" {
+		t.Errorf("expected the job to be labeled with the volume name, got %v", job.Labels)
+	}
+	if len(job.Spec.Template.Spec.Volumes) != 1 || job.Spec.Template.Spec.Volumes[0].HostPath == nil {
+		t.Errorf("expected the volume source to be injected, got %+v", job.Spec.Template.Spec.Volumes)
+	}
+	if job.Spec.Template.Spec.RestartPolicy != v1.RestartPolicyNever {
+		t.Errorf("expected restart policy Never, got %q", job.Spec.Template.Spec.RestartPolicy)
+	}
+}
+
+func TestEnsureWipeJobCompletion(t *testing.T) {
+	volume := newWipeableVolume("volume-1")
+	ctrl, fakeKubeClient := newWipeTestController(t)
+
+	if _, err := ctrl.ensureWipeJob(context.TODO(), volume); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	job, err := fakeKubeClient.BatchV1().Jobs(wipeJobNamespace).Get(context.TODO(), "wipe-volume-1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Still running: not done, no error.
+	done, err := ctrl.ensureWipeJob(context.TODO(), volume)
+	if err != nil || done {
+		t.Errorf("expected a running job to report not done, got done=%v err=%v", done, err)
+	}
+
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: v1.ConditionTrue}}
+	if _, err := fakeKubeClient.BatchV1().Jobs(wipeJobNamespace).UpdateStatus(context.TODO(), job, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	done, err = ctrl.ensureWipeJob(context.TODO(), volume)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !done {
+		t.Error("expected a completed job to report done")
+	}
+}
+
+func TestEnsureWipeJobFailure(t *testing.T) {
+	volume := newWipeableVolume("volume-1")
+	ctrl, fakeKubeClient := newWipeTestController(t)
+
+	if _, err := ctrl.ensureWipeJob(context.TODO(), volume); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	job, err := fakeKubeClient.BatchV1().Jobs(wipeJobNamespace).Get(context.TODO(), "wipe-volume-1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: v1.ConditionTrue, Message: "backoff limit exceeded"}}
+	if _, err := fakeKubeClient.BatchV1().Jobs(wipeJobNamespace).UpdateStatus(context.TODO(), job, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := ctrl.ensureWipeJob(context.TODO(), volume); err == nil {
+		t.Fatal("expected a failed job to surface an error")
+	}
+	// The failed job is deleted so the next sync starts over.
+	if _, err := fakeKubeClient.BatchV1().Jobs(wipeJobNamespace).Get(context.TODO(), "wipe-volume-1", metav1.GetOptions{}); err == nil {
+		t.Error("expected the failed job to be deleted")
+	}
+}
+
+func TestNewWipeJobRejectsUnsupportedSources(t *testing.T) {
+	volume := newVolume("volume-1", "1Gi", "uid-1", "claim-1", v1.VolumeReleased, v1.PersistentVolumeReclaimRecycle, "")
+	if _, err := newWipeJob(wipeJobTemplate(), volume); err == nil {
+		t.Error("expected an error for a volume source a job cannot wipe")
+	}
+}
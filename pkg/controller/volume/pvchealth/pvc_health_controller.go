@@ -0,0 +1,281 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pvchealth
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/component-helpers/storage/ephemeral"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// eventReasonAbnormal is the reason of the event emitted by kubelet when
+	// a CSI driver reports an abnormal volume condition.
+	eventReasonAbnormal = "VolumeConditionAbnormal"
+	// eventReasonNormal is the reason of the event emitted by kubelet when a
+	// previously abnormal volume reports a healthy condition again.
+	eventReasonNormal = "VolumeConditionNormal"
+)
+
+// volumeHealthEvent is the unit of work of the controller: a single volume
+// health transition reported by a kubelet through a pod event.
+type volumeHealthEvent struct {
+	podNamespace string
+	podName      string
+	volumeName   string
+	healthy      bool
+	message      string
+}
+
+// Controller reflects CSI volume health reported by kubelets into a
+// VolumeHealthy condition on the bound PersistentVolumeClaim, so that
+// workloads and operators can react to failing disks by watching the claim
+// instead of scraping kubelet logs.
+type Controller struct {
+	client clientset.Interface
+
+	pvcLister       corelisters.PersistentVolumeClaimLister
+	pvcListerSynced cache.InformerSynced
+
+	podLister       corelisters.PodLister
+	podListerSynced cache.InformerSynced
+
+	eventListerSynced cache.InformerSynced
+
+	queue workqueue.RateLimitingInterface
+}
+
+// NewPVCHealthController returns a new instance of the PVC health controller.
+func NewPVCHealthController(eventInformer coreinformers.EventInformer, pvcInformer coreinformers.PersistentVolumeClaimInformer, podInformer coreinformers.PodInformer, cl clientset.Interface) *Controller {
+	c := &Controller{
+		client: cl,
+		queue:  workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "pvchealth"),
+	}
+
+	c.pvcLister = pvcInformer.Lister()
+	c.pvcListerSynced = pvcInformer.Informer().HasSynced
+	c.podLister = podInformer.Lister()
+	c.podListerSynced = podInformer.Informer().HasSynced
+
+	c.eventListerSynced = eventInformer.Informer().HasSynced
+	eventInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.eventAddedUpdated,
+		UpdateFunc: func(old, new interface{}) {
+			c.eventAddedUpdated(new)
+		},
+	})
+
+	return c
+}
+
+// Run runs the controller goroutines.
+func (c *Controller) Run(ctx context.Context, workers int) {
+	defer utilruntime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	klog.InfoS("Starting PVC health controller")
+	defer klog.InfoS("Shutting down PVC health controller")
+
+	if !cache.WaitForNamedCacheSync("PVC health", ctx.Done(), c.eventListerSynced, c.pvcListerSynced, c.podListerSynced) {
+		return
+	}
+
+	for i := 0; i < workers; i++ {
+		go wait.UntilWithContext(ctx, c.runWorker, time.Second)
+	}
+
+	<-ctx.Done()
+}
+
+func (c *Controller) runWorker(ctx context.Context) {
+	for c.processNextWorkItem(ctx) {
+	}
+}
+
+func (c *Controller) processNextWorkItem(ctx context.Context) bool {
+	item, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(item)
+
+	healthEvent := item.(volumeHealthEvent)
+	err := c.syncHealthEvent(ctx, healthEvent)
+	if err == nil {
+		c.queue.Forget(item)
+		return true
+	}
+
+	utilruntime.HandleError(err)
+	c.queue.AddRateLimited(item)
+
+	return true
+}
+
+// eventAddedUpdated reacts to volume health events recorded by kubelets.
+func (c *Controller) eventAddedUpdated(obj interface{}) {
+	event, ok := obj.(*v1.Event)
+	if !ok {
+		utilruntime.HandleError(fmt.Errorf("event informer returned non-event object: %#v", obj))
+		return
+	}
+	if event.Reason != eventReasonAbnormal && event.Reason != eventReasonNormal {
+		return
+	}
+	if event.InvolvedObject.Kind != "Pod" {
+		return
+	}
+	volumeName, message, ok := parseVolumeHealthMessage(event.Message)
+	if !ok {
+		klog.V(4).InfoS("Ignoring volume health event with unexpected message", "event", klog.KObj(event))
+		return
+	}
+	klog.V(4).InfoS("Got volume health event", "pod", klog.KRef(event.InvolvedObject.Namespace, event.InvolvedObject.Name), "volumeName", volumeName, "reason", event.Reason)
+	c.queue.Add(volumeHealthEvent{
+		podNamespace: event.InvolvedObject.Namespace,
+		podName:      event.InvolvedObject.Name,
+		volumeName:   volumeName,
+		healthy:      event.Reason == eventReasonNormal,
+		message:      message,
+	})
+}
+
+// parseVolumeHealthMessage splits the "Volume <name>: <detail>" message
+// format used by kubelet's volume health events.
+func parseVolumeHealthMessage(message string) (volumeName, detail string, ok bool) {
+	rest := strings.TrimPrefix(message, "Volume ")
+	if rest == message {
+		return "", "", false
+	}
+	parts := strings.SplitN(rest, ": ", 2)
+	if len(parts) != 2 {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+// syncHealthEvent resolves the PVC bound to the reported pod volume and
+// updates its VolumeHealthy condition.
+func (c *Controller) syncHealthEvent(ctx context.Context, healthEvent volumeHealthEvent) error {
+	pod, err := c.podLister.Pods(healthEvent.podNamespace).Get(healthEvent.podName)
+	if apierrors.IsNotFound(err) {
+		// The pod is gone; the stale condition will be corrected by the next
+		// health event of a pod that still uses the volume.
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	claimName := claimNameForVolume(pod, healthEvent.volumeName)
+	if claimName == "" {
+		// Not a PVC-backed volume.
+		return nil
+	}
+
+	pvc, err := c.pvcLister.PersistentVolumeClaims(pod.Namespace).Get(claimName)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	status := v1.ConditionFalse
+	reason := eventReasonAbnormal
+	if healthEvent.healthy {
+		status = v1.ConditionTrue
+		reason = eventReasonNormal
+	}
+	newCondition := v1.PersistentVolumeClaimCondition{
+		Type:    v1.PersistentVolumeClaimVolumeHealthy,
+		Status:  status,
+		Reason:  reason,
+		Message: healthEvent.message,
+	}
+
+	updated, changed := mergeHealthCondition(pvc, newCondition)
+	if !changed {
+		return nil
+	}
+	_, err = c.client.CoreV1().PersistentVolumeClaims(updated.Namespace).UpdateStatus(ctx, updated, metav1.UpdateOptions{})
+	if err != nil {
+		return err
+	}
+	klog.V(2).InfoS("Updated PVC volume health condition", "PVC", klog.KObj(pvc), "status", status, "reason", reason)
+	return nil
+}
+
+// claimNameForVolume returns the name of the PVC backing the named pod
+// volume, or an empty string if the volume is not claim-backed.
+func claimNameForVolume(pod *v1.Pod, volumeName string) string {
+	for i := range pod.Spec.Volumes {
+		vol := &pod.Spec.Volumes[i]
+		if vol.Name != volumeName {
+			continue
+		}
+		if vol.PersistentVolumeClaim != nil {
+			return vol.PersistentVolumeClaim.ClaimName
+		}
+		if vol.Ephemeral != nil {
+			return ephemeral.VolumeClaimName(pod, vol)
+		}
+		return ""
+	}
+	return ""
+}
+
+// mergeHealthCondition returns a copy of the PVC with the VolumeHealthy
+// condition applied, plus whether the condition actually changed.
+func mergeHealthCondition(pvc *v1.PersistentVolumeClaim, condition v1.PersistentVolumeClaimCondition) (*v1.PersistentVolumeClaim, bool) {
+	clone := pvc.DeepCopy()
+	now := metav1.Now()
+	condition.LastProbeTime = now
+	for i := range clone.Status.Conditions {
+		existing := &clone.Status.Conditions[i]
+		if existing.Type != condition.Type {
+			continue
+		}
+		if existing.Status == condition.Status && existing.Message == condition.Message {
+			return clone, false
+		}
+		if existing.Status != condition.Status {
+			condition.LastTransitionTime = now
+		} else {
+			condition.LastTransitionTime = existing.LastTransitionTime
+		}
+		clone.Status.Conditions[i] = condition
+		return clone, true
+	}
+	condition.LastTransitionTime = now
+	clone.Status.Conditions = append(clone.Status.Conditions, condition)
+	return clone, true
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pvchealth
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func pod(namespace, name, volumeName, claimName string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec: v1.PodSpec{
+			Volumes: []v1.Volume{
+				{
+					Name: volumeName,
+					VolumeSource: v1.VolumeSource{
+						PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+							ClaimName: claimName,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func pvc(namespace, name string) *v1.PersistentVolumeClaim {
+	return &v1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Status:     v1.PersistentVolumeClaimStatus{Phase: v1.ClaimBound},
+	}
+}
+
+func newTestController(t *testing.T, objs ...interface{}) (*Controller, *fake.Clientset) {
+	t.Helper()
+	client := fake.NewSimpleClientset()
+	informerFactory := informers.NewSharedInformerFactory(client, 0)
+	c := NewPVCHealthController(
+		informerFactory.Core().V1().Events(),
+		informerFactory.Core().V1().PersistentVolumeClaims(),
+		informerFactory.Core().V1().Pods(),
+		client,
+	)
+	for _, obj := range objs {
+		var err error
+		switch typed := obj.(type) {
+		case *v1.Pod:
+			err = informerFactory.Core().V1().Pods().Informer().GetIndexer().Add(typed)
+		case *v1.PersistentVolumeClaim:
+			err = informerFactory.Core().V1().PersistentVolumeClaims().Informer().GetIndexer().Add(typed)
+			if err == nil {
+				_, err = client.CoreV1().PersistentVolumeClaims(typed.Namespace).Create(context.TODO(), typed, metav1.CreateOptions{})
+			}
+		default:
+			t.Fatalf("unknown object type %T", obj)
+		}
+		if err != nil {
+			t.Fatalf("failed to set up test object: %v", err)
+		}
+	}
+	return c, client
+}
+
+func getHealthCondition(t *testing.T, client *fake.Clientset, namespace, name string) *v1.PersistentVolumeClaimCondition {
+	t.Helper()
+	updated, err := client.CoreV1().PersistentVolumeClaims(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get PVC: %v", err)
+	}
+	for i := range updated.Status.Conditions {
+		if updated.Status.Conditions[i].Type == v1.PersistentVolumeClaimVolumeHealthy {
+			return &updated.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+func TestSyncHealthEvent(t *testing.T) {
+	c, client := newTestController(t, pod("ns", "pod", "data", "claim"), pvc("ns", "claim"))
+
+	// An abnormal report marks the claim unhealthy.
+	err := c.syncHealthEvent(context.TODO(), volumeHealthEvent{
+		podNamespace: "ns",
+		podName:      "pod",
+		volumeName:   "data",
+		healthy:      false,
+		message:      "disk is failing",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	condition := getHealthCondition(t, client, "ns", "claim")
+	if condition == nil {
+		t.Fatal("expected a VolumeHealthy condition, got none")
+	}
+	if condition.Status != v1.ConditionFalse {
+		t.Errorf("expected condition status %v, got %v", v1.ConditionFalse, condition.Status)
+	}
+	if condition.Message != "disk is failing" {
+		t.Errorf("unexpected condition message: %q", condition.Message)
+	}
+
+	// A recovery report flips the condition back to True.
+	err = c.syncHealthEvent(context.TODO(), volumeHealthEvent{
+		podNamespace: "ns",
+		podName:      "pod",
+		volumeName:   "data",
+		healthy:      true,
+		message:      "health is back to normal",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	condition = getHealthCondition(t, client, "ns", "claim")
+	if condition == nil {
+		t.Fatal("expected a VolumeHealthy condition, got none")
+	}
+	if condition.Status != v1.ConditionTrue {
+		t.Errorf("expected condition status %v, got %v", v1.ConditionTrue, condition.Status)
+	}
+}
+
+func TestSyncHealthEventNonPVCVolume(t *testing.T) {
+	emptyDirPod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "pod"},
+		Spec: v1.PodSpec{
+			Volumes: []v1.Volume{
+				{Name: "scratch", VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}}},
+			},
+		},
+	}
+	c, client := newTestController(t, emptyDirPod, pvc("ns", "claim"))
+
+	err := c.syncHealthEvent(context.TODO(), volumeHealthEvent{
+		podNamespace: "ns",
+		podName:      "pod",
+		volumeName:   "scratch",
+		healthy:      false,
+		message:      "disk is failing",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if condition := getHealthCondition(t, client, "ns", "claim"); condition != nil {
+		t.Errorf("expected no condition on an unrelated claim, got %+v", condition)
+	}
+}
+
+func TestParseVolumeHealthMessage(t *testing.T) {
+	tests := []struct {
+		message    string
+		wantVolume string
+		wantDetail string
+		wantOK     bool
+	}{
+		{message: "Volume data: disk is failing", wantVolume: "data", wantDetail: "disk is failing", wantOK: true},
+		{message: "Volume data: health is back to normal", wantVolume: "data", wantDetail: "health is back to normal", wantOK: true},
+		{message: "something unrelated", wantOK: false},
+		{message: "Volume data", wantOK: false},
+	}
+	for _, test := range tests {
+		volumeName, detail, ok := parseVolumeHealthMessage(test.message)
+		if ok != test.wantOK || volumeName != test.wantVolume || detail != test.wantDetail {
+			t.Errorf("parseVolumeHealthMessage(%q) = (%q, %q, %v), want (%q, %q, %v)",
+				test.message, volumeName, detail, ok, test.wantVolume, test.wantDetail, test.wantOK)
+		}
+	}
+}
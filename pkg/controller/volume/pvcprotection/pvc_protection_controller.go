@@ -28,6 +28,8 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 	coreinformers "k8s.io/client-go/informers/core/v1"
 	clientset "k8s.io/client-go/kubernetes"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	batchlisters "k8s.io/client-go/listers/batch/v1"
 	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
@@ -52,6 +54,14 @@ type Controller struct {
 	podListerSynced cache.InformerSynced
 	podIndexer      cache.Indexer
 
+	// Workload awareness, set only by
+	// NewPVCProtectionControllerWithWorkloadProtection.
+	statefulSetLister        appslisters.StatefulSetLister
+	statefulSetListerSynced  cache.InformerSynced
+	cronJobLister            batchlisters.CronJobLister
+	cronJobListerSynced      cache.InformerSynced
+	blockOnWorkloadReference bool
+
 	queue workqueue.RateLimitingInterface
 }
 
@@ -103,7 +113,11 @@ func (c *Controller) Run(ctx context.Context, workers int) {
 	klog.InfoS("Starting PVC protection controller")
 	defer klog.InfoS("Shutting down PVC protection controller")
 
-	if !cache.WaitForNamedCacheSync("PVC protection", ctx.Done(), c.pvcListerSynced, c.podListerSynced) {
+	synced := []cache.InformerSynced{c.pvcListerSynced, c.podListerSynced}
+	if c.statefulSetListerSynced != nil {
+		synced = append(synced, c.statefulSetListerSynced, c.cronJobListerSynced)
+	}
+	if !cache.WaitForNamedCacheSync("PVC protection", ctx.Done(), synced...) {
 		return
 	}
 
@@ -169,6 +183,15 @@ func (c *Controller) processPVC(ctx context.Context, pvcNamespace, pvcName strin
 			return err
 		}
 		if !isUsed {
+			if c.blockOnWorkloadReference {
+				referrers, err := c.referencingWorkloads(pvc)
+				if err != nil {
+					return err
+				}
+				if len(referrers) > 0 {
+					return c.markReferencedByWorkload(ctx, pvc, referrers)
+				}
+			}
 			return c.removeFinalizer(ctx, pvc)
 		}
 		klog.V(2).InfoS("Keeping PVC because it is being used", "PVC", klog.KObj(pvc))
@@ -0,0 +1,211 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pvcprotection
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	apps "k8s.io/api/apps/v1"
+	batch "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	appsinformers "k8s.io/client-go/informers/apps/v1"
+	batchinformers "k8s.io/client-go/informers/batch/v1"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/controller/volume/protectionutil"
+	volumeutil "k8s.io/kubernetes/pkg/volume/util"
+)
+
+// PVCReferencedByWorkload is the condition the controller sets on a deleted
+// PVC it keeps protected because workload objects still reference it. The
+// condition message lists the referrers.
+const PVCReferencedByWorkload v1.PersistentVolumeClaimConditionType = "ReferencedByWorkload"
+
+// NewPVCProtectionControllerWithWorkloadProtection returns a controller
+// that, in addition to running pods, considers StatefulSets and CronJobs
+// whose templates reference a PVC. With blockOnWorkloadReference set, a
+// deleted PVC keeps its protection finalizer while such referrers exist and
+// carries a ReferencedByWorkload condition listing them; without it the
+// workload informers are only used to re-queue PVCs when a referrer goes
+// away.
+func NewPVCProtectionControllerWithWorkloadProtection(
+	pvcInformer coreinformers.PersistentVolumeClaimInformer,
+	podInformer coreinformers.PodInformer,
+	statefulSetInformer appsinformers.StatefulSetInformer,
+	cronJobInformer batchinformers.CronJobInformer,
+	cl clientset.Interface,
+	blockOnWorkloadReference bool) (*Controller, error) {
+	e, err := NewPVCProtectionController(pvcInformer, podInformer, cl)
+	if err != nil {
+		return nil, err
+	}
+
+	e.statefulSetLister = statefulSetInformer.Lister()
+	e.statefulSetListerSynced = statefulSetInformer.Informer().HasSynced
+	e.cronJobLister = cronJobInformer.Lister()
+	e.cronJobListerSynced = cronJobInformer.Informer().HasSynced
+	e.blockOnWorkloadReference = blockOnWorkloadReference
+
+	// A deleted referrer may unblock a PVC waiting for its finalizer.
+	statefulSetInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		DeleteFunc: e.workloadDeleted,
+	})
+	cronJobInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		DeleteFunc: e.workloadDeleted,
+	})
+
+	return e, nil
+}
+
+// referencingWorkloads returns the workload objects whose templates
+// reference the PVC, as "Kind/name" strings sorted for stable condition
+// messages.
+func (c *Controller) referencingWorkloads(pvc *v1.PersistentVolumeClaim) ([]string, error) {
+	var referrers []string
+
+	statefulSets, err := c.statefulSetLister.StatefulSets(pvc.Namespace).List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("cannot list StatefulSets in namespace %q: %v", pvc.Namespace, err)
+	}
+	for _, statefulSet := range statefulSets {
+		if statefulSetReferencesPVC(statefulSet, pvc) {
+			referrers = append(referrers, "StatefulSet/"+statefulSet.Name)
+		}
+	}
+
+	cronJobs, err := c.cronJobLister.CronJobs(pvc.Namespace).List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("cannot list CronJobs in namespace %q: %v", pvc.Namespace, err)
+	}
+	for _, cronJob := range cronJobs {
+		if cronJobReferencesPVC(cronJob, pvc) {
+			referrers = append(referrers, "CronJob/"+cronJob.Name)
+		}
+	}
+
+	sort.Strings(referrers)
+	return referrers, nil
+}
+
+// statefulSetReferencesPVC returns true when the StatefulSet's pod template
+// mounts the PVC directly or one of its volumeClaimTemplates produced it.
+func statefulSetReferencesPVC(statefulSet *apps.StatefulSet, pvc *v1.PersistentVolumeClaim) bool {
+	if podSpecReferencesPVC(&statefulSet.Spec.Template.Spec, pvc.Name) {
+		return true
+	}
+	for _, template := range statefulSet.Spec.VolumeClaimTemplates {
+		// Claims from a template are named <template>-<set>-<ordinal>.
+		prefix := template.Name + "-" + statefulSet.Name + "-"
+		if !strings.HasPrefix(pvc.Name, prefix) {
+			continue
+		}
+		if _, err := strconv.Atoi(strings.TrimPrefix(pvc.Name, prefix)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// cronJobReferencesPVC returns true when the CronJob's job template mounts
+// the PVC.
+func cronJobReferencesPVC(cronJob *batch.CronJob, pvc *v1.PersistentVolumeClaim) bool {
+	return podSpecReferencesPVC(&cronJob.Spec.JobTemplate.Spec.Template.Spec, pvc.Name)
+}
+
+func podSpecReferencesPVC(spec *v1.PodSpec, claimName string) bool {
+	for _, volume := range spec.Volumes {
+		if volume.PersistentVolumeClaim != nil && volume.PersistentVolumeClaim.ClaimName == claimName {
+			return true
+		}
+	}
+	return false
+}
+
+// markReferencedByWorkload records the referrers in the PVC's
+// ReferencedByWorkload condition so users can see why the deletion is
+// blocked. The update is skipped when the condition already says the same.
+func (c *Controller) markReferencedByWorkload(ctx context.Context, pvc *v1.PersistentVolumeClaim, referrers []string) error {
+	message := "Deletion is blocked because the claim is still referenced by: " + strings.Join(referrers, ", ")
+	for _, condition := range pvc.Status.Conditions {
+		if condition.Type == PVCReferencedByWorkload && condition.Status == v1.ConditionTrue && condition.Message == message {
+			return nil
+		}
+	}
+
+	claimClone := pvc.DeepCopy()
+	newCondition := v1.PersistentVolumeClaimCondition{
+		Type:               PVCReferencedByWorkload,
+		Status:             v1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             "WorkloadReferencesClaim",
+		Message:            message,
+	}
+	replaced := false
+	for i, condition := range claimClone.Status.Conditions {
+		if condition.Type == PVCReferencedByWorkload {
+			claimClone.Status.Conditions[i] = newCondition
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		claimClone.Status.Conditions = append(claimClone.Status.Conditions, newCondition)
+	}
+
+	_, err := c.client.CoreV1().PersistentVolumeClaims(claimClone.Namespace).UpdateStatus(ctx, claimClone, metav1.UpdateOptions{})
+	if err != nil {
+		klog.ErrorS(err, "Error updating ReferencedByWorkload condition on PVC", "PVC", klog.KObj(pvc))
+		return err
+	}
+	klog.V(2).InfoS("Keeping PVC because workload objects still reference it", "PVC", klog.KObj(pvc), "referrers", referrers)
+	return nil
+}
+
+// workloadDeleted reacts to StatefulSet and CronJob deletions by re-queuing
+// the deletion-candidate PVCs of the workload's namespace, so claims kept
+// only for the deleted referrer lose their finalizer.
+func (c *Controller) workloadDeleted(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	object, err := meta.Accessor(obj)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("couldn't get object from %#v: %v", obj, err))
+		return
+	}
+
+	pvcs, err := c.pvcLister.PersistentVolumeClaims(object.GetNamespace()).List(labels.Everything())
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("cannot list PVCs in namespace %q: %v", object.GetNamespace(), err))
+		return
+	}
+	for _, pvc := range pvcs {
+		if protectionutil.IsDeletionCandidate(pvc, volumeutil.PVCProtectionFinalizer) {
+			c.queue.Add(pvc.Namespace + "/" + pvc.Name)
+		}
+	}
+}
@@ -0,0 +1,244 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pvcprotection
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	apps "k8s.io/api/apps/v1"
+	batch "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/kubernetes/pkg/controller"
+	"k8s.io/kubernetes/pkg/util/slice"
+	volumeutil "k8s.io/kubernetes/pkg/volume/util"
+)
+
+func statefulSet(name string, claimTemplates []string, podSpec v1.PodSpec) *apps.StatefulSet {
+	set := &apps.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: defaultNS},
+		Spec: apps.StatefulSetSpec{
+			Template: v1.PodTemplateSpec{Spec: podSpec},
+		},
+	}
+	for _, template := range claimTemplates {
+		set.Spec.VolumeClaimTemplates = append(set.Spec.VolumeClaimTemplates, v1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: template},
+		})
+	}
+	return set
+}
+
+func cronJob(name string, podSpec v1.PodSpec) *batch.CronJob {
+	return &batch.CronJob{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: defaultNS},
+		Spec: batch.CronJobSpec{
+			JobTemplate: batch.JobTemplateSpec{
+				Spec: batch.JobSpec{
+					Template: v1.PodTemplateSpec{Spec: podSpec},
+				},
+			},
+		},
+	}
+}
+
+func podSpecWithPVC(claimName string) v1.PodSpec {
+	return v1.PodSpec{
+		Volumes: []v1.Volume{
+			{
+				Name: claimName,
+				VolumeSource: v1.VolumeSource{
+					PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: claimName},
+				},
+			},
+		},
+	}
+}
+
+func newWorkloadProtectionController(t *testing.T, block bool, objs ...interface{}) (*Controller, *fake.Clientset) {
+	t.Helper()
+	client := fake.NewSimpleClientset()
+	informerFactory := informers.NewSharedInformerFactory(client, controller.NoResyncPeriodFunc())
+	c, err := NewPVCProtectionControllerWithWorkloadProtection(
+		informerFactory.Core().V1().PersistentVolumeClaims(),
+		informerFactory.Core().V1().Pods(),
+		informerFactory.Apps().V1().StatefulSets(),
+		informerFactory.Batch().V1().CronJobs(),
+		client,
+		block)
+	if err != nil {
+		t.Fatalf("cannot construct pvc protection controller: %v", err)
+	}
+	for _, obj := range objs {
+		switch typed := obj.(type) {
+		case *apps.StatefulSet:
+			informerFactory.Apps().V1().StatefulSets().Informer().GetIndexer().Add(typed)
+		case *batch.CronJob:
+			informerFactory.Batch().V1().CronJobs().Informer().GetIndexer().Add(typed)
+		case *v1.PersistentVolumeClaim:
+			informerFactory.Core().V1().PersistentVolumeClaims().Informer().GetIndexer().Add(typed)
+		default:
+			t.Fatalf("unknown object type %T", obj)
+		}
+	}
+	return c, client
+}
+
+func TestReferencingWorkloads(t *testing.T) {
+	tests := []struct {
+		name      string
+		workloads []interface{}
+		claimName string
+		expected  []string
+	}{
+		{
+			name:      "no workloads",
+			claimName: defaultPVCName,
+			expected:  nil,
+		},
+		{
+			name: "statefulset pod template reference",
+			workloads: []interface{}{
+				statefulSet("web", nil, podSpecWithPVC(defaultPVCName)),
+			},
+			claimName: defaultPVCName,
+			expected:  []string{"StatefulSet/web"},
+		},
+		{
+			name: "statefulset volume claim template reference",
+			workloads: []interface{}{
+				statefulSet("web", []string{"data"}, v1.PodSpec{}),
+			},
+			claimName: "data-web-0",
+			expected:  []string{"StatefulSet/web"},
+		},
+		{
+			name: "claim template prefix without ordinal does not match",
+			workloads: []interface{}{
+				statefulSet("web", []string{"data"}, v1.PodSpec{}),
+			},
+			claimName: "data-web-extra",
+			expected:  nil,
+		},
+		{
+			name: "cronjob job template reference",
+			workloads: []interface{}{
+				cronJob("backup", podSpecWithPVC(defaultPVCName)),
+			},
+			claimName: defaultPVCName,
+			expected:  []string{"CronJob/backup"},
+		},
+		{
+			name: "multiple referrers sorted",
+			workloads: []interface{}{
+				statefulSet("web", nil, podSpecWithPVC(defaultPVCName)),
+				cronJob("backup", podSpecWithPVC(defaultPVCName)),
+			},
+			claimName: defaultPVCName,
+			expected:  []string{"CronJob/backup", "StatefulSet/web"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, _ := newWorkloadProtectionController(t, true, test.workloads...)
+			claim := pvc()
+			claim.Name = test.claimName
+			referrers, err := c.referencingWorkloads(claim)
+			if err != nil {
+				t.Fatalf("referencingWorkloads failed: %v", err)
+			}
+			if !reflect.DeepEqual(referrers, test.expected) {
+				t.Errorf("expected referrers %v, got %v", test.expected, referrers)
+			}
+		})
+	}
+}
+
+func TestWorkloadReferenceBlocksFinalizerRemoval(t *testing.T) {
+	claim := deleted(withProtectionFinalizer(pvc()))
+	set := statefulSet("web", nil, podSpecWithPVC(defaultPVCName))
+	c, client := newWorkloadProtectionController(t, true, claim, set)
+	if _, err := client.CoreV1().PersistentVolumeClaims(defaultNS).Create(context.TODO(), claim, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("cannot create claim: %v", err)
+	}
+
+	if err := c.processPVC(context.TODO(), defaultNS, defaultPVCName); err != nil {
+		t.Fatalf("processPVC failed: %v", err)
+	}
+
+	stored, err := client.CoreV1().PersistentVolumeClaims(defaultNS).Get(context.TODO(), defaultPVCName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("cannot get claim: %v", err)
+	}
+	if !slice.ContainsString(stored.Finalizers, volumeutil.PVCProtectionFinalizer, nil) {
+		t.Errorf("expected the finalizer to stay while the StatefulSet references the claim")
+	}
+	found := false
+	for _, condition := range stored.Status.Conditions {
+		if condition.Type == PVCReferencedByWorkload && condition.Status == v1.ConditionTrue {
+			found = true
+			if !strings.Contains(condition.Message, "StatefulSet/web") {
+				t.Errorf("expected the condition message to list the referrer, got %q", condition.Message)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("expected a %s condition on the claim, got %v", PVCReferencedByWorkload, stored.Status.Conditions)
+	}
+}
+
+func TestWorkloadReferenceDoesNotBlockWithoutPolicy(t *testing.T) {
+	claim := deleted(withProtectionFinalizer(pvc()))
+	set := statefulSet("web", nil, podSpecWithPVC(defaultPVCName))
+	c, client := newWorkloadProtectionController(t, false, claim, set)
+	if _, err := client.CoreV1().PersistentVolumeClaims(defaultNS).Create(context.TODO(), claim, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("cannot create claim: %v", err)
+	}
+
+	if err := c.processPVC(context.TODO(), defaultNS, defaultPVCName); err != nil {
+		t.Fatalf("processPVC failed: %v", err)
+	}
+
+	stored, err := client.CoreV1().PersistentVolumeClaims(defaultNS).Get(context.TODO(), defaultPVCName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("cannot get claim: %v", err)
+	}
+	if slice.ContainsString(stored.Finalizers, volumeutil.PVCProtectionFinalizer, nil) {
+		t.Errorf("expected the finalizer to be removed when blocking is not enabled")
+	}
+}
+
+func TestWorkloadDeletedRequeuesDeletionCandidates(t *testing.T) {
+	claim := deleted(withProtectionFinalizer(pvc()))
+	set := statefulSet("web", nil, podSpecWithPVC(defaultPVCName))
+	c, _ := newWorkloadProtectionController(t, true, claim)
+
+	c.workloadDeleted(set)
+
+	if c.queue.Len() != 1 {
+		t.Fatalf("expected 1 queued claim, got %d", c.queue.Len())
+	}
+	key, _ := c.queue.Get()
+	if key != defaultNS+"/"+defaultPVCName {
+		t.Errorf("expected key %q, got %q", defaultNS+"/"+defaultPVCName, key)
+	}
+}
@@ -435,6 +435,13 @@ const (
 	// that have never been unsuspended before.
 	JobMutableNodeSchedulingDirectives featuregate.Feature = "JobMutableNodeSchedulingDirectives"
 
+	// owner: @kannon92
+	// alpha: v1.25
+	//
+	// Allow specifying when the Job controller creates replacement pods
+	// through the podReplacementPolicy field of the Job spec.
+	JobPodReplacementPolicy featuregate.Feature = "JobPodReplacementPolicy"
+
 	// owner: @alculquicondor
 	// alpha: v1.23
 	// beta: v1.24
@@ -666,6 +673,14 @@ const (
 	// Allow users to recover from volume expansion failure
 	RecoverVolumeExpansionFailure featuregate.Feature = "RecoverVolumeExpansionFailure"
 
+	// owner: @RomanBednar
+	// kep: http://kep.k8s.io/3333
+	// alpha: v1.25
+	//
+	// Retroactively assigns the default StorageClass to unbound
+	// PersistentVolumeClaims that were created without a class.
+	RetroactiveDefaultStorageClass featuregate.Feature = "RetroactiveDefaultStorageClass"
+
 	// owner: @mikedanese
 	// alpha: v1.7
 	// beta: v1.12
@@ -757,6 +772,15 @@ const (
 	// Enable resource managers to make NUMA aligned decisions
 	TopologyManager featuregate.Feature = "TopologyManager"
 
+	// owner: @jpbetz
+	// alpha: v1.25
+	//
+	// Tolerates pre-existing validation violations on unchanged parts of
+	// PersistentVolume, PersistentVolumeClaim and Pod objects during update,
+	// so validation rules can be tightened without breaking updates to
+	// legacy objects stored before the rule existed.
+	ValidationRatcheting featuregate.Feature = "ValidationRatcheting"
+
 	// owner: @cofyc
 	// alpha: v1.21
 	VolumeCapacityPriority featuregate.Feature = "VolumeCapacityPriority"
@@ -913,6 +937,8 @@ var defaultKubernetesFeatureGates = map[featuregate.Feature]featuregate.FeatureS
 
 	JobMutableNodeSchedulingDirectives: {Default: true, PreRelease: featuregate.Beta},
 
+	JobPodReplacementPolicy: {Default: false, PreRelease: featuregate.Alpha},
+
 	JobReadyPods: {Default: true, PreRelease: featuregate.Beta},
 
 	JobTrackingWithFinalizers: {Default: false, PreRelease: featuregate.Beta}, // Disabled due to #109485
@@ -977,6 +1003,8 @@ var defaultKubernetesFeatureGates = map[featuregate.Feature]featuregate.FeatureS
 
 	RecoverVolumeExpansionFailure: {Default: false, PreRelease: featuregate.Alpha},
 
+	RetroactiveDefaultStorageClass: {Default: false, PreRelease: featuregate.Alpha},
+
 	RotateKubeletServerCertificate: {Default: true, PreRelease: featuregate.Beta},
 
 	SeccompDefault: {Default: false, PreRelease: featuregate.Alpha},
@@ -1001,6 +1029,8 @@ var defaultKubernetesFeatureGates = map[featuregate.Feature]featuregate.FeatureS
 
 	TopologyManager: {Default: true, PreRelease: featuregate.Beta},
 
+	ValidationRatcheting: {Default: false, PreRelease: featuregate.Alpha},
+
 	VolumeCapacityPriority: {Default: false, PreRelease: featuregate.Alpha},
 
 	WinDSR: {Default: false, PreRelease: featuregate.Alpha},
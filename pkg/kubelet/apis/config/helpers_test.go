@@ -185,6 +185,7 @@ var (
 		"ContainerLogMaxFiles",
 		"ContainerLogMaxSize",
 		"ContentType",
+		"DefaultFSGroupChangePolicy",
 		"EnableContentionProfiling",
 		"EnableControllerAttachDetach",
 		"EnableDebugFlagsHandler",
@@ -241,6 +242,7 @@ var (
 		"StaticPodURLHeader[*][*]",
 		"MaxOpenFiles",
 		"MaxPods",
+		"MaxVolumeUnmountDuration.Duration",
 		"MemoryManagerPolicy",
 		"MemorySwap.SwapBehavior",
 		"NodeLeaseDurationSeconds",
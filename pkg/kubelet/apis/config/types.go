@@ -203,6 +203,18 @@ type KubeletConfiguration struct {
 	ImageGCLowThresholdPercent int32
 	// How frequently to calculate and cache volume disk usage for all pods
 	VolumeStatsAggPeriod metav1.Duration
+	// maxVolumeUnmountDuration is the maximum amount of time an unmount of a
+	// volume of a terminating pod may take before the kubelet force-unmounts
+	// it (falling back to a lazy detach), records a node event and marks the
+	// pod with a VolumesForceUnmounted condition. A zero value disables
+	// forced unmounts.
+	MaxVolumeUnmountDuration metav1.Duration
+	// DefaultFSGroupChangePolicy is the fsGroupChangePolicy applied to volumes
+	// of pods that do not specify one in their security context. Valid values
+	// are "Always", "OnRootMismatch" and "OnRootMismatchUsingXattr". The empty
+	// value keeps the current behavior of always changing permissions and
+	// ownership recursively.
+	DefaultFSGroupChangePolicy string
 	// KubeletCgroups is the absolute name of cgroups to isolate the kubelet in
 	KubeletCgroups string
 	// SystemCgroups is absolute name of cgroups in which to place
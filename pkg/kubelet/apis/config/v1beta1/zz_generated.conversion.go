@@ -399,6 +399,8 @@ func autoConvert_v1beta1_KubeletConfiguration_To_config_KubeletConfiguration(in
 		return err
 	}
 	out.VolumeStatsAggPeriod = in.VolumeStatsAggPeriod
+	out.MaxVolumeUnmountDuration = in.MaxVolumeUnmountDuration
+	out.DefaultFSGroupChangePolicy = in.DefaultFSGroupChangePolicy
 	out.KubeletCgroups = in.KubeletCgroups
 	out.SystemCgroups = in.SystemCgroups
 	out.CgroupRoot = in.CgroupRoot
@@ -577,6 +579,8 @@ func autoConvert_config_KubeletConfiguration_To_v1beta1_KubeletConfiguration(in
 		return err
 	}
 	out.VolumeStatsAggPeriod = in.VolumeStatsAggPeriod
+	out.MaxVolumeUnmountDuration = in.MaxVolumeUnmountDuration
+	out.DefaultFSGroupChangePolicy = in.DefaultFSGroupChangePolicy
 	out.KubeletCgroups = in.KubeletCgroups
 	out.SystemCgroups = in.SystemCgroups
 	out.CgroupRoot = in.CgroupRoot
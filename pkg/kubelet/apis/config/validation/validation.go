@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"time"
 
+	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
@@ -32,6 +33,7 @@ import (
 	"k8s.io/kubernetes/pkg/kubelet/cm/cpuset"
 	kubetypes "k8s.io/kubernetes/pkg/kubelet/types"
 	utiltaints "k8s.io/kubernetes/pkg/util/taints"
+	"k8s.io/kubernetes/pkg/volume"
 )
 
 var (
@@ -94,6 +96,9 @@ func ValidateKubeletConfiguration(kc *kubeletconfig.KubeletConfiguration) error
 	if kc.KubeAPIQPS < 0 {
 		allErrors = append(allErrors, fmt.Errorf("invalid configuration: kubeAPIQPS (--kube-api-qps) %v must not be a negative number", kc.KubeAPIQPS))
 	}
+	if kc.MaxVolumeUnmountDuration.Duration < 0 {
+		allErrors = append(allErrors, fmt.Errorf("invalid configuration: maxVolumeUnmountDuration %v must not be a negative duration", kc.MaxVolumeUnmountDuration.Duration))
+	}
 	if kc.NodeStatusMaxImages < -1 {
 		allErrors = append(allErrors, fmt.Errorf("invalid configuration: nodeStatusMaxImages (--node-status-max-images) %v must be -1 or greater", kc.NodeStatusMaxImages))
 	}
@@ -191,6 +196,14 @@ func ValidateKubeletConfiguration(kc *kubeletconfig.KubeletConfiguration) error
 	if !localFeatureGate.Enabled(features.NodeSwap) && kc.MemorySwap != (kubeletconfig.MemorySwapConfiguration{}) {
 		allErrors = append(allErrors, fmt.Errorf("invalid configuration: memorySwap.swapBehavior cannot be set when NodeSwap feature flag is disabled"))
 	}
+	switch kc.DefaultFSGroupChangePolicy {
+	case "":
+	case string(v1.FSGroupChangeAlways):
+	case string(v1.FSGroupChangeOnRootMismatch):
+	case string(volume.FSGroupChangeOnRootMismatchUsingXattr):
+	default:
+		allErrors = append(allErrors, fmt.Errorf("invalid configuration: defaultFSGroupChangePolicy %q must be one of: \"\", %q, %q, or %q", kc.DefaultFSGroupChangePolicy, v1.FSGroupChangeAlways, v1.FSGroupChangeOnRootMismatch, volume.FSGroupChangeOnRootMismatchUsingXattr))
+	}
 
 	for _, val := range kc.EnforceNodeAllocatable {
 		switch val {
@@ -399,6 +399,14 @@ func TestValidateKubeletConfiguration(t *testing.T) {
 			},
 			errMsg: "invalid configuration: memorySwap.swapBehavior cannot be set when NodeSwap feature flag is disabled",
 		},
+		{
+			name: "invalid DefaultFSGroupChangePolicy",
+			configure: func(conf *kubeletconfig.KubeletConfiguration) *kubeletconfig.KubeletConfiguration {
+				conf.DefaultFSGroupChangePolicy = "invalid-policy"
+				return conf
+			},
+			errMsg: "invalid configuration: defaultFSGroupChangePolicy \"invalid-policy\" must be one of: \"\", \"Always\", \"OnRootMismatch\", or \"OnRootMismatchUsingXattr\"",
+		},
 		{
 			name: "specify SystemReservedEnforcementKey without specifying SystemReservedCgroup",
 			configure: func(conf *kubeletconfig.KubeletConfiguration) *kubeletconfig.KubeletConfiguration {
@@ -33,16 +33,18 @@ type v1PodResourcesServer struct {
 	devicesProvider DevicesProvider
 	cpusProvider    CPUsProvider
 	memoryProvider  MemoryProvider
+	volumesProvider VolumesProvider
 }
 
 // NewV1PodResourcesServer returns a PodResourcesListerServer which lists pods provided by the PodsProvider
 // with device information provided by the DevicesProvider
-func NewV1PodResourcesServer(podsProvider PodsProvider, devicesProvider DevicesProvider, cpusProvider CPUsProvider, memoryProvider MemoryProvider) v1.PodResourcesListerServer {
+func NewV1PodResourcesServer(podsProvider PodsProvider, devicesProvider DevicesProvider, cpusProvider CPUsProvider, memoryProvider MemoryProvider, volumesProvider VolumesProvider) v1.PodResourcesListerServer {
 	return &v1PodResourcesServer{
 		podsProvider:    podsProvider,
 		devicesProvider: devicesProvider,
 		cpusProvider:    cpusProvider,
 		memoryProvider:  memoryProvider,
+		volumesProvider: volumesProvider,
 	}
 }
 
@@ -60,6 +62,7 @@ func (p *v1PodResourcesServer) List(ctx context.Context, req *v1.ListPodResource
 			Name:       pod.Name,
 			Namespace:  pod.Namespace,
 			Containers: make([]*v1.ContainerResources, len(pod.Spec.Containers)),
+			Volumes:    p.volumesProvider.GetVolumes(string(pod.UID)),
 		}
 
 		for j, container := range pod.Spec.Containers {
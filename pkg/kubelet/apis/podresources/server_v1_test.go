@@ -53,6 +53,14 @@ func TestListPodResourcesV1(t *testing.T) {
 
 	cpus := []int64{12, 23, 30}
 
+	vols := []*podresourcesapi.PodVolume{
+		{
+			Name:          "volume-name",
+			CsiDriverName: "csi-driver",
+			DevicePath:    "/var/lib/kubelet/plugins/kubernetes.io/csi/pv/pv-name/globalmount",
+		},
+	}
+
 	memory := []*podresourcesapi.ContainerMemory{
 		{
 			MemoryType: "memory",
@@ -72,6 +80,7 @@ func TestListPodResourcesV1(t *testing.T) {
 		devices          []*podresourcesapi.ContainerDevices
 		cpus             []int64
 		memory           []*podresourcesapi.ContainerMemory
+		volumes          []*podresourcesapi.PodVolume
 		expectedResponse *podresourcesapi.ListPodResourcesResponse
 	}{
 		{
@@ -80,6 +89,7 @@ func TestListPodResourcesV1(t *testing.T) {
 			devices:          []*podresourcesapi.ContainerDevices{},
 			cpus:             []int64{},
 			memory:           []*podresourcesapi.ContainerMemory{},
+			volumes:          []*podresourcesapi.PodVolume{},
 			expectedResponse: &podresourcesapi.ListPodResourcesResponse{},
 		},
 		{
@@ -103,6 +113,7 @@ func TestListPodResourcesV1(t *testing.T) {
 			devices: []*podresourcesapi.ContainerDevices{},
 			cpus:    []int64{},
 			memory:  []*podresourcesapi.ContainerMemory{},
+			volumes: []*podresourcesapi.PodVolume{},
 			expectedResponse: &podresourcesapi.ListPodResourcesResponse{
 				PodResources: []*podresourcesapi.PodResources{
 					{
@@ -139,6 +150,7 @@ func TestListPodResourcesV1(t *testing.T) {
 			devices: devs,
 			cpus:    cpus,
 			memory:  memory,
+			volumes: vols,
 			expectedResponse: &podresourcesapi.ListPodResourcesResponse{
 				PodResources: []*podresourcesapi.PodResources{
 					{
@@ -152,6 +164,7 @@ func TestListPodResourcesV1(t *testing.T) {
 								Memory:  memory,
 							},
 						},
+						Volumes: vols,
 					},
 				},
 			},
@@ -162,17 +175,19 @@ func TestListPodResourcesV1(t *testing.T) {
 			mockPodsProvider := podresourcetest.NewMockPodsProvider(mockCtrl)
 			mockCPUsProvider := podresourcetest.NewMockCPUsProvider(mockCtrl)
 			mockMemoryProvider := podresourcetest.NewMockMemoryProvider(mockCtrl)
+			mockVolumesProvider := podresourcetest.NewMockVolumesProvider(mockCtrl)
 
 			mockPodsProvider.EXPECT().GetPods().Return(tc.pods).AnyTimes().AnyTimes()
 			mockDevicesProvider.EXPECT().GetDevices(string(podUID), containerName).Return(tc.devices).AnyTimes()
 			mockCPUsProvider.EXPECT().GetCPUs(string(podUID), containerName).Return(tc.cpus).AnyTimes()
 			mockMemoryProvider.EXPECT().GetMemory(string(podUID), containerName).Return(tc.memory).AnyTimes()
+			mockVolumesProvider.EXPECT().GetVolumes(string(podUID)).Return(tc.volumes).AnyTimes()
 			mockDevicesProvider.EXPECT().UpdateAllocatedDevices().Return().AnyTimes()
 			mockCPUsProvider.EXPECT().GetAllocatableCPUs().Return([]int64{}).AnyTimes()
 			mockDevicesProvider.EXPECT().GetAllocatableDevices().Return([]*podresourcesapi.ContainerDevices{}).AnyTimes()
 			mockMemoryProvider.EXPECT().GetAllocatableMemory().Return([]*podresourcesapi.ContainerMemory{}).AnyTimes()
 
-			server := NewV1PodResourcesServer(mockPodsProvider, mockDevicesProvider, mockCPUsProvider, mockMemoryProvider)
+			server := NewV1PodResourcesServer(mockPodsProvider, mockDevicesProvider, mockCPUsProvider, mockMemoryProvider, mockVolumesProvider)
 			resp, err := server.List(context.TODO(), &podresourcesapi.ListPodResourcesRequest{})
 			if err != nil {
 				t.Errorf("want err = %v, got %q", nil, err)
@@ -450,6 +465,7 @@ func TestAllocatableResources(t *testing.T) {
 			mockPodsProvider := podresourcetest.NewMockPodsProvider(mockCtrl)
 			mockCPUsProvider := podresourcetest.NewMockCPUsProvider(mockCtrl)
 			mockMemoryProvider := podresourcetest.NewMockMemoryProvider(mockCtrl)
+			mockVolumesProvider := podresourcetest.NewMockVolumesProvider(mockCtrl)
 
 			mockDevicesProvider.EXPECT().GetDevices("", "").Return([]*podresourcesapi.ContainerDevices{}).AnyTimes()
 			mockCPUsProvider.EXPECT().GetCPUs("", "").Return([]int64{}).AnyTimes()
@@ -459,7 +475,7 @@ func TestAllocatableResources(t *testing.T) {
 			mockCPUsProvider.EXPECT().GetAllocatableCPUs().Return(tc.allCPUs).AnyTimes()
 			mockMemoryProvider.EXPECT().GetAllocatableMemory().Return(tc.allMemory).AnyTimes()
 
-			server := NewV1PodResourcesServer(mockPodsProvider, mockDevicesProvider, mockCPUsProvider, mockMemoryProvider)
+			server := NewV1PodResourcesServer(mockPodsProvider, mockDevicesProvider, mockCPUsProvider, mockMemoryProvider, mockVolumesProvider)
 
 			resp, err := server.GetAllocatableResources(context.TODO(), &podresourcesapi.AllocatableResourcesRequest{})
 			if err != nil {
@@ -504,7 +520,33 @@ func equalListResponse(respA, respB *podresourcesapi.ListPodResourcesResponse) b
 				return false
 			}
 		}
+		if !equalVolumes(podResA.Volumes, podResB.Volumes) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalVolumes(volA, volB []*podresourcesapi.PodVolume) bool {
+	if len(volA) != len(volB) {
+		return false
 	}
+
+	for idx := 0; idx < len(volA); idx++ {
+		podVolA := volA[idx]
+		podVolB := volB[idx]
+
+		if podVolA.Name != podVolB.Name {
+			return false
+		}
+		if podVolA.CsiDriverName != podVolB.CsiDriverName {
+			return false
+		}
+		if podVolA.DevicePath != podVolB.DevicePath {
+			return false
+		}
+	}
+
 	return true
 }
 
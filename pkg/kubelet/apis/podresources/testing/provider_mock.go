@@ -229,3 +229,40 @@ func (mr *MockMemoryProviderMockRecorder) GetMemory(podUID, containerName interf
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMemory", reflect.TypeOf((*MockMemoryProvider)(nil).GetMemory), podUID, containerName)
 }
+
+// MockVolumesProvider is a mock of VolumesProvider interface.
+type MockVolumesProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockVolumesProviderMockRecorder
+}
+
+// MockVolumesProviderMockRecorder is the mock recorder for MockVolumesProvider.
+type MockVolumesProviderMockRecorder struct {
+	mock *MockVolumesProvider
+}
+
+// NewMockVolumesProvider creates a new mock instance.
+func NewMockVolumesProvider(ctrl *gomock.Controller) *MockVolumesProvider {
+	mock := &MockVolumesProvider{ctrl: ctrl}
+	mock.recorder = &MockVolumesProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVolumesProvider) EXPECT() *MockVolumesProviderMockRecorder {
+	return m.recorder
+}
+
+// GetVolumes mocks base method.
+func (m *MockVolumesProvider) GetVolumes(podUID string) []*v10.PodVolume {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVolumes", podUID)
+	ret0, _ := ret[0].([]*v10.PodVolume)
+	return ret0
+}
+
+// GetVolumes indicates an expected call of GetVolumes.
+func (mr *MockVolumesProviderMockRecorder) GetVolumes(podUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVolumes", reflect.TypeOf((*MockVolumesProvider)(nil).GetVolumes), podUID)
+}
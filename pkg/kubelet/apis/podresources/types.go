@@ -14,7 +14,7 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-//go:generate mockgen -source=types.go -destination=testing/provider_mock.go -package=testing DevicesProvider,PodsProvider,CPUsProvider,MemoryProvider
+//go:generate mockgen -source=types.go -destination=testing/provider_mock.go -package=testing DevicesProvider,PodsProvider,CPUsProvider,MemoryProvider,VolumesProvider
 package podresources
 
 import (
@@ -51,3 +51,9 @@ type MemoryProvider interface {
 	// GetAllocatableMemory returns the allocatable memory from the node
 	GetAllocatableMemory() []*podresourcesapi.ContainerMemory
 }
+
+// VolumesProvider knows how to provide the volumes mounted into the given pod
+type VolumesProvider interface {
+	// GetVolumes returns information about the volumes mounted into the pod
+	GetVolumes(podUID string) []*podresourcesapi.PodVolume
+}
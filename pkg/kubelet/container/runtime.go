@@ -467,6 +467,12 @@ type VolumeInfo struct {
 	// Inner volume spec name, which is the PV name if used, otherwise
 	// it is the same as the outer volume spec name.
 	InnerVolumeSpecName string
+	// DeviceMountPath is the path where the volume's device is mounted on
+	// the host, empty if the volume is not backed by an attachable device.
+	DeviceMountPath string
+	// CSIDriverName is the name of the CSI driver providing the volume,
+	// empty for non-CSI volumes.
+	CSIDriverName string
 }
 
 // VolumeMap represents the map of volumes.
@@ -525,6 +525,11 @@ func NewMainKubelet(kubeCfg *kubeletconfiginternal.KubeletConfiguration,
 		lastContainerStartedTime:                newTimeCache(),
 	}
 
+	if kubeCfg.DefaultFSGroupChangePolicy != "" {
+		policy := v1.PodFSGroupChangePolicy(kubeCfg.DefaultFSGroupChangePolicy)
+		klet.defaultFSGroupChangePolicy = &policy
+	}
+
 	if klet.cloud != nil {
 		klet.cloudResourceSyncManager = cloudresource.NewSyncManager(klet.cloud, nodeName, klet.nodeStatusUpdateFrequency)
 	}
@@ -763,6 +768,8 @@ func NewMainKubelet(kubeCfg *kubeletconfiginternal.KubeletConfiguration,
 		klet.getPodsDir(),
 		kubeDeps.Recorder,
 		keepTerminatedPodVolumes,
+		kubeCfg.MaxVolumeUnmountDuration.Duration,
+		klet.statusManager.SetPodCondition,
 		volumepathhandler.NewBlockVolumePathHandler())
 
 	klet.backOff = flowcontrol.NewBackOff(backOffPeriod, MaxContainerBackOff)
@@ -1157,6 +1164,10 @@ type Kubelet struct {
 	// This can be useful for debugging volume related issues.
 	keepTerminatedPodVolumes bool // DEPRECATED
 
+	// defaultFSGroupChangePolicy, if set, is the fsGroupChangePolicy applied
+	// to volumes of pods that do not specify one in their security context.
+	defaultFSGroupChangePolicy *v1.PodFSGroupChangePolicy
+
 	// pluginmanager runs a set of asynchronous loops that figure out which
 	// plugins need to be registered/unregistered based on this node and makes it so.
 	pluginManager pluginmanager.PluginManager
@@ -2393,7 +2404,7 @@ func (kl *Kubelet) ListenAndServePodResources() {
 		klog.V(2).InfoS("Failed to get local endpoint for PodResources endpoint", "err", err)
 		return
 	}
-	server.ListenAndServePodResources(socket, kl.podManager, kl.containerManager, kl.containerManager, kl.containerManager)
+	server.ListenAndServePodResources(socket, kl.podManager, kl.containerManager, kl.containerManager, kl.containerManager, kl)
 }
 
 // Delete the eligible dead container instances in a pod. Depending on the configuration, the latest dead containers may be kept around.
@@ -1501,8 +1501,31 @@ func (kl *Kubelet) generateAPIPodStatus(pod *v1.Pod, podStatus *kubecontainer.Po
 			s.Conditions = append(s.Conditions, c)
 		}
 	}
+	// preserve conditions shared by the kubelet that were recorded in the
+	// status manager but have not reached the apiserver yet; they are set in
+	// response to one-time events and cannot be regenerated here
+	for _, c := range oldPodStatus.Conditions {
+		if kubetypes.PodConditionSharedByKubelet(c.Type) {
+			if _, current := podutil.GetPodConditionFromList(s.Conditions, c.Type); current == nil {
+				s.Conditions = append(s.Conditions, c)
+			}
+		}
+	}
 	// set all Kubelet-owned conditions
 	s.Conditions = append(s.Conditions, status.GeneratePodInitializedCondition(&pod.Spec, s.InitContainerStatuses, s.Phase))
+	// the StorageReady condition is only maintained for pods that request it
+	// through a readiness gate; it must be generated before the PodReady
+	// condition so that the gate evaluation can observe it.
+	for _, rg := range pod.Spec.ReadinessGates {
+		if rg.ConditionType == v1.PodStorageReady {
+			mountedVolumes := sets.NewString()
+			for name := range kl.volumeManager.GetMountedVolumesForPod(volumeutil.GetUniquePodName(pod)) {
+				mountedVolumes.Insert(name)
+			}
+			s.Conditions = append(s.Conditions, status.GenerateStorageReadyCondition(&pod.Spec, mountedVolumes))
+			break
+		}
+	}
 	s.Conditions = append(s.Conditions, status.GeneratePodReadyCondition(&pod.Spec, s.Conditions, s.ContainerStatuses, s.Phase))
 	s.Conditions = append(s.Conditions, status.GenerateContainersReadyCondition(&pod.Spec, s.ContainerStatuses, s.Phase))
 	s.Conditions = append(s.Conditions, v1.PodCondition{
@@ -1510,6 +1533,18 @@ func (kl *Kubelet) generateAPIPodStatus(pod *v1.Pod, podStatus *kubecontainer.Po
 		Status: v1.ConditionTrue,
 	})
 
+	// expose the per-volume mount state maintained by the volume manager
+	mountedVolumePaths := map[string]string{}
+	for name, volumeInfo := range kl.volumeManager.GetMountedVolumesForPod(volumeutil.GetUniquePodName(pod)) {
+		if volumeInfo.Mounter == nil {
+			continue
+		}
+		if volumePath, err := volumeutil.GetPath(volumeInfo.Mounter); err == nil {
+			mountedVolumePaths[name] = volumePath
+		}
+	}
+	s.VolumeStatuses = status.GenerateVolumeStatuses(&pod.Spec, mountedVolumePaths)
+
 	// set HostIP and initialize PodIP/PodIPs for host network pods
 	if kl.kubeClient != nil {
 		hostIPs, err := kl.getHostIPsAnyWay()
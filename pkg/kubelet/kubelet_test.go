@@ -364,6 +364,8 @@ func newTestKubeletWithImageList(
 		kubelet.getPodsDir(),
 		kubelet.recorder,
 		false, /* keepTerminatedPodVolumes */
+		0,     /* maxVolumeUnmountDuration */
+		kubelet.statusManager.SetPodCondition,
 		volumetest.NewBlockVolumePathHandler())
 
 	kubelet.pluginManager = pluginmanager.NewPluginManager(
@@ -27,6 +27,7 @@ import (
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
+	podresourcesapi "k8s.io/kubelet/pkg/apis/podresources/v1"
 	kubecontainer "k8s.io/kubernetes/pkg/kubelet/container"
 	"k8s.io/kubernetes/pkg/util/removeall"
 	"k8s.io/kubernetes/pkg/volume"
@@ -72,6 +73,24 @@ func (kl *Kubelet) ListBlockVolumesForPod(podUID types.UID) (map[string]volume.B
 	return volumesToReturn, len(volumesToReturn) > 0
 }
 
+// GetVolumes returns information about the volumes mounted into the given
+// pod for the podresources API. The volume name is the OuterVolumeSpecName
+// (i.e. pod.Spec.Volumes[x].Name).
+func (kl *Kubelet) GetVolumes(podUID string) []*podresourcesapi.PodVolume {
+	podVolumes := kl.volumeManager.GetMountedVolumesForPod(
+		volumetypes.UniquePodName(podUID))
+	volumes := make([]*podresourcesapi.PodVolume, 0, len(podVolumes))
+	for outerVolumeSpecName, volume := range podVolumes {
+		volumes = append(volumes, &podresourcesapi.PodVolume{
+			Name:          outerVolumeSpecName,
+			CsiDriverName: volume.CSIDriverName,
+			DevicePath:    volume.DeviceMountPath,
+		})
+	}
+
+	return volumes
+}
+
 // podVolumesExist checks with the volume manager and returns true any of the
 // pods for the specified volume are mounted or are uncertain.
 func (kl *Kubelet) podVolumesExist(podUID types.UID) bool {
@@ -0,0 +1,50 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package confighash computes a stable hash of the kubelet's effective
+// configuration. The hash is reported on the Node object so that config
+// rollout tooling can observe which nodes have applied a given
+// configuration profile.
+package confighash
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	utilcodec "k8s.io/kubernetes/pkg/kubelet/kubeletconfig/util/codec"
+
+	kubeletconfig "k8s.io/kubernetes/pkg/kubelet/apis/config"
+	kubeletconfigv1beta1 "k8s.io/kubernetes/pkg/kubelet/apis/config/v1beta1"
+)
+
+// AppliedConfigHashAnnotation is the node annotation under which the
+// kubelet reports the hash of the configuration it is currently running.
+// A configuration that cannot be hashed is reported in the kubelet log at
+// the point of node registration instead; the kubelet has no running state
+// in which a configuration failed to apply, since an invalid configuration
+// prevents startup.
+const AppliedConfigHashAnnotation = "kubelet.config.k8s.io/applied-config-hash"
+
+// ConfigHash returns a hex-encoded sha256 hash of the given configuration,
+// computed over its serialized external (v1beta1) representation so that the
+// hash is stable across kubelet restarts on the same configuration.
+func ConfigHash(kc *kubeletconfig.KubeletConfiguration) (string, error) {
+	data, err := utilcodec.EncodeKubeletConfig(kc, kubeletconfigv1beta1.SchemeGroupVersion)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode kubelet configuration for hashing: %v", err)
+	}
+	return fmt.Sprintf("sha256-%x", sha256.Sum256(data)), nil
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package confighash
+
+import (
+	"strings"
+	"testing"
+
+	kubeletconfig "k8s.io/kubernetes/pkg/kubelet/apis/config"
+)
+
+func TestConfigHash(t *testing.T) {
+	base := &kubeletconfig.KubeletConfiguration{}
+	baseHash, err := ConfigHash(base)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(baseHash, "sha256-") {
+		t.Errorf("expected hash to be prefixed with the hash algorithm, got %q", baseHash)
+	}
+
+	// the hash must be stable for equal configurations
+	sameHash, err := ConfigHash(base.DeepCopy())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if baseHash != sameHash {
+		t.Errorf("expected equal configurations to produce equal hashes, got %q and %q", baseHash, sameHash)
+	}
+
+	// and must change when the configuration changes
+	modified := base.DeepCopy()
+	modified.MaxPods = 42
+	modifiedHash, err := ConfigHash(modified)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if baseHash == modifiedHash {
+		t.Errorf("expected different configurations to produce different hashes, both were %q", baseHash)
+	}
+}
@@ -67,6 +67,31 @@ var (
 		"Abnormal volume health status. The count is either 1 or 0. 1 indicates the volume is unhealthy, 0 indicates volume is healthy",
 		[]string{"namespace", "persistentvolumeclaim"}, nil,
 		metrics.ALPHA, "")
+
+	volumeStatsReadBytesDesc = metrics.NewDesc(
+		metrics.BuildFQName("", kubeletmetrics.KubeletSubsystem, kubeletmetrics.VolumeStatsReadBytesKey),
+		"Cumulative number of bytes read from the volume's device by the pods using it",
+		[]string{"namespace", "persistentvolumeclaim"}, nil,
+		metrics.ALPHA, "",
+	)
+	volumeStatsWriteBytesDesc = metrics.NewDesc(
+		metrics.BuildFQName("", kubeletmetrics.KubeletSubsystem, kubeletmetrics.VolumeStatsWriteBytesKey),
+		"Cumulative number of bytes written to the volume's device by the pods using it",
+		[]string{"namespace", "persistentvolumeclaim"}, nil,
+		metrics.ALPHA, "",
+	)
+	volumeStatsReadsDesc = metrics.NewDesc(
+		metrics.BuildFQName("", kubeletmetrics.KubeletSubsystem, kubeletmetrics.VolumeStatsReadsKey),
+		"Cumulative number of read IOs issued to the volume's device by the pods using it",
+		[]string{"namespace", "persistentvolumeclaim"}, nil,
+		metrics.ALPHA, "",
+	)
+	volumeStatsWritesDesc = metrics.NewDesc(
+		metrics.BuildFQName("", kubeletmetrics.KubeletSubsystem, kubeletmetrics.VolumeStatsWritesKey),
+		"Cumulative number of write IOs issued to the volume's device by the pods using it",
+		[]string{"namespace", "persistentvolumeclaim"}, nil,
+		metrics.ALPHA, "",
+	)
 )
 
 type volumeStatsCollector struct {
@@ -92,6 +117,10 @@ func (collector *volumeStatsCollector) DescribeWithStability(ch chan<- *metrics.
 	ch <- volumeStatsInodesFreeDesc
 	ch <- volumeStatsInodesUsedDesc
 	ch <- volumeStatsHealthAbnormalDesc
+	ch <- volumeStatsReadBytesDesc
+	ch <- volumeStatsWriteBytesDesc
+	ch <- volumeStatsReadsDesc
+	ch <- volumeStatsWritesDesc
 }
 
 // CollectWithStability implements the metrics.StableCollector interface.
@@ -104,6 +133,10 @@ func (collector *volumeStatsCollector) CollectWithStability(ch chan<- metrics.Me
 		lv = append([]string{pvcRef.Namespace, pvcRef.Name}, lv...)
 		ch <- metrics.NewLazyConstMetric(desc, metrics.GaugeValue, v, lv...)
 	}
+	addCounter := func(desc *metrics.Desc, pvcRef *stats.PVCReference, v float64, lv ...string) {
+		lv = append([]string{pvcRef.Namespace, pvcRef.Name}, lv...)
+		ch <- metrics.NewLazyConstMetric(desc, metrics.CounterValue, v, lv...)
+	}
 	allPVCs := sets.String{}
 	for _, podStat := range podStats {
 		if podStat.VolumeStats == nil {
@@ -129,6 +162,20 @@ func (collector *volumeStatsCollector) CollectWithStability(ch chan<- metrics.Me
 			if volumeStat.VolumeHealthStats != nil {
 				addGauge(volumeStatsHealthAbnormalDesc, pvcRef, convertBoolToFloat64(volumeStat.VolumeHealthStats.Abnormal))
 			}
+			if ioStats := volumeStat.IOStats; ioStats != nil {
+				if ioStats.ReadBytes != nil {
+					addCounter(volumeStatsReadBytesDesc, pvcRef, float64(*ioStats.ReadBytes))
+				}
+				if ioStats.WriteBytes != nil {
+					addCounter(volumeStatsWriteBytesDesc, pvcRef, float64(*ioStats.WriteBytes))
+				}
+				if ioStats.ReadOperations != nil {
+					addCounter(volumeStatsReadsDesc, pvcRef, float64(*ioStats.ReadOperations))
+				}
+				if ioStats.WriteOperations != nil {
+					addCounter(volumeStatsWritesDesc, pvcRef, float64(*ioStats.WriteOperations))
+				}
+			}
 			allPVCs.Insert(pvcUniqStr)
 		}
 	}
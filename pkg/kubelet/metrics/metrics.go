@@ -51,6 +51,10 @@ const (
 	VolumeStatsInodesFreeKey           = "volume_stats_inodes_free"
 	VolumeStatsInodesUsedKey           = "volume_stats_inodes_used"
 	VolumeStatsHealthStatusAbnormalKey = "volume_stats_health_status_abnormal"
+	VolumeStatsReadBytesKey            = "volume_stats_read_bytes_total"
+	VolumeStatsWriteBytesKey           = "volume_stats_write_bytes_total"
+	VolumeStatsReadsKey                = "volume_stats_reads_total"
+	VolumeStatsWritesKey               = "volume_stats_writes_total"
 	RunningPodsKey                     = "running_pods"
 	RunningContainersKey               = "running_containers"
 	// Metrics keys of remote runtime operations
@@ -115,6 +115,8 @@ func TestRunOnce(t *testing.T) {
 		kb.getPodsDir(),
 		kb.recorder,
 		false, /* keepTerminatedPodVolumes */
+		0,     /* maxVolumeUnmountDuration */
+		kb.statusManager.SetPodCondition,
 		volumetest.NewBlockVolumePathHandler())
 
 	// TODO: Factor out "stats.Provider" from Kubelet so we don't have a cyclic dependency
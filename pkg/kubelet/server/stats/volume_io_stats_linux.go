@@ -0,0 +1,181 @@
+//go:build linux
+// +build linux
+
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stats
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sys/unix"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	stats "k8s.io/kubelet/pkg/apis/stats/v1alpha1"
+)
+
+// cgroupfsRoot is the mount point of the unified cgroup v2 hierarchy.
+const cgroupfsRoot = "/sys/fs/cgroup"
+
+// deviceID identifies a block device by its major:minor number pair, which is
+// how the kernel keys per-device IO accounting in cgroup io.stat.
+type deviceID struct {
+	major uint32
+	minor uint32
+}
+
+// ioStatEntry holds the cumulative IO counters for a single device as
+// reported by a cgroup v2 io.stat file.
+type ioStatEntry struct {
+	readBytes  uint64
+	writeBytes uint64
+	readOps    uint64
+	writeOps   uint64
+}
+
+// parseCgroupIOStat parses the content of a cgroup v2 io.stat file. Each line
+// describes one device:
+//
+//	253:0 rbytes=4096 wbytes=8192 rios=1 wios=2 dbytes=0 dios=0
+//
+// Malformed lines and unknown keys are skipped so that new kernel fields do
+// not break parsing.
+func parseCgroupIOStat(content string) map[deviceID]ioStatEntry {
+	entries := make(map[deviceID]ioStatEntry)
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		var major, minor uint32
+		if _, err := fmt.Sscanf(fields[0], "%d:%d", &major, &minor); err != nil {
+			continue
+		}
+		var entry ioStatEntry
+		for _, field := range fields[1:] {
+			key, value, found := strings.Cut(field, "=")
+			if !found {
+				continue
+			}
+			n, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				continue
+			}
+			switch key {
+			case "rbytes":
+				entry.readBytes = n
+			case "wbytes":
+				entry.writeBytes = n
+			case "rios":
+				entry.readOps = n
+			case "wios":
+				entry.writeOps = n
+			}
+		}
+		entries[deviceID{major: major, minor: minor}] = entry
+	}
+	return entries
+}
+
+// deviceIDForPath returns the major:minor numbers of the block device backing
+// the filesystem that path resides on.
+func deviceIDForPath(path string) (deviceID, error) {
+	var stat unix.Stat_t
+	if err := unix.Stat(path, &stat); err != nil {
+		return deviceID{}, err
+	}
+	dev := uint64(stat.Dev)
+	return deviceID{major: unix.Major(dev), minor: unix.Minor(dev)}, nil
+}
+
+// findPodIOStatFile locates the io.stat file of the pod cgroup by scanning the
+// pod cgroup root and its QoS class subdirectories for a directory whose name
+// contains the pod UID. Both the cgroupfs naming ("pod<uid>") and the systemd
+// naming (dashes replaced with underscores) are recognized. An empty string is
+// returned when the pod cgroup cannot be located, e.g. on cgroup v1 hosts
+// where no per-cgroup io.stat exists.
+func (s *volumeStatCalculator) findPodIOStatFile() string {
+	root := filepath.Join(cgroupfsRoot, s.statsProvider.GetPodCgroupRoot())
+	uid := string(s.pod.UID)
+	names := []string{"pod" + uid, "pod" + strings.ReplaceAll(uid, "-", "_")}
+
+	dirs := []string{root}
+	if entries, err := os.ReadDir(root); err == nil {
+		for _, entry := range entries {
+			if entry.IsDir() {
+				dirs = append(dirs, filepath.Join(root, entry.Name()))
+			}
+		}
+	}
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				continue
+			}
+			for _, name := range names {
+				if strings.Contains(entry.Name(), name) {
+					ioStatPath := filepath.Join(dir, entry.Name(), "io.stat")
+					if _, err := os.Stat(ioStatPath); err == nil {
+						return ioStatPath
+					}
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// collectVolumeIOStats maps the pod cgroup IO counters back to the device
+// backing the volume mounted at volumePath. It returns nil when the pod
+// cgroup, the backing device, or its io.stat entry cannot be determined;
+// volumes without IO statistics simply omit the field in the Summary API.
+func (s *volumeStatCalculator) collectVolumeIOStats(volumePath string) *stats.VolumeIOStats {
+	ioStatPath := s.findPodIOStatFile()
+	if ioStatPath == "" {
+		return nil
+	}
+	data, err := os.ReadFile(ioStatPath)
+	if err != nil {
+		klog.V(4).InfoS("Failed to read pod cgroup io.stat", "pod", klog.KObj(s.pod), "path", ioStatPath, "err", err)
+		return nil
+	}
+	dev, err := deviceIDForPath(volumePath)
+	if err != nil {
+		klog.V(4).InfoS("Failed to resolve volume device", "pod", klog.KObj(s.pod), "volumePath", volumePath, "err", err)
+		return nil
+	}
+	entry, found := parseCgroupIOStat(string(data))[dev]
+	if !found {
+		return nil
+	}
+	return &stats.VolumeIOStats{
+		Time:            metav1.Now(),
+		ReadBytes:       &entry.readBytes,
+		WriteBytes:      &entry.writeBytes,
+		ReadOperations:  &entry.readOps,
+		WriteOperations: &entry.writeOps,
+	}
+}
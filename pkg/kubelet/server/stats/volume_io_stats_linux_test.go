@@ -0,0 +1,78 @@
+//go:build linux
+// +build linux
+
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stats
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseCgroupIOStat(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected map[deviceID]ioStatEntry
+	}{
+		{
+			name:     "empty file",
+			content:  "",
+			expected: map[deviceID]ioStatEntry{},
+		},
+		{
+			name:    "single device",
+			content: "253:0 rbytes=4096 wbytes=8192 rios=1 wios=2 dbytes=0 dios=0\n",
+			expected: map[deviceID]ioStatEntry{
+				{major: 253, minor: 0}: {readBytes: 4096, writeBytes: 8192, readOps: 1, writeOps: 2},
+			},
+		},
+		{
+			name: "multiple devices",
+			content: "8:16 rbytes=1048576 wbytes=0 rios=256 wios=0 dbytes=0 dios=0\n" +
+				"8:0 rbytes=0 wbytes=2097152 rios=0 wios=512 dbytes=0 dios=0\n",
+			expected: map[deviceID]ioStatEntry{
+				{major: 8, minor: 16}: {readBytes: 1048576, readOps: 256},
+				{major: 8, minor: 0}:  {writeBytes: 2097152, writeOps: 512},
+			},
+		},
+		{
+			name:    "unknown keys are ignored",
+			content: "253:1 rbytes=100 wbytes=200 rios=1 wios=2 cost.usage=42\n",
+			expected: map[deviceID]ioStatEntry{
+				{major: 253, minor: 1}: {readBytes: 100, writeBytes: 200, readOps: 1, writeOps: 2},
+			},
+		},
+		{
+			name:    "malformed lines are skipped",
+			content: "not-a-device rbytes=100\n253:2\n253:3 rbytes=5\n",
+			expected: map[deviceID]ioStatEntry{
+				{major: 253, minor: 3}: {readBytes: 5},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := parseCgroupIOStat(test.content)
+			if !reflect.DeepEqual(got, test.expected) {
+				t.Errorf("parseCgroupIOStat() = %+v, expected %+v", got, test.expected)
+			}
+		})
+	}
+}
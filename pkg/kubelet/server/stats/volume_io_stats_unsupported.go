@@ -0,0 +1,30 @@
+//go:build !linux
+// +build !linux
+
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stats
+
+import (
+	stats "k8s.io/kubelet/pkg/apis/stats/v1alpha1"
+)
+
+// collectVolumeIOStats always returns nil on platforms without cgroup IO
+// accounting.
+func (s *volumeStatCalculator) collectVolumeIOStats(volumePath string) *stats.VolumeIOStats {
+	return nil
+}
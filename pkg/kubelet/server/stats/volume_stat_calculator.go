@@ -23,6 +23,7 @@ import (
 	"time"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
 	"k8s.io/client-go/tools/record"
@@ -44,6 +45,10 @@ type volumeStatCalculator struct {
 	stopO         sync.Once
 	latest        atomic.Value
 	eventRecorder record.EventRecorder
+	// abnormalVolumes holds the names of volumes that reported an abnormal
+	// condition, so that recovery can be announced once. It is only touched
+	// by the calculation goroutine.
+	abnormalVolumes sets.String
 }
 
 // PodVolumeStats encapsulates the VolumeStats for a pod.
@@ -56,11 +61,12 @@ type PodVolumeStats struct {
 // newVolumeStatCalculator creates a new VolumeStatCalculator
 func newVolumeStatCalculator(statsProvider Provider, jitterPeriod time.Duration, pod *v1.Pod, eventRecorder record.EventRecorder) *volumeStatCalculator {
 	return &volumeStatCalculator{
-		statsProvider: statsProvider,
-		jitterPeriod:  jitterPeriod,
-		pod:           pod,
-		stopChannel:   make(chan struct{}),
-		eventRecorder: eventRecorder,
+		statsProvider:   statsProvider,
+		jitterPeriod:    jitterPeriod,
+		pod:             pod,
+		stopChannel:     make(chan struct{}),
+		eventRecorder:   eventRecorder,
+		abnormalVolumes: sets.NewString(),
 	}
 }
 
@@ -103,10 +109,14 @@ func (s *volumeStatCalculator) calcAndStoreStats() {
 	}
 
 	metricVolumes := make(map[string]volume.MetricsProvider)
+	// Mount paths of filesystem volumes, used to attribute pod cgroup IO
+	// statistics back to the volume's backing device.
+	volumePaths := make(map[string]string)
 
 	if found {
 		for name, v := range volumes {
 			metricVolumes[name] = v
+			volumePaths[name] = v.GetPath()
 		}
 	}
 	if bvFound {
@@ -156,6 +166,9 @@ func (s *volumeStatCalculator) calcAndStoreStats() {
 			}
 		}
 		volumeStats := s.parsePodVolumeStats(name, pvcRef, metric, volSpec)
+		if path, ok := volumePaths[name]; ok {
+			volumeStats.IOStats = s.collectVolumeIOStats(path)
+		}
 		if util.IsLocalEphemeralVolume(volSpec) {
 			ephemeralStats = append(ephemeralStats, volumeStats)
 		} else {
@@ -165,6 +178,10 @@ func (s *volumeStatCalculator) calcAndStoreStats() {
 		if utilfeature.DefaultFeatureGate.Enabled(features.CSIVolumeHealth) {
 			if metric.Abnormal != nil && metric.Message != nil && (*metric.Abnormal) {
 				s.eventRecorder.Event(s.pod, v1.EventTypeWarning, "VolumeConditionAbnormal", fmt.Sprintf("Volume %s: %s", name, *metric.Message))
+				s.abnormalVolumes.Insert(name)
+			} else if metric.Abnormal != nil && !(*metric.Abnormal) && s.abnormalVolumes.Has(name) {
+				s.eventRecorder.Event(s.pod, v1.EventTypeNormal, "VolumeConditionNormal", fmt.Sprintf("Volume %s: health is back to normal", name))
+				s.abnormalVolumes.Delete(name)
 			}
 		}
 	}
@@ -18,10 +18,13 @@ package status
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
 
 	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	podutil "k8s.io/kubernetes/pkg/api/v1/pod"
+	volumevalidation "k8s.io/kubernetes/pkg/volume/validation"
 )
 
 const (
@@ -35,6 +38,8 @@ const (
 	ContainersNotInitialized = "ContainersNotInitialized"
 	// ReadinessGatesNotReady says that one or more pod readiness gates are not ready.
 	ReadinessGatesNotReady = "ReadinessGatesNotReady"
+	// VolumesNotReady says that one or more pod volumes have not finished their initial preparation.
+	VolumesNotReady = "VolumesNotReady"
 )
 
 // GenerateContainersReadyCondition returns the status of "ContainersReady" condition.
@@ -191,3 +196,74 @@ func GeneratePodInitializedCondition(spec *v1.PodSpec, containerStatuses []v1.Co
 		Status: v1.ConditionTrue,
 	}
 }
+
+// GenerateStorageReadyCondition returns the status of the "StorageReady"
+// condition. The condition is true once the volume manager has mounted every
+// volume the pod requires, meaning initial preparation such as fsGroup
+// ownership changes and datasource population has completed.
+func GenerateStorageReadyCondition(spec *v1.PodSpec, mountedVolumes sets.String) v1.PodCondition {
+	unreadyVolumes := []string{}
+	for _, volume := range spec.Volumes {
+		if !mountedVolumes.Has(volume.Name) {
+			unreadyVolumes = append(unreadyVolumes, volume.Name)
+		}
+	}
+	if len(unreadyVolumes) > 0 {
+		return v1.PodCondition{
+			Type:    v1.PodStorageReady,
+			Status:  v1.ConditionFalse,
+			Reason:  VolumesNotReady,
+			Message: fmt.Sprintf("volumes with unfinished preparation: %s", unreadyVolumes),
+		}
+	}
+
+	return v1.PodCondition{
+		Type:   v1.PodStorageReady,
+		Status: v1.ConditionTrue,
+	}
+}
+
+// GenerateVolumeStatuses returns one status entry per volume in the pod spec.
+// mountedVolumePaths maps the names of the volumes the volume manager has
+// mounted for the pod to the paths at which they are mounted on the host.
+// Subpaths of container volume mounts are resolved against those paths;
+// subPathExpr mounts are only resolved when their container starts and are
+// left out.
+func GenerateVolumeStatuses(spec *v1.PodSpec, mountedVolumePaths map[string]string) []v1.PodVolumeStatus {
+	if len(spec.Volumes) == 0 {
+		return nil
+	}
+	containers := append(append([]v1.Container{}, spec.InitContainers...), spec.Containers...)
+	statuses := make([]v1.PodVolumeStatus, 0, len(spec.Volumes))
+	for _, volume := range spec.Volumes {
+		volumePath, mounted := mountedVolumePaths[volume.Name]
+		status := v1.PodVolumeStatus{
+			Name:    volume.Name,
+			Mounted: mounted,
+		}
+		resolvedSubPaths := sets.NewString()
+		for _, container := range containers {
+			for _, mount := range container.VolumeMounts {
+				if mount.Name != volume.Name || mount.SubPath == "" {
+					continue
+				}
+				if filepath.IsAbs(mount.SubPath) {
+					status.Message = fmt.Sprintf("subPath %q of container %q must not be an absolute path", mount.SubPath, container.Name)
+					continue
+				}
+				if err := volumevalidation.ValidatePathNoBacksteps(mount.SubPath); err != nil {
+					status.Message = fmt.Sprintf("subPath %q of container %q is invalid: %v", mount.SubPath, container.Name, err)
+					continue
+				}
+				if mounted {
+					resolvedSubPaths.Insert(filepath.Join(volumePath, mount.SubPath))
+				}
+			}
+		}
+		if resolvedSubPaths.Len() > 0 {
+			status.ResolvedSubPaths = resolvedSubPaths.List()
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
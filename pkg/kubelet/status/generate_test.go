@@ -22,6 +22,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 func TestGenerateContainersReadyCondition(t *testing.T) {
@@ -417,6 +418,179 @@ func TestGeneratePodInitializedCondition(t *testing.T) {
 	}
 }
 
+func TestGenerateStorageReadyCondition(t *testing.T) {
+	tests := []struct {
+		spec           *v1.PodSpec
+		mountedVolumes sets.String
+		expected       v1.PodCondition
+	}{
+		{
+			spec:           &v1.PodSpec{},
+			mountedVolumes: sets.NewString(),
+			expected: v1.PodCondition{
+				Status: v1.ConditionTrue,
+			},
+		},
+		{
+			spec: &v1.PodSpec{
+				Volumes: []v1.Volume{
+					{Name: "vol1"},
+					{Name: "vol2"},
+				},
+			},
+			mountedVolumes: sets.NewString("vol1", "vol2"),
+			expected: v1.PodCondition{
+				Status: v1.ConditionTrue,
+			},
+		},
+		{
+			spec: &v1.PodSpec{
+				Volumes: []v1.Volume{
+					{Name: "vol1"},
+					{Name: "vol2"},
+				},
+			},
+			mountedVolumes: sets.NewString("vol1"),
+			expected: v1.PodCondition{
+				Status:  v1.ConditionFalse,
+				Reason:  VolumesNotReady,
+				Message: "volumes with unfinished preparation: [vol2]",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test.expected.Type = v1.PodStorageReady
+		condition := GenerateStorageReadyCondition(test.spec, test.mountedVolumes)
+		assert.Equal(t, test.expected, condition)
+	}
+}
+
+func TestGenerateVolumeStatuses(t *testing.T) {
+	tests := []struct {
+		name               string
+		spec               *v1.PodSpec
+		mountedVolumePaths map[string]string
+		expected           []v1.PodVolumeStatus
+	}{
+		{
+			name:               "no volumes",
+			spec:               &v1.PodSpec{},
+			mountedVolumePaths: map[string]string{},
+			expected:           nil,
+		},
+		{
+			name: "mounted and unmounted volumes",
+			spec: &v1.PodSpec{
+				Volumes: []v1.Volume{
+					{Name: "vol1"},
+					{Name: "vol2"},
+				},
+			},
+			mountedVolumePaths: map[string]string{
+				"vol1": "/var/lib/kubelet/pods/uid/volumes/plugin/vol1",
+			},
+			expected: []v1.PodVolumeStatus{
+				{Name: "vol1", Mounted: true},
+				{Name: "vol2", Mounted: false},
+			},
+		},
+		{
+			name: "subPaths are resolved against the volume mount path",
+			spec: &v1.PodSpec{
+				InitContainers: []v1.Container{
+					{
+						Name: "init",
+						VolumeMounts: []v1.VolumeMount{
+							{Name: "vol1", SubPath: "init"},
+						},
+					},
+				},
+				Containers: []v1.Container{
+					{
+						Name: "main",
+						VolumeMounts: []v1.VolumeMount{
+							{Name: "vol1", SubPath: "logs/main"},
+							{Name: "vol1", SubPathExpr: "$(POD_NAME)"},
+						},
+					},
+				},
+				Volumes: []v1.Volume{
+					{Name: "vol1"},
+				},
+			},
+			mountedVolumePaths: map[string]string{
+				"vol1": "/var/lib/kubelet/pods/uid/volumes/plugin/vol1",
+			},
+			expected: []v1.PodVolumeStatus{
+				{
+					Name:    "vol1",
+					Mounted: true,
+					ResolvedSubPaths: []string{
+						"/var/lib/kubelet/pods/uid/volumes/plugin/vol1/init",
+						"/var/lib/kubelet/pods/uid/volumes/plugin/vol1/logs/main",
+					},
+				},
+			},
+		},
+		{
+			name: "invalid subPaths are reported in the message",
+			spec: &v1.PodSpec{
+				Containers: []v1.Container{
+					{
+						Name: "main",
+						VolumeMounts: []v1.VolumeMount{
+							{Name: "vol1", SubPath: "/absolute"},
+						},
+					},
+				},
+				Volumes: []v1.Volume{
+					{Name: "vol1"},
+				},
+			},
+			mountedVolumePaths: map[string]string{
+				"vol1": "/var/lib/kubelet/pods/uid/volumes/plugin/vol1",
+			},
+			expected: []v1.PodVolumeStatus{
+				{
+					Name:    "vol1",
+					Mounted: true,
+					Message: "subPath \"/absolute\" of container \"main\" must not be an absolute path",
+				},
+			},
+		},
+		{
+			name: "backstepping subPaths are reported in the message",
+			spec: &v1.PodSpec{
+				Containers: []v1.Container{
+					{
+						Name: "main",
+						VolumeMounts: []v1.VolumeMount{
+							{Name: "vol1", SubPath: "../escape"},
+						},
+					},
+				},
+				Volumes: []v1.Volume{
+					{Name: "vol1"},
+				},
+			},
+			mountedVolumePaths: map[string]string{},
+			expected: []v1.PodVolumeStatus{
+				{
+					Name:    "vol1",
+					Mounted: false,
+					Message: "subPath \"../escape\" of container \"main\" is invalid: must not contain '..'",
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		statuses := GenerateVolumeStatuses(test.spec, test.mountedVolumePaths)
+		assert.Equal(t, test.expected, statuses, test.name)
+	}
+}
+
 func getPodCondition(conditionType v1.PodConditionType, status v1.ConditionStatus, reason, message string) v1.PodCondition {
 	return v1.PodCondition{
 		Type:    conditionType,
@@ -108,6 +108,14 @@ type Manager interface {
 	// triggers a status update.
 	SetContainerStartup(podUID types.UID, containerID kubecontainer.ContainerID, started bool)
 
+	// SetPodCondition updates the cached status of the given pod with the given
+	// condition, whose type must be shared by the kubelet (see
+	// kubetypes.PodConditionsSharedByKubelet), and triggers a status update.
+	// Components recording one-time conditions must write them through this
+	// method rather than updating the pod status directly, so the write does
+	// not race the regular status syncs.
+	SetPodCondition(podUID types.UID, condition v1.PodCondition)
+
 	// TerminatePod resets the container status for the provided pod to terminated and triggers
 	// a status update.
 	TerminatePod(pod *v1.Pod)
@@ -308,6 +316,41 @@ func (m *manager) SetContainerStartup(podUID types.UID, containerID kubecontaine
 	m.updateStatusInternal(pod, status, false)
 }
 
+func (m *manager) SetPodCondition(podUID types.UID, condition v1.PodCondition) {
+	if !kubetypes.PodConditionSharedByKubelet(condition.Type) {
+		klog.ErrorS(nil, "Pod condition type is not shared by kubelet, refusing to set it",
+			"podUID", string(podUID), "conditionType", condition.Type)
+		return
+	}
+
+	m.podStatusesLock.Lock()
+	defer m.podStatusesLock.Unlock()
+
+	pod, ok := m.podManager.GetPodByUID(podUID)
+	if !ok {
+		klog.V(4).InfoS("Pod has been deleted, no need to update condition",
+			"podUID", string(podUID), "conditionType", condition.Type)
+		return
+	}
+
+	// Start from the cached status so the condition does not revert other
+	// pending updates; fall back to the apiserver's view of the pod before
+	// the first sync.
+	var status v1.PodStatus
+	if oldStatus, found := m.podStatuses[pod.UID]; found {
+		status = *oldStatus.status.DeepCopy()
+	} else {
+		status = *pod.Status.DeepCopy()
+	}
+	if !podutil.UpdatePodCondition(&status, &condition) {
+		return
+	}
+
+	// Force the update: shared conditions are excluded from the
+	// kubelet-owned status comparison.
+	m.updateStatusInternal(pod, status, true)
+}
+
 func findContainerStatus(status *v1.PodStatus, containerID string) (containerStatus *v1.ContainerStatus, init bool, ok bool) {
 	// Find the container to update.
 	for i, c := range status.ContainerStatuses {
@@ -846,6 +889,15 @@ func mergePodStatus(oldPodStatus, newPodStatus v1.PodStatus, couldHaveRunningCon
 	for _, c := range newPodStatus.Conditions {
 		if kubetypes.PodConditionByKubelet(c.Type) {
 			podConditions = append(podConditions, c)
+		} else if kubetypes.PodConditionSharedByKubelet(c.Type) {
+			// Replace or append conditions the kubelet shares with other
+			// components; they are set in response to one-time events and
+			// cannot be regenerated.
+			if i, _ := podutil.GetPodConditionFromList(podConditions, c.Type); i >= 0 {
+				podConditions[i] = c
+			} else {
+				podConditions = append(podConditions, c)
+			}
 		}
 	}
 	newPodStatus.Conditions = podConditions
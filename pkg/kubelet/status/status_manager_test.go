@@ -1159,6 +1159,50 @@ func TestSetContainerStartup(t *testing.T) {
 	verifyStartup("ignore non-existent", &status, true, true, true)
 }
 
+func TestSetPodCondition(t *testing.T) {
+	pod := getTestPod()
+	m := newTestManager(&fake.Clientset{})
+	m.podManager.AddPod(pod)
+
+	verifyCondition := func(step string, conditionType v1.PodConditionType, expectSet bool) {
+		status := expectPodStatus(t, m, pod)
+		_, condition := podutil.GetPodCondition(&status, conditionType)
+		if expectSet && (condition == nil || condition.Status != v1.ConditionTrue) {
+			t.Errorf("[%s] Expected condition %q to be set to True, got %+v", step, conditionType, condition)
+		}
+		if !expectSet && condition != nil {
+			t.Errorf("[%s] Expected condition %q to be absent, got %+v", step, conditionType, condition)
+		}
+	}
+
+	t.Log("Setting a condition not shared by kubelet should be refused.")
+	m.SetPodCondition(pod.UID, v1.PodCondition{Type: v1.PodConditionType("example.com/feature"), Status: v1.ConditionTrue})
+	verifyUpdates(t, m, 0)
+	if status, ok := m.GetPodStatus(pod.UID); ok {
+		t.Errorf("Unexpected PodStatus: %+v", status)
+	}
+
+	t.Log("Setting a shared condition before the first status should update.")
+	m.SetPodCondition(pod.UID, v1.PodCondition{Type: kubetypes.VolumesForceUnmounted, Status: v1.ConditionTrue})
+	verifyUpdates(t, m, 1)
+	verifyCondition("before first status", kubetypes.VolumesForceUnmounted, true)
+
+	t.Log("Setting the initial status keeps regenerated conditions only.")
+	m.SetPodStatus(pod, getRandomPodStatus())
+	verifyUpdates(t, m, 1)
+	verifyCondition("initial status", kubetypes.VolumesForceUnmounted, false)
+
+	t.Log("Setting a shared condition after a status should update.")
+	m.SetPodCondition(pod.UID, v1.PodCondition{Type: kubetypes.VolumesForceUnmounted, Status: v1.ConditionTrue})
+	verifyUpdates(t, m, 1)
+	verifyCondition("after status", kubetypes.VolumesForceUnmounted, true)
+
+	t.Log("Setting an unchanged shared condition should do nothing.")
+	m.SetPodCondition(pod.UID, v1.PodCondition{Type: kubetypes.VolumesForceUnmounted, Status: v1.ConditionTrue})
+	verifyUpdates(t, m, 0)
+	verifyCondition("unchanged", kubetypes.VolumesForceUnmounted, true)
+}
+
 func TestSyncBatchCleanupVersions(t *testing.T) {
 	m := newTestManager(&fake.Clientset{})
 	testPod := getTestPod()
@@ -1528,6 +1572,72 @@ func TestMergePodStatus(t *testing.T) {
 				Message: "Message",
 			},
 		},
+		{
+			"condition shared by kubelet is added",
+			false,
+			func(input v1.PodStatus) v1.PodStatus { return input },
+			func(input v1.PodStatus) v1.PodStatus {
+				input.Conditions = append(input.Conditions, v1.PodCondition{
+					Type:   kubetypes.VolumesForceUnmounted,
+					Status: v1.ConditionTrue,
+				})
+				return input
+			},
+			v1.PodStatus{
+				Phase: v1.PodRunning,
+				Conditions: []v1.PodCondition{
+					{
+						Type:   v1.PodReady,
+						Status: v1.ConditionTrue,
+					},
+					{
+						Type:   v1.PodScheduled,
+						Status: v1.ConditionTrue,
+					},
+					{
+						Type:   kubetypes.VolumesForceUnmounted,
+						Status: v1.ConditionTrue,
+					},
+				},
+				Message: "Message",
+			},
+		},
+		{
+			"condition shared by kubelet replaces the old one",
+			false,
+			func(input v1.PodStatus) v1.PodStatus {
+				input.Conditions = append(input.Conditions, v1.PodCondition{
+					Type:   kubetypes.VolumesForceUnmounted,
+					Status: v1.ConditionFalse,
+				})
+				return input
+			},
+			func(input v1.PodStatus) v1.PodStatus {
+				input.Conditions = append(input.Conditions, v1.PodCondition{
+					Type:   kubetypes.VolumesForceUnmounted,
+					Status: v1.ConditionTrue,
+				})
+				return input
+			},
+			v1.PodStatus{
+				Phase: v1.PodRunning,
+				Conditions: []v1.PodCondition{
+					{
+						Type:   v1.PodReady,
+						Status: v1.ConditionTrue,
+					},
+					{
+						Type:   v1.PodScheduled,
+						Status: v1.ConditionTrue,
+					},
+					{
+						Type:   kubetypes.VolumesForceUnmounted,
+						Status: v1.ConditionTrue,
+					},
+				},
+				Message: "Message",
+			},
+		},
 		{
 			"phase is transitioning to failed and no containers running",
 			false,
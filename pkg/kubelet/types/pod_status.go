@@ -26,6 +26,7 @@ var PodConditionsByKubelet = []v1.PodConditionType{
 	v1.PodReady,
 	v1.PodInitialized,
 	v1.ContainersReady,
+	v1.PodStorageReady,
 }
 
 // PodConditionByKubelet returns if the pod condition type is owned by kubelet
@@ -37,3 +38,26 @@ func PodConditionByKubelet(conditionType v1.PodConditionType) bool {
 	}
 	return false
 }
+
+// VolumesForceUnmounted is the pod condition type set on a terminating pod
+// when at least one of its volumes was force-unmounted after exceeding the
+// configured unmount deadline.
+const VolumesForceUnmounted v1.PodConditionType = "VolumesForceUnmounted"
+
+// PodConditionsSharedByKubelet is the list of pod conditions that the kubelet
+// sets in response to one-time events rather than regenerating on every
+// status sync. The status manager carries them over between syncs instead of
+// dropping them like conditions owned by other components.
+var PodConditionsSharedByKubelet = []v1.PodConditionType{
+	VolumesForceUnmounted,
+}
+
+// PodConditionSharedByKubelet returns if the pod condition type is shared by kubelet
+func PodConditionSharedByKubelet(conditionType v1.PodConditionType) bool {
+	for _, c := range PodConditionsSharedByKubelet {
+		if c == conditionType {
+			return true
+		}
+	}
+	return false
+}
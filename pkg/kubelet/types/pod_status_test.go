@@ -28,6 +28,7 @@ func TestPodConditionByKubelet(t *testing.T) {
 		v1.PodReady,
 		v1.PodInitialized,
 		v1.ContainersReady,
+		v1.PodStorageReady,
 	}
 
 	for _, tc := range trueCases {
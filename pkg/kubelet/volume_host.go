@@ -120,6 +120,10 @@ func (kvh *kubeletVolumeHost) SetKubeletError(err error) {
 	kvh.kubelet.runtimeState.setStorageState(err)
 }
 
+func (kvh *kubeletVolumeHost) GetDefaultFSGroupChangePolicy() *v1.PodFSGroupChangePolicy {
+	return kvh.kubelet.defaultFSGroupChangePolicy
+}
+
 func (kvh *kubeletVolumeHost) GetVolumeDevicePluginDir(pluginName string) string {
 	return kvh.kubelet.getVolumeDevicePluginDir(pluginName)
 }
@@ -0,0 +1,125 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	kubetypes "k8s.io/kubernetes/pkg/kubelet/types"
+	"k8s.io/kubernetes/pkg/kubelet/volumemanager/cache"
+	volumetypes "k8s.io/kubernetes/pkg/volume/util/types"
+	mount "k8s.io/mount-utils"
+)
+
+// forcedUnmountTimeout bounds the force-unmount syscall itself; past it the
+// unmount falls back to a lazy (detach) unmount.
+const forcedUnmountTimeout = 30 * time.Second
+
+// ForcedUnmountEventReason is the reason of the node event recorded when a
+// volume is force-unmounted after exceeding the unmount deadline.
+const ForcedUnmountEventReason = "VolumeForceUnmounted"
+
+// VolumesForceUnmountedCondition is the pod condition type set on a
+// terminating pod when at least one of its volumes was force-unmounted.
+const VolumesForceUnmountedCondition = kubetypes.VolumesForceUnmounted
+
+// unmountTrackingKey identifies one pod/volume unmount in flight.
+type unmountTrackingKey struct {
+	podName    volumetypes.UniquePodName
+	volumeName v1.UniqueVolumeName
+}
+
+// trackUnmountStart records the first time an unmount for the given
+// pod/volume was attempted and returns it.
+func (rc *reconciler) trackUnmountStart(key unmountTrackingKey) time.Time {
+	if start, ok := rc.unmountStartTimes[key]; ok {
+		return start
+	}
+	now := time.Now()
+	rc.unmountStartTimes[key] = now
+	return now
+}
+
+// pruneUnmountTracking drops tracking entries for volumes that are no longer
+// mounted, i.e. whose unmount completed.
+func (rc *reconciler) pruneUnmountTracking(mountedVolumes []cache.MountedVolume) {
+	if len(rc.unmountStartTimes) == 0 {
+		return
+	}
+	mounted := make(map[unmountTrackingKey]bool, len(mountedVolumes))
+	for _, mountedVolume := range mountedVolumes {
+		mounted[unmountTrackingKey{podName: mountedVolume.PodName, volumeName: mountedVolume.VolumeName}] = true
+	}
+	for key := range rc.unmountStartTimes {
+		if !mounted[key] {
+			delete(rc.unmountStartTimes, key)
+		}
+	}
+}
+
+// forceUnmount performs a forced (and ultimately lazy) unmount of a volume
+// whose regular unmount has exceeded the deadline, marks the volume as
+// unmounted and records a node event. This keeps terminating pods from being
+// stuck for hours on dead network filesystem servers.
+func (rc *reconciler) forceUnmount(mountedVolume cache.MountedVolume) {
+	forceUnmounter, ok := rc.mounter.(mount.MounterForceUnmounter)
+	if !ok {
+		klog.V(2).InfoS(mountedVolume.GenerateMsgDetailed("Cannot force-unmount volume: mounter does not support forced unmount", ""))
+		return
+	}
+	if mountedVolume.Mounter == nil {
+		return
+	}
+	path := mountedVolume.Mounter.GetPath()
+	klog.InfoS(mountedVolume.GenerateMsgDetailed("Force-unmounting volume: unmount deadline exceeded", ""), "path", path)
+	if err := mount.CleanupMountWithForce(path, forceUnmounter, false /* extensiveMountPointCheck */, forcedUnmountTimeout); err != nil {
+		klog.ErrorS(err, mountedVolume.GenerateErrorDetailed("Force-unmount failed", err).Error())
+		return
+	}
+	if err := rc.actualStateOfWorld.MarkVolumeAsUnmounted(mountedVolume.PodName, mountedVolume.VolumeName); err != nil {
+		klog.ErrorS(err, mountedVolume.GenerateErrorDetailed("Failed to mark force-unmounted volume as unmounted", err).Error())
+	}
+	delete(rc.unmountStartTimes, unmountTrackingKey{podName: mountedVolume.PodName, volumeName: mountedVolume.VolumeName})
+	if rc.recorder != nil {
+		nodeRef := &v1.ObjectReference{Kind: "Node", Name: string(rc.nodeName), UID: types.UID(rc.nodeName), Namespace: ""}
+		rc.recorder.Eventf(nodeRef, v1.EventTypeWarning, ForcedUnmountEventReason,
+			"Volume %q of pod %q was force-unmounted after exceeding the %s unmount deadline", mountedVolume.OuterVolumeSpecName, mountedVolume.PodName, rc.maxUnmountDuration)
+	}
+	rc.markPodForceUnmounted(mountedVolume)
+}
+
+// markPodForceUnmounted sets the VolumesForceUnmounted condition on the pod
+// whose volume was force-unmounted, so the forced cleanup is visible on the
+// pod itself and not only in node events. The condition is written through
+// the kubelet status manager, not directly to the apiserver, so it does not
+// race the regular status syncs.
+func (rc *reconciler) markPodForceUnmounted(mountedVolume cache.MountedVolume) {
+	if rc.setPodCondition == nil {
+		return
+	}
+	rc.setPodCondition(types.UID(mountedVolume.PodName), v1.PodCondition{
+		Type:   VolumesForceUnmountedCondition,
+		Status: v1.ConditionTrue,
+		Reason: ForcedUnmountEventReason,
+		Message: fmt.Sprintf("Volume %q was force-unmounted after exceeding the %s unmount deadline",
+			mountedVolume.OuterVolumeSpecName, rc.maxUnmountDuration),
+	})
+}
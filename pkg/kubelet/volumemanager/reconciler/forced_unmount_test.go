@@ -0,0 +1,74 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/kubernetes/pkg/kubelet/volumemanager/cache"
+	"k8s.io/kubernetes/pkg/volume/util/operationexecutor"
+	volumetypes "k8s.io/kubernetes/pkg/volume/util/types"
+)
+
+func mountedVolumeFor(podName volumetypes.UniquePodName, volumeName v1.UniqueVolumeName) cache.MountedVolume {
+	return cache.MountedVolume{
+		MountedVolume: operationexecutor.MountedVolume{
+			PodName:    podName,
+			VolumeName: volumeName,
+		},
+	}
+}
+
+func Test_TrackUnmountStart(t *testing.T) {
+	rc := &reconciler{unmountStartTimes: map[unmountTrackingKey]time.Time{}}
+	key := unmountTrackingKey{podName: "pod1", volumeName: "volume1"}
+
+	first := rc.trackUnmountStart(key)
+	time.Sleep(time.Millisecond)
+	second := rc.trackUnmountStart(key)
+	if !first.Equal(second) {
+		t.Errorf("expected the first start time to be retained, got %v and %v", first, second)
+	}
+
+	other := rc.trackUnmountStart(unmountTrackingKey{podName: "pod2", volumeName: "volume1"})
+	if other.Equal(first) {
+		t.Errorf("expected a separate start time per pod/volume")
+	}
+	if len(rc.unmountStartTimes) != 2 {
+		t.Errorf("expected 2 tracked unmounts, got %d", len(rc.unmountStartTimes))
+	}
+}
+
+func Test_PruneUnmountTracking(t *testing.T) {
+	rc := &reconciler{unmountStartTimes: map[unmountTrackingKey]time.Time{}}
+	rc.trackUnmountStart(unmountTrackingKey{podName: "pod1", volumeName: "volume1"})
+	rc.trackUnmountStart(unmountTrackingKey{podName: "pod2", volumeName: "volume2"})
+
+	// pod2's volume finished unmounting and is no longer mounted
+	rc.pruneUnmountTracking([]cache.MountedVolume{
+		mountedVolumeFor("pod1", "volume1"),
+	})
+
+	if len(rc.unmountStartTimes) != 1 {
+		t.Fatalf("expected 1 tracked unmount after pruning, got %d", len(rc.unmountStartTimes))
+	}
+	if _, ok := rc.unmountStartTimes[unmountTrackingKey{podName: "pod1", volumeName: "volume1"}]; !ok {
+		t.Errorf("expected the still-mounted volume to remain tracked")
+	}
+}
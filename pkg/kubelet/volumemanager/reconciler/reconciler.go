@@ -40,6 +40,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
 	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/kubernetes/pkg/kubelet/config"
 	"k8s.io/kubernetes/pkg/kubelet/volumemanager/cache"
 	"k8s.io/kubernetes/pkg/util/goroutinemap/exponentialbackoff"
@@ -122,9 +123,53 @@ func NewReconciler(
 		volumePluginMgr:               volumePluginMgr,
 		kubeletPodsDir:                kubeletPodsDir,
 		timeOfLastSync:                time.Time{},
+		unmountStartTimes:             map[unmountTrackingKey]time.Time{},
 	}
 }
 
+// NewReconcilerWithForcedUnmount returns a Reconciler that additionally
+// force-unmounts (falling back to a lazy detach) volumes whose regular
+// unmount has not completed within maxUnmountDuration, recording a node
+// event and a pod condition for every forced unmount. Forced unmounts are
+// opt-in: a zero maxUnmountDuration (the KubeletConfiguration
+// maxVolumeUnmountDuration default) disables the behavior.
+func NewReconcilerWithForcedUnmount(
+	kubeClient clientset.Interface,
+	controllerAttachDetachEnabled bool,
+	loopSleepDuration time.Duration,
+	waitForAttachTimeout time.Duration,
+	nodeName types.NodeName,
+	desiredStateOfWorld cache.DesiredStateOfWorld,
+	actualStateOfWorld cache.ActualStateOfWorld,
+	populatorHasAddedPods func() bool,
+	operationExecutor operationexecutor.OperationExecutor,
+	mounter mount.Interface,
+	hostutil hostutil.HostUtils,
+	volumePluginMgr *volumepkg.VolumePluginMgr,
+	kubeletPodsDir string,
+	recorder record.EventRecorder,
+	setPodCondition func(podUID types.UID, condition v1.PodCondition),
+	maxUnmountDuration time.Duration) Reconciler {
+	rc := NewReconciler(
+		kubeClient,
+		controllerAttachDetachEnabled,
+		loopSleepDuration,
+		waitForAttachTimeout,
+		nodeName,
+		desiredStateOfWorld,
+		actualStateOfWorld,
+		populatorHasAddedPods,
+		operationExecutor,
+		mounter,
+		hostutil,
+		volumePluginMgr,
+		kubeletPodsDir).(*reconciler)
+	rc.recorder = recorder
+	rc.setPodCondition = setPodCondition
+	rc.maxUnmountDuration = maxUnmountDuration
+	return rc
+}
+
 type reconciler struct {
 	kubeClient                    clientset.Interface
 	controllerAttachDetachEnabled bool
@@ -140,6 +185,17 @@ type reconciler struct {
 	volumePluginMgr               *volumepkg.VolumePluginMgr
 	kubeletPodsDir                string
 	timeOfLastSync                time.Time
+
+	// recorder, setPodCondition and maxUnmountDuration configure forced
+	// unmounts of volumes stuck in a regular unmount; see
+	// NewReconcilerWithForcedUnmount.
+	recorder           record.EventRecorder
+	setPodCondition    func(podUID types.UID, condition v1.PodCondition)
+	maxUnmountDuration time.Duration
+	// unmountStartTimes tracks when the first unmount attempt for a
+	// pod/volume was made, so the deadline is measured from the start of the
+	// unmount rather than from the last retry.
+	unmountStartTimes map[unmountTrackingKey]time.Time
 }
 
 func (rc *reconciler) Run(stopCh <-chan struct{}) {
@@ -179,8 +235,17 @@ func (rc *reconciler) reconcile() {
 
 func (rc *reconciler) unmountVolumes() {
 	// Ensure volumes that should be unmounted are unmounted.
-	for _, mountedVolume := range rc.actualStateOfWorld.GetAllMountedVolumes() {
+	mountedVolumes := rc.actualStateOfWorld.GetAllMountedVolumes()
+	rc.pruneUnmountTracking(mountedVolumes)
+	for _, mountedVolume := range mountedVolumes {
 		if !rc.desiredStateOfWorld.PodExistsInVolume(mountedVolume.PodName, mountedVolume.VolumeName) {
+			if rc.maxUnmountDuration > 0 {
+				start := rc.trackUnmountStart(unmountTrackingKey{podName: mountedVolume.PodName, volumeName: mountedVolume.VolumeName})
+				if time.Since(start) > rc.maxUnmountDuration {
+					rc.forceUnmount(mountedVolume)
+					continue
+				}
+			}
 			// Volume is mounted, unmount it
 			klog.V(5).InfoS(mountedVolume.GenerateMsgDetailed("Starting operationExecutor.UnmountVolume", ""))
 			err := rc.operationExecutor.UnmountVolume(
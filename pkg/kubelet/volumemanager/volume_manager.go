@@ -181,6 +181,8 @@ func NewVolumeManager(
 	kubeletPodsDir string,
 	recorder record.EventRecorder,
 	keepTerminatedPodVolumes bool,
+	maxVolumeUnmountDuration time.Duration,
+	setPodCondition func(podUID k8stypes.UID, condition v1.PodCondition),
 	blockVolumePathHandler volumepathhandler.BlockVolumePathHandler) VolumeManager {
 
 	vm := &volumeManager{
@@ -213,7 +215,7 @@ func NewVolumeManager(
 		csiMigratedPluginManager,
 		intreeToCSITranslator,
 		volumePluginMgr)
-	vm.reconciler = reconciler.NewReconciler(
+	vm.reconciler = reconciler.NewReconcilerWithForcedUnmount(
 		kubeClient,
 		controllerAttachDetachEnabled,
 		reconcilerLoopSleepPeriod,
@@ -226,7 +228,10 @@ func NewVolumeManager(
 		mounter,
 		hostutil,
 		volumePluginMgr,
-		kubeletPodsDir)
+		kubeletPodsDir,
+		recorder,
+		setPodCondition,
+		maxVolumeUnmountDuration)
 
 	return vm
 }
@@ -303,6 +308,8 @@ func (vm *volumeManager) GetMountedVolumesForPod(podName types.UniquePodName) co
 			BlockVolumeMapper:   mountedVolume.BlockVolumeMapper,
 			ReadOnly:            mountedVolume.VolumeSpec.ReadOnly,
 			InnerVolumeSpecName: mountedVolume.InnerVolumeSpecName,
+			DeviceMountPath:     mountedVolume.DeviceMountPath,
+			CSIDriverName:       csiDriverName(mountedVolume.VolumeSpec),
 		}
 	}
 	return podVolumes
@@ -316,11 +323,28 @@ func (vm *volumeManager) GetPossiblyMountedVolumesForPod(podName types.UniquePod
 			BlockVolumeMapper:   mountedVolume.BlockVolumeMapper,
 			ReadOnly:            mountedVolume.VolumeSpec.ReadOnly,
 			InnerVolumeSpecName: mountedVolume.InnerVolumeSpecName,
+			DeviceMountPath:     mountedVolume.DeviceMountPath,
+			CSIDriverName:       csiDriverName(mountedVolume.VolumeSpec),
 		}
 	}
 	return podVolumes
 }
 
+// csiDriverName returns the name of the CSI driver providing the volume in
+// the given spec, or an empty string for non-CSI volumes.
+func csiDriverName(spec *volume.Spec) string {
+	if spec == nil {
+		return ""
+	}
+	if spec.PersistentVolume != nil && spec.PersistentVolume.Spec.CSI != nil {
+		return spec.PersistentVolume.Spec.CSI.Driver
+	}
+	if spec.Volume != nil && spec.Volume.CSI != nil {
+		return spec.Volume.CSI.Driver
+	}
+	return ""
+}
+
 func (vm *volumeManager) GetExtraSupplementalGroupsForPod(pod *v1.Pod) []int64 {
 	podName := util.GetUniquePodName(pod)
 	supplementalGroups := sets.NewString()
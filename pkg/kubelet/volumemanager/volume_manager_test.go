@@ -316,6 +316,8 @@ func newTestVolumeManager(t *testing.T, tmpDir string, podManager kubepod.Manage
 		"",
 		fakeRecorder,
 		false, /* keepTerminatedPodVolumes */
+		0,     /* maxVolumeUnmountDuration */
+		nil,   /* setPodCondition */
 		fakePathHandler)
 
 	return vm
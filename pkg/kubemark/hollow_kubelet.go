@@ -100,7 +100,8 @@ func NewHollowKubelet(
 	cadvisorInterface cadvisor.Interface,
 	imageService internalapi.ImageManagerService,
 	runtimeService internalapi.RuntimeService,
-	containerManager cm.ContainerManager) *HollowKubelet {
+	containerManager cm.ContainerManager,
+	volumeLatencies VolumeLatencies) *HollowKubelet {
 	d := &kubelet.Dependencies{
 		KubeClient:           client,
 		HeartbeatClient:      heartbeatClient,
@@ -114,7 +115,7 @@ func NewHollowKubelet(
 		VolumePlugins:        volumePlugins(),
 		TLSOptions:           nil,
 		OOMAdjuster:          oom.NewFakeOOMAdjuster(),
-		Mounter:              &mount.FakeMounter{},
+		Mounter:              newLatencyMounter(&mount.FakeMounter{}, volumeLatencies),
 		Subpather:            &subpath.FakeSubpath{},
 		HostUtil:             hostutil.NewFakeHostUtil(nil),
 	}
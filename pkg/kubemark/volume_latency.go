@@ -0,0 +1,95 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubemark
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/mount-utils"
+)
+
+// VolumeLatencies configures artificial delays injected into the hollow
+// kubelet's volume operations, so kubemark clusters running storage churn
+// workloads exercise the same timing behavior as clusters with real storage.
+// All latencies default to zero, which keeps volume operations instantaneous.
+type VolumeLatencies struct {
+	// Attach is the additional delay of the first mount of every volume,
+	// emulating the time a real volume needs to be attached to the node.
+	Attach time.Duration
+	// Mount is the delay of every mount operation.
+	Mount time.Duration
+	// Unmount is the delay of every unmount operation.
+	Unmount time.Duration
+}
+
+// enabled returns true if any latency is configured.
+func (l VolumeLatencies) enabled() bool {
+	return l.Attach > 0 || l.Mount > 0 || l.Unmount > 0
+}
+
+// latencyMounter delays mount and unmount operations of the wrapped mounter
+// by the configured latencies.
+type latencyMounter struct {
+	mount.Interface
+	latencies VolumeLatencies
+
+	mutex sync.Mutex
+	// attached records sources whose attach latency has already been paid.
+	attached map[string]bool
+}
+
+// newLatencyMounter wraps the given mounter with the configured latencies.
+// The mounter is returned unwrapped when no latency is configured.
+func newLatencyMounter(delegate mount.Interface, latencies VolumeLatencies) mount.Interface {
+	if !latencies.enabled() {
+		return delegate
+	}
+	return &latencyMounter{
+		Interface: delegate,
+		latencies: latencies,
+		attached:  map[string]bool{},
+	}
+}
+
+func (m *latencyMounter) Mount(source string, target string, fstype string, options []string) error {
+	m.delayMount(source)
+	return m.Interface.Mount(source, target, fstype, options)
+}
+
+func (m *latencyMounter) MountSensitive(source string, target string, fstype string, options []string, sensitiveOptions []string) error {
+	m.delayMount(source)
+	return m.Interface.MountSensitive(source, target, fstype, options, sensitiveOptions)
+}
+
+func (m *latencyMounter) Unmount(target string) error {
+	time.Sleep(m.latencies.Unmount)
+	return m.Interface.Unmount(target)
+}
+
+// delayMount sleeps for the mount latency, plus the attach latency if this is
+// the first mount of the given source.
+func (m *latencyMounter) delayMount(source string) {
+	delay := m.latencies.Mount
+	m.mutex.Lock()
+	if !m.attached[source] {
+		m.attached[source] = true
+		delay += m.latencies.Attach
+	}
+	m.mutex.Unlock()
+	time.Sleep(delay)
+}
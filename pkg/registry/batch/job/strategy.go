@@ -101,6 +101,10 @@ func (jobStrategy) PrepareForCreate(ctx context.Context, obj runtime.Object) {
 		dropJobTrackingAnnotation(job)
 	}
 
+	if !utilfeature.DefaultFeatureGate.Enabled(features.JobPodReplacementPolicy) {
+		job.Spec.PodReplacementPolicy = nil
+	}
+
 	pod.DropDisabledTemplateFields(&job.Spec.Template, nil)
 }
 
@@ -133,6 +137,10 @@ func (jobStrategy) PrepareForUpdate(ctx context.Context, obj, old runtime.Object
 		dropJobTrackingAnnotation(newJob)
 	}
 
+	if !utilfeature.DefaultFeatureGate.Enabled(features.JobPodReplacementPolicy) && oldJob.Spec.PodReplacementPolicy == nil {
+		newJob.Spec.PodReplacementPolicy = nil
+	}
+
 	pod.DropDisabledTemplateFields(&newJob.Spec.Template, &oldJob.Spec.Template)
 
 	// Any changes to the spec increment the generation number.
@@ -457,6 +457,84 @@ func TestJobStrategyValidateUpdate(t *testing.T) {
 	}
 }
 
+func TestJobStrategyPodReplacementPolicy(t *testing.T) {
+	validSelector := &metav1.LabelSelector{
+		MatchLabels: map[string]string{"a": "b"},
+	}
+	validPodTemplateSpec := api.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: validSelector.MatchLabels,
+		},
+		Spec: api.PodSpec{
+			RestartPolicy: api.RestartPolicyOnFailure,
+			DNSPolicy:     api.DNSClusterFirst,
+			Containers:    []api.Container{{Name: "abc", Image: "image", ImagePullPolicy: "IfNotPresent", TerminationMessagePolicy: api.TerminationMessageReadFile}},
+		},
+	}
+	newJob := func() *batch.Job {
+		return &batch.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "myjob",
+				Namespace:       metav1.NamespaceDefault,
+				ResourceVersion: "0",
+			},
+			Spec: batch.JobSpec{
+				Selector:             validSelector,
+				Template:             validPodTemplateSpec,
+				ManualSelector:       pointer.BoolPtr(true),
+				PodReplacementPolicy: podReplacementPolicyPtr(batch.ReplaceOnNodeFailure),
+			},
+		}
+	}
+
+	cases := map[string]struct {
+		enabled      bool
+		wantOnCreate *batch.PodReplacementPolicy
+		oldPolicySet bool
+		wantOnUpdate *batch.PodReplacementPolicy
+	}{
+		"feature disabled drops the field": {
+			enabled: false,
+		},
+		"feature enabled keeps the field": {
+			enabled:      true,
+			wantOnCreate: podReplacementPolicyPtr(batch.ReplaceOnNodeFailure),
+			wantOnUpdate: podReplacementPolicyPtr(batch.ReplaceOnNodeFailure),
+		},
+		"feature disabled keeps the field when already in use": {
+			enabled:      false,
+			oldPolicySet: true,
+			wantOnUpdate: podReplacementPolicyPtr(batch.ReplaceOnNodeFailure),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			defer featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultFeatureGate, features.JobPodReplacementPolicy, tc.enabled)()
+			ctx := genericapirequest.NewDefaultContext()
+
+			job := newJob()
+			Strategy.PrepareForCreate(ctx, job)
+			if diff := cmp.Diff(tc.wantOnCreate, job.Spec.PodReplacementPolicy); diff != "" {
+				t.Errorf("Unexpected podReplacementPolicy after create (-want,+got):\n%s", diff)
+			}
+
+			oldJob := newJob()
+			if !tc.oldPolicySet {
+				oldJob.Spec.PodReplacementPolicy = nil
+			}
+			updatedJob := newJob()
+			Strategy.PrepareForUpdate(ctx, updatedJob, oldJob)
+			if diff := cmp.Diff(tc.wantOnUpdate, updatedJob.Spec.PodReplacementPolicy); diff != "" {
+				t.Errorf("Unexpected podReplacementPolicy after update (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func podReplacementPolicyPtr(p batch.PodReplacementPolicy) *batch.PodReplacementPolicy {
+	return &p
+}
+
 func TestJobStrategyWithGeneration(t *testing.T) {
 	ctx := genericapirequest.NewDefaultContext()
 
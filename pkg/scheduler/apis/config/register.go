@@ -45,6 +45,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&VolumeBindingArgs{},
 		&NodeResourcesBalancedAllocationArgs{},
 		&NodeAffinityArgs{},
+		&PrioritySortArgs{},
+		&NodeCSIOverheadArgs{},
 	)
 	return nil
 }
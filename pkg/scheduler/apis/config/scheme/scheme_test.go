@@ -505,6 +505,10 @@ profiles:
 									{Name: "scalar3", Weight: 2}},
 							},
 						},
+						{
+							Name: "PrioritySort",
+							Args: &config.PrioritySortArgs{NonPreemptingAgingMaxBoost: 10},
+						},
 					},
 				},
 			},
@@ -565,6 +569,10 @@ profiles:
 								DefaultingType: config.SystemDefaulting,
 							},
 						},
+						{
+							Name: "PrioritySort",
+							Args: &config.PrioritySortArgs{NonPreemptingAgingMaxBoost: 10},
+						},
 						{
 							Name: "VolumeBinding",
 							Args: &config.VolumeBindingArgs{
@@ -719,6 +727,10 @@ profiles:
 								Resources: []config.ResourceSpec{{Name: "cpu", Weight: 1}, {Name: "memory", Weight: 1}},
 							},
 						},
+						{
+							Name: "PrioritySort",
+							Args: &config.PrioritySortArgs{NonPreemptingAgingMaxBoost: 10},
+						},
 					},
 				},
 			},
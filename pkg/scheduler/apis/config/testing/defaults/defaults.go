@@ -313,6 +313,12 @@ var PluginConfigsV1beta3 = []config.PluginConfig{
 			DefaultingType: config.SystemDefaulting,
 		},
 	},
+	{
+		Name: "PrioritySort",
+		Args: &config.PrioritySortArgs{
+			NonPreemptingAgingMaxBoost: 10,
+		},
+	},
 	{
 		Name: "VolumeBinding",
 		Args: &config.VolumeBindingArgs{
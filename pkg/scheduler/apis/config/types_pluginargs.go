@@ -179,6 +179,38 @@ type NodeAffinityArgs struct {
 	AddedAffinity *v1.NodeAffinity
 }
 
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// NodeCSIOverheadArgs holds arguments used to configure the NodeCSIOverhead plugin.
+type NodeCSIOverheadArgs struct {
+	metav1.TypeMeta
+
+	// PerDriverReserved defines resources assumed to be consumed on a node by
+	// each CSI driver installed on it, as reported by the node's CSINode
+	// object. Keys are resource names ("cpu", "memory", "ephemeral-storage")
+	// and values are resource quantities. The plugin subtracts the total
+	// reservation from the node's allocatable when checking whether a pod
+	// fits. An empty map disables the check.
+	PerDriverReserved map[string]string
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PrioritySortArgs holds arguments used to configure the PrioritySort plugin.
+type PrioritySortArgs struct {
+	metav1.TypeMeta
+
+	// NonPreemptingAgingInterval is how long a pending pod with
+	// preemptionPolicy Never must wait in the scheduling queue per point of
+	// effective priority boost. Aging lets non-preempting high-priority pods
+	// eventually outrank lower-priority pods instead of starving behind
+	// them. Zero disables aging.
+	NonPreemptingAgingInterval metav1.Duration
+	// NonPreemptingAgingMaxBoost caps the effective priority boost a pod can
+	// accumulate through aging.
+	NonPreemptingAgingMaxBoost int32
+}
+
 // ScoringStrategyType the type of scoring strategy used in NodeResourcesFit plugin.
 type ScoringStrategyType string
 
@@ -18,6 +18,7 @@ package v1beta3
 
 import (
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apiserver/pkg/util/feature"
@@ -193,6 +194,16 @@ func SetDefaults_InterPodAffinityArgs(obj *v1beta3.InterPodAffinityArgs) {
 	}
 }
 
+func SetDefaults_PrioritySortArgs(obj *v1beta3.PrioritySortArgs) {
+	if obj.NonPreemptingAgingInterval == nil {
+		// Zero keeps aging disabled unless explicitly configured.
+		obj.NonPreemptingAgingInterval = &metav1.Duration{}
+	}
+	if obj.NonPreemptingAgingMaxBoost == nil {
+		obj.NonPreemptingAgingMaxBoost = pointer.Int32Ptr(10)
+	}
+}
+
 func SetDefaults_VolumeBindingArgs(obj *v1beta3.VolumeBindingArgs) {
 	if obj.BindTimeoutSeconds == nil {
 		obj.BindTimeoutSeconds = pointer.Int64Ptr(600)
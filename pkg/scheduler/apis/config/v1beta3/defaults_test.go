@@ -102,6 +102,17 @@ var pluginConfigs = []v1beta3.PluginConfig{
 			DefaultingType: v1beta3.SystemDefaulting,
 		}},
 	},
+	{
+		Name: "PrioritySort",
+		Args: runtime.RawExtension{Object: &v1beta3.PrioritySortArgs{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "PrioritySortArgs",
+				APIVersion: "kubescheduler.config.k8s.io/v1beta3",
+			},
+			NonPreemptingAgingInterval: &metav1.Duration{},
+			NonPreemptingAgingMaxBoost: pointer.Int32Ptr(10),
+		}},
+	},
 	{
 		Name: "VolumeBinding",
 		Args: runtime.RawExtension{Object: &v1beta3.VolumeBindingArgs{
@@ -310,6 +321,17 @@ func TestSchedulerDefaults(t *testing.T) {
 									DefaultingType: v1beta3.SystemDefaulting,
 								}},
 							},
+							{
+								Name: "PrioritySort",
+								Args: runtime.RawExtension{Object: &v1beta3.PrioritySortArgs{
+									TypeMeta: metav1.TypeMeta{
+										Kind:       "PrioritySortArgs",
+										APIVersion: "kubescheduler.config.k8s.io/v1beta3",
+									},
+									NonPreemptingAgingInterval: &metav1.Duration{},
+									NonPreemptingAgingMaxBoost: pointer.Int32Ptr(10),
+								}},
+							},
 							{
 								Name: "VolumeBinding",
 								Args: runtime.RawExtension{Object: &v1beta3.VolumeBindingArgs{
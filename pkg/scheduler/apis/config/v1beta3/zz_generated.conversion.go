@@ -110,6 +110,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1beta3.NodeCSIOverheadArgs)(nil), (*config.NodeCSIOverheadArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta3_NodeCSIOverheadArgs_To_config_NodeCSIOverheadArgs(a.(*v1beta3.NodeCSIOverheadArgs), b.(*config.NodeCSIOverheadArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.NodeCSIOverheadArgs)(nil), (*v1beta3.NodeCSIOverheadArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_NodeCSIOverheadArgs_To_v1beta3_NodeCSIOverheadArgs(a.(*config.NodeCSIOverheadArgs), b.(*v1beta3.NodeCSIOverheadArgs), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1beta3.NodeResourcesBalancedAllocationArgs)(nil), (*config.NodeResourcesBalancedAllocationArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta3_NodeResourcesBalancedAllocationArgs_To_config_NodeResourcesBalancedAllocationArgs(a.(*v1beta3.NodeResourcesBalancedAllocationArgs), b.(*config.NodeResourcesBalancedAllocationArgs), scope)
 	}); err != nil {
@@ -180,6 +190,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1beta3.PrioritySortArgs)(nil), (*config.PrioritySortArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta3_PrioritySortArgs_To_config_PrioritySortArgs(a.(*v1beta3.PrioritySortArgs), b.(*config.PrioritySortArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.PrioritySortArgs)(nil), (*v1beta3.PrioritySortArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_PrioritySortArgs_To_v1beta3_PrioritySortArgs(a.(*config.PrioritySortArgs), b.(*v1beta3.PrioritySortArgs), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1beta3.RequestedToCapacityRatioParam)(nil), (*config.RequestedToCapacityRatioParam)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta3_RequestedToCapacityRatioParam_To_config_RequestedToCapacityRatioParam(a.(*v1beta3.RequestedToCapacityRatioParam), b.(*config.RequestedToCapacityRatioParam), scope)
 	}); err != nil {
@@ -555,6 +575,26 @@ func Convert_config_NodeAffinityArgs_To_v1beta3_NodeAffinityArgs(in *config.Node
 	return autoConvert_config_NodeAffinityArgs_To_v1beta3_NodeAffinityArgs(in, out, s)
 }
 
+func autoConvert_v1beta3_NodeCSIOverheadArgs_To_config_NodeCSIOverheadArgs(in *v1beta3.NodeCSIOverheadArgs, out *config.NodeCSIOverheadArgs, s conversion.Scope) error {
+	out.PerDriverReserved = *(*map[string]string)(unsafe.Pointer(&in.PerDriverReserved))
+	return nil
+}
+
+// Convert_v1beta3_NodeCSIOverheadArgs_To_config_NodeCSIOverheadArgs is an autogenerated conversion function.
+func Convert_v1beta3_NodeCSIOverheadArgs_To_config_NodeCSIOverheadArgs(in *v1beta3.NodeCSIOverheadArgs, out *config.NodeCSIOverheadArgs, s conversion.Scope) error {
+	return autoConvert_v1beta3_NodeCSIOverheadArgs_To_config_NodeCSIOverheadArgs(in, out, s)
+}
+
+func autoConvert_config_NodeCSIOverheadArgs_To_v1beta3_NodeCSIOverheadArgs(in *config.NodeCSIOverheadArgs, out *v1beta3.NodeCSIOverheadArgs, s conversion.Scope) error {
+	out.PerDriverReserved = *(*map[string]string)(unsafe.Pointer(&in.PerDriverReserved))
+	return nil
+}
+
+// Convert_config_NodeCSIOverheadArgs_To_v1beta3_NodeCSIOverheadArgs is an autogenerated conversion function.
+func Convert_config_NodeCSIOverheadArgs_To_v1beta3_NodeCSIOverheadArgs(in *config.NodeCSIOverheadArgs, out *v1beta3.NodeCSIOverheadArgs, s conversion.Scope) error {
+	return autoConvert_config_NodeCSIOverheadArgs_To_v1beta3_NodeCSIOverheadArgs(in, out, s)
+}
+
 func autoConvert_v1beta3_NodeResourcesBalancedAllocationArgs_To_config_NodeResourcesBalancedAllocationArgs(in *v1beta3.NodeResourcesBalancedAllocationArgs, out *config.NodeResourcesBalancedAllocationArgs, s conversion.Scope) error {
 	out.Resources = *(*[]config.ResourceSpec)(unsafe.Pointer(&in.Resources))
 	return nil
@@ -825,6 +865,36 @@ func Convert_config_PodTopologySpreadArgs_To_v1beta3_PodTopologySpreadArgs(in *c
 	return autoConvert_config_PodTopologySpreadArgs_To_v1beta3_PodTopologySpreadArgs(in, out, s)
 }
 
+func autoConvert_v1beta3_PrioritySortArgs_To_config_PrioritySortArgs(in *v1beta3.PrioritySortArgs, out *config.PrioritySortArgs, s conversion.Scope) error {
+	if err := v1.Convert_Pointer_v1_Duration_To_v1_Duration(&in.NonPreemptingAgingInterval, &out.NonPreemptingAgingInterval, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_Pointer_int32_To_int32(&in.NonPreemptingAgingMaxBoost, &out.NonPreemptingAgingMaxBoost, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta3_PrioritySortArgs_To_config_PrioritySortArgs is an autogenerated conversion function.
+func Convert_v1beta3_PrioritySortArgs_To_config_PrioritySortArgs(in *v1beta3.PrioritySortArgs, out *config.PrioritySortArgs, s conversion.Scope) error {
+	return autoConvert_v1beta3_PrioritySortArgs_To_config_PrioritySortArgs(in, out, s)
+}
+
+func autoConvert_config_PrioritySortArgs_To_v1beta3_PrioritySortArgs(in *config.PrioritySortArgs, out *v1beta3.PrioritySortArgs, s conversion.Scope) error {
+	if err := v1.Convert_v1_Duration_To_Pointer_v1_Duration(&in.NonPreemptingAgingInterval, &out.NonPreemptingAgingInterval, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_int32_To_Pointer_int32(&in.NonPreemptingAgingMaxBoost, &out.NonPreemptingAgingMaxBoost, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_config_PrioritySortArgs_To_v1beta3_PrioritySortArgs is an autogenerated conversion function.
+func Convert_config_PrioritySortArgs_To_v1beta3_PrioritySortArgs(in *config.PrioritySortArgs, out *v1beta3.PrioritySortArgs, s conversion.Scope) error {
+	return autoConvert_config_PrioritySortArgs_To_v1beta3_PrioritySortArgs(in, out, s)
+}
+
 func autoConvert_v1beta3_RequestedToCapacityRatioParam_To_config_RequestedToCapacityRatioParam(in *v1beta3.RequestedToCapacityRatioParam, out *config.RequestedToCapacityRatioParam, s conversion.Scope) error {
 	out.Shape = *(*[]config.UtilizationShapePoint)(unsafe.Pointer(&in.Shape))
 	return nil
@@ -40,6 +40,7 @@ func RegisterDefaults(scheme *runtime.Scheme) error {
 	})
 	scheme.AddTypeDefaultingFunc(&v1beta3.NodeResourcesFitArgs{}, func(obj interface{}) { SetObjectDefaults_NodeResourcesFitArgs(obj.(*v1beta3.NodeResourcesFitArgs)) })
 	scheme.AddTypeDefaultingFunc(&v1beta3.PodTopologySpreadArgs{}, func(obj interface{}) { SetObjectDefaults_PodTopologySpreadArgs(obj.(*v1beta3.PodTopologySpreadArgs)) })
+	scheme.AddTypeDefaultingFunc(&v1beta3.PrioritySortArgs{}, func(obj interface{}) { SetObjectDefaults_PrioritySortArgs(obj.(*v1beta3.PrioritySortArgs)) })
 	scheme.AddTypeDefaultingFunc(&v1beta3.VolumeBindingArgs{}, func(obj interface{}) { SetObjectDefaults_VolumeBindingArgs(obj.(*v1beta3.VolumeBindingArgs)) })
 	return nil
 }
@@ -68,6 +69,10 @@ func SetObjectDefaults_PodTopologySpreadArgs(in *v1beta3.PodTopologySpreadArgs)
 	SetDefaults_PodTopologySpreadArgs(in)
 }
 
+func SetObjectDefaults_PrioritySortArgs(in *v1beta3.PrioritySortArgs) {
+	SetDefaults_PrioritySortArgs(in)
+}
+
 func SetObjectDefaults_VolumeBindingArgs(in *v1beta3.VolumeBindingArgs) {
 	SetDefaults_VolumeBindingArgs(in)
 }
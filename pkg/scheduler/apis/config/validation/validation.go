@@ -177,9 +177,11 @@ func validatePluginConfig(path *field.Path, apiVersion string, profile *config.K
 		"DefaultPreemption":               ValidateDefaultPreemptionArgs,
 		"InterPodAffinity":                ValidateInterPodAffinityArgs,
 		"NodeAffinity":                    ValidateNodeAffinityArgs,
+		"NodeCSIOverhead":                 ValidateNodeCSIOverheadArgs,
 		"NodeResourcesBalancedAllocation": ValidateNodeResourcesBalancedAllocationArgs,
 		"NodeResourcesFitArgs":            ValidateNodeResourcesFitArgs,
 		"PodTopologySpread":               ValidatePodTopologySpreadArgs,
+		"PrioritySort":                    ValidatePrioritySortArgs,
 		"VolumeBinding":                   ValidateVolumeBindingArgs,
 	}
 
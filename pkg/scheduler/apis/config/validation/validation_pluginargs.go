@@ -21,6 +21,7 @@ import (
 	"strings"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -257,6 +258,42 @@ type VolumeBindingArgsValidationOptions struct {
 	AllowVolumeCapacityPriority bool
 }
 
+// ValidateNodeCSIOverheadArgs validates that NodeCSIOverheadArgs are set correctly.
+func ValidateNodeCSIOverheadArgs(path *field.Path, args *config.NodeCSIOverheadArgs) error {
+	var allErrs field.ErrorList
+	reservedPath := path.Child("perDriverReserved")
+	supportedResources := []string{string(v1.ResourceCPU), string(v1.ResourceMemory), string(v1.ResourceEphemeralStorage)}
+	for name, value := range args.PerDriverReserved {
+		switch v1.ResourceName(name) {
+		case v1.ResourceCPU, v1.ResourceMemory, v1.ResourceEphemeralStorage:
+		default:
+			allErrs = append(allErrs, field.NotSupported(reservedPath.Key(name), name, supportedResources))
+			continue
+		}
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(reservedPath.Key(name), value, "must be a valid resource quantity"))
+			continue
+		}
+		if quantity.Sign() < 0 {
+			allErrs = append(allErrs, field.Invalid(reservedPath.Key(name), value, "must not be a negative quantity"))
+		}
+	}
+	return allErrs.ToAggregate()
+}
+
+// ValidatePrioritySortArgs validates that PrioritySortArgs are set correctly.
+func ValidatePrioritySortArgs(path *field.Path, args *config.PrioritySortArgs) error {
+	var allErrs field.ErrorList
+	if args.NonPreemptingAgingInterval.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("nonPreemptingAgingInterval"), args.NonPreemptingAgingInterval, "invalid NonPreemptingAgingInterval, should not be a negative value"))
+	}
+	if args.NonPreemptingAgingMaxBoost < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("nonPreemptingAgingMaxBoost"), args.NonPreemptingAgingMaxBoost, "invalid NonPreemptingAgingMaxBoost, should not be a negative value"))
+	}
+	return allErrs.ToAggregate()
+}
+
 // ValidateVolumeBindingArgs validates that VolumeBindingArgs are set correctly.
 func ValidateVolumeBindingArgs(path *field.Path, args *config.VolumeBindingArgs) error {
 	return ValidateVolumeBindingArgsWithOptions(path, args, VolumeBindingArgsValidationOptions{
@@ -250,6 +250,38 @@ func (in *NodeAffinityArgs) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeCSIOverheadArgs) DeepCopyInto(out *NodeCSIOverheadArgs) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.PerDriverReserved != nil {
+		in, out := &in.PerDriverReserved, &out.PerDriverReserved
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeCSIOverheadArgs.
+func (in *NodeCSIOverheadArgs) DeepCopy() *NodeCSIOverheadArgs {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeCSIOverheadArgs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NodeCSIOverheadArgs) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeResourcesBalancedAllocationArgs) DeepCopyInto(out *NodeResourcesBalancedAllocationArgs) {
 	*out = *in
@@ -441,6 +473,32 @@ func (in *PodTopologySpreadArgs) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrioritySortArgs) DeepCopyInto(out *PrioritySortArgs) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.NonPreemptingAgingInterval = in.NonPreemptingAgingInterval
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrioritySortArgs.
+func (in *PrioritySortArgs) DeepCopy() *PrioritySortArgs {
+	if in == nil {
+		return nil
+	}
+	out := new(PrioritySortArgs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PrioritySortArgs) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RequestedToCapacityRatioParam) DeepCopyInto(out *RequestedToCapacityRatioParam) {
 	*out = *in
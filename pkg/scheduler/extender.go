@@ -196,7 +196,7 @@ func (h *HTTPExtender) ProcessPreemption(
 
 	// Extender will always return NodeNameToMetaVictims.
 	// So let's convert it to NodeNameToVictims by using <nodeInfos>.
-	newNodeNameToVictims, err := h.convertToVictims(result.NodeNameToMetaVictims, nodeInfos)
+	newNodeNameToVictims, err := convertToVictims(h.extenderURL, result.NodeNameToMetaVictims, nodeInfos)
 	if err != nil {
 		return nil, err
 	}
@@ -206,7 +206,8 @@ func (h *HTTPExtender) ProcessPreemption(
 
 // convertToVictims converts "nodeNameToMetaVictims" from object identifiers,
 // such as UIDs and names, to object pointers.
-func (h *HTTPExtender) convertToVictims(
+func convertToVictims(
+	extenderName string,
 	nodeNameToMetaVictims map[string]*extenderv1.MetaVictims,
 	nodeInfos framework.NodeInfoLister,
 ) (map[string]*extenderv1.Victims, error) {
@@ -221,7 +222,7 @@ func (h *HTTPExtender) convertToVictims(
 			NumPDBViolations: metaVictims.NumPDBViolations,
 		}
 		for _, metaPod := range metaVictims.Pods {
-			pod, err := h.convertPodUIDToPod(metaPod, nodeInfo)
+			pod, err := convertPodUIDToPod(extenderName, metaPod, nodeInfo)
 			if err != nil {
 				return nil, err
 			}
@@ -236,7 +237,8 @@ func (h *HTTPExtender) convertToVictims(
 // The v1.Pod object is restored by nodeInfo.Pods().
 // It returns an error if there's cache inconsistency between default scheduler
 // and extender, i.e. when the pod is not found in nodeInfo.Pods.
-func (h *HTTPExtender) convertPodUIDToPod(
+func convertPodUIDToPod(
+	extenderName string,
 	metaPod *extenderv1.MetaPod,
 	nodeInfo *framework.NodeInfo) (*v1.Pod, error) {
 	for _, p := range nodeInfo.Pods {
@@ -245,7 +247,7 @@ func (h *HTTPExtender) convertPodUIDToPod(
 		}
 	}
 	return nil, fmt.Errorf("extender: %v claims to preempt pod (UID: %v) on node: %v, but the pod is not found on that node",
-		h.extenderURL, metaPod, nodeInfo.Node().Name)
+		extenderName, metaPod, nodeInfo.Node().Name)
 }
 
 // convertToMetaVictims converts from struct type to meta types.
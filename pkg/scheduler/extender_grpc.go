@@ -0,0 +1,421 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/encoding"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	restclient "k8s.io/client-go/rest"
+	extenderv1 "k8s.io/kube-scheduler/extender/v1"
+	schedulerapi "k8s.io/kubernetes/pkg/scheduler/apis/config"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+)
+
+const (
+	// GRPCExtenderScheme and GRPCExtenderSchemeTLS select the gRPC extender
+	// protocol in an extender's URLPrefix, with and without TLS.
+	GRPCExtenderScheme    = "grpc://"
+	GRPCExtenderSchemeTLS = "grpcs://"
+
+	// grpcExtenderService is the gRPC service the extender must implement.
+	// Requests and responses are the same JSON payloads the HTTP extender
+	// exchanges, carried with the "json" content subtype, so an extender can
+	// serve both protocols from one implementation. Each call batches all
+	// candidate nodes of a scheduling cycle.
+	grpcExtenderService = "extender.v1.Extender"
+
+	// grpcScoreCacheTTL is how long prioritize results are reused for
+	// repeated scheduling attempts of an unchanged pod, sparing the extender
+	// a call per retry.
+	grpcScoreCacheTTL = 10 * time.Second
+)
+
+// jsonCodec carries the extender's JSON payloads over gRPC.
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) { return json.Marshal(v) }
+
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error { return json.Unmarshal(data, v) }
+
+func (jsonCodec) Name() string { return "json" }
+
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
+
+// GRPCExtender implements the Extender interface over a gRPC connection
+// instead of the legacy HTTP protocol. It is selected by a grpc:// or
+// grpcs:// scheme in the extender's URLPrefix; the verb fields choose which
+// methods of the extender.v1.Extender service are called, exactly as they
+// choose URL paths for the HTTP extender.
+type GRPCExtender struct {
+	target           string
+	conn             *grpc.ClientConn
+	timeout          time.Duration
+	preemptVerb      string
+	filterVerb       string
+	prioritizeVerb   string
+	bindVerb         string
+	weight           int64
+	nodeCacheCapable bool
+	managedResources sets.String
+	ignorable        bool
+
+	scoreCacheLock sync.Mutex
+	scoreCache     map[string]grpcScoreCacheEntry
+}
+
+// grpcScoreCacheEntry remembers one prioritize response.
+type grpcScoreCacheEntry struct {
+	// podRevision is the pod's resource version; a changed pod invalidates
+	// the entry.
+	podRevision string
+	scores      extenderv1.HostPriorityList
+	expires     time.Time
+}
+
+// IsGRPCExtender returns whether the extender configuration selects the gRPC
+// protocol.
+func IsGRPCExtender(config *schedulerapi.Extender) bool {
+	return strings.HasPrefix(config.URLPrefix, GRPCExtenderScheme) ||
+		strings.HasPrefix(config.URLPrefix, GRPCExtenderSchemeTLS)
+}
+
+// NewGRPCExtender creates a GRPCExtender object. The connection is
+// established lazily, so a temporarily unreachable extender does not keep the
+// scheduler from starting.
+func NewGRPCExtender(config *schedulerapi.Extender) (framework.Extender, error) {
+	if config.HTTPTimeout.Duration.Nanoseconds() == 0 {
+		config.HTTPTimeout.Duration = time.Duration(DefaultExtenderTimeout)
+	}
+
+	var target string
+	creds := insecure.NewCredentials()
+	switch {
+	case strings.HasPrefix(config.URLPrefix, GRPCExtenderScheme):
+		target = strings.TrimPrefix(config.URLPrefix, GRPCExtenderScheme)
+	case strings.HasPrefix(config.URLPrefix, GRPCExtenderSchemeTLS):
+		target = strings.TrimPrefix(config.URLPrefix, GRPCExtenderSchemeTLS)
+		tlsConfig, err := makeGRPCTLSConfig(config)
+		if err != nil {
+			return nil, err
+		}
+		creds = credentials.NewTLS(tlsConfig)
+	default:
+		return nil, fmt.Errorf("extender URL %q does not use the %s or %s scheme", config.URLPrefix, GRPCExtenderScheme, GRPCExtenderSchemeTLS)
+	}
+
+	conn, err := grpc.Dial(target,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithDefaultCallOptions(grpc.CallContentSubtype(jsonCodec{}.Name())))
+	if err != nil {
+		return nil, err
+	}
+
+	managedResources := sets.NewString()
+	for _, r := range config.ManagedResources {
+		managedResources.Insert(string(r.Name))
+	}
+	return &GRPCExtender{
+		target:           target,
+		conn:             conn,
+		timeout:          config.HTTPTimeout.Duration,
+		preemptVerb:      config.PreemptVerb,
+		filterVerb:       config.FilterVerb,
+		prioritizeVerb:   config.PrioritizeVerb,
+		bindVerb:         config.BindVerb,
+		weight:           config.Weight,
+		nodeCacheCapable: config.NodeCacheCapable,
+		managedResources: managedResources,
+		ignorable:        config.Ignorable,
+		scoreCache:       map[string]grpcScoreCacheEntry{},
+	}, nil
+}
+
+func makeGRPCTLSConfig(config *schedulerapi.Extender) (*tls.Config, error) {
+	var cfg restclient.Config
+	if config.TLSConfig != nil {
+		cfg.TLSClientConfig.Insecure = config.TLSConfig.Insecure
+		cfg.TLSClientConfig.ServerName = config.TLSConfig.ServerName
+		cfg.TLSClientConfig.CertFile = config.TLSConfig.CertFile
+		cfg.TLSClientConfig.KeyFile = config.TLSConfig.KeyFile
+		cfg.TLSClientConfig.CAFile = config.TLSConfig.CAFile
+		cfg.TLSClientConfig.CertData = config.TLSConfig.CertData
+		cfg.TLSClientConfig.KeyData = config.TLSConfig.KeyData
+		cfg.TLSClientConfig.CAData = config.TLSConfig.CAData
+	}
+	tlsConfig, err := restclient.TLSConfigFor(&cfg)
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig == nil {
+		tlsConfig = &tls.Config{}
+	}
+	return tlsConfig, nil
+}
+
+// Name returns the dial target to identify the extender.
+func (g *GRPCExtender) Name() string {
+	return g.target
+}
+
+// IsIgnorable returns true indicates scheduling should not fail when this
+// extender is unavailable.
+func (g *GRPCExtender) IsIgnorable() bool {
+	return g.ignorable
+}
+
+// SupportsPreemption returns true if the extender has the preempt method
+// enabled.
+func (g *GRPCExtender) SupportsPreemption() bool {
+	return len(g.preemptVerb) > 0
+}
+
+// IsBinder returns whether this extender is configured for the Bind method.
+func (g *GRPCExtender) IsBinder() bool {
+	return g.bindVerb != ""
+}
+
+// call invokes the named method of the extender service with the given
+// argument, decoding the response into result.
+func (g *GRPCExtender) call(method string, args interface{}, result interface{}) error {
+	ctx, cancel := context.WithTimeout(context.Background(), g.timeout)
+	defer cancel()
+	return g.conn.Invoke(ctx, "/"+grpcExtenderService+"/"+method, args, result)
+}
+
+// makeExtenderArgs builds the argument payload for Filter and Prioritize,
+// sending node names only when the extender caches nodes itself.
+func (g *GRPCExtender) makeExtenderArgs(pod *v1.Pod, nodes []*v1.Node) *extenderv1.ExtenderArgs {
+	args := &extenderv1.ExtenderArgs{Pod: pod}
+	if g.nodeCacheCapable {
+		nodeNameSlice := make([]string, 0, len(nodes))
+		for _, node := range nodes {
+			nodeNameSlice = append(nodeNameSlice, node.Name)
+		}
+		args.NodeNames = &nodeNameSlice
+	} else {
+		nodeList := &v1.NodeList{}
+		for _, node := range nodes {
+			nodeList.Items = append(nodeList.Items, *node)
+		}
+		args.Nodes = nodeList
+	}
+	return args
+}
+
+// Filter based on extender implemented predicate functions. The filtered list
+// is expected to be a subset of the supplied list; otherwise the function
+// returns an error.
+func (g *GRPCExtender) Filter(
+	pod *v1.Pod,
+	nodes []*v1.Node,
+) (filteredList []*v1.Node, failedNodes, failedAndUnresolvableNodes extenderv1.FailedNodesMap, err error) {
+	if g.filterVerb == "" {
+		return nodes, extenderv1.FailedNodesMap{}, extenderv1.FailedNodesMap{}, nil
+	}
+	fromNodeName := make(map[string]*v1.Node)
+	for _, n := range nodes {
+		fromNodeName[n.Name] = n
+	}
+
+	var result extenderv1.ExtenderFilterResult
+	if err := g.call(g.filterVerb, g.makeExtenderArgs(pod, nodes), &result); err != nil {
+		return nil, nil, nil, err
+	}
+	if result.Error != "" {
+		return nil, nil, nil, fmt.Errorf(result.Error)
+	}
+
+	var nodeResult []*v1.Node
+	if g.nodeCacheCapable && result.NodeNames != nil {
+		nodeResult = make([]*v1.Node, len(*result.NodeNames))
+		for i, nodeName := range *result.NodeNames {
+			if n, ok := fromNodeName[nodeName]; ok {
+				nodeResult[i] = n
+			} else {
+				return nil, nil, nil, fmt.Errorf(
+					"extender %q claims a filtered node %q which is not found in the input node list",
+					g.target, nodeName)
+			}
+		}
+	} else if result.Nodes != nil {
+		nodeResult = make([]*v1.Node, len(result.Nodes.Items))
+		for i := range result.Nodes.Items {
+			nodeResult[i] = &result.Nodes.Items[i]
+		}
+	}
+
+	return nodeResult, result.FailedNodes, result.FailedAndUnresolvableNodes, nil
+}
+
+// Prioritize based on extender implemented priority functions. Results for an
+// unchanged pod are served from a short-lived cache, so retried scheduling
+// attempts do not call the extender again.
+func (g *GRPCExtender) Prioritize(pod *v1.Pod, nodes []*v1.Node) (*extenderv1.HostPriorityList, int64, error) {
+	if g.prioritizeVerb == "" {
+		result := extenderv1.HostPriorityList{}
+		for _, node := range nodes {
+			result = append(result, extenderv1.HostPriority{Host: node.Name, Score: 0})
+		}
+		return &result, 0, nil
+	}
+
+	if scores, ok := g.cachedScores(pod, nodes); ok {
+		return &scores, g.weight, nil
+	}
+
+	var result extenderv1.HostPriorityList
+	if err := g.call(g.prioritizeVerb, g.makeExtenderArgs(pod, nodes), &result); err != nil {
+		return nil, 0, err
+	}
+	g.storeScores(pod, result)
+	return &result, g.weight, nil
+}
+
+// cachedScores returns a previously stored prioritize result when the pod has
+// not changed, the entry has not expired and it covers every candidate node.
+func (g *GRPCExtender) cachedScores(pod *v1.Pod, nodes []*v1.Node) (extenderv1.HostPriorityList, bool) {
+	g.scoreCacheLock.Lock()
+	defer g.scoreCacheLock.Unlock()
+	entry, ok := g.scoreCache[string(pod.UID)]
+	if !ok {
+		return nil, false
+	}
+	if entry.podRevision != pod.ResourceVersion || time.Now().After(entry.expires) {
+		delete(g.scoreCache, string(pod.UID))
+		return nil, false
+	}
+	scored := make(map[string]int64, len(entry.scores))
+	for _, hostPriority := range entry.scores {
+		scored[hostPriority.Host] = hostPriority.Score
+	}
+	scores := make(extenderv1.HostPriorityList, 0, len(nodes))
+	for _, node := range nodes {
+		score, ok := scored[node.Name]
+		if !ok {
+			return nil, false
+		}
+		scores = append(scores, extenderv1.HostPriority{Host: node.Name, Score: score})
+	}
+	return scores, true
+}
+
+func (g *GRPCExtender) storeScores(pod *v1.Pod, scores extenderv1.HostPriorityList) {
+	g.scoreCacheLock.Lock()
+	defer g.scoreCacheLock.Unlock()
+	now := time.Now()
+	for uid, entry := range g.scoreCache {
+		if now.After(entry.expires) {
+			delete(g.scoreCache, uid)
+		}
+	}
+	g.scoreCache[string(pod.UID)] = grpcScoreCacheEntry{
+		podRevision: pod.ResourceVersion,
+		scores:      scores,
+		expires:     now.Add(grpcScoreCacheTTL),
+	}
+}
+
+// Bind delegates the action of binding a pod to a node to the extender.
+func (g *GRPCExtender) Bind(binding *v1.Binding) error {
+	if !g.IsBinder() {
+		// This shouldn't happen as this extender wouldn't have become a Binder.
+		return fmt.Errorf("unexpected empty bindVerb in extender")
+	}
+	args := &extenderv1.ExtenderBindingArgs{
+		PodName:      binding.Name,
+		PodNamespace: binding.Namespace,
+		PodUID:       binding.UID,
+		Node:         binding.Target.Name,
+	}
+	var result extenderv1.ExtenderBindingResult
+	if err := g.call(g.bindVerb, args, &result); err != nil {
+		return err
+	}
+	if result.Error != "" {
+		return fmt.Errorf(result.Error)
+	}
+	return nil
+}
+
+// ProcessPreemption returns filtered candidate nodes and victims after running
+// preemption logic in the extender.
+func (g *GRPCExtender) ProcessPreemption(
+	pod *v1.Pod,
+	nodeNameToVictims map[string]*extenderv1.Victims,
+	nodeInfos framework.NodeInfoLister,
+) (map[string]*extenderv1.Victims, error) {
+	if !g.SupportsPreemption() {
+		return nil, fmt.Errorf("preempt verb is not defined for extender %v but run into ProcessPreemption", g.target)
+	}
+
+	args := &extenderv1.ExtenderPreemptionArgs{Pod: pod}
+	if g.nodeCacheCapable {
+		args.NodeNameToMetaVictims = convertToMetaVictims(nodeNameToVictims)
+	} else {
+		args.NodeNameToVictims = nodeNameToVictims
+	}
+
+	var result extenderv1.ExtenderPreemptionResult
+	if err := g.call(g.preemptVerb, args, &result); err != nil {
+		return nil, err
+	}
+	return convertToVictims(g.target, result.NodeNameToMetaVictims, nodeInfos)
+}
+
+// IsInterested returns true if at least one extended resource requested by
+// this pod is managed by this extender.
+func (g *GRPCExtender) IsInterested(pod *v1.Pod) bool {
+	if g.managedResources.Len() == 0 {
+		return true
+	}
+	if g.hasManagedResources(pod.Spec.Containers) {
+		return true
+	}
+	return g.hasManagedResources(pod.Spec.InitContainers)
+}
+
+func (g *GRPCExtender) hasManagedResources(containers []v1.Container) bool {
+	for i := range containers {
+		container := &containers[i]
+		for resourceName := range container.Resources.Requests {
+			if g.managedResources.Has(string(resourceName)) {
+				return true
+			}
+		}
+		for resourceName := range container.Resources.Limits {
+			if g.managedResources.Has(string(resourceName)) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,206 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	extenderv1 "k8s.io/kube-scheduler/extender/v1"
+	schedulerapi "k8s.io/kubernetes/pkg/scheduler/apis/config"
+)
+
+// fakeGRPCExtenderServer serves the extender.v1.Extender service for tests,
+// keeping node "machine1" and scoring it highest.
+type fakeGRPCExtenderServer struct {
+	filterCalls     int32
+	prioritizeCalls int32
+}
+
+func (s *fakeGRPCExtenderServer) filter(args *extenderv1.ExtenderArgs) (*extenderv1.ExtenderFilterResult, error) {
+	atomic.AddInt32(&s.filterCalls, 1)
+	result := &extenderv1.ExtenderFilterResult{
+		Nodes:       &v1.NodeList{},
+		FailedNodes: extenderv1.FailedNodesMap{},
+	}
+	for _, node := range args.Nodes.Items {
+		if node.Name == "machine1" {
+			result.Nodes.Items = append(result.Nodes.Items, node)
+		} else {
+			result.FailedNodes[node.Name] = "not machine1"
+		}
+	}
+	return result, nil
+}
+
+func (s *fakeGRPCExtenderServer) prioritize(args *extenderv1.ExtenderArgs) (*extenderv1.HostPriorityList, error) {
+	atomic.AddInt32(&s.prioritizeCalls, 1)
+	result := extenderv1.HostPriorityList{}
+	for _, node := range args.Nodes.Items {
+		score := int64(1)
+		if node.Name == "machine1" {
+			score = 10
+		}
+		result = append(result, extenderv1.HostPriority{Host: node.Name, Score: score})
+	}
+	return &result, nil
+}
+
+func decodeHandler(dec func(interface{}) error, handle func(*extenderv1.ExtenderArgs) (interface{}, error)) (interface{}, error) {
+	args := &extenderv1.ExtenderArgs{}
+	if err := dec(args); err != nil {
+		return nil, err
+	}
+	return handle(args)
+}
+
+// startFakeGRPCExtender serves the fake extender on a local listener and
+// returns its dial target.
+func startFakeGRPCExtender(t *testing.T, server *fakeGRPCExtenderServer) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("cannot listen: %v", err)
+	}
+	grpcServer := grpc.NewServer()
+	grpcServer.RegisterService(&grpc.ServiceDesc{
+		ServiceName: grpcExtenderService,
+		HandlerType: (*interface{})(nil),
+		Methods: []grpc.MethodDesc{
+			{
+				MethodName: "Filter",
+				Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+					return decodeHandler(dec, func(args *extenderv1.ExtenderArgs) (interface{}, error) {
+						return srv.(*fakeGRPCExtenderServer).filter(args)
+					})
+				},
+			},
+			{
+				MethodName: "Prioritize",
+				Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+					return decodeHandler(dec, func(args *extenderv1.ExtenderArgs) (interface{}, error) {
+						return srv.(*fakeGRPCExtenderServer).prioritize(args)
+					})
+				},
+			},
+		},
+	}, server)
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+	return listener.Addr().String()
+}
+
+func TestGRPCExtender(t *testing.T) {
+	server := &fakeGRPCExtenderServer{}
+	target := startFakeGRPCExtender(t, server)
+
+	extender, err := NewGRPCExtender(&schedulerapi.Extender{
+		URLPrefix:      GRPCExtenderScheme + target,
+		FilterVerb:     "Filter",
+		PrioritizeVerb: "Prioritize",
+		Weight:         2,
+		HTTPTimeout:    metav1.Duration{Duration: 5 * time.Second},
+	})
+	if err != nil {
+		t.Fatalf("cannot create gRPC extender: %v", err)
+	}
+
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", UID: "uid1", ResourceVersion: "1"}}
+	nodes := []*v1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "machine1"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "machine2"}},
+	}
+
+	filtered, failed, _, err := extender.Filter(pod, nodes)
+	if err != nil {
+		t.Fatalf("Filter failed: %v", err)
+	}
+	if len(filtered) != 1 || filtered[0].Name != "machine1" {
+		t.Errorf("expected only machine1 to pass the filter, got %v", filtered)
+	}
+	if _, ok := failed["machine2"]; !ok {
+		t.Errorf("expected machine2 in the failed nodes map, got %v", failed)
+	}
+
+	scores, weight, err := extender.Prioritize(pod, nodes)
+	if err != nil {
+		t.Fatalf("Prioritize failed: %v", err)
+	}
+	if weight != 2 {
+		t.Errorf("expected weight 2, got %d", weight)
+	}
+	scoreByHost := map[string]int64{}
+	for _, hostPriority := range *scores {
+		scoreByHost[hostPriority.Host] = hostPriority.Score
+	}
+	if scoreByHost["machine1"] != 10 || scoreByHost["machine2"] != 1 {
+		t.Errorf("unexpected scores: %v", scoreByHost)
+	}
+}
+
+func TestGRPCExtenderScoreCache(t *testing.T) {
+	server := &fakeGRPCExtenderServer{}
+	target := startFakeGRPCExtender(t, server)
+
+	extender, err := NewGRPCExtender(&schedulerapi.Extender{
+		URLPrefix:      GRPCExtenderScheme + target,
+		PrioritizeVerb: "Prioritize",
+		Weight:         1,
+		HTTPTimeout:    metav1.Duration{Duration: 5 * time.Second},
+	})
+	if err != nil {
+		t.Fatalf("cannot create gRPC extender: %v", err)
+	}
+
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", UID: "uid1", ResourceVersion: "1"}}
+	nodes := []*v1.Node{{ObjectMeta: metav1.ObjectMeta{Name: "machine1"}}}
+
+	for i := 0; i < 2; i++ {
+		if _, _, err := extender.Prioritize(pod, nodes); err != nil {
+			t.Fatalf("Prioritize failed: %v", err)
+		}
+	}
+	if calls := atomic.LoadInt32(&server.prioritizeCalls); calls != 1 {
+		t.Errorf("expected the second prioritize call to be served from cache, extender was called %d times", calls)
+	}
+
+	// A changed pod invalidates the cached scores.
+	updatedPod := pod.DeepCopy()
+	updatedPod.ResourceVersion = "2"
+	if _, _, err := extender.Prioritize(updatedPod, nodes); err != nil {
+		t.Fatalf("Prioritize failed: %v", err)
+	}
+	if calls := atomic.LoadInt32(&server.prioritizeCalls); calls != 2 {
+		t.Errorf("expected a changed pod to bypass the cache, extender was called %d times", calls)
+	}
+
+	// A node without cached scores bypasses the cache as well.
+	moreNodes := append(nodes, &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "machine2"}})
+	if _, _, err := extender.Prioritize(updatedPod, moreNodes); err != nil {
+		t.Fatalf("Prioritize failed: %v", err)
+	}
+	if calls := atomic.LoadInt32(&server.prioritizeCalls); calls != 3 {
+		t.Errorf("expected an uncovered node to bypass the cache, extender was called %d times", calls)
+	}
+}
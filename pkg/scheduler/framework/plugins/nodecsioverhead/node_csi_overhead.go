@@ -0,0 +1,154 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodecsioverhead
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+	storagelisters "k8s.io/client-go/listers/storage/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/scheduler/apis/config"
+	"k8s.io/kubernetes/pkg/scheduler/apis/config/validation"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+	"k8s.io/kubernetes/pkg/scheduler/framework/plugins/names"
+)
+
+// Name is the name of the plugin used in the plugin registry and configurations.
+const Name = names.NodeCSIOverhead
+
+// NodeCSIOverhead is a plugin that reserves a configured amount of node
+// resources for every CSI driver installed on a node, so that pods do not get
+// scheduled into capacity that the driver daemonsets and kubelet volume
+// operations will consume.
+type NodeCSIOverhead struct {
+	csiNodeLister storagelisters.CSINodeLister
+	// perDriverReserved is the reservation applied once per installed driver.
+	perDriverReserved *framework.Resource
+}
+
+var _ framework.FilterPlugin = &NodeCSIOverhead{}
+var _ framework.EnqueueExtensions = &NodeCSIOverhead{}
+
+// Name returns name of the plugin. It is used in logs, etc.
+func (pl *NodeCSIOverhead) Name() string {
+	return Name
+}
+
+// EventsToRegister returns the possible events that may make a Pod
+// failed by this plugin schedulable.
+func (pl *NodeCSIOverhead) EventsToRegister() []framework.ClusterEvent {
+	return []framework.ClusterEvent{
+		{Resource: framework.CSINode, ActionType: framework.Add | framework.Update},
+		{Resource: framework.Pod, ActionType: framework.Delete},
+	}
+}
+
+// Filter invoked at the filter extension point. It rejects nodes whose free
+// capacity, after reserving resources for every installed CSI driver, cannot
+// hold the pod's requests.
+func (pl *NodeCSIOverhead) Filter(ctx context.Context, _ *framework.CycleState, pod *v1.Pod, nodeInfo *framework.NodeInfo) *framework.Status {
+	if pl.perDriverReserved == nil {
+		return nil
+	}
+
+	node := nodeInfo.Node()
+	if node == nil {
+		return framework.NewStatus(framework.Error, "node not found")
+	}
+
+	csiNode, err := pl.csiNodeLister.Get(node.Name)
+	if err != nil {
+		// Without a CSINode object there is nothing to reserve for.
+		klog.V(5).InfoS("Could not get a CSINode object for the node", "node", klog.KObj(node), "err", err)
+		return nil
+	}
+	drivers := int64(len(csiNode.Spec.Drivers))
+	if drivers == 0 {
+		return nil
+	}
+
+	podRequest := computePodRequest(pod)
+	if podRequest.MilliCPU == 0 && podRequest.Memory == 0 && podRequest.EphemeralStorage == 0 {
+		return nil
+	}
+
+	if podRequest.MilliCPU > 0 && podRequest.MilliCPU > nodeInfo.Allocatable.MilliCPU-nodeInfo.Requested.MilliCPU-drivers*pl.perDriverReserved.MilliCPU {
+		return framework.NewStatus(framework.Unschedulable, insufficientResourceMsg(v1.ResourceCPU, drivers))
+	}
+	if podRequest.Memory > 0 && podRequest.Memory > nodeInfo.Allocatable.Memory-nodeInfo.Requested.Memory-drivers*pl.perDriverReserved.Memory {
+		return framework.NewStatus(framework.Unschedulable, insufficientResourceMsg(v1.ResourceMemory, drivers))
+	}
+	if podRequest.EphemeralStorage > 0 && podRequest.EphemeralStorage > nodeInfo.Allocatable.EphemeralStorage-nodeInfo.Requested.EphemeralStorage-drivers*pl.perDriverReserved.EphemeralStorage {
+		return framework.NewStatus(framework.Unschedulable, insufficientResourceMsg(v1.ResourceEphemeralStorage, drivers))
+	}
+	return nil
+}
+
+func insufficientResourceMsg(resourceName v1.ResourceName, drivers int64) string {
+	return fmt.Sprintf("Insufficient %v after reserving resources for %d CSI driver(s)", resourceName, drivers)
+}
+
+// computePodRequest returns the resources requested by the pod, following the
+// same rules as the NodeResourcesFit plugin: the sum over containers, raised
+// to any init container's request, plus the pod overhead.
+func computePodRequest(pod *v1.Pod) *framework.Resource {
+	result := &framework.Resource{}
+	for _, container := range pod.Spec.Containers {
+		result.Add(container.Resources.Requests)
+	}
+	for _, container := range pod.Spec.InitContainers {
+		result.SetMaxResource(container.Resources.Requests)
+	}
+	if pod.Spec.Overhead != nil {
+		result.Add(pod.Spec.Overhead)
+	}
+	return result
+}
+
+// New initializes a new plugin and returns it.
+func New(plArgs runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+	args, ok := plArgs.(*config.NodeCSIOverheadArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type NodeCSIOverheadArgs, got %T", plArgs)
+	}
+	if err := validation.ValidateNodeCSIOverheadArgs(nil, args); err != nil {
+		return nil, err
+	}
+	pl := &NodeCSIOverhead{
+		csiNodeLister: handle.SharedInformerFactory().Storage().V1().CSINodes().Lister(),
+	}
+	if len(args.PerDriverReserved) > 0 {
+		reserved := &framework.Resource{}
+		for name, value := range args.PerDriverReserved {
+			quantity := resource.MustParse(value)
+			switch v1.ResourceName(name) {
+			case v1.ResourceCPU:
+				reserved.MilliCPU = quantity.MilliValue()
+			case v1.ResourceMemory:
+				reserved.Memory = quantity.Value()
+			case v1.ResourceEphemeralStorage:
+				reserved.EphemeralStorage = quantity.Value()
+			}
+		}
+		pl.perDriverReserved = reserved
+	}
+	return pl, nil
+}
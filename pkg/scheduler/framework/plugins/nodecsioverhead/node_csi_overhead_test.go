@@ -0,0 +1,111 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodecsioverhead
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+	fakeframework "k8s.io/kubernetes/pkg/scheduler/framework/fake"
+	st "k8s.io/kubernetes/pkg/scheduler/testing"
+)
+
+func TestNodeCSIOverheadFilter(t *testing.T) {
+	csiNode := func(driverNames ...string) *storagev1.CSINode {
+		drivers := make([]storagev1.CSINodeDriver, 0, len(driverNames))
+		for _, name := range driverNames {
+			drivers = append(drivers, storagev1.CSINodeDriver{Name: name, NodeID: "node"})
+		}
+		return &storagev1.CSINode{
+			ObjectMeta: metav1.ObjectMeta{Name: "node"},
+			Spec:       storagev1.CSINodeSpec{Drivers: drivers},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		reserved   *framework.Resource
+		csiNode    *storagev1.CSINode
+		pod        *v1.Pod
+		wantStatus *framework.Status
+	}{
+		{
+			name:     "pod fits within the reserved capacity",
+			reserved: &framework.Resource{Memory: 100 * 1024 * 1024},
+			csiNode:  csiNode("ebs.csi.aws.com"),
+			pod:      st.MakePod().Req(map[v1.ResourceName]string{v1.ResourceMemory: "500Mi"}).Obj(),
+		},
+		{
+			name:     "pod rejected when driver reservations eat the free memory",
+			reserved: &framework.Resource{Memory: 300 * 1024 * 1024},
+			csiNode:  csiNode("ebs.csi.aws.com", "efs.csi.aws.com"),
+			pod:      st.MakePod().Req(map[v1.ResourceName]string{v1.ResourceMemory: "500Mi"}).Obj(),
+			wantStatus: framework.NewStatus(framework.Unschedulable,
+				insufficientResourceMsg(v1.ResourceMemory, 2)),
+		},
+		{
+			name:     "pod rejected on cpu",
+			reserved: &framework.Resource{MilliCPU: 400},
+			csiNode:  csiNode("ebs.csi.aws.com"),
+			pod:      st.MakePod().Req(map[v1.ResourceName]string{v1.ResourceCPU: "700m"}).Obj(),
+			wantStatus: framework.NewStatus(framework.Unschedulable,
+				insufficientResourceMsg(v1.ResourceCPU, 1)),
+		},
+		{
+			name:     "no installed drivers means nothing is reserved",
+			reserved: &framework.Resource{Memory: 2 * 1024 * 1024 * 1024},
+			csiNode:  csiNode(),
+			pod:      st.MakePod().Req(map[v1.ResourceName]string{v1.ResourceMemory: "500Mi"}).Obj(),
+		},
+		{
+			name:    "plugin without configuration is a no-op",
+			csiNode: csiNode("ebs.csi.aws.com"),
+			pod:     st.MakePod().Req(map[v1.ResourceName]string{v1.ResourceMemory: "1Gi"}).Obj(),
+		},
+		{
+			name:     "best effort pod always fits",
+			reserved: &framework.Resource{Memory: 2 * 1024 * 1024 * 1024},
+			csiNode:  csiNode("ebs.csi.aws.com"),
+			pod:      st.MakePod().Obj(),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			node := st.MakeNode().Name("node").Capacity(map[v1.ResourceName]string{
+				v1.ResourceCPU:    "1",
+				v1.ResourceMemory: "1Gi",
+			}).Obj()
+			nodeInfo := framework.NewNodeInfo()
+			nodeInfo.SetNode(node)
+
+			pl := &NodeCSIOverhead{
+				csiNodeLister:     fakeframework.CSINodeLister(*test.csiNode),
+				perDriverReserved: test.reserved,
+			}
+			gotStatus := pl.Filter(context.Background(), nil, test.pod, nodeInfo)
+			if !reflect.DeepEqual(gotStatus, test.wantStatus) {
+				t.Errorf("status does not match: %v, want: %v", gotStatus, test.wantStatus)
+			}
+		})
+	}
+}
@@ -17,8 +17,14 @@ limitations under the License.
 package queuesort
 
 import (
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	corev1helpers "k8s.io/component-helpers/scheduling/corev1"
+	"k8s.io/kubernetes/pkg/scheduler/apis/config"
+	"k8s.io/kubernetes/pkg/scheduler/apis/config/validation"
 	"k8s.io/kubernetes/pkg/scheduler/framework"
 	"k8s.io/kubernetes/pkg/scheduler/framework/plugins/names"
 )
@@ -27,7 +33,13 @@ import (
 const Name = names.PrioritySort
 
 // PrioritySort is a plugin that implements Priority based sorting.
-type PrioritySort struct{}
+type PrioritySort struct {
+	// agingInterval is the queueing time after which the effective priority of
+	// a non-preempting pod is raised by one. Zero disables aging.
+	agingInterval time.Duration
+	// maxBoost caps the priority increase earned through aging.
+	maxBoost int64
+}
 
 var _ framework.QueueSortPlugin = &PrioritySort{}
 
@@ -39,13 +51,53 @@ func (pl *PrioritySort) Name() string {
 // Less is the function used by the activeQ heap algorithm to sort pods.
 // It sorts pods based on their priority. When priorities are equal, it uses
 // PodQueueInfo.timestamp.
+// Pods with preemptionPolicy Never cannot evict lower-priority pods, so when
+// aging is enabled their effective priority grows with the time they have
+// spent waiting. This lets them eventually sort ahead of lower-priority pods
+// competing for freed capacity instead of starving behind them.
 func (pl *PrioritySort) Less(pInfo1, pInfo2 *framework.QueuedPodInfo) bool {
-	p1 := corev1helpers.PodPriority(pInfo1.Pod)
-	p2 := corev1helpers.PodPriority(pInfo2.Pod)
+	p1 := pl.effectivePriority(pInfo1)
+	p2 := pl.effectivePriority(pInfo2)
 	return (p1 > p2) || (p1 == p2 && pInfo1.Timestamp.Before(pInfo2.Timestamp))
 }
 
+// effectivePriority returns the pod priority plus the aging boost earned by
+// non-preempting pods.
+func (pl *PrioritySort) effectivePriority(pInfo *framework.QueuedPodInfo) int64 {
+	priority := int64(corev1helpers.PodPriority(pInfo.Pod))
+	if pl.agingInterval <= 0 {
+		return priority
+	}
+	policy := pInfo.Pod.Spec.PreemptionPolicy
+	if policy == nil || *policy != v1.PreemptNever {
+		return priority
+	}
+	queuedAt := pInfo.InitialAttemptTimestamp
+	if queuedAt.IsZero() {
+		queuedAt = pInfo.Timestamp
+	}
+	boost := int64(time.Since(queuedAt) / pl.agingInterval)
+	if boost > pl.maxBoost {
+		boost = pl.maxBoost
+	}
+	return priority + boost
+}
+
 // New initializes a new plugin and returns it.
-func New(_ runtime.Object, handle framework.Handle) (framework.Plugin, error) {
-	return &PrioritySort{}, nil
+func New(plArgs runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+	if plArgs == nil {
+		// Queue sorting works without configuration; aging stays disabled.
+		return &PrioritySort{}, nil
+	}
+	args, ok := plArgs.(*config.PrioritySortArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type PrioritySortArgs, got %T", plArgs)
+	}
+	if err := validation.ValidatePrioritySortArgs(nil, args); err != nil {
+		return nil, err
+	}
+	return &PrioritySort{
+		agingInterval: args.NonPreemptingAgingInterval.Duration,
+		maxBoost:      int64(args.NonPreemptingAgingMaxBoost),
+	}, nil
 }
@@ -20,6 +20,7 @@ import (
 	"testing"
 	"time"
 
+	v1 "k8s.io/api/core/v1"
 	"k8s.io/kubernetes/pkg/scheduler/framework"
 	st "k8s.io/kubernetes/pkg/scheduler/testing"
 )
@@ -87,3 +88,82 @@ func TestLess(t *testing.T) {
 		})
 	}
 }
+
+func TestLessNonPreemptingAging(t *testing.T) {
+	var lowPriority, highPriority = int32(10), int32(15)
+	queuedLongAgo := time.Now().Add(-30 * time.Minute)
+	for _, tt := range []struct {
+		name     string
+		plugin   *PrioritySort
+		p1       *framework.QueuedPodInfo
+		p2       *framework.QueuedPodInfo
+		expected bool
+	}{
+		{
+			name:   "aged non-preempting pod outranks a higher-priority pod",
+			plugin: &PrioritySort{agingInterval: time.Minute, maxBoost: 10},
+			p1: &framework.QueuedPodInfo{
+				PodInfo:                 framework.NewPodInfo(st.MakePod().Priority(lowPriority).PreemptionPolicy(v1.PreemptNever).Obj()),
+				InitialAttemptTimestamp: queuedLongAgo,
+			},
+			p2: &framework.QueuedPodInfo{
+				PodInfo: framework.NewPodInfo(st.MakePod().Priority(highPriority).Obj()),
+			},
+			expected: true, // effective priority 10+10 beats 15
+		},
+		{
+			name:   "boost is capped at maxBoost",
+			plugin: &PrioritySort{agingInterval: time.Minute, maxBoost: 3},
+			p1: &framework.QueuedPodInfo{
+				PodInfo:                 framework.NewPodInfo(st.MakePod().Priority(lowPriority).PreemptionPolicy(v1.PreemptNever).Obj()),
+				InitialAttemptTimestamp: queuedLongAgo,
+			},
+			p2: &framework.QueuedPodInfo{
+				PodInfo: framework.NewPodInfo(st.MakePod().Priority(highPriority).Obj()),
+			},
+			expected: false, // effective priority 10+3 does not reach 15
+		},
+		{
+			name:   "preempting pod gets no boost",
+			plugin: &PrioritySort{agingInterval: time.Minute, maxBoost: 10},
+			p1: &framework.QueuedPodInfo{
+				PodInfo:                 framework.NewPodInfo(st.MakePod().Priority(lowPriority).PreemptionPolicy(v1.PreemptLowerPriority).Obj()),
+				InitialAttemptTimestamp: queuedLongAgo,
+			},
+			p2: &framework.QueuedPodInfo{
+				PodInfo: framework.NewPodInfo(st.MakePod().Priority(highPriority).Obj()),
+			},
+			expected: false,
+		},
+		{
+			name:   "zero interval disables aging",
+			plugin: &PrioritySort{},
+			p1: &framework.QueuedPodInfo{
+				PodInfo:                 framework.NewPodInfo(st.MakePod().Priority(lowPriority).PreemptionPolicy(v1.PreemptNever).Obj()),
+				InitialAttemptTimestamp: queuedLongAgo,
+			},
+			p2: &framework.QueuedPodInfo{
+				PodInfo: framework.NewPodInfo(st.MakePod().Priority(highPriority).Obj()),
+			},
+			expected: false,
+		},
+		{
+			name:   "timestamp is used before the first scheduling attempt",
+			plugin: &PrioritySort{agingInterval: time.Minute, maxBoost: 10},
+			p1: &framework.QueuedPodInfo{
+				PodInfo:   framework.NewPodInfo(st.MakePod().Priority(lowPriority).PreemptionPolicy(v1.PreemptNever).Obj()),
+				Timestamp: queuedLongAgo,
+			},
+			p2: &framework.QueuedPodInfo{
+				PodInfo: framework.NewPodInfo(st.MakePod().Priority(highPriority).Obj()),
+			},
+			expected: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.plugin.Less(tt.p1, tt.p2); got != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
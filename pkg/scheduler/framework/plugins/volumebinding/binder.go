@@ -107,6 +107,12 @@ type PodVolumes struct {
 	StaticBindings []*BindingInfo
 	// DynamicProvisions are PVCs that require dynamic provisioning
 	DynamicProvisions []*v1.PersistentVolumeClaim
+	// DynamicProvisionCapacities holds, per storage class, the size requested
+	// by the PVCs in DynamicProvisions and the largest remaining provisionable
+	// capacity among the CSIStorageCapacity topology segments accessible from
+	// the node. Classes whose driver does not publish capacity information are
+	// not included. It is used for scoring.
+	DynamicProvisionCapacities map[string]*StorageResource
 }
 
 // InTreeToCSITranslator contains methods required to check migratable status
@@ -288,8 +294,9 @@ func (b *volumeBinder) FindPodVolumes(pod *v1.Pod, boundClaims, claimsToBind []*
 	}()
 
 	var (
-		staticBindings    []*BindingInfo
-		dynamicProvisions []*v1.PersistentVolumeClaim
+		staticBindings      []*BindingInfo
+		dynamicProvisions   []*v1.PersistentVolumeClaim
+		provisionCapacities map[string]*StorageResource
 	)
 	defer func() {
 		// Although we do not distinguish nil from empty in this function, for
@@ -300,8 +307,12 @@ func (b *volumeBinder) FindPodVolumes(pod *v1.Pod, boundClaims, claimsToBind []*
 		if len(dynamicProvisions) == 0 {
 			dynamicProvisions = nil
 		}
+		if len(provisionCapacities) == 0 {
+			provisionCapacities = nil
+		}
 		podVolumes.StaticBindings = staticBindings
 		podVolumes.DynamicProvisions = dynamicProvisions
+		podVolumes.DynamicProvisionCapacities = provisionCapacities
 	}()
 
 	// Check PV node affinity on bound volumes
@@ -346,7 +357,7 @@ func (b *volumeBinder) FindPodVolumes(pod *v1.Pod, boundClaims, claimsToBind []*
 		// Check for claims to provision. This is the first time where we potentially
 		// find out that storage is not sufficient for the node.
 		if len(claimsToProvision) > 0 {
-			unboundVolumesSatisfied, sufficientStorage, dynamicProvisions, err = b.checkVolumeProvisions(pod, claimsToProvision, node)
+			unboundVolumesSatisfied, sufficientStorage, dynamicProvisions, provisionCapacities, err = b.checkVolumeProvisions(pod, claimsToProvision, node)
 			if err != nil {
 				return
 			}
@@ -455,11 +466,43 @@ func (b *volumeBinder) BindPodVolumes(assumedPod *v1.Pod, podVolumes *PodVolumes
 		return b, err
 	})
 	if err != nil {
+		// The pod-wide binding transaction failed. Ask the PV controller to
+		// roll back the claims which are not fully bound yet, so that they do
+		// not stay pinned to a node the pod may never land on.
+		b.rollbackBindings(assumedPod, claimsToProvision)
 		return fmt.Errorf("binding volumes: %w", err)
 	}
 	return nil
 }
 
+// rollbackBindings marks the given claims for rollback by the PV controller.
+// It is a best effort operation, claims which cannot be updated are retried
+// the next time the pod's volumes are bound.
+func (b *volumeBinder) rollbackBindings(pod *v1.Pod, claimsToProvision []*v1.PersistentVolumeClaim) {
+	for _, claim := range claimsToProvision {
+		pvc, err := b.pvcCache.GetAPIPVC(getPVCName(claim))
+		if err != nil {
+			klog.V(4).InfoS("Failed to get claim for rollback", "pod", klog.KObj(pod), "PVC", klog.KObj(claim), "err", err)
+			continue
+		}
+		if pvc.Spec.VolumeName != "" {
+			// Already bound, provisioning succeeded for this claim. The PV
+			// controller ignores rollback requests on bound claims, so do
+			// not even make one.
+			continue
+		}
+		if _, ok := pvc.Annotations[volume.AnnSelectedNode]; !ok {
+			// Nothing to roll back, e.g. provisioning already got rescheduled.
+			continue
+		}
+		newPVC := pvc.DeepCopy()
+		metav1.SetMetaDataAnnotation(&newPVC.ObjectMeta, volume.AnnBindRollback, "yes")
+		if _, err := b.kubeClient.CoreV1().PersistentVolumeClaims(newPVC.Namespace).Update(context.TODO(), newPVC, metav1.UpdateOptions{}); err != nil {
+			klog.V(4).InfoS("Failed to mark claim for rollback", "pod", klog.KObj(pod), "PVC", klog.KObj(newPVC), "err", err)
+		}
+	}
+}
+
 func getPodName(pod *v1.Pod) string {
 	return pod.Namespace + "/" + pod.Name
 }
@@ -851,9 +894,12 @@ func (b *volumeBinder) findMatchingVolumes(pod *v1.Pod, claimsToBind []*v1.Persi
 
 // checkVolumeProvisions checks given unbound claims (the claims have gone through func
 // findMatchingVolumes, and do not have matching volumes for binding), and return true
-// if all of the claims are eligible for dynamic provision.
-func (b *volumeBinder) checkVolumeProvisions(pod *v1.Pod, claimsToProvision []*v1.PersistentVolumeClaim, node *v1.Node) (provisionSatisfied, sufficientStorage bool, dynamicProvisions []*v1.PersistentVolumeClaim, err error) {
+// if all of the claims are eligible for dynamic provision. It also returns, per storage
+// class, the requested size and the remaining provisionable capacity reported by
+// CSIStorageCapacity, which is used for scoring.
+func (b *volumeBinder) checkVolumeProvisions(pod *v1.Pod, claimsToProvision []*v1.PersistentVolumeClaim, node *v1.Node) (provisionSatisfied, sufficientStorage bool, dynamicProvisions []*v1.PersistentVolumeClaim, provisionCapacities map[string]*StorageResource, err error) {
 	dynamicProvisions = []*v1.PersistentVolumeClaim{}
+	provisionCapacities = map[string]*StorageResource{}
 
 	// We return early with provisionedClaims == nil if a check
 	// fails or we encounter an error.
@@ -861,33 +907,45 @@ func (b *volumeBinder) checkVolumeProvisions(pod *v1.Pod, claimsToProvision []*v
 		pvcName := getPVCName(claim)
 		className := volume.GetPersistentVolumeClaimClass(claim)
 		if className == "" {
-			return false, false, nil, fmt.Errorf("no class for claim %q", pvcName)
+			return false, false, nil, nil, fmt.Errorf("no class for claim %q", pvcName)
 		}
 
 		class, err := b.classLister.Get(className)
 		if err != nil {
-			return false, false, nil, fmt.Errorf("failed to find storage class %q", className)
+			return false, false, nil, nil, fmt.Errorf("failed to find storage class %q", className)
 		}
 		provisioner := class.Provisioner
 		if provisioner == "" || provisioner == volume.NotSupportedProvisioner {
 			klog.V(4).InfoS("Storage class of claim does not support dynamic provisioning", "storageClassName", className, "PVC", klog.KObj(claim))
-			return false, true, nil, nil
+			return false, true, nil, nil, nil
 		}
 
 		// Check if the node can satisfy the topology requirement in the class
 		if !v1helper.MatchTopologySelectorTerms(class.AllowedTopologies, labels.Set(node.Labels)) {
 			klog.V(4).InfoS("Node cannot satisfy provisioning topology requirements of claim", "node", klog.KObj(node), "PVC", klog.KObj(claim))
-			return false, true, nil, nil
+			return false, true, nil, nil, nil
 		}
 
 		// Check storage capacity.
-		sufficient, err := b.hasEnoughCapacity(provisioner, claim, class, node)
+		sufficient, maxCapacity, err := b.hasEnoughCapacity(provisioner, claim, class, node)
 		if err != nil {
-			return false, false, nil, err
+			return false, false, nil, nil, err
 		}
 		if !sufficient {
 			// hasEnoughCapacity logs an explanation.
-			return true, false, nil, nil
+			return true, false, nil, nil, nil
+		}
+		if maxCapacity > 0 {
+			resource, ok := provisionCapacities[className]
+			if !ok {
+				resource = &StorageResource{}
+				provisionCapacities[className] = resource
+			}
+			quantity := claim.Spec.Resources.Requests[v1.ResourceStorage]
+			resource.Requested += quantity.Value()
+			if maxCapacity > resource.Capacity {
+				resource.Capacity = maxCapacity
+			}
 		}
 
 		dynamicProvisions = append(dynamicProvisions, claim)
@@ -895,7 +953,7 @@ func (b *volumeBinder) checkVolumeProvisions(pod *v1.Pod, claimsToProvision []*v
 	}
 	klog.V(4).InfoS("Provisioning for claims of pod that has no matching volumes...", "claimCount", len(claimsToProvision), "pod", klog.KObj(pod), "node", klog.KObj(node))
 
-	return true, true, dynamicProvisions, nil
+	return true, true, dynamicProvisions, provisionCapacities, nil
 }
 
 func (b *volumeBinder) revertAssumedPVs(bindings []*BindingInfo) {
@@ -911,12 +969,13 @@ func (b *volumeBinder) revertAssumedPVCs(claims []*v1.PersistentVolumeClaim) {
 }
 
 // hasEnoughCapacity checks whether the provisioner has enough capacity left for a new volume of the given size
-// that is available from the node.
-func (b *volumeBinder) hasEnoughCapacity(provisioner string, claim *v1.PersistentVolumeClaim, storageClass *storagev1.StorageClass, node *v1.Node) (bool, error) {
+// that is available from the node. It also returns the largest remaining capacity among the topology segments
+// which were found to be sufficient, or zero if capacity checking was skipped.
+func (b *volumeBinder) hasEnoughCapacity(provisioner string, claim *v1.PersistentVolumeClaim, storageClass *storagev1.StorageClass, node *v1.Node) (bool, int64, error) {
 	quantity, ok := claim.Spec.Resources.Requests[v1.ResourceStorage]
 	if !ok {
 		// No capacity to check for.
-		return true, nil
+		return true, 0, nil
 	}
 
 	// Only enabled for CSI drivers which opt into it.
@@ -926,36 +985,45 @@ func (b *volumeBinder) hasEnoughCapacity(provisioner string, claim *v1.Persisten
 			// Either the provisioner is not a CSI driver or the driver does not
 			// opt into storage capacity scheduling. Either way, skip
 			// capacity checking.
-			return true, nil
+			return true, 0, nil
 		}
-		return false, err
+		return false, 0, err
 	}
 	if driver.Spec.StorageCapacity == nil || !*driver.Spec.StorageCapacity {
-		return true, nil
+		return true, 0, nil
 	}
 
 	// Look for a matching CSIStorageCapacity object(s).
 	// TODO (for beta): benchmark this and potentially introduce some kind of lookup structure (https://github.com/kubernetes/enhancements/issues/1698#issuecomment-654356718).
 	capacities, err := b.csiStorageCapacityLister.List(labels.Everything())
 	if err != nil {
-		return false, err
+		return false, 0, err
 	}
 
 	sizeInBytes := quantity.Value()
+	sufficient := false
+	var maxCapacity int64
 	for _, capacity := range capacities {
 		if capacity.StorageClassName == storageClass.Name &&
 			capacitySufficient(capacity, sizeInBytes) &&
 			b.nodeHasAccess(node, capacity) {
-			// Enough capacity found.
-			return true, nil
+			// Enough capacity found. Remember the largest remaining capacity
+			// among all accessible segments, it is used for scoring.
+			sufficient = true
+			if capacity.Capacity != nil && capacity.Capacity.Value() > maxCapacity {
+				maxCapacity = capacity.Capacity.Value()
+			}
 		}
 	}
+	if sufficient {
+		return true, maxCapacity, nil
+	}
 
 	// TODO (?): this doesn't give any information about which pools where considered and why
 	// they had to be rejected. Log that above? But that might be a lot of log output...
 	klog.V(4).InfoS("Node has no accessible CSIStorageCapacity with enough capacity for PVC",
 		"node", klog.KObj(node), "PVC", klog.KObj(claim), "size", sizeInBytes, "storageClass", klog.KObj(storageClass))
-	return false, nil
+	return false, 0, nil
 }
 
 func capacitySufficient(capacity *storagev1.CSIStorageCapacity, sizeInBytes int64) bool {
@@ -2182,28 +2182,39 @@ func TestCapacity(t *testing.T) {
 		capacities []*storagev1.CSIStorageCapacity
 
 		// Expected return values
-		reasons    ConflictReasons
-		shouldFail bool
+		expectedCapacities map[string]*StorageResource
+		reasons            ConflictReasons
+		shouldFail         bool
 	}
+	oneGig := resource.MustParse("1Gi").Value()
 	scenarios := map[string]scenarioType{
 		"network-attached": {
 			pvcs: []*v1.PersistentVolumeClaim{provisionedPVC},
 			capacities: []*storagev1.CSIStorageCapacity{
 				makeCapacity("net", waitClassWithProvisioner, nil, "1Gi", ""),
 			},
+			expectedCapacities: map[string]*StorageResource{
+				waitClassWithProvisioner: {Requested: oneGig, Capacity: oneGig},
+			},
 		},
 		"local-storage": {
 			pvcs: []*v1.PersistentVolumeClaim{provisionedPVC},
 			capacities: []*storagev1.CSIStorageCapacity{
 				makeCapacity("net", waitClassWithProvisioner, node1, "1Gi", ""),
 			},
+			expectedCapacities: map[string]*StorageResource{
+				waitClassWithProvisioner: {Requested: oneGig, Capacity: oneGig},
+			},
 		},
 		"multiple": {
 			pvcs: []*v1.PersistentVolumeClaim{provisionedPVC},
 			capacities: []*storagev1.CSIStorageCapacity{
 				makeCapacity("net", waitClassWithProvisioner, nil, "1Gi", ""),
 				makeCapacity("net", waitClassWithProvisioner, node2, "1Gi", ""),
-				makeCapacity("net", waitClassWithProvisioner, node1, "1Gi", ""),
+				makeCapacity("net", waitClassWithProvisioner, node1, "2Gi", ""),
+			},
+			expectedCapacities: map[string]*StorageResource{
+				waitClassWithProvisioner: {Requested: oneGig, Capacity: 2 * oneGig},
 			},
 		},
 		"no-storage": {
@@ -2310,6 +2321,15 @@ func TestCapacity(t *testing.T) {
 			provisions = nil
 		}
 		testEnv.validatePodCache(t, pod.Spec.NodeName, pod, podVolumes, nil, provisions)
+
+		expectedCapacities := scenario.expectedCapacities
+		if !optIn || len(reasons) > 0 {
+			// Without the driver opting in there is no capacity information.
+			expectedCapacities = nil
+		}
+		if podVolumes != nil && !reflect.DeepEqual(podVolumes.DynamicProvisionCapacities, expectedCapacities) {
+			t.Errorf("expected DynamicProvisionCapacities %+v, got %+v", expectedCapacities, podVolumes.DynamicProvisionCapacities)
+		}
 	}
 
 	yesNo := []bool{true, false}
@@ -284,6 +284,20 @@ func (pl *VolumeBinding) Score(ctx context.Context, cs *framework.CycleState, po
 		classResources[class].Requested += storageResource.Requested
 		classResources[class].Capacity += storageResource.Capacity
 	}
+	// add the capacity remaining for dynamic provisioning per topology
+	// segment, so that nodes are also ranked by the headroom that
+	// CSIStorageCapacity reports for the volumes which still have to be
+	// created
+	for class, storageResource := range podVolumes.DynamicProvisionCapacities {
+		if _, ok := classResources[class]; !ok {
+			classResources[class] = &StorageResource{
+				Requested: 0,
+				Capacity:  0,
+			}
+		}
+		classResources[class].Requested += storageResource.Requested
+		classResources[class].Capacity += storageResource.Capacity
+	}
 	return pl.scorer(classResources), nil
 }
 
@@ -407,7 +407,13 @@ func buildExtenders(extenders []schedulerapi.Extender, profiles []schedulerapi.K
 	var ignorableExtenders []framework.Extender
 	for i := range extenders {
 		klog.V(2).InfoS("Creating extender", "extender", extenders[i])
-		extender, err := NewHTTPExtender(&extenders[i])
+		var extender framework.Extender
+		var err error
+		if IsGRPCExtender(&extenders[i]) {
+			extender, err = NewGRPCExtender(&extenders[i])
+		} else {
+			extender, err = NewHTTPExtender(&extenders[i])
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,92 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package csi
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+)
+
+// nodeAllocatableUpdatePeriod is the interval at which the allocatable count
+// published in the CSINode object is refreshed from the driver. Some drivers
+// report attach limits that change at runtime (for example after NIC hotplug)
+// and the scheduler relies on CSINode being kept up to date.
+const nodeAllocatableUpdatePeriod = 1 * time.Minute
+
+// nodeUpdaterStore tracks the refresher goroutine running for each registered
+// driver so it can be stopped when the driver unregisters.
+type nodeUpdaterStore struct {
+	sync.Mutex
+	stopChans map[string]chan struct{}
+}
+
+var nodeUpdaters = &nodeUpdaterStore{stopChans: map[string]chan struct{}{}}
+
+// startNodeAllocatableUpdater starts a goroutine that periodically re-queries
+// NodeGetInfo for the given driver and records the reported attach limit in
+// the CSINode object. It replaces any updater already running for the driver.
+func startNodeAllocatableUpdater(driverName string) {
+	stopCh := make(chan struct{})
+
+	nodeUpdaters.Lock()
+	if oldStopCh, ok := nodeUpdaters.stopChans[driverName]; ok {
+		close(oldStopCh)
+	}
+	nodeUpdaters.stopChans[driverName] = stopCh
+	nodeUpdaters.Unlock()
+
+	go wait.Until(func() {
+		if err := updateNodeAllocatable(driverName); err != nil {
+			klog.Error(log("failed to update CSINode allocatable for driver %s: %v", driverName, err))
+		}
+	}, nodeAllocatableUpdatePeriod, stopCh)
+}
+
+// stopNodeAllocatableUpdater stops the refresher goroutine for the given
+// driver, if one is running.
+func stopNodeAllocatableUpdater(driverName string) {
+	nodeUpdaters.Lock()
+	defer nodeUpdaters.Unlock()
+
+	if stopCh, ok := nodeUpdaters.stopChans[driverName]; ok {
+		close(stopCh)
+		delete(nodeUpdaters.stopChans, driverName)
+	}
+}
+
+// updateNodeAllocatable queries the driver's NodeGetInfo and patches the
+// allocatable count in the CSINode object if it has changed.
+func updateNodeAllocatable(driverName string) error {
+	csi, err := newCsiDriverClient(csiDriverName(driverName))
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), csiTimeout)
+	defer cancel()
+
+	_, maxVolumePerNode, _, err := csi.NodeGetInfo(ctx)
+	if err != nil {
+		return err
+	}
+
+	return nim.UpdateCSINodeAllocatable(driverName, maxVolumePerNode)
+}
@@ -148,6 +148,10 @@ func (h *RegistrationHandler) RegisterPlugin(pluginName string, endpoint string,
 		return err
 	}
 
+	// Keep the allocatable count published in CSINode in sync with the driver
+	// for as long as it stays registered.
+	startNodeAllocatableUpdater(pluginName)
+
 	return nil
 }
 
@@ -994,6 +998,7 @@ func (p *csiPlugin) podInfoEnabled(driverName string) (bool, error) {
 }
 
 func unregisterDriver(driverName string) error {
+	stopNodeAllocatableUpdater(driverName)
 	csiDrivers.Delete(driverName)
 
 	if err := nim.UninstallCSIDriver(driverName); err != nil {
@@ -88,6 +88,10 @@ type Interface interface {
 	// Concurrent calls to UninstallCSIDriver() is allowed, but they should not be intertwined with calls
 	// to other methods in this interface.
 	UninstallCSIDriver(driverName string) error
+
+	// Record in the cluster a new attach limit reported by the CSI driver with the given name.
+	// It is a no-op if the limit recorded in the CSINode object already matches.
+	UpdateCSINodeAllocatable(driverName string, maxAttachLimit int64) error
 }
 
 // NewNodeInfoManager initializes nodeInfoManager
@@ -389,6 +393,66 @@ func (nim *nodeInfoManager) tryUpdateCSINode(
 	return nim.installDriverToCSINode(nodeInfo, driverName, driverNodeID, maxAttachLimit, topology)
 }
 
+// UpdateCSINodeAllocatable updates the allocatable count recorded in the CSINode object for the
+// driver with the given name. It allows drivers whose attach limits change at runtime to keep the
+// published limit in sync without re-registering.
+func (nim *nodeInfoManager) UpdateCSINodeAllocatable(driverName string, maxAttachLimit int64) error {
+	csiKubeClient := nim.volumeHost.GetKubeClient()
+	if csiKubeClient == nil {
+		return fmt.Errorf("error getting CSI client")
+	}
+
+	var updateErrs []error
+	err := wait.ExponentialBackoff(updateBackoff, func() (bool, error) {
+		if err := nim.tryUpdateCSINodeAllocatable(csiKubeClient, driverName, maxAttachLimit); err != nil {
+			updateErrs = append(updateErrs, err)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return fmt.Errorf("error updating CSINode: %v; caused by: %v", err, utilerrors.NewAggregate(updateErrs))
+	}
+	return nil
+}
+
+func (nim *nodeInfoManager) tryUpdateCSINodeAllocatable(
+	csiKubeClient clientset.Interface,
+	driverName string,
+	maxAttachLimit int64) error {
+
+	nodeInfo, err := csiKubeClient.StorageV1().CSINodes().Get(context.TODO(), string(nim.nodeName), metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	if maxAttachLimit <= 0 {
+		klog.Errorf("Invalid attach limit value %d cannot be added to CSINode object for %q", maxAttachLimit, driverName)
+		return nil
+	}
+	if maxAttachLimit > math.MaxInt32 {
+		klog.Warningf("Exceeded max supported attach limit value, truncating it to %d", math.MaxInt32)
+		maxAttachLimit = math.MaxInt32
+	}
+
+	for i, driverInfoSpec := range nodeInfo.Spec.Drivers {
+		if driverInfoSpec.Name != driverName {
+			continue
+		}
+		if driverInfoSpec.Allocatable != nil && driverInfoSpec.Allocatable.Count != nil &&
+			int64(*driverInfoSpec.Allocatable.Count) == maxAttachLimit {
+			// Recorded limit is already up to date.
+			return nil
+		}
+		m := int32(maxAttachLimit)
+		nodeInfo.Spec.Drivers[i].Allocatable = &storagev1.VolumeNodeResources{Count: &m}
+		_, err := csiKubeClient.StorageV1().CSINodes().Update(context.TODO(), nodeInfo, metav1.UpdateOptions{})
+		return err
+	}
+
+	return fmt.Errorf("CSINode %s does not contain driver %s", string(nim.nodeName), driverName)
+}
+
 func (nim *nodeInfoManager) InitializeCSINodeWithAnnotation() error {
 	csiKubeClient := nim.volumeHost.GetKubeClient()
 	if csiKubeClient == nil {
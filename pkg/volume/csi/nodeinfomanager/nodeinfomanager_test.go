@@ -997,6 +997,114 @@ func TestInstallCSIDriverExistingAnnotation(t *testing.T) {
 	}
 }
 
+func TestUpdateCSINodeAllocatable(t *testing.T) {
+	driverName := "com.example.csi/driver1"
+
+	testcases := []struct {
+		name          string
+		existingLimit *storage.VolumeNodeResources
+		driverName    string
+		inputLimit    int64
+		expectedLimit *storage.VolumeNodeResources
+		expectFail    bool
+	}{
+		{
+			name:          "limit changed",
+			existingLimit: &storage.VolumeNodeResources{Count: utilpointer.Int32Ptr(10)},
+			driverName:    driverName,
+			inputLimit:    20,
+			expectedLimit: &storage.VolumeNodeResources{Count: utilpointer.Int32Ptr(20)},
+		},
+		{
+			name:          "limit unchanged",
+			existingLimit: &storage.VolumeNodeResources{Count: utilpointer.Int32Ptr(10)},
+			driverName:    driverName,
+			inputLimit:    10,
+			expectedLimit: &storage.VolumeNodeResources{Count: utilpointer.Int32Ptr(10)},
+		},
+		{
+			name:          "limit previously unset",
+			existingLimit: nil,
+			driverName:    driverName,
+			inputLimit:    10,
+			expectedLimit: &storage.VolumeNodeResources{Count: utilpointer.Int32Ptr(10)},
+		},
+		{
+			name:          "invalid limit ignored",
+			existingLimit: &storage.VolumeNodeResources{Count: utilpointer.Int32Ptr(10)},
+			driverName:    driverName,
+			inputLimit:    0,
+			expectedLimit: &storage.VolumeNodeResources{Count: utilpointer.Int32Ptr(10)},
+		},
+		{
+			name:          "limit truncated to max supported value",
+			existingLimit: &storage.VolumeNodeResources{Count: utilpointer.Int32Ptr(10)},
+			driverName:    driverName,
+			inputLimit:    math.MaxInt32 + 1,
+			expectedLimit: &storage.VolumeNodeResources{Count: utilpointer.Int32Ptr(math.MaxInt32)},
+		},
+		{
+			name:          "driver not installed",
+			existingLimit: &storage.VolumeNodeResources{Count: utilpointer.Int32Ptr(10)},
+			driverName:    "net.example.storage/other-driver",
+			inputLimit:    20,
+			expectedLimit: &storage.VolumeNodeResources{Count: utilpointer.Int32Ptr(10)},
+			expectFail:    true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Logf("test case: %q", tc.name)
+
+		// Arrange
+		existingCSINode := generateCSINode(
+			nodeIDMap{
+				driverName: "com.example.csi/csi-node1",
+			},
+			tc.existingLimit,
+			nil /* topologyKeys */)
+		nodeName := existingCSINode.Name
+		client := getClientSet(generateNode(nil, nil, nil), existingCSINode)
+
+		tmpDir, err := utiltesting.MkTmpdir("nodeinfomanager-test")
+		if err != nil {
+			t.Fatalf("can't create temp dir: %v", err)
+		}
+		defer os.RemoveAll(tmpDir)
+		host := volumetest.NewFakeVolumeHostWithCSINodeName(t,
+			tmpDir,
+			client,
+			nil,
+			nodeName,
+			nil,
+			nil,
+		)
+		nim := NewNodeInfoManager(types.NodeName(nodeName), host, nil)
+
+		// Act
+		err = nim.UpdateCSINodeAllocatable(tc.driverName, tc.inputLimit)
+		if tc.expectFail {
+			if err == nil {
+				t.Errorf("expected an error from UpdateCSINodeAllocatable but got none")
+			}
+		} else if err != nil {
+			t.Errorf("expected no error from UpdateCSINodeAllocatable but got: %v", err)
+			continue
+		}
+
+		// Assert
+		nodeInfo, err := client.StorageV1().CSINodes().Get(context.TODO(), nodeName, metav1.GetOptions{})
+		if err != nil {
+			t.Errorf("error getting CSINode: %v", err)
+			continue
+		}
+
+		if !reflect.DeepEqual(nodeInfo.Spec.Drivers[0].Allocatable, tc.expectedLimit) {
+			t.Errorf("expected allocatable %v, but got: %v", tc.expectedLimit, nodeInfo.Spec.Drivers[0].Allocatable)
+		}
+	}
+}
+
 func getClientSet(existingNode *v1.Node, existingCSINode *storage.CSINode) *fake.Clientset {
 	objects := []runtime.Object{}
 	if existingNode != nil {
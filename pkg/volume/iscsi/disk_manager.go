@@ -49,7 +49,17 @@ func diskSetUp(manager diskManager, b iscsiDiskMounter, volPath string, mounter
 		return err
 	}
 	if !notMnt {
-		return nil
+		if !util.IsStaleMount(volPath) {
+			return nil
+		}
+		// The existing mount is stale (e.g. the session disconnected);
+		// unmount it and fall through to a fresh bind mount.
+		klog.V(2).Infof("iSCSI mount %s is stale, remounting", volPath)
+		if err := mounter.Unmount(volPath); err != nil {
+			klog.Errorf("failed to unmount stale mount %s: %v", volPath, err)
+			return err
+		}
+		util.RecordStaleMountRemount(iscsiPluginName)
 	}
 
 	if err := os.MkdirAll(volPath, 0750); err != nil {
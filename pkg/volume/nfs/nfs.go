@@ -228,7 +228,17 @@ func (nfsMounter *nfsMounter) SetUpAt(dir string, mounterArgs volume.MounterArgs
 		return err
 	}
 	if !notMnt {
-		return nil
+		if !util.IsStaleMount(dir) {
+			return nil
+		}
+		// The existing mount is stale (e.g. the server rebooted or the
+		// export moved); unmount it and fall through to a fresh mount.
+		klog.V(2).Infof("NFS mount %s is stale, remounting", dir)
+		if err := nfsMounter.mounter.Unmount(dir); err != nil {
+			klog.Errorf("Failed to unmount stale NFS mount %s: %v", dir, err)
+			return err
+		}
+		util.RecordStaleMountRemount(nfsPluginName)
 	}
 	if err := os.MkdirAll(dir, 0750); err != nil {
 		return err
@@ -339,6 +339,11 @@ type KubeletVolumeHost interface {
 	WaitForCacheSync() error
 	// Returns hostutil.HostUtils
 	GetHostUtil() hostutil.HostUtils
+
+	// GetDefaultFSGroupChangePolicy returns the fsGroupChangePolicy from the
+	// kubelet configuration that is applied to volumes of pods that do not
+	// specify one, or nil if none is configured.
+	GetDefaultFSGroupChangePolicy() *v1.PodFSGroupChangePolicy
 }
 
 // AttachDetachVolumeHost is a AttachDetach Controller specific interface that plugins can use
@@ -17,7 +17,9 @@ limitations under the License.
 package projected
 
 import (
+	"context"
 	"fmt"
+	"path"
 
 	authenticationv1 "k8s.io/api/authentication/v1"
 	v1 "k8s.io/api/core/v1"
@@ -347,6 +349,32 @@ func (s *projectedVolumeMounter) collectData(mounterArgs volume.MounterArgs) (ma
 				Mode:   mode,
 				FsUser: mounterArgs.FsUser,
 			}
+		case source.ServiceAccountIssuer != nil:
+			ip := source.ServiceAccountIssuer
+
+			restClient := kubeClient.Discovery().RESTClient()
+			discoveryDoc, err := restClient.Get().AbsPath("/.well-known/openid-configuration").Do(context.TODO()).Raw()
+			if err != nil {
+				klog.Errorf("Couldn't get OIDC discovery document for volume %v: %v", s.volName, err)
+				errlist = append(errlist, err)
+				continue
+			}
+			keySet, err := restClient.Get().AbsPath("/openid/v1/jwks").Do(context.TODO()).Raw()
+			if err != nil {
+				klog.Errorf("Couldn't get OIDC key set for volume %v: %v", s.volName, err)
+				errlist = append(errlist, err)
+				continue
+			}
+			payload[path.Join(ip.Path, "openid-configuration")] = volumeutil.FileProjection{
+				Data:   discoveryDoc,
+				Mode:   *s.source.DefaultMode,
+				FsUser: mounterArgs.FsUser,
+			}
+			payload[path.Join(ip.Path, "jwks.json")] = volumeutil.FileProjection{
+				Data:   keySet,
+				Mode:   *s.source.DefaultMode,
+				FsUser: mounterArgs.FsUser,
+			}
 		}
 	}
 	return payload, utilerrors.NewAggregate(errlist)
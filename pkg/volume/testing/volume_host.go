@@ -361,6 +361,10 @@ func (f *fakeKubeletVolumeHost) WithNode(node *v1.Node) *fakeKubeletVolumeHost {
 	return f
 }
 
+func (f *fakeKubeletVolumeHost) GetDefaultFSGroupChangePolicy() *v1.PodFSGroupChangePolicy {
+	return nil
+}
+
 func (f *fakeKubeletVolumeHost) SetKubeletError(err error) {
 	f.mux.Lock()
 	defer f.mux.Unlock()
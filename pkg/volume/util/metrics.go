@@ -75,6 +75,15 @@ var csiOperationsLatencyMetric = metrics.NewHistogramVec(
 	[]string{"driver_name", "method_name", "grpc_status_code", "migrated"},
 )
 
+var staleMountRemountMetric = metrics.NewCounterVec(
+	&metrics.CounterOpts{
+		Name:           "volume_stale_mount_remount_total",
+		Help:           "Number of remounts performed after a stale mount (e.g. ESTALE) was detected, partitioned by volume plugin",
+		StabilityLevel: metrics.ALPHA,
+	},
+	[]string{"volume_plugin"},
+)
+
 func init() {
 	registerMetrics()
 }
@@ -85,6 +94,13 @@ func registerMetrics() {
 	legacyregistry.MustRegister(storageOperationMetric)
 	legacyregistry.MustRegister(storageOperationEndToEndLatencyMetric)
 	legacyregistry.MustRegister(csiOperationsLatencyMetric)
+	legacyregistry.MustRegister(staleMountRemountMetric)
+}
+
+// RecordStaleMountRemount counts a remount performed because the existing
+// mount of the given plugin was detected as stale.
+func RecordStaleMountRemount(pluginName string) {
+	staleMountRemountMetric.WithLabelValues(pluginName).Inc()
 }
 
 // OperationCompleteHook returns a hook to call when an operation is completed
@@ -0,0 +1,37 @@
+//go:build linux
+// +build linux
+
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"errors"
+
+	"golang.org/x/sys/unix"
+)
+
+// IsStaleMount probes the mount at path and returns true if the kernel
+// reports it as no longer usable: ESTALE for a stale NFS file handle and
+// ENOTCONN for a disconnected session (e.g. iSCSI or FUSE). Network
+// filesystem plugins use this to detect dead mounts and perform a controlled
+// remount instead of handing pods a mount that fails every I/O.
+func IsStaleMount(path string) bool {
+	var stat unix.Stat_t
+	err := unix.Stat(path, &stat)
+	return errors.Is(err, unix.ESTALE) || errors.Is(err, unix.ENOTCONN)
+}
@@ -0,0 +1,33 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+)
+
+func TestIsStaleMountHealthyPath(t *testing.T) {
+	// a healthy directory is never reported stale
+	if IsStaleMount(t.TempDir()) {
+		t.Errorf("expected a healthy path not to be stale")
+	}
+	// a missing path is not stale either: ENOENT is a different failure mode
+	// handled by the mount check that precedes the staleness probe
+	if IsStaleMount("/nonexistent-path-for-stale-check") {
+		t.Errorf("expected a missing path not to be stale")
+	}
+}
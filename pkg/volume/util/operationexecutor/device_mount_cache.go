@@ -0,0 +1,174 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operationexecutor
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"k8s.io/klog/v2"
+)
+
+// DeviceMountCache tracks the devices that have been staged on this node and
+// how many volume mounts reference each of them, keyed by the device mount
+// path. The cache is persisted to a state file so that redundant staging can
+// be skipped across kubelet restarts; entries whose device mount did not
+// survive the restart are dropped when the staleness is detected.
+//
+// The cache is an optimization only. Persistence failures are logged and
+// otherwise ignored, and callers must verify that a cached device mount still
+// exists before trusting it.
+type DeviceMountCache interface {
+	// IsDeviceStaged returns true if the device mounted at deviceMountPath
+	// has been recorded as staged.
+	IsDeviceStaged(deviceMountPath string) bool
+
+	// MarkDeviceStaged records that the device mounted at deviceMountPath
+	// has been staged.
+	MarkDeviceStaged(deviceMountPath string)
+
+	// MarkDeviceUnstaged removes the device mounted at deviceMountPath from
+	// the cache, together with its mount references.
+	MarkDeviceUnstaged(deviceMountPath string)
+
+	// AddMountRef records an additional volume mount referencing the staged
+	// device.
+	AddMountRef(deviceMountPath string)
+
+	// RemoveMountRef drops a volume mount reference from the staged device.
+	RemoveMountRef(deviceMountPath string)
+}
+
+// deviceMountRecord is the persisted state of a single staged device.
+type deviceMountRecord struct {
+	// MountRefs is the number of volume mounts that reference the staged
+	// device.
+	MountRefs int `json:"mountRefs"`
+}
+
+type deviceMountCache struct {
+	sync.Mutex
+
+	// stateFilePath is the file the cache is persisted to after every
+	// mutation.
+	stateFilePath string
+
+	// devices maps the device mount path of each staged device to its
+	// record. Presence in the map means the device is staged.
+	devices map[string]*deviceMountRecord
+}
+
+var _ DeviceMountCache = &deviceMountCache{}
+
+// NewDeviceMountCache returns a DeviceMountCache persisted at stateFilePath,
+// pre-populated from an earlier state file if one exists. A missing or
+// unreadable state file yields an empty cache.
+func NewDeviceMountCache(stateFilePath string) DeviceMountCache {
+	cache := &deviceMountCache{
+		stateFilePath: stateFilePath,
+		devices:       map[string]*deviceMountRecord{},
+	}
+	data, err := os.ReadFile(stateFilePath)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			klog.InfoS("Could not read device mount cache state file, starting with an empty cache", "path", stateFilePath, "err", err)
+		}
+		return cache
+	}
+	if err := json.Unmarshal(data, &cache.devices); err != nil {
+		klog.InfoS("Could not parse device mount cache state file, starting with an empty cache", "path", stateFilePath, "err", err)
+		cache.devices = map[string]*deviceMountRecord{}
+	}
+	return cache
+}
+
+func (c *deviceMountCache) IsDeviceStaged(deviceMountPath string) bool {
+	c.Lock()
+	defer c.Unlock()
+	_, staged := c.devices[deviceMountPath]
+	return staged
+}
+
+func (c *deviceMountCache) MarkDeviceStaged(deviceMountPath string) {
+	c.Lock()
+	defer c.Unlock()
+	if _, staged := c.devices[deviceMountPath]; staged {
+		return
+	}
+	c.devices[deviceMountPath] = &deviceMountRecord{}
+	c.save()
+}
+
+func (c *deviceMountCache) MarkDeviceUnstaged(deviceMountPath string) {
+	c.Lock()
+	defer c.Unlock()
+	if _, staged := c.devices[deviceMountPath]; !staged {
+		return
+	}
+	delete(c.devices, deviceMountPath)
+	c.save()
+}
+
+func (c *deviceMountCache) AddMountRef(deviceMountPath string) {
+	c.Lock()
+	defer c.Unlock()
+	record, staged := c.devices[deviceMountPath]
+	if !staged {
+		record = &deviceMountRecord{}
+		c.devices[deviceMountPath] = record
+	}
+	record.MountRefs++
+	c.save()
+}
+
+func (c *deviceMountCache) RemoveMountRef(deviceMountPath string) {
+	c.Lock()
+	defer c.Unlock()
+	record, staged := c.devices[deviceMountPath]
+	if !staged {
+		return
+	}
+	if record.MountRefs > 0 {
+		record.MountRefs--
+	}
+	c.save()
+}
+
+// save persists the cache to the state file, writing to a temporary file
+// first so a crash cannot leave a partially written state behind. The caller
+// must hold the lock.
+func (c *deviceMountCache) save() {
+	data, err := json.Marshal(c.devices)
+	if err != nil {
+		klog.ErrorS(err, "Could not serialize device mount cache", "path", c.stateFilePath)
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(c.stateFilePath), 0750); err != nil {
+		klog.ErrorS(err, "Could not create device mount cache directory", "path", c.stateFilePath)
+		return
+	}
+	tmpFile := c.stateFilePath + ".tmp"
+	if err := os.WriteFile(tmpFile, data, 0600); err != nil {
+		klog.ErrorS(err, "Could not write device mount cache state file", "path", tmpFile)
+		return
+	}
+	if err := os.Rename(tmpFile, c.stateFilePath); err != nil {
+		klog.ErrorS(err, "Could not replace device mount cache state file", "path", c.stateFilePath)
+	}
+}
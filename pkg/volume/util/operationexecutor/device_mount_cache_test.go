@@ -0,0 +1,88 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operationexecutor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDeviceMountCacheStagingAndRefs(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "device_mounts.json")
+	cache := NewDeviceMountCache(stateFile)
+
+	devicePath := "/var/lib/kubelet/plugins/kubernetes.io/fake/mounts/device1"
+	if cache.IsDeviceStaged(devicePath) {
+		t.Errorf("expected device %q to not be staged in an empty cache", devicePath)
+	}
+
+	cache.MarkDeviceStaged(devicePath)
+	if !cache.IsDeviceStaged(devicePath) {
+		t.Errorf("expected device %q to be staged", devicePath)
+	}
+
+	cache.AddMountRef(devicePath)
+	cache.AddMountRef(devicePath)
+	cache.RemoveMountRef(devicePath)
+	if !cache.IsDeviceStaged(devicePath) {
+		t.Errorf("expected device %q to stay staged while mount refs remain", devicePath)
+	}
+
+	cache.MarkDeviceUnstaged(devicePath)
+	if cache.IsDeviceStaged(devicePath) {
+		t.Errorf("expected device %q to not be staged after unstaging", devicePath)
+	}
+}
+
+func TestDeviceMountCachePersistence(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "device_mounts.json")
+	devicePath := "/var/lib/kubelet/plugins/kubernetes.io/fake/mounts/device1"
+
+	cache := NewDeviceMountCache(stateFile)
+	cache.MarkDeviceStaged(devicePath)
+	cache.AddMountRef(devicePath)
+
+	// A new cache built from the same state file sees the staged device.
+	restarted := NewDeviceMountCache(stateFile)
+	if !restarted.IsDeviceStaged(devicePath) {
+		t.Errorf("expected device %q to be staged after reloading the state file", devicePath)
+	}
+
+	restarted.MarkDeviceUnstaged(devicePath)
+	reloaded := NewDeviceMountCache(stateFile)
+	if reloaded.IsDeviceStaged(devicePath) {
+		t.Errorf("expected device %q to not be staged after unstaging and reloading", devicePath)
+	}
+}
+
+func TestDeviceMountCacheCorruptStateFile(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "device_mounts.json")
+	if err := os.WriteFile(stateFile, []byte("not json"), 0600); err != nil {
+		t.Fatalf("failed to write state file: %v", err)
+	}
+
+	cache := NewDeviceMountCache(stateFile)
+	if cache.IsDeviceStaged("/some/device") {
+		t.Errorf("expected an empty cache after loading a corrupt state file")
+	}
+	// The cache must still be usable and persist new entries.
+	cache.MarkDeviceStaged("/some/device")
+	if !NewDeviceMountCache(stateFile).IsDeviceStaged("/some/device") {
+		t.Errorf("expected the cache to recover from a corrupt state file")
+	}
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operationexecutor
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	volumetypes "k8s.io/kubernetes/pkg/volume/util/types"
+)
+
+// OperationDeadlines configures how long each type of volume operation may
+// run before the executor gives up on it and surfaces a timeout error to
+// the caller. A zero deadline keeps the classic behavior for that type:
+// the operation stays pending until it returns on its own.
+//
+// The plugin call itself is not preempted — the in-tree plugin interfaces
+// accept no context, and CSI RPCs are already bounded by the CSI client's
+// per-call timeout — so an expired operation keeps running in the
+// background. The executor refuses to start a new operation for the same
+// volume until the expired one actually returns, preserving the
+// one-operation-per-volume guarantee.
+type OperationDeadlines struct {
+	// Attach bounds attach operations.
+	Attach time.Duration
+	// Mount bounds mount and map operations.
+	Mount time.Duration
+	// Expand bounds in-use expansion operations.
+	Expand time.Duration
+}
+
+// operationWatchdog enforces OperationDeadlines on generated operations and
+// tracks per-volume retry counts for the timeout diagnostics.
+type operationWatchdog struct {
+	mu sync.Mutex
+	// retries counts started attempts per operation key, reset on the
+	// first success.
+	retries map[string]int
+	// inFlight marks keys whose previous attempt timed out but is still
+	// running in the background.
+	inFlight map[string]bool
+}
+
+func newOperationWatchdog() *operationWatchdog {
+	return &operationWatchdog{
+		retries:  map[string]int{},
+		inFlight: map[string]bool{},
+	}
+}
+
+// wrapWithDeadline returns the operations with their OperationFunc bounded
+// by the deadline. A non-positive deadline returns the operations
+// unchanged.
+func (w *operationWatchdog) wrapWithDeadline(kind, key string, deadline time.Duration, generatedOperations volumetypes.GeneratedOperations) volumetypes.GeneratedOperations {
+	if deadline <= 0 {
+		return generatedOperations
+	}
+	operationFunc := generatedOperations.OperationFunc
+	generatedOperations.OperationFunc = func() volumetypes.OperationContext {
+		w.mu.Lock()
+		if w.inFlight[key] {
+			w.mu.Unlock()
+			eventErr := fmt.Errorf("a previous %s operation for %q exceeded its deadline and is still running", kind, key)
+			return volumetypes.NewOperationContext(eventErr, eventErr, false)
+		}
+		w.inFlight[key] = true
+		w.retries[key]++
+		attempt := w.retries[key]
+		w.mu.Unlock()
+
+		startTime := time.Now()
+		resultCh := make(chan volumetypes.OperationContext, 1)
+		go func() {
+			opContext := operationFunc()
+			w.mu.Lock()
+			w.inFlight[key] = false
+			if opContext.DetailedErr == nil {
+				delete(w.retries, key)
+			}
+			w.mu.Unlock()
+			resultCh <- opContext
+		}()
+
+		select {
+		case opContext := <-resultCh:
+			return opContext
+		case <-time.After(deadline):
+			elapsed := time.Since(startTime)
+			klog.ErrorS(nil, "Volume operation exceeded its deadline",
+				"operation", kind, "key", key, "deadline", deadline, "elapsed", elapsed, "attempt", attempt)
+			eventErr := fmt.Errorf("%s operation for %q timed out after %v (deadline %v, attempt %d)", kind, key, elapsed.Round(time.Second), deadline, attempt)
+			return volumetypes.NewOperationContext(eventErr, eventErr, false)
+		}
+	}
+	return generatedOperations
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operationexecutor
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	volumetypes "k8s.io/kubernetes/pkg/volume/util/types"
+)
+
+func TestWrapWithDeadlineZeroIsPassthrough(t *testing.T) {
+	watchdog := newOperationWatchdog()
+	operations := volumetypes.GeneratedOperations{
+		OperationFunc: func() volumetypes.OperationContext {
+			return volumetypes.NewOperationContext(nil, nil, false)
+		},
+	}
+	wrapped := watchdog.wrapWithDeadline("attach", "volume-1", 0, operations)
+	if eventErr, detailedErr := wrapped.Run(); eventErr != nil || detailedErr != nil {
+		t.Errorf("expected the operation to pass through, got %v, %v", eventErr, detailedErr)
+	}
+}
+
+func TestWrapWithDeadlineTimesOut(t *testing.T) {
+	watchdog := newOperationWatchdog()
+	release := make(chan struct{})
+	operations := volumetypes.GeneratedOperations{
+		OperationFunc: func() volumetypes.OperationContext {
+			<-release
+			return volumetypes.NewOperationContext(nil, nil, false)
+		},
+	}
+	wrapped := watchdog.wrapWithDeadline("attach", "volume-1", 10*time.Millisecond, operations)
+
+	_, detailedErr := wrapped.Run()
+	if detailedErr == nil {
+		t.Fatal("expected a timeout error")
+	}
+	if !strings.Contains(detailedErr.Error(), "timed out") || !strings.Contains(detailedErr.Error(), "attempt 1") {
+		t.Errorf("expected elapsed time and attempt count in the error, got %q", detailedErr.Error())
+	}
+
+	// A retry while the expired attempt still runs must be refused so two
+	// operations never run on the same volume at once.
+	_, detailedErr = wrapped.Run()
+	if detailedErr == nil || !strings.Contains(detailedErr.Error(), "still running") {
+		t.Errorf("expected the retry to be refused while the previous attempt runs, got %v", detailedErr)
+	}
+
+	// Once the expired attempt returns, retries go through again.
+	close(release)
+	if err := wait.PollImmediate(10*time.Millisecond, wait.ForeverTestTimeout, func() (bool, error) {
+		_, detailedErr := wrapped.Run()
+		return detailedErr == nil, nil
+	}); err != nil {
+		t.Errorf("expected retries to resume after the previous attempt returned: %v", err)
+	}
+}
+
+func TestWrapWithDeadlineResetsRetriesOnSuccess(t *testing.T) {
+	watchdog := newOperationWatchdog()
+	operations := volumetypes.GeneratedOperations{
+		OperationFunc: func() volumetypes.OperationContext {
+			return volumetypes.NewOperationContext(nil, nil, false)
+		},
+	}
+	wrapped := watchdog.wrapWithDeadline("expand", "volume-1", time.Minute, operations)
+	if _, detailedErr := wrapped.Run(); detailedErr != nil {
+		t.Fatalf("unexpected error: %v", detailedErr)
+	}
+	watchdog.mu.Lock()
+	defer watchdog.mu.Unlock()
+	if count := watchdog.retries["volume-1"]; count != 0 {
+		t.Errorf("expected the retry counter to reset on success, got %d", count)
+	}
+}
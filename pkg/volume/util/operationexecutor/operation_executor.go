@@ -163,6 +163,23 @@ func NewOperationExecutor(
 		pendingOperations: nestedpendingoperations.NewNestedPendingOperations(
 			true /* exponentialBackOffOnError */),
 		operationGenerator: operationGenerator,
+		watchdog:           newOperationWatchdog(),
+	}
+}
+
+// NewOperationExecutorWithDeadlines returns a new instance of
+// OperationExecutor that bounds attach, mount and expand operations by the
+// given deadlines instead of letting them stay pending forever.
+func NewOperationExecutorWithDeadlines(
+	operationGenerator OperationGenerator,
+	deadlines OperationDeadlines) OperationExecutor {
+
+	return &operationExecutor{
+		pendingOperations: nestedpendingoperations.NewNestedPendingOperations(
+			true /* exponentialBackOffOnError */),
+		operationGenerator: operationGenerator,
+		deadlines:          deadlines,
+		watchdog:           newOperationWatchdog(),
 	}
 }
 
@@ -744,6 +761,13 @@ type operationExecutor struct {
 	// operationGenerator is an interface that provides implementations for
 	// generating volume function
 	operationGenerator OperationGenerator
+
+	// deadlines bounds how long each type of operation may run; zero
+	// values keep operations pending until they return on their own
+	deadlines OperationDeadlines
+
+	// watchdog enforces deadlines and tracks retry counts per volume
+	watchdog *operationWatchdog
 }
 
 func (oe *operationExecutor) IsOperationPending(
@@ -766,6 +790,8 @@ func (oe *operationExecutor) AttachVolume(
 	actualStateOfWorld ActualStateOfWorldAttacherUpdater) error {
 	generatedOperations :=
 		oe.operationGenerator.GenerateAttachVolumeFunc(volumeToAttach, actualStateOfWorld)
+	generatedOperations = oe.watchdog.wrapWithDeadline(
+		"attach", string(volumeToAttach.VolumeName), oe.deadlines.Attach, generatedOperations)
 
 	if util.IsMultiAttachAllowed(volumeToAttach.VolumeSpec) {
 		return oe.pendingOperations.Run(
@@ -936,6 +962,9 @@ func (oe *operationExecutor) MountVolume(
 		podName = util.GetUniquePodName(volumeToMount.Pod)
 	}
 
+	generatedOperations = oe.watchdog.wrapWithDeadline(
+		"mount", string(volumeToMount.VolumeName)+"/"+string(podName), oe.deadlines.Mount, generatedOperations)
+
 	// TODO mount_device
 	return oe.pendingOperations.Run(
 		volumeToMount.VolumeName, podName, "" /* nodeName */, generatedOperations)
@@ -1008,6 +1037,8 @@ func (oe *operationExecutor) ExpandInUseVolume(volumeToMount VolumeToMount, actu
 	if err != nil {
 		return err
 	}
+	generatedOperations = oe.watchdog.wrapWithDeadline(
+		"expand", string(volumeToMount.VolumeName), oe.deadlines.Expand, generatedOperations)
 	return oe.pendingOperations.Run(volumeToMount.VolumeName, "", "" /* nodeName */, generatedOperations)
 }
 
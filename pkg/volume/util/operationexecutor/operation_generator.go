@@ -85,6 +85,11 @@ type operationGenerator struct {
 	blkUtil volumepathhandler.BlockVolumePathHandler
 
 	translator InTreeToCSITranslator
+
+	// deviceMountCache tracks staged devices and their mount references on
+	// this node so redundant MountDevice calls can be skipped. It is nil
+	// when the generator does not run on a node.
+	deviceMountCache DeviceMountCache
 }
 
 type inTreeResizeResponse struct {
@@ -103,15 +108,34 @@ func NewOperationGenerator(kubeClient clientset.Interface,
 	recorder record.EventRecorder,
 	blkUtil volumepathhandler.BlockVolumePathHandler) OperationGenerator {
 
-	return &operationGenerator{
+	og := &operationGenerator{
 		kubeClient:      kubeClient,
 		volumePluginMgr: volumePluginMgr,
 		recorder:        recorder,
 		blkUtil:         blkUtil,
 		translator:      csitrans.New(),
 	}
+	// Staging devices is a node-side concern; generators running in
+	// controllers do not get a device mount cache.
+	if volumePluginMgr != nil && volumePluginMgr.Host != nil {
+		if _, ok := volumePluginMgr.Host.(volume.KubeletVolumeHost); ok {
+			og.deviceMountCache = NewDeviceMountCache(
+				filepath.Join(volumePluginMgr.Host.GetPluginDir(deviceMountCachePluginName), deviceMountCacheStateFile))
+		}
+	}
+	return og
 }
 
+const (
+	// deviceMountCachePluginName names the directory under the plugins
+	// directory that holds the operation executor's node-level state.
+	deviceMountCachePluginName = "kubernetes.io/operation-executor"
+
+	// deviceMountCacheStateFile is the file the device mount cache is
+	// persisted to, relative to the operation executor's plugin directory.
+	deviceMountCacheStateFile = "device_mounts.json"
+)
+
 // OperationGenerator interface that extracts out the functions from operation_executor to make it dependency injectable
 type OperationGenerator interface {
 	// Generates the MountVolume function needed to perform the mount of a volume plugin
@@ -609,6 +633,12 @@ func (og *operationGenerator) GenerateMountVolumeFunc(
 				fsGroupChangePolicy = podSc.FSGroupChangePolicy
 			}
 		}
+		if fsGroupChangePolicy == nil {
+			// Fall back to the policy configured on the kubelet, if any.
+			if kvh, ok := og.GetVolumePluginMgr().Host.(volume.KubeletVolumeHost); ok {
+				fsGroupChangePolicy = kvh.GetDefaultFSGroupChangePolicy()
+			}
+		}
 
 		devicePath := volumeToMount.DevicePath
 		if volumeAttacher != nil {
@@ -631,31 +661,52 @@ func (og *operationGenerator) GenerateMountVolumeFunc(
 			DevicePath: devicePath,
 		}
 
-		if volumeDeviceMounter != nil && actualStateOfWorld.GetDeviceMountState(volumeToMount.VolumeName) != DeviceGloballyMounted {
-			deviceMountPath, err :=
-				volumeDeviceMounter.GetDeviceMountPath(volumeToMount.VolumeSpec)
+		var deviceMountPath string
+		if volumeDeviceMounter != nil {
+			deviceMountPath, err = volumeDeviceMounter.GetDeviceMountPath(volumeToMount.VolumeSpec)
 			if err != nil {
 				// On failure, return error. Caller will log and retry.
 				eventErr, detailedErr := volumeToMount.GenerateError("MountVolume.GetDeviceMountPath failed", err)
 				return volumetypes.NewOperationContext(eventErr, detailedErr, migrated)
 			}
+		}
 
-			// Mount device to global mount path
-			err = volumeDeviceMounter.MountDevice(
-				volumeToMount.VolumeSpec,
-				devicePath,
-				deviceMountPath,
-				volume.DeviceMounterArgs{FsGroup: fsGroup},
-			)
-			if err != nil {
-				og.checkForFailedMount(volumeToMount, err)
-				og.markDeviceErrorState(volumeToMount, devicePath, deviceMountPath, err, actualStateOfWorld)
-				// On failure, return error. Caller will log and retry.
-				eventErr, detailedErr := volumeToMount.GenerateError("MountVolume.MountDevice failed", err)
-				return volumetypes.NewOperationContext(eventErr, detailedErr, migrated)
+		if volumeDeviceMounter != nil && actualStateOfWorld.GetDeviceMountState(volumeToMount.VolumeName) != DeviceGloballyMounted {
+			stagedByCache := false
+			if og.deviceMountCache != nil && og.deviceMountCache.IsDeviceStaged(deviceMountPath) {
+				// Trust the cache only if the device mount really exists;
+				// the state file may outlive the mounts across a reboot.
+				mounter := og.volumePluginMgr.Host.GetMounter(volumePluginName)
+				if notMnt, err := mounter.IsLikelyNotMountPoint(deviceMountPath); err == nil && !notMnt {
+					stagedByCache = true
+					klog.V(4).InfoS(volumeToMount.GenerateMsgDetailed("MountVolume.MountDevice skipped, device is already staged", fmt.Sprintf("device mount path %q", deviceMountPath)), "pod", klog.KObj(volumeToMount.Pod))
+				} else {
+					og.deviceMountCache.MarkDeviceUnstaged(deviceMountPath)
+				}
 			}
 
-			klog.InfoS(volumeToMount.GenerateMsgDetailed("MountVolume.MountDevice succeeded", fmt.Sprintf("device mount path %q", deviceMountPath)), "pod", klog.KObj(volumeToMount.Pod))
+			if !stagedByCache {
+				// Mount device to global mount path
+				err = volumeDeviceMounter.MountDevice(
+					volumeToMount.VolumeSpec,
+					devicePath,
+					deviceMountPath,
+					volume.DeviceMounterArgs{FsGroup: fsGroup},
+				)
+				if err != nil {
+					og.checkForFailedMount(volumeToMount, err)
+					og.markDeviceErrorState(volumeToMount, devicePath, deviceMountPath, err, actualStateOfWorld)
+					// On failure, return error. Caller will log and retry.
+					eventErr, detailedErr := volumeToMount.GenerateError("MountVolume.MountDevice failed", err)
+					return volumetypes.NewOperationContext(eventErr, detailedErr, migrated)
+				}
+
+				klog.InfoS(volumeToMount.GenerateMsgDetailed("MountVolume.MountDevice succeeded", fmt.Sprintf("device mount path %q", deviceMountPath)), "pod", klog.KObj(volumeToMount.Pod))
+
+				if og.deviceMountCache != nil {
+					og.deviceMountCache.MarkDeviceStaged(deviceMountPath)
+				}
+			}
 
 			// Update actual state of world to reflect volume is globally mounted
 			markDeviceMountedErr := actualStateOfWorld.MarkDeviceAsMounted(
@@ -730,6 +781,10 @@ func (og *operationGenerator) GenerateMountVolumeFunc(
 			eventErr, detailedErr := volumeToMount.GenerateError("MountVolume.MarkVolumeAsMounted failed", markVolMountedErr)
 			return volumetypes.NewOperationContext(eventErr, detailedErr, migrated)
 		}
+
+		if og.deviceMountCache != nil && deviceMountPath != "" && !isRemount {
+			og.deviceMountCache.AddMountRef(deviceMountPath)
+		}
 		return volumetypes.NewOperationContext(nil, nil, migrated)
 	}
 
@@ -871,6 +926,10 @@ func (og *operationGenerator) GenerateUnmountVolumeFunc(
 			klog.Errorf(volumeToUnmount.GenerateErrorDetailed("UnmountVolume.MarkVolumeAsUnmounted failed", markVolMountedErr).Error())
 		}
 
+		if og.deviceMountCache != nil && volumeToUnmount.DeviceMountPath != "" {
+			og.deviceMountCache.RemoveMountRef(volumeToUnmount.DeviceMountPath)
+		}
+
 		return volumetypes.NewOperationContext(nil, nil, migrated)
 	}
 
@@ -969,6 +1028,10 @@ func (og *operationGenerator) GenerateUnmountDeviceFunc(
 
 		klog.Info(deviceToDetach.GenerateMsgDetailed("UnmountDevice succeeded", ""))
 
+		if og.deviceMountCache != nil {
+			og.deviceMountCache.MarkDeviceUnstaged(deviceMountPath)
+		}
+
 		// Update actual state of world
 		markDeviceUnmountedErr := actualStateOfWorld.MarkDeviceAsUnmounted(
 			deviceToDetach.VolumeName)
@@ -120,6 +120,14 @@ type Attributes struct {
 	SELinuxRelabel bool
 }
 
+// FSGroupChangeOnRootMismatchUsingXattr is a kubelet-only extension of the
+// pod-level fsGroupChangePolicy values. It behaves like
+// v1.FSGroupChangeOnRootMismatch, but only skips the recursive permission
+// change when a marker xattr written after a previous recursive change
+// records the same fsGroup. It can be selected through the kubelet
+// configuration, not through the pod API.
+const FSGroupChangeOnRootMismatchUsingXattr v1.PodFSGroupChangePolicy = "OnRootMismatchUsingXattr"
+
 // MounterArgs provides more easily extensible arguments to Mounter
 type MounterArgs struct {
 	// When FsUser is set, the ownership of the volume will be modified to be
@@ -21,11 +21,13 @@ package volume
 
 import (
 	"path/filepath"
+	"strconv"
 	"syscall"
 
 	"os"
 	"time"
 
+	"golang.org/x/sys/unix"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/klog/v2"
 	"k8s.io/kubernetes/pkg/volume/util/types"
@@ -35,6 +37,12 @@ const (
 	rwMask   = os.FileMode(0660)
 	roMask   = os.FileMode(0440)
 	execMask = os.FileMode(0110)
+
+	// fsGroupXattrName is the xattr written to the root of a volume after a
+	// successful recursive permission change. It records the fsGroup that was
+	// applied and is checked by the OnRootMismatchUsingXattr policy before
+	// skipping the recursive walk.
+	fsGroupXattrName = "user.kubernetes.fsgroup"
 )
 
 // SetVolumeOwnership modifies the given volume to be owned by
@@ -61,6 +69,9 @@ func SetVolumeOwnership(mounter Mounter, fsGroup *int64, fsGroupChangePolicy *v1
 		}
 		return changeFilePermission(path, fsGroup, mounter.GetAttributes().ReadOnly, info)
 	})
+	if err == nil && fsGroupChangePolicy != nil && *fsGroupChangePolicy == FSGroupChangeOnRootMismatchUsingXattr {
+		markFSGroupApplied(mounter.GetPath(), fsGroup)
+	}
 	if completeFunc != nil {
 		completeFunc(types.CompleteFuncParam{
 			Err: &err,
@@ -107,11 +118,45 @@ func changeFilePermission(filename string, fsGroup *int64, readonly bool, info o
 func skipPermissionChange(mounter Mounter, fsGroup *int64, fsGroupChangePolicy *v1.PodFSGroupChangePolicy) bool {
 	dir := mounter.GetPath()
 
-	if fsGroupChangePolicy == nil || *fsGroupChangePolicy != v1.FSGroupChangeOnRootMismatch {
+	if fsGroupChangePolicy == nil {
+		klog.V(4).InfoS("Perform recursive ownership change for directory", "path", dir)
+		return false
+	}
+	switch *fsGroupChangePolicy {
+	case v1.FSGroupChangeOnRootMismatch:
+		return !requiresPermissionChange(dir, fsGroup, mounter.GetAttributes().ReadOnly)
+	case FSGroupChangeOnRootMismatchUsingXattr:
+		return !requiresPermissionChange(dir, fsGroup, mounter.GetAttributes().ReadOnly) && fsGroupXattrMatches(dir, fsGroup)
+	default:
 		klog.V(4).InfoS("Perform recursive ownership change for directory", "path", dir)
 		return false
 	}
-	return !requiresPermissionChange(mounter.GetPath(), fsGroup, mounter.GetAttributes().ReadOnly)
+}
+
+// markFSGroupApplied records on the root of the volume the fsGroup that a
+// recursive permission change applied. Failures are logged and ignored: not
+// all filesystems support xattrs and the only consequence is that the next
+// mount performs the recursive walk again.
+func markFSGroupApplied(rootDir string, fsGroup *int64) {
+	if err := unix.Setxattr(rootDir, fsGroupXattrName, []byte(strconv.FormatInt(*fsGroup, 10)), 0); err != nil {
+		klog.V(4).InfoS("Failed to record applied fsGroup in xattr", "path", rootDir, "err", err)
+	}
+}
+
+// fsGroupXattrMatches returns true if the marker xattr on the root of the
+// volume records the same fsGroup that is about to be applied.
+func fsGroupXattrMatches(rootDir string, fsGroup *int64) bool {
+	buf := make([]byte, 32)
+	n, err := unix.Getxattr(rootDir, fsGroupXattrName, buf)
+	if err != nil {
+		klog.V(4).InfoS("Performing recursive ownership change on rootDir because the fsGroup marker xattr could not be read", "path", rootDir, "err", err)
+		return false
+	}
+	if string(buf[:n]) != strconv.FormatInt(*fsGroup, 10) {
+		klog.V(4).InfoS("Performing recursive ownership change on rootDir because the fsGroup marker xattr does not match", "path", rootDir, "GID", *fsGroup)
+		return false
+	}
+	return true
 }
 
 func requiresPermissionChange(rootDir string, fsGroup *int64, readonly bool) bool {
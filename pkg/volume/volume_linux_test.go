@@ -23,9 +23,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"syscall"
 	"testing"
 
+	"golang.org/x/sys/unix"
 	v1 "k8s.io/api/core/v1"
 	utiltesting "k8s.io/client-go/util/testing"
 )
@@ -58,12 +60,14 @@ func (l *localFakeMounter) GetMetrics() (*Metrics, error) {
 func TestSkipPermissionChange(t *testing.T) {
 	always := v1.FSGroupChangeAlways
 	onrootMismatch := v1.FSGroupChangeOnRootMismatch
+	onrootMismatchUsingXattr := FSGroupChangeOnRootMismatchUsingXattr
 	tests := []struct {
 		description         string
 		fsGroupChangePolicy *v1.PodFSGroupChangePolicy
 		gidOwnerMatch       bool
 		permissionMatch     bool
 		sgidMatch           bool
+		xattrRecorded       bool
 		skipPermssion       bool
 	}{
 		{
@@ -115,6 +119,23 @@ func TestSkipPermissionChange(t *testing.T) {
 			sgidMatch:           true,
 			skipPermssion:       true,
 		},
+		{
+			description:         "skippermission=false, policy=onrootmismatchusingxattr, gidmatch=true, permmatch=true, sgidmatch=true, xattr=false",
+			fsGroupChangePolicy: &onrootMismatchUsingXattr,
+			gidOwnerMatch:       true,
+			permissionMatch:     true,
+			sgidMatch:           true,
+			skipPermssion:       false,
+		},
+		{
+			description:         "skippermission=true, policy=onrootmismatchusingxattr, gidmatch=true, permmatch=true, sgidmatch=true, xattr=true",
+			fsGroupChangePolicy: &onrootMismatchUsingXattr,
+			gidOwnerMatch:       true,
+			permissionMatch:     true,
+			sgidMatch:           true,
+			xattrRecorded:       true,
+			skipPermssion:       true,
+		},
 	}
 
 	for _, test := range tests {
@@ -165,6 +186,12 @@ func TestSkipPermissionChange(t *testing.T) {
 				t.Errorf("Chmod failed on %v: %v", tmpDir, err)
 			}
 
+			if test.xattrRecorded {
+				if err := unix.Setxattr(tmpDir, fsGroupXattrName, []byte(strconv.FormatInt(expectedGid, 10)), 0); err != nil {
+					t.Skipf("filesystem backing %s does not support xattrs: %v", tmpDir, err)
+				}
+			}
+
 			mounter := &localFakeMounter{path: tmpDir}
 			ok = skipPermissionChange(mounter, &expectedGid, test.fsGroupChangePolicy)
 			if ok != test.skipPermssion {
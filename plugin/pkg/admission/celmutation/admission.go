@@ -0,0 +1,248 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package celmutation implements an admission plugin that applies
+// administrator-defined CEL mutating policies to objects on create, without
+// requiring a mutating webhook. Typical uses are defaulting PVC storage
+// classes, adding labels to PersistentVolumes or injecting tolerations.
+//
+// Policies are evaluated in the order they are declared; each expression
+// receives the (possibly already mutated) object under the variable `object`
+// and returns a fragment that is deep-merged into the object. Returning an
+// empty map leaves the object unchanged.
+package celmutation
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/checker/decls"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/common/types/traits"
+	"sigs.k8s.io/yaml"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/admission"
+	"k8s.io/kubernetes/pkg/api/legacyscheme"
+)
+
+// PluginName indicates name of admission plugin.
+const PluginName = "CELMutatingPolicy"
+
+// Configuration holds the plugin configuration, read from the admission
+// control config file.
+type Configuration struct {
+	Policies []Policy `json:"policies"`
+}
+
+// Policy is a single mutating rule.
+type Policy struct {
+	// Name identifies the policy in errors and logs.
+	Name string `json:"name"`
+	// Resource is the lowercase plural resource the policy applies to,
+	// e.g. "persistentvolumeclaims".
+	Resource string `json:"resource"`
+	// Expression is a CEL expression evaluated against the object (bound to
+	// the variable `object`, as an unstructured map of its versioned form).
+	// It must return a map that is deep-merged into the object.
+	Expression string `json:"expression"`
+}
+
+// Register registers a plugin
+func Register(plugins *admission.Plugins) {
+	plugins.Register(PluginName, func(config io.Reader) (admission.Interface, error) {
+		if config == nil {
+			return NewCELMutatingPolicy(&Configuration{})
+		}
+		data, err := io.ReadAll(config)
+		if err != nil {
+			return nil, err
+		}
+		configuration := &Configuration{}
+		if err := yaml.Unmarshal(data, configuration); err != nil {
+			return nil, fmt.Errorf("failed to parse %s configuration: %v", PluginName, err)
+		}
+		return NewCELMutatingPolicy(configuration)
+	})
+}
+
+// compiledPolicy pairs a policy with its compiled CEL program.
+type compiledPolicy struct {
+	policy  Policy
+	program cel.Program
+}
+
+// Plugin applies CEL mutating policies on create.
+type Plugin struct {
+	*admission.Handler
+	policies []compiledPolicy
+}
+
+var _ admission.MutationInterface = &Plugin{}
+
+// NewCELMutatingPolicy compiles the configured policies and returns the
+// plugin. Compilation errors fail apiserver startup rather than being
+// deferred to request time.
+func NewCELMutatingPolicy(configuration *Configuration) (*Plugin, error) {
+	env, err := cel.NewEnv(cel.Declarations(decls.NewVar("object", decls.Dyn)))
+	if err != nil {
+		return nil, err
+	}
+	policies := make([]compiledPolicy, 0, len(configuration.Policies))
+	for _, policy := range configuration.Policies {
+		if len(policy.Name) == 0 || len(policy.Resource) == 0 || len(policy.Expression) == 0 {
+			return nil, fmt.Errorf("policies require name, resource and expression to be set")
+		}
+		ast, issues := env.Compile(policy.Expression)
+		if issues != nil && issues.Err() != nil {
+			return nil, fmt.Errorf("failed to compile policy %q: %v", policy.Name, issues.Err())
+		}
+		program, err := env.Program(ast)
+		if err != nil {
+			return nil, fmt.Errorf("failed to program policy %q: %v", policy.Name, err)
+		}
+		policies = append(policies, compiledPolicy{policy: policy, program: program})
+	}
+	return &Plugin{
+		Handler:  admission.NewHandler(admission.Create),
+		policies: policies,
+	}, nil
+}
+
+// Admit evaluates matching policies in order and merges their results into
+// the object.
+func (p *Plugin) Admit(ctx context.Context, attributes admission.Attributes, o admission.ObjectInterfaces) error {
+	if len(p.policies) == 0 || len(attributes.GetSubresource()) > 0 {
+		return nil
+	}
+	resource := attributes.GetResource().Resource
+	matching := []compiledPolicy{}
+	for _, policy := range p.policies {
+		if policy.policy.Resource == resource {
+			matching = append(matching, policy)
+		}
+	}
+	if len(matching) == 0 {
+		return nil
+	}
+
+	obj := attributes.GetObject()
+	// Evaluate against the versioned form so expressions see the documented
+	// field names rather than internal ones.
+	versioned, err := legacyscheme.Scheme.ConvertToVersion(obj, attributes.GetKind().GroupVersion())
+	if err != nil {
+		return admission.NewForbidden(attributes, fmt.Errorf("%s: %v", PluginName, err))
+	}
+	objMap, err := runtime.DefaultUnstructuredConverter.ToUnstructured(versioned)
+	if err != nil {
+		return admission.NewForbidden(attributes, fmt.Errorf("%s: %v", PluginName, err))
+	}
+
+	for _, policy := range matching {
+		result, _, err := policy.program.Eval(map[string]interface{}{"object": objMap})
+		if err != nil {
+			return admission.NewForbidden(attributes, fmt.Errorf("%s: policy %q failed: %v", PluginName, policy.policy.Name, err))
+		}
+		fragment, err := nativeMap(result)
+		if err != nil {
+			return admission.NewForbidden(attributes, fmt.Errorf("%s: policy %q must return a map: %v", PluginName, policy.policy.Name, err))
+		}
+		if len(fragment) == 0 {
+			continue
+		}
+		objMap = mergeFragment(objMap, fragment)
+	}
+
+	mutated := versioned.DeepCopyObject()
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(objMap, mutated); err != nil {
+		return admission.NewForbidden(attributes, fmt.Errorf("%s: %v", PluginName, err))
+	}
+	if err := legacyscheme.Scheme.Convert(mutated, obj, nil); err != nil {
+		return admission.NewForbidden(attributes, fmt.Errorf("%s: %v", PluginName, err))
+	}
+	return nil
+}
+
+// nativeMap converts an evaluation result into a plain map with string keys.
+// ConvertToNative only unwraps the top level, leaving nested CEL values that
+// the unstructured converter cannot handle, so the conversion is done
+// recursively here.
+func nativeMap(val ref.Val) (map[string]interface{}, error) {
+	mapper, ok := val.(traits.Mapper)
+	if !ok {
+		return nil, fmt.Errorf("expected a map, got %v", val.Type())
+	}
+	out := map[string]interface{}{}
+	it := mapper.Iterator()
+	for it.HasNext() == types.True {
+		key := it.Next()
+		keyString, ok := key.Value().(string)
+		if !ok {
+			return nil, fmt.Errorf("map keys must be strings, got %v", key.Type())
+		}
+		value, err := nativeValue(mapper.Get(key))
+		if err != nil {
+			return nil, err
+		}
+		out[keyString] = value
+	}
+	return out, nil
+}
+
+// nativeValue converts a single CEL value into the plain Go representation
+// the unstructured converter expects.
+func nativeValue(val ref.Val) (interface{}, error) {
+	switch typed := val.(type) {
+	case traits.Mapper:
+		return nativeMap(typed)
+	case traits.Lister:
+		size, ok := typed.Size().Value().(int64)
+		if !ok {
+			return nil, fmt.Errorf("invalid list size %v", typed.Size())
+		}
+		out := make([]interface{}, 0, size)
+		for i := int64(0); i < size; i++ {
+			value, err := nativeValue(typed.Get(types.Int(i)))
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, value)
+		}
+		return out, nil
+	}
+	if value, ok := val.Value().(uint64); ok {
+		return int64(value), nil
+	}
+	return val.Value(), nil
+}
+
+// mergeFragment deep-merges fragment into base: maps are merged recursively,
+// any other value (including lists) replaces the base value.
+func mergeFragment(base, fragment map[string]interface{}) map[string]interface{} {
+	for key, value := range fragment {
+		fragmentMap, fragmentIsMap := value.(map[string]interface{})
+		baseMap, baseIsMap := base[key].(map[string]interface{})
+		if fragmentIsMap && baseIsMap {
+			base[key] = mergeFragment(baseMap, fragmentMap)
+			continue
+		}
+		base[key] = value
+	}
+	return base
+}
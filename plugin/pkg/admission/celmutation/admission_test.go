@@ -0,0 +1,144 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package celmutation
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/admission"
+	"k8s.io/apiserver/pkg/authentication/user"
+	api "k8s.io/kubernetes/pkg/apis/core"
+
+	_ "k8s.io/kubernetes/pkg/apis/core/install"
+)
+
+func TestAdmitDefaultsStorageClass(t *testing.T) {
+	plugin, err := NewCELMutatingPolicy(&Configuration{
+		Policies: []Policy{
+			{
+				Name:       "default-storage-class",
+				Resource:   "persistentvolumeclaims",
+				Expression: `!has(object.spec.storageClassName) ? {"spec": {"storageClassName": "standard"}} : {}`,
+			},
+			{
+				Name:       "label-pvcs",
+				Resource:   "persistentvolumeclaims",
+				Expression: `{"metadata": {"labels": {"mutated": "true"}}}`,
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	pvc := &api.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "claim1", Namespace: "ns1"},
+		Spec: api.PersistentVolumeClaimSpec{
+			AccessModes: []api.PersistentVolumeAccessMode{api.ReadWriteOnce},
+		},
+	}
+	attrs := admission.NewAttributesRecord(
+		pvc, nil,
+		api.Kind("PersistentVolumeClaim").WithVersion("v1"),
+		pvc.Namespace, pvc.Name,
+		api.Resource("persistentvolumeclaims").WithVersion("v1"),
+		"", admission.Create, &metav1.CreateOptions{}, false, &user.DefaultInfo{})
+
+	if err := plugin.Admit(context.TODO(), attrs, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pvc.Spec.StorageClassName == nil || *pvc.Spec.StorageClassName != "standard" {
+		t.Errorf("expected storage class to be defaulted to %q, got %v", "standard", pvc.Spec.StorageClassName)
+	}
+	if pvc.Labels["mutated"] != "true" {
+		t.Errorf("expected label from second policy, got %v", pvc.Labels)
+	}
+	// pre-existing fields survive the merge
+	if len(pvc.Spec.AccessModes) != 1 || pvc.Spec.AccessModes[0] != api.ReadWriteOnce {
+		t.Errorf("expected access modes to be preserved, got %v", pvc.Spec.AccessModes)
+	}
+}
+
+func TestAdmitSkipsNonMatchingResources(t *testing.T) {
+	plugin, err := NewCELMutatingPolicy(&Configuration{
+		Policies: []Policy{
+			{
+				Name:       "default-storage-class",
+				Resource:   "persistentvolumeclaims",
+				Expression: `{"spec": {"storageClassName": "standard"}}`,
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	pod := &api.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "ns1"}}
+	attrs := admission.NewAttributesRecord(
+		pod, nil,
+		api.Kind("Pod").WithVersion("v1"),
+		pod.Namespace, pod.Name,
+		api.Resource("pods").WithVersion("v1"),
+		"", admission.Create, &metav1.CreateOptions{}, false, &user.DefaultInfo{})
+
+	if err := plugin.Admit(context.TODO(), attrs, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestNewCELMutatingPolicyRejectsBadExpressions(t *testing.T) {
+	_, err := NewCELMutatingPolicy(&Configuration{
+		Policies: []Policy{
+			{Name: "broken", Resource: "pods", Expression: "object..."},
+		},
+	})
+	if err == nil {
+		t.Errorf("expected a compilation error")
+	}
+}
+
+func TestMergeFragment(t *testing.T) {
+	base := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"accessModes":      []interface{}{"ReadWriteOnce"},
+			"storageClassName": "old",
+		},
+	}
+	fragment := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"storageClassName": "new",
+		},
+		"metadata": map[string]interface{}{
+			"labels": map[string]interface{}{"a": "b"},
+		},
+	}
+	expected := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"accessModes":      []interface{}{"ReadWriteOnce"},
+			"storageClassName": "new",
+		},
+		"metadata": map[string]interface{}{
+			"labels": map[string]interface{}{"a": "b"},
+		},
+	}
+	if got := mergeFragment(base, fragment); !reflect.DeepEqual(got, expected) {
+		t.Errorf("unexpected merge result: %+v", got)
+	}
+}
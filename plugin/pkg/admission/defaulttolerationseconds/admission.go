@@ -21,16 +21,30 @@ import (
 	"flag"
 	"fmt"
 	"io"
+	"strconv"
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apiserver/pkg/admission"
+	genericadmissioninitializer "k8s.io/apiserver/pkg/admission/initializer"
+	"k8s.io/client-go/informers"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	schedulingv1listers "k8s.io/client-go/listers/scheduling/v1"
 	api "k8s.io/kubernetes/pkg/apis/core"
 )
 
 // PluginName indicates name of admission plugin.
 const PluginName = "DefaultTolerationSeconds"
 
+// The annotation keys carrying per-namespace and per-priority-class overrides
+// of the default tolerationSeconds. The same keys are recognized on Namespace
+// and PriorityClass objects; a namespace override takes precedence over a
+// priority class override, which takes precedence over the cluster defaults.
+const (
+	NotReadyTolerationAnnotation    = "scheduler.alpha.kubernetes.io/default-not-ready-toleration-seconds"
+	UnreachableTolerationAnnotation = "scheduler.alpha.kubernetes.io/default-unreachable-toleration-seconds"
+)
+
 var (
 	defaultNotReadyTolerationSeconds = flag.Int64("default-not-ready-toleration-seconds", 300,
 		"Indicates the tolerationSeconds of the toleration for notReady:NoExecute"+
@@ -39,20 +53,6 @@ var (
 	defaultUnreachableTolerationSeconds = flag.Int64("default-unreachable-toleration-seconds", 300,
 		"Indicates the tolerationSeconds of the toleration for unreachable:NoExecute"+
 			" that is added by default to every pod that does not already have such a toleration.")
-
-	notReadyToleration = api.Toleration{
-		Key:               v1.TaintNodeNotReady,
-		Operator:          api.TolerationOpExists,
-		Effect:            api.TaintEffectNoExecute,
-		TolerationSeconds: defaultNotReadyTolerationSeconds,
-	}
-
-	unreachableToleration = api.Toleration{
-		Key:               v1.TaintNodeUnreachable,
-		Operator:          api.TolerationOpExists,
-		Effect:            api.TaintEffectNoExecute,
-		TolerationSeconds: defaultUnreachableTolerationSeconds,
-	}
 )
 
 // Register registers a plugin
@@ -65,14 +65,18 @@ func Register(plugins *admission.Plugins) {
 // Plugin contains the client used by the admission controller
 // It will add default tolerations for every pod
 // that tolerate taints `notReady:NoExecute` and `unreachable:NoExecute`,
-// with tolerationSeconds of 300s.
+// with tolerationSeconds of 300s, unless the pod's namespace or priority
+// class carries an override annotation.
 // If the pod already specifies a toleration for taint `notReady:NoExecute`
 // or `unreachable:NoExecute`, the plugin won't touch it.
 type Plugin struct {
 	*admission.Handler
+	namespaceLister     corev1listers.NamespaceLister
+	priorityClassLister schedulingv1listers.PriorityClassLister
 }
 
 var _ admission.MutationInterface = &Plugin{}
+var _ = genericadmissioninitializer.WantsExternalKubeInformerFactory(&Plugin{})
 
 // NewDefaultTolerationSeconds creates a new instance of the DefaultTolerationSeconds admission controller
 func NewDefaultTolerationSeconds() *Plugin {
@@ -113,13 +117,96 @@ func (p *Plugin) Admit(ctx context.Context, attributes admission.Attributes, o a
 		}
 	}
 
+	notReadySeconds, unreachableSeconds := p.resolveDefaults(pod, attributes.GetNamespace())
+
 	if !toleratesNodeNotReady {
-		pod.Spec.Tolerations = append(pod.Spec.Tolerations, notReadyToleration)
+		pod.Spec.Tolerations = append(pod.Spec.Tolerations, api.Toleration{
+			Key:               v1.TaintNodeNotReady,
+			Operator:          api.TolerationOpExists,
+			Effect:            api.TaintEffectNoExecute,
+			TolerationSeconds: &notReadySeconds,
+		})
 	}
 
 	if !toleratesNodeUnreachable {
-		pod.Spec.Tolerations = append(pod.Spec.Tolerations, unreachableToleration)
+		pod.Spec.Tolerations = append(pod.Spec.Tolerations, api.Toleration{
+			Key:               v1.TaintNodeUnreachable,
+			Operator:          api.TolerationOpExists,
+			Effect:            api.TaintEffectNoExecute,
+			TolerationSeconds: &unreachableSeconds,
+		})
 	}
 
 	return nil
 }
+
+// SetExternalKubeInformerFactory initializes the listers used to look up
+// per-namespace and per-priority-class overrides.
+func (p *Plugin) SetExternalKubeInformerFactory(f informers.SharedInformerFactory) {
+	namespaceInformer := f.Core().V1().Namespaces()
+	priorityClassInformer := f.Scheduling().V1().PriorityClasses()
+	p.namespaceLister = namespaceInformer.Lister()
+	p.priorityClassLister = priorityClassInformer.Lister()
+	p.SetReadyFunc(func() bool {
+		return namespaceInformer.Informer().HasSynced() && priorityClassInformer.Informer().HasSynced()
+	})
+}
+
+// ValidateInitialization checks the object is properly initialized
+func (p *Plugin) ValidateInitialization() error {
+	if p.namespaceLister == nil {
+		return fmt.Errorf("missing namespaceLister")
+	}
+	if p.priorityClassLister == nil {
+		return fmt.Errorf("missing priorityClassLister")
+	}
+	return nil
+}
+
+// resolveDefaults returns the not-ready and unreachable tolerationSeconds to
+// inject for the given pod. Namespace annotations take precedence over the
+// pod's priority class annotations, which take precedence over the
+// cluster-wide flag defaults. Lookups are best-effort: if the namespace or
+// priority class cannot be resolved, the flag defaults apply.
+func (p *Plugin) resolveDefaults(pod *api.Pod, namespace string) (notReadySeconds, unreachableSeconds int64) {
+	notReadySeconds = *defaultNotReadyTolerationSeconds
+	unreachableSeconds = *defaultUnreachableTolerationSeconds
+
+	if p.priorityClassLister != nil && len(pod.Spec.PriorityClassName) > 0 {
+		if pc, err := p.priorityClassLister.Get(pod.Spec.PriorityClassName); err == nil {
+			if seconds, ok := tolerationSecondsFromAnnotations(pc.Annotations, NotReadyTolerationAnnotation); ok {
+				notReadySeconds = seconds
+			}
+			if seconds, ok := tolerationSecondsFromAnnotations(pc.Annotations, UnreachableTolerationAnnotation); ok {
+				unreachableSeconds = seconds
+			}
+		}
+	}
+
+	if p.namespaceLister != nil && len(namespace) > 0 {
+		if ns, err := p.namespaceLister.Get(namespace); err == nil {
+			if seconds, ok := tolerationSecondsFromAnnotations(ns.Annotations, NotReadyTolerationAnnotation); ok {
+				notReadySeconds = seconds
+			}
+			if seconds, ok := tolerationSecondsFromAnnotations(ns.Annotations, UnreachableTolerationAnnotation); ok {
+				unreachableSeconds = seconds
+			}
+		}
+	}
+
+	return notReadySeconds, unreachableSeconds
+}
+
+// tolerationSecondsFromAnnotations parses the override annotation under key,
+// returning false for missing, malformed or negative values.
+func tolerationSecondsFromAnnotations(annotations map[string]string, key string) (int64, bool) {
+	value, ok := annotations[key]
+	if !ok {
+		return 0, false
+	}
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || seconds < 0 {
+		return 0, false
+	}
+	return seconds, true
+}
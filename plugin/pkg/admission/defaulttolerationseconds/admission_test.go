@@ -21,8 +21,13 @@ import (
 	"testing"
 
 	v1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apiserver/pkg/admission"
 	admissiontesting "k8s.io/apiserver/pkg/admission/testing"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	schedulingv1listers "k8s.io/client-go/listers/scheduling/v1"
+	"k8s.io/client-go/tools/cache"
 	api "k8s.io/kubernetes/pkg/apis/core"
 	"k8s.io/kubernetes/pkg/apis/core/helper"
 )
@@ -291,3 +296,86 @@ func TestHandles(t *testing.T) {
 		}
 	}
 }
+
+func TestResolveDefaults(t *testing.T) {
+	namespaceIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	namespaceIndexer.Add(&v1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "stateful",
+			Annotations: map[string]string{
+				NotReadyTolerationAnnotation:    "30",
+				UnreachableTolerationAnnotation: "60",
+			},
+		},
+	})
+	namespaceIndexer.Add(&v1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "plain"},
+	})
+	priorityClassIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	priorityClassIndexer.Add(&schedulingv1.PriorityClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "critical",
+			Annotations: map[string]string{
+				NotReadyTolerationAnnotation:    "10",
+				UnreachableTolerationAnnotation: "invalid",
+			},
+		},
+	})
+
+	plugin := NewDefaultTolerationSeconds()
+	plugin.namespaceLister = corev1listers.NewNamespaceLister(namespaceIndexer)
+	plugin.priorityClassLister = schedulingv1listers.NewPriorityClassLister(priorityClassIndexer)
+
+	tests := []struct {
+		name               string
+		namespace          string
+		priorityClassName  string
+		expectNotReady     int64
+		expectUnreachable  int64
+	}{
+		{
+			name:              "flag defaults when nothing is annotated",
+			namespace:         "plain",
+			expectNotReady:    300,
+			expectUnreachable: 300,
+		},
+		{
+			name:              "namespace annotations override the defaults",
+			namespace:         "stateful",
+			expectNotReady:    30,
+			expectUnreachable: 60,
+		},
+		{
+			name:              "priority class annotation applies, malformed values are ignored",
+			namespace:         "plain",
+			priorityClassName: "critical",
+			expectNotReady:    10,
+			expectUnreachable: 300,
+		},
+		{
+			name:              "namespace wins over priority class",
+			namespace:         "stateful",
+			priorityClassName: "critical",
+			expectNotReady:    30,
+			expectUnreachable: 60,
+		},
+		{
+			name:              "missing namespace falls back to the defaults",
+			namespace:         "no-such-namespace",
+			expectNotReady:    300,
+			expectUnreachable: 300,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			pod := &api.Pod{Spec: api.PodSpec{PriorityClassName: tc.priorityClassName}}
+			notReady, unreachable := plugin.resolveDefaults(pod, tc.namespace)
+			if notReady != tc.expectNotReady {
+				t.Errorf("expected not-ready tolerationSeconds %d, got %d", tc.expectNotReady, notReady)
+			}
+			if unreachable != tc.expectUnreachable {
+				t.Errorf("expected unreachable tolerationSeconds %d, got %d", tc.expectUnreachable, unreachable)
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package deletepropagationpolicy contains an admission plugin that lets
+// cluster admins require a deletion propagation policy per owner kind, e.g.
+// orphaning dependents whenever a StatefulSet is deleted. Delete options
+// cannot be mutated at admission, so delete requests whose effective
+// propagation policy conflicts with the configured one are rejected; the
+// enforced policy is recorded as an audit annotation.
+package deletepropagationpolicy
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/apiserver/pkg/admission"
+)
+
+// PluginName is a string with the name of the plugin
+const PluginName = "DeletePropagationPolicy"
+
+// requiredPolicyAnnotationKey is the audit annotation key recording the
+// propagation policy this plugin enforced for the request.
+const requiredPolicyAnnotationKey = "deletepropagationpolicy.admission.k8s.io/required-policy"
+
+// Register registers a plugin
+func Register(plugins *admission.Plugins) {
+	plugins.Register(PluginName, func(config io.Reader) (admission.Interface, error) {
+		// TODO move this to a versioned configuration file format.
+		pluginConfig := readConfig(config)
+		return newDeletePropagationPolicy(pluginConfig.DeletePropagationPolicyPluginConfig)
+	})
+}
+
+// Plugin is an implementation of admission.Interface.
+type Plugin struct {
+	*admission.Handler
+	// policies maps the kind of an owner object to the deletion propagation
+	// policy its delete requests must carry.
+	policies map[schema.GroupKind]metav1.DeletionPropagation
+}
+
+var _ admission.ValidationInterface = &Plugin{}
+
+type pluginConfig struct {
+	DeletePropagationPolicyPluginConfig map[string]string
+}
+
+// readConfig reads the per owner kind propagation policies from the file
+// provided with --admission-control-config-file. Keys are kinds qualified
+// with their group ("apps/StatefulSet"), or a bare kind for the core group.
+// The format in a file:
+// deletePropagationPolicyPluginConfig:
+//  apps/StatefulSet: Orphan
+//  PersistentVolumeClaim: Foreground
+func readConfig(config io.Reader) *pluginConfig {
+	defaultConfig := &pluginConfig{}
+	if config == nil || reflect.ValueOf(config).IsNil() {
+		return defaultConfig
+	}
+	d := yaml.NewYAMLOrJSONDecoder(config, 4096)
+	for {
+		if err := d.Decode(defaultConfig); err != nil {
+			if err != io.EOF {
+				continue
+			}
+		}
+		break
+	}
+	return defaultConfig
+}
+
+func newDeletePropagationPolicy(config map[string]string) (*Plugin, error) {
+	policies := make(map[schema.GroupKind]metav1.DeletionPropagation, len(config))
+	for qualifiedKind, policy := range config {
+		switch metav1.DeletionPropagation(policy) {
+		case metav1.DeletePropagationOrphan, metav1.DeletePropagationBackground, metav1.DeletePropagationForeground:
+		default:
+			return nil, fmt.Errorf("invalid deletion propagation policy %q for kind %q; supported policies are %s, %s and %s",
+				policy, qualifiedKind, metav1.DeletePropagationOrphan, metav1.DeletePropagationBackground, metav1.DeletePropagationForeground)
+		}
+		groupKind := schema.GroupKind{Kind: qualifiedKind}
+		if index := strings.LastIndex(qualifiedKind, "/"); index != -1 {
+			groupKind = schema.GroupKind{Group: qualifiedKind[:index], Kind: qualifiedKind[index+1:]}
+		}
+		policies[groupKind] = metav1.DeletionPropagation(policy)
+	}
+	return &Plugin{
+		Handler:  admission.NewHandler(admission.Delete),
+		policies: policies,
+	}, nil
+}
+
+// Validate rejects delete requests for configured owner kinds whose effective
+// propagation policy is not the required one.
+func (p *Plugin) Validate(ctx context.Context, a admission.Attributes, o admission.ObjectInterfaces) error {
+	if a.GetOperation() != admission.Delete || a.GetSubresource() != "" {
+		return nil
+	}
+	requiredPolicy, found := p.policies[a.GetKind().GroupKind()]
+	if !found {
+		return nil
+	}
+	options, ok := a.GetOperationOptions().(*metav1.DeleteOptions)
+	if !ok {
+		return nil
+	}
+
+	if err := a.AddAnnotation(requiredPolicyAnnotationKey, string(requiredPolicy)); err != nil {
+		return err
+	}
+
+	if requestedPolicy(options) == requiredPolicy {
+		return nil
+	}
+	return errors.NewForbidden(a.GetResource().GroupResource(), a.GetName(), fmt.Errorf(
+		"deletion of %s requires propagation policy %s; retry the request with propagationPolicy=%s",
+		a.GetKind().GroupKind(), requiredPolicy, requiredPolicy))
+}
+
+// requestedPolicy returns the deletion propagation policy the client asked
+// for, translating the deprecated OrphanDependents field; it returns the
+// empty policy if the request left the choice to the server.
+func requestedPolicy(options *metav1.DeleteOptions) metav1.DeletionPropagation {
+	if options.PropagationPolicy != nil {
+		return *options.PropagationPolicy
+	}
+	if options.OrphanDependents != nil {
+		if *options.OrphanDependents {
+			return metav1.DeletePropagationOrphan
+		}
+		return metav1.DeletePropagationBackground
+	}
+	return ""
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deletepropagationpolicy
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/admission"
+)
+
+var (
+	statefulSetKind     = schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "StatefulSet"}
+	statefulSetResource = schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "statefulsets"}
+	podKind             = schema.GroupVersionKind{Version: "v1", Kind: "Pod"}
+	podResource         = schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+)
+
+// annotatingAttributes records audit annotations added by the plugin so the
+// tests can inspect them.
+type annotatingAttributes struct {
+	admission.Attributes
+	annotations map[string]string
+}
+
+func (a *annotatingAttributes) AddAnnotation(key, value string) error {
+	if a.annotations == nil {
+		a.annotations = map[string]string{}
+	}
+	a.annotations[key] = value
+	return nil
+}
+
+func policyPtr(policy metav1.DeletionPropagation) *metav1.DeletionPropagation {
+	return &policy
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func TestNewDeletePropagationPolicy(t *testing.T) {
+	if _, err := newDeletePropagationPolicy(map[string]string{"apps/StatefulSet": "Orphan", "Pod": "Background"}); err != nil {
+		t.Errorf("unexpected error for valid config: %v", err)
+	}
+	if _, err := newDeletePropagationPolicy(map[string]string{"apps/StatefulSet": "Sideways"}); err == nil {
+		t.Error("expected an error for an invalid propagation policy")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	plugin, err := newDeletePropagationPolicy(map[string]string{"apps/StatefulSet": "Orphan"})
+	if err != nil {
+		t.Fatalf("failed to create plugin: %v", err)
+	}
+
+	tests := []struct {
+		name           string
+		kind           schema.GroupVersionKind
+		resource       schema.GroupVersionResource
+		options        *metav1.DeleteOptions
+		expectError    string
+		expectAnnotate bool
+	}{
+		{
+			name:           "matching policy is allowed",
+			kind:           statefulSetKind,
+			resource:       statefulSetResource,
+			options:        &metav1.DeleteOptions{PropagationPolicy: policyPtr(metav1.DeletePropagationOrphan)},
+			expectAnnotate: true,
+		},
+		{
+			name:           "deprecated orphanDependents is translated",
+			kind:           statefulSetKind,
+			resource:       statefulSetResource,
+			options:        &metav1.DeleteOptions{OrphanDependents: boolPtr(true)},
+			expectAnnotate: true,
+		},
+		{
+			name:           "conflicting policy is rejected",
+			kind:           statefulSetKind,
+			resource:       statefulSetResource,
+			options:        &metav1.DeleteOptions{PropagationPolicy: policyPtr(metav1.DeletePropagationBackground)},
+			expectError:    "requires propagation policy Orphan",
+			expectAnnotate: true,
+		},
+		{
+			name:           "unspecified policy is rejected",
+			kind:           statefulSetKind,
+			resource:       statefulSetResource,
+			options:        &metav1.DeleteOptions{},
+			expectError:    "requires propagation policy Orphan",
+			expectAnnotate: true,
+		},
+		{
+			name:     "unconfigured kind is ignored",
+			kind:     podKind,
+			resource: podResource,
+			options:  &metav1.DeleteOptions{PropagationPolicy: policyPtr(metav1.DeletePropagationBackground)},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			attrs := &annotatingAttributes{
+				Attributes: admission.NewAttributesRecord(nil, nil, test.kind, "ns", "name", test.resource, "", admission.Delete, test.options, false, nil),
+			}
+			err := plugin.Validate(context.TODO(), attrs, nil)
+			if test.expectError == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			} else {
+				if err == nil {
+					t.Fatal("expected an error, got none")
+				}
+				if !strings.Contains(err.Error(), test.expectError) {
+					t.Fatalf("expected error containing %q, got %q", test.expectError, err.Error())
+				}
+			}
+
+			_, annotated := attrs.annotations[requiredPolicyAnnotationKey]
+			if annotated != test.expectAnnotate {
+				t.Errorf("expected annotation presence %v, got annotations %v", test.expectAnnotate, attrs.annotations)
+			}
+		})
+	}
+}
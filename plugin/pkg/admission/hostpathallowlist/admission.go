@@ -0,0 +1,201 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package hostpathallowlist restricts hostPath volumes to an admin-defined
+// allowlist of path prefixes. The allowlist can be set per namespace or per
+// runtime class, with a cluster-wide default, which makes it a lighter-weight
+// alternative to webhook-based policy engines for this one check. Denials are
+// recorded as audit annotations.
+package hostpathallowlist
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/apiserver/pkg/admission"
+	api "k8s.io/kubernetes/pkg/apis/core"
+)
+
+// PluginName is a string with the name of the plugin
+const PluginName = "HostPathAllowlist"
+
+const (
+	// clusterDefaultKey is the config key holding the allowlist which applies
+	// to namespaces without their own entry.
+	clusterDefaultKey = "clusterDefaultAllowedPrefixes"
+
+	// runtimeClassKeyPrefix marks config keys which select pods by runtime
+	// class instead of namespace. A runtime class entry takes precedence over
+	// the namespace entry.
+	runtimeClassKeyPrefix = "runtimeClass:"
+
+	// deniedPathAnnotationKey is the audit annotation recorded when a
+	// hostPath volume is rejected.
+	deniedPathAnnotationKey = "hostpathallowlist.admission.k8s.io/denied-path"
+)
+
+// Register registers a plugin
+func Register(plugins *admission.Plugins) {
+	plugins.Register(PluginName, func(config io.Reader) (admission.Interface, error) {
+		// TODO move this to a versioned configuration file format.
+		pluginConfig := readConfig(config)
+		return NewHostPathAllowlist(pluginConfig.HostPathAllowlistPluginConfig)
+	})
+}
+
+// Plugin is an implementation of admission.Interface.
+type Plugin struct {
+	*admission.Handler
+	// allowedPrefixes maps a namespace, a runtime class entry or the cluster
+	// default key to the list of allowed hostPath prefixes.
+	allowedPrefixes map[string][]string
+}
+
+var _ admission.ValidationInterface = &Plugin{}
+
+type pluginConfig struct {
+	HostPathAllowlistPluginConfig map[string]string
+}
+
+// readConfig reads the allowlist from the file provided with
+// --admission-control-config-file. If the file is not supplied, every
+// hostPath is allowed.
+// The format in a file:
+// hostPathAllowlistPluginConfig:
+//  clusterDefaultAllowedPrefixes: /var/log,/var/lib/kubelet
+//  namespace1: /data
+//  runtimeClass:sandboxed: /var/run/sandbox
+func readConfig(config io.Reader) *pluginConfig {
+	defaultConfig := &pluginConfig{}
+	if config == nil || reflect.ValueOf(config).IsNil() {
+		return defaultConfig
+	}
+	d := yaml.NewYAMLOrJSONDecoder(config, 4096)
+	for {
+		if err := d.Decode(defaultConfig); err != nil {
+			if err != io.EOF {
+				continue
+			}
+		}
+		break
+	}
+	return defaultConfig
+}
+
+// NewHostPathAllowlist initializes the plugin from the given map of config
+// keys to comma-separated path prefixes.
+func NewHostPathAllowlist(config map[string]string) (*Plugin, error) {
+	allowedPrefixes := map[string][]string{}
+	for key, value := range config {
+		var prefixes []string
+		for _, prefix := range strings.Split(value, ",") {
+			prefix = strings.TrimSpace(prefix)
+			if prefix == "" {
+				continue
+			}
+			if !strings.HasPrefix(prefix, "/") {
+				return nil, fmt.Errorf("invalid hostPath prefix %q for %q: must be an absolute path", prefix, key)
+			}
+			prefixes = append(prefixes, strings.TrimSuffix(prefix, "/"))
+		}
+		allowedPrefixes[key] = prefixes
+	}
+	return &Plugin{
+		Handler:         admission.NewHandler(admission.Create, admission.Update),
+		allowedPrefixes: allowedPrefixes,
+	}, nil
+}
+
+// Validate rejects pods with hostPath volumes outside the allowlist which
+// applies to their namespace or runtime class.
+func (p *Plugin) Validate(ctx context.Context, a admission.Attributes, o admission.ObjectInterfaces) error {
+	if shouldIgnore(a) {
+		return nil
+	}
+	pod := a.GetObject().(*api.Pod)
+
+	prefixes, ok := p.prefixesForPod(a.GetNamespace(), pod)
+	if !ok {
+		// No allowlist configured for this pod, nothing to enforce.
+		return nil
+	}
+
+	for _, vol := range pod.Spec.Volumes {
+		if vol.HostPath == nil {
+			continue
+		}
+		if !pathAllowed(vol.HostPath.Path, prefixes) {
+			a.AddAnnotation(deniedPathAnnotationKey, vol.HostPath.Path)
+			return errors.NewForbidden(a.GetResource().GroupResource(), pod.Name, fmt.Errorf(
+				"hostPath volume %q uses path %q which is not in the allowed prefixes %v", vol.Name, vol.HostPath.Path, prefixes))
+		}
+	}
+	return nil
+}
+
+// prefixesForPod looks up the allowlist which applies to the pod. A runtime
+// class entry takes precedence over the namespace entry, which takes
+// precedence over the cluster default.
+func (p *Plugin) prefixesForPod(namespace string, pod *api.Pod) ([]string, bool) {
+	if pod.Spec.RuntimeClassName != nil {
+		if prefixes, ok := p.allowedPrefixes[runtimeClassKeyPrefix+*pod.Spec.RuntimeClassName]; ok {
+			return prefixes, true
+		}
+	}
+	if prefixes, ok := p.allowedPrefixes[namespace]; ok {
+		return prefixes, true
+	}
+	prefixes, ok := p.allowedPrefixes[clusterDefaultKey]
+	return prefixes, ok
+}
+
+// pathAllowed returns true if the path equals one of the prefixes or is
+// nested below one of them. Matching happens on path segment boundaries so
+// that /var/log does not allow /var/log2.
+func pathAllowed(path string, prefixes []string) bool {
+	path = strings.TrimSuffix(path, "/")
+	for _, prefix := range prefixes {
+		if path == prefix || strings.HasPrefix(path, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func shouldIgnore(a admission.Attributes) bool {
+	if a.GetResource().GroupResource() != api.Resource("pods") {
+		return true
+	}
+	if a.GetSubresource() != "" {
+		// only run the checks below on pods proper and not subresources
+		return true
+	}
+
+	_, ok := a.GetObject().(*api.Pod)
+	if !ok {
+		klog.Errorf("expected pod but got %s", a.GetKind().Kind)
+		return true
+	}
+
+	return false
+}
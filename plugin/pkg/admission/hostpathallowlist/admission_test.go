@@ -0,0 +1,160 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hostpathallowlist
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/admission"
+	api "k8s.io/kubernetes/pkg/apis/core"
+)
+
+// annotatingAttributes records audit annotations added by the plugin so the
+// tests can inspect them.
+type annotatingAttributes struct {
+	admission.Attributes
+	annotations map[string]string
+}
+
+func (a *annotatingAttributes) AddAnnotation(key, value string) error {
+	if a.annotations == nil {
+		a.annotations = map[string]string{}
+	}
+	a.annotations[key] = value
+	return nil
+}
+
+func hostPathPod(namespace string, runtimeClass *string, paths ...string) *api.Pod {
+	pod := &api.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pod", Namespace: namespace},
+		Spec: api.PodSpec{
+			RuntimeClassName: runtimeClass,
+		},
+	}
+	for i, path := range paths {
+		pod.Spec.Volumes = append(pod.Spec.Volumes, api.Volume{
+			Name: "vol" + string(rune('a'+i)),
+			VolumeSource: api.VolumeSource{
+				HostPath: &api.HostPathVolumeSource{Path: path},
+			},
+		})
+	}
+	return pod
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func TestNewHostPathAllowlist(t *testing.T) {
+	if _, err := NewHostPathAllowlist(map[string]string{"ns1": "/var/log, /data/"}); err != nil {
+		t.Errorf("unexpected error for valid config: %v", err)
+	}
+	if _, err := NewHostPathAllowlist(map[string]string{"ns1": "var/log"}); err == nil {
+		t.Error("expected an error for a relative path prefix")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	plugin, err := NewHostPathAllowlist(map[string]string{
+		clusterDefaultKey:                  "/var/log",
+		"team-a":                           "/data,/var/lib/kubelet",
+		runtimeClassKeyPrefix + "sandbox":  "/var/run/sandbox",
+		"unrestricted-with-empty-entry-ns": "",
+	})
+	if err != nil {
+		t.Fatalf("failed to create plugin: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		pod         *api.Pod
+		expectError string
+	}{
+		{
+			name: "no hostPath volumes",
+			pod:  hostPathPod("other-ns", nil),
+		},
+		{
+			name: "cluster default allows",
+			pod:  hostPathPod("other-ns", nil, "/var/log/audit"),
+		},
+		{
+			name:        "cluster default denies",
+			pod:         hostPathPod("other-ns", nil, "/etc/passwd"),
+			expectError: "not in the allowed prefixes",
+		},
+		{
+			name:        "no partial path segment match",
+			pod:         hostPathPod("other-ns", nil, "/var/log2"),
+			expectError: "not in the allowed prefixes",
+		},
+		{
+			name: "namespace entry allows",
+			pod:  hostPathPod("team-a", nil, "/data/db"),
+		},
+		{
+			name:        "namespace entry overrides cluster default",
+			pod:         hostPathPod("team-a", nil, "/var/log"),
+			expectError: "not in the allowed prefixes",
+		},
+		{
+			name: "runtime class entry overrides namespace",
+			pod:  hostPathPod("team-a", stringPtr("sandbox"), "/var/run/sandbox/sock"),
+		},
+		{
+			name:        "empty entry denies everything",
+			pod:         hostPathPod("unrestricted-with-empty-entry-ns", nil, "/var/log"),
+			expectError: "not in the allowed prefixes",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			attrs := &annotatingAttributes{
+				Attributes: admission.NewAttributesRecord(
+					tc.pod, nil,
+					api.Kind("Pod").WithVersion("version"),
+					tc.pod.Namespace, tc.pod.Name,
+					api.Resource("pods").WithVersion("version"),
+					"", admission.Create, &metav1.CreateOptions{}, false, nil),
+			}
+			err := plugin.Validate(context.TODO(), attrs, nil)
+			if tc.expectError == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				if len(attrs.annotations) > 0 {
+					t.Errorf("unexpected audit annotations: %v", attrs.annotations)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected error containing %q, got none", tc.expectError)
+			}
+			if !strings.Contains(err.Error(), tc.expectError) {
+				t.Errorf("expected error containing %q, got: %v", tc.expectError, err)
+			}
+			if _, ok := attrs.annotations[deniedPathAnnotationKey]; !ok {
+				t.Errorf("expected audit annotation %q, got: %v", deniedPathAnnotationKey, attrs.annotations)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package deprecation implements an admission plugin that enforces
+// StorageClass deprecation. Administrators mark a class as deprecated via
+// annotations; new claims using it either get a warning pointing at the
+// replacement class, or are rejected outright when the class blocks new
+// claims. This makes storage tier retirement manageable: existing claims
+// keep working while new usage is steered to the replacement.
+package deprecation
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apiserver/pkg/admission"
+	genericadmissioninitializer "k8s.io/apiserver/pkg/admission/initializer"
+	"k8s.io/apiserver/pkg/warning"
+	"k8s.io/client-go/informers"
+	storagev1listers "k8s.io/client-go/listers/storage/v1"
+	api "k8s.io/kubernetes/pkg/apis/core"
+	"k8s.io/kubernetes/pkg/apis/core/helper"
+	storageutil "k8s.io/kubernetes/pkg/apis/storage/util"
+)
+
+// PluginName is the name of this admission controller plugin.
+const PluginName = "StorageClassDeprecation"
+
+// Register registers a plugin
+func Register(plugins *admission.Plugins) {
+	plugins.Register(PluginName, func(config io.Reader) (admission.Interface, error) {
+		return newPlugin(), nil
+	})
+}
+
+// deprecationPlugin holds state for and implements the admission plugin.
+type deprecationPlugin struct {
+	*admission.Handler
+
+	lister storagev1listers.StorageClassLister
+}
+
+var _ admission.Interface = &deprecationPlugin{}
+var _ admission.ValidationInterface = &deprecationPlugin{}
+var _ = genericadmissioninitializer.WantsExternalKubeInformerFactory(&deprecationPlugin{})
+
+// newPlugin creates a new admission plugin.
+func newPlugin() *deprecationPlugin {
+	return &deprecationPlugin{
+		Handler: admission.NewHandler(admission.Create),
+	}
+}
+
+func (a *deprecationPlugin) SetExternalKubeInformerFactory(f informers.SharedInformerFactory) {
+	informer := f.Storage().V1().StorageClasses()
+	a.lister = informer.Lister()
+	a.SetReadyFunc(informer.Informer().HasSynced)
+}
+
+// ValidateInitialization ensures lister is set.
+func (a *deprecationPlugin) ValidateInitialization() error {
+	if a.lister == nil {
+		return fmt.Errorf("missing lister")
+	}
+	return nil
+}
+
+// Validate rejects or warns about new claims that use a deprecated
+// StorageClass.
+func (a *deprecationPlugin) Validate(ctx context.Context, attr admission.Attributes, o admission.ObjectInterfaces) error {
+	if attr.GetResource().GroupResource() != api.Resource("persistentvolumeclaims") {
+		return nil
+	}
+	if len(attr.GetSubresource()) != 0 {
+		return nil
+	}
+	pvc, ok := attr.GetObject().(*api.PersistentVolumeClaim)
+	// if we can't convert then we don't handle this object so just return
+	if !ok {
+		return nil
+	}
+	className := helper.GetPersistentVolumeClaimClass(pvc)
+	if len(className) == 0 {
+		return nil
+	}
+
+	class, err := a.lister.Get(className)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// the claim references a class that does not exist (yet); that
+			// is not this plugin's concern
+			return nil
+		}
+		return admission.NewForbidden(attr, err)
+	}
+
+	if !storageutil.IsDeprecatedAnnotation(class.ObjectMeta) {
+		return nil
+	}
+
+	replacement := class.Annotations[storageutil.ReplacementClassAnnotation]
+	hint := ""
+	if len(replacement) > 0 {
+		hint = fmt.Sprintf("; use StorageClass %q instead", replacement)
+	}
+
+	if class.Annotations[storageutil.BlockNewClaimsAnnotation] == "true" {
+		return admission.NewForbidden(attr, fmt.Errorf("StorageClass %q is deprecated and does not accept new claims%s", className, hint))
+	}
+	warning.AddWarning(ctx, "", fmt.Sprintf("StorageClass %q is deprecated%s", className, hint))
+	return nil
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deprecation
+
+import (
+	"context"
+	"testing"
+
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/admission"
+	storagev1listers "k8s.io/client-go/listers/storage/v1"
+	"k8s.io/client-go/tools/cache"
+	api "k8s.io/kubernetes/pkg/apis/core"
+	storageutil "k8s.io/kubernetes/pkg/apis/storage/util"
+)
+
+func newClaimWithClass(className string) *api.PersistentVolumeClaim {
+	return &api.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "claim1", Namespace: "ns1"},
+		Spec: api.PersistentVolumeClaimSpec{
+			StorageClassName: &className,
+		},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		class       *storagev1.StorageClass
+		claimClass  string
+		expectError bool
+	}{
+		{
+			name: "class not deprecated",
+			class: &storagev1.StorageClass{
+				ObjectMeta: metav1.ObjectMeta{Name: "gold"},
+			},
+			claimClass: "gold",
+		},
+		{
+			name: "deprecated class warns but admits",
+			class: &storagev1.StorageClass{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "gold",
+					Annotations: map[string]string{
+						storageutil.DeprecatedStorageClassAnnotation: "true",
+						storageutil.ReplacementClassAnnotation:       "platinum",
+					},
+				},
+			},
+			claimClass: "gold",
+		},
+		{
+			name: "deprecated class blocking new claims rejects",
+			class: &storagev1.StorageClass{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "gold",
+					Annotations: map[string]string{
+						storageutil.DeprecatedStorageClassAnnotation: "true",
+						storageutil.BlockNewClaimsAnnotation:         "true",
+						storageutil.ReplacementClassAnnotation:       "platinum",
+					},
+				},
+			},
+			claimClass:  "gold",
+			expectError: true,
+		},
+		{
+			name: "blocking without deprecation is ignored",
+			class: &storagev1.StorageClass{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "gold",
+					Annotations: map[string]string{
+						storageutil.BlockNewClaimsAnnotation: "true",
+					},
+				},
+			},
+			claimClass: "gold",
+		},
+		{
+			name: "claim referencing a missing class is admitted",
+			class: &storagev1.StorageClass{
+				ObjectMeta: metav1.ObjectMeta{Name: "gold"},
+			},
+			claimClass: "does-not-exist",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			plugin := newPlugin()
+			informer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			if err := informer.Add(tc.class); err != nil {
+				t.Fatal(err)
+			}
+			plugin.lister = storagev1listers.NewStorageClassLister(informer)
+
+			claim := newClaimWithClass(tc.claimClass)
+			attrs := admission.NewAttributesRecord(
+				claim, nil,
+				api.Kind("PersistentVolumeClaim").WithVersion("version"),
+				claim.Namespace, claim.Name,
+				api.Resource("persistentvolumeclaims").WithVersion("version"),
+				"", admission.Create, &metav1.CreateOptions{}, false, nil)
+
+			err := plugin.Validate(context.TODO(), attrs, nil)
+			if tc.expectError && err == nil {
+				t.Errorf("expected an error")
+			}
+			if !tc.expectError && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -87,6 +87,24 @@ const (
 	IndexedCompletion CompletionMode = "Indexed"
 )
 
+// PodReplacementPolicy specifies when the Job controller creates replacement
+// Pods for failed Pods.
+// +enum
+type PodReplacementPolicy string
+
+const (
+	// ReplaceAlways means that every failed Pod is replaced until the Job
+	// reaches its backoff limit, regardless of why the Pod failed.
+	ReplaceAlways PodReplacementPolicy = "Always"
+
+	// ReplaceOnNodeFailure means that only Pods whose failure was caused by
+	// the node infrastructure are replaced. Pods that are deleted while the
+	// Job still wants them running, for example because the node was removed
+	// or the Pod was evicted by a NoExecute taint, are recreated; Pods that
+	// failed because a container exited with a non-zero code are not.
+	ReplaceOnNodeFailure PodReplacementPolicy = "OnNodeFailure"
+)
+
 // JobSpec describes how the job execution will look like.
 type JobSpec struct {
 
@@ -193,6 +211,23 @@ type JobSpec struct {
 	//
 	// +optional
 	Suspend *bool `json:"suspend,omitempty" protobuf:"varint,10,opt,name=suspend"`
+
+	// podReplacementPolicy specifies when the Job controller creates
+	// replacement Pods for failed Pods. It can be `Always` (default) or
+	// `OnNodeFailure`.
+	//
+	// `Always` means that every failed Pod is replaced until the Job reaches
+	// its backoff limit, regardless of why the Pod failed.
+	//
+	// `OnNodeFailure` means that only Pods whose failure was caused by the
+	// node infrastructure are replaced: Pods that are deleted while the Job
+	// still wants them running, for example because the node was removed or
+	// the Pod was evicted by a NoExecute taint. Pods that failed because a
+	// container exited with a non-zero code are not recreated, although they
+	// still count against the backoff limit. This is intended for workloads
+	// that are expensive to restart.
+	// +optional
+	PodReplacementPolicy *PodReplacementPolicy `json:"podReplacementPolicy,omitempty" protobuf:"bytes,11,opt,name=podReplacementPolicy,casttype=PodReplacementPolicy"`
 }
 
 // JobStatus represents the current state of a Job.
@@ -529,6 +529,10 @@ const (
 	PersistentVolumeClaimResizing PersistentVolumeClaimConditionType = "Resizing"
 	// PersistentVolumeClaimFileSystemResizePending - controller resize is finished and a file system resize is pending on node
 	PersistentVolumeClaimFileSystemResizePending PersistentVolumeClaimConditionType = "FileSystemResizePending"
+	// PersistentVolumeClaimVolumeHealthy - status of the volume health as reported
+	// by the CSI driver of the bound volume. It is False while the driver reports
+	// an abnormal volume condition.
+	PersistentVolumeClaimVolumeHealthy PersistentVolumeClaimConditionType = "VolumeHealthy"
 )
 
 // +enum
@@ -1677,6 +1681,18 @@ type ServiceAccountTokenProjection struct {
 	Path string `json:"path" protobuf:"bytes,3,opt,name=path"`
 }
 
+// ServiceAccountIssuerProjection projects the OIDC discovery document and
+// JSON Web Key Set of the cluster's service account token issuer into the
+// volume, so that workloads can validate service account tokens without
+// calling the kube-apiserver. The kubelet keeps the projected documents
+// fresh the same way it rotates projected tokens.
+type ServiceAccountIssuerProjection struct {
+	// path is the path relative to the mount point of the directory to
+	// project the documents into. The discovery document is written as
+	// "openid-configuration" and the key set as "jwks.json" below it.
+	Path string `json:"path" protobuf:"bytes,1,opt,name=path"`
+}
+
 // Represents a projected volume source
 type ProjectedVolumeSource struct {
 	// sources is the list of volume projections
@@ -1708,6 +1724,10 @@ type VolumeProjection struct {
 	// serviceAccountToken is information about the serviceAccountToken data to project
 	// +optional
 	ServiceAccountToken *ServiceAccountTokenProjection `json:"serviceAccountToken,omitempty" protobuf:"bytes,4,opt,name=serviceAccountToken"`
+	// serviceAccountIssuer projects the OIDC discovery document and key set of
+	// the cluster's service account token issuer
+	// +optional
+	ServiceAccountIssuer *ServiceAccountIssuerProjection `json:"serviceAccountIssuer,omitempty" protobuf:"bytes,5,opt,name=serviceAccountIssuer"`
 }
 
 const (
@@ -2653,6 +2673,11 @@ const (
 	PodReady PodConditionType = "Ready"
 	// PodScheduled represents status of the scheduling process for this pod.
 	PodScheduled PodConditionType = "PodScheduled"
+	// PodStorageReady indicates that all of the pod's volumes have finished their
+	// initial preparation, such as fsGroup ownership changes and datasource
+	// population. It is maintained by the kubelet only for pods that list it in
+	// their readiness gates.
+	PodStorageReady PodConditionType = "StorageReady"
 )
 
 // These are reasons for a pod's transition to a condition.
@@ -3823,6 +3848,26 @@ type EphemeralContainer struct {
 	TargetContainerName string `json:"targetContainerName,omitempty" protobuf:"bytes,2,opt,name=targetContainerName"`
 }
 
+// PodVolumeStatus describes the observed state of a volume used by a pod.
+type PodVolumeStatus struct {
+	// Name of the volume, matching an entry in pod.spec.volumes.
+	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
+	// Mounted indicates that the volume has been set up on the node and is
+	// ready to be mounted into the pod's containers.
+	// +optional
+	Mounted bool `json:"mounted,omitempty" protobuf:"varint,2,opt,name=mounted"`
+	// ResolvedSubPaths lists the host paths that the subPath values of the
+	// pod's volume mounts for this volume resolve to. Only populated once
+	// the volume is mounted; subPathExpr mounts are resolved when their
+	// container starts and are not listed.
+	// +optional
+	ResolvedSubPaths []string `json:"resolvedSubPaths,omitempty" protobuf:"bytes,3,rep,name=resolvedSubPaths"`
+	// Message describes the last error observed while setting up the volume
+	// or resolving a subPath, if any.
+	// +optional
+	Message string `json:"message,omitempty" protobuf:"bytes,4,opt,name=message"`
+}
+
 // PodStatus represents information about the status of a pod. Status may trail the actual
 // state of a system, especially if the node that hosts the pod cannot contact the control
 // plane.
@@ -3910,6 +3955,11 @@ type PodStatus struct {
 	// This field is beta-level and available on clusters that haven't disabled the EphemeralContainers feature gate.
 	// +optional
 	EphemeralContainerStatuses []ContainerStatus `json:"ephemeralContainerStatuses,omitempty" protobuf:"bytes,13,rep,name=ephemeralContainerStatuses"`
+	// Status of volumes used by containers in this pod, populated by the kubelet.
+	// +optional
+	// +patchMergeKey=name
+	// +patchStrategy=merge
+	VolumeStatuses []PodVolumeStatus `json:"volumeStatuses,omitempty" patchStrategy:"merge" patchMergeKey:"name" protobuf:"bytes,14,rep,name=volumeStatuses"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
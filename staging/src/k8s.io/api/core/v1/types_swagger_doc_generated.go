@@ -1692,6 +1692,7 @@ var map_PodStatus = map[string]string{
 	"containerStatuses":          "The list has one entry per container in the manifest. More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#pod-and-container-status",
 	"qosClass":                   "The Quality of Service (QOS) classification assigned to the pod based on resource requirements See PodQOSClass type for available QOS classes More info: https://git.k8s.io/community/contributors/design-proposals/node/resource-qos.md",
 	"ephemeralContainerStatuses": "Status for any ephemeral containers that have run in this pod. This field is beta-level and available on clusters that haven't disabled the EphemeralContainers feature gate.",
+	"volumeStatuses":             "Status of volumes used by containers in this pod, populated by the kubelet.",
 }
 
 func (PodStatus) SwaggerDoc() map[string]string {
@@ -1738,6 +1739,18 @@ func (PodTemplateSpec) SwaggerDoc() map[string]string {
 	return map_PodTemplateSpec
 }
 
+var map_PodVolumeStatus = map[string]string{
+	"":                 "PodVolumeStatus describes the observed state of a volume used by a pod.",
+	"name":             "Name of the volume, matching an entry in pod.spec.volumes.",
+	"mounted":          "Mounted indicates that the volume has been set up on the node and is ready to be mounted into the pod's containers.",
+	"resolvedSubPaths": "ResolvedSubPaths lists the host paths that the subPath values of the pod's volume mounts for this volume resolve to. Only populated once the volume is mounted; subPathExpr mounts are resolved when their container starts and are not listed.",
+	"message":          "Message describes the last error observed while setting up the volume or resolving a subPath, if any.",
+}
+
+func (PodVolumeStatus) SwaggerDoc() map[string]string {
+	return map_PodVolumeStatus
+}
+
 var map_PortStatus = map[string]string{
 	"port":     "Port is the port number of the service port of which status is recorded here",
 	"protocol": "Protocol is the protocol of the service port of which status is recorded here The supported values are: \"TCP\", \"UDP\", \"SCTP\"",
@@ -2209,6 +2222,15 @@ func (ServiceAccount) SwaggerDoc() map[string]string {
 	return map_ServiceAccount
 }
 
+var map_ServiceAccountIssuerProjection = map[string]string{
+	"":     "ServiceAccountIssuerProjection projects the OIDC discovery document and JSON Web Key Set of the cluster's service account token issuer into the volume, so that workloads can validate service account tokens without calling the kube-apiserver. The kubelet keeps the projected documents fresh the same way it rotates projected tokens.",
+	"path": "path is the path relative to the mount point of the directory to project the documents into. The discovery document is written as \"openid-configuration\" and the key set as \"jwks.json\" below it.",
+}
+
+func (ServiceAccountIssuerProjection) SwaggerDoc() map[string]string {
+	return map_ServiceAccountIssuerProjection
+}
+
 var map_ServiceAccountList = map[string]string{
 	"":         "ServiceAccountList is a list of ServiceAccount objects",
 	"metadata": "Standard list metadata. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
@@ -2468,11 +2490,12 @@ func (VolumeNodeAffinity) SwaggerDoc() map[string]string {
 }
 
 var map_VolumeProjection = map[string]string{
-	"":                    "Projection that may be projected along with other supported volume types",
-	"secret":              "secret information about the secret data to project",
-	"downwardAPI":         "downwardAPI information about the downwardAPI data to project",
-	"configMap":           "configMap information about the configMap data to project",
-	"serviceAccountToken": "serviceAccountToken is information about the serviceAccountToken data to project",
+	"":                     "Projection that may be projected along with other supported volume types",
+	"secret":               "secret information about the secret data to project",
+	"downwardAPI":          "downwardAPI information about the downwardAPI data to project",
+	"configMap":            "configMap information about the configMap data to project",
+	"serviceAccountToken":  "serviceAccountToken is information about the serviceAccountToken data to project",
+	"serviceAccountIssuer": "serviceAccountIssuer projects the OIDC discovery document and key set of the cluster's service account token issuer",
 }
 
 func (VolumeProjection) SwaggerDoc() map[string]string {
@@ -0,0 +1,119 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deprecation
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// maxUsageEntries bounds the tracker so a flood of distinct clients cannot
+// grow apiserver memory without limit. Once full, new distinct clients are
+// dropped; existing entries keep updating.
+const maxUsageEntries = 4096
+
+// UsageKey identifies one client hitting one deprecated endpoint.
+type UsageKey struct {
+	Group       string `json:"group"`
+	Version     string `json:"version"`
+	Resource    string `json:"resource"`
+	Subresource string `json:"subresource,omitempty"`
+	// Username is the authenticated user, e.g. a service account like
+	// system:serviceaccount:ns:name.
+	Username  string `json:"username"`
+	UserAgent string `json:"userAgent"`
+}
+
+// UsageEntry reports aggregate usage for a UsageKey.
+type UsageEntry struct {
+	UsageKey       `json:",inline"`
+	Count          int64     `json:"count"`
+	LastOccurrence time.Time `json:"lastOccurrence"`
+	RemovedRelease string    `json:"removedRelease,omitempty"`
+}
+
+// UsageTracker aggregates deprecated API usage per client so that upgrades
+// can be gated on an empty report. It complements the
+// apiserver_requested_deprecated_apis metric, which has no client dimension.
+type UsageTracker struct {
+	mutex   sync.Mutex
+	entries map[UsageKey]*UsageEntry
+}
+
+// DefaultUsageTracker is the tracker fed by the request monitoring path.
+var DefaultUsageTracker = NewUsageTracker()
+
+// NewUsageTracker returns an empty tracker.
+func NewUsageTracker() *UsageTracker {
+	return &UsageTracker{entries: map[UsageKey]*UsageEntry{}}
+}
+
+// Record notes one deprecated request at time now.
+func (t *UsageTracker) Record(key UsageKey, removedRelease string, now time.Time) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	entry, ok := t.entries[key]
+	if !ok {
+		if len(t.entries) >= maxUsageEntries {
+			return
+		}
+		entry = &UsageEntry{UsageKey: key, RemovedRelease: removedRelease}
+		t.entries[key] = entry
+	}
+	entry.Count++
+	entry.LastOccurrence = now
+}
+
+// Report returns a snapshot of all entries, most recent first.
+func (t *UsageTracker) Report() []UsageEntry {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	report := make([]UsageEntry, 0, len(t.entries))
+	for _, entry := range t.entries {
+		report = append(report, *entry)
+	}
+	sort.Slice(report, func(i, j int) bool {
+		if !report[i].LastOccurrence.Equal(report[j].LastOccurrence) {
+			return report[i].LastOccurrence.After(report[j].LastOccurrence)
+		}
+		return report[i].UsageKey.Username < report[j].UsageKey.Username
+	})
+	return report
+}
+
+// Reset clears the tracker, e.g. after the report has been acted upon.
+func (t *UsageTracker) Reset() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.entries = map[UsageKey]*UsageEntry{}
+}
+
+// ServeHTTP serves the report as JSON, making the tracker mountable on the
+// debug mux (e.g. under /debug/api_deprecation/report).
+func (t *UsageTracker) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	data, err := json.MarshalIndent(t.Report(), "", "  ")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Write(data)
+}
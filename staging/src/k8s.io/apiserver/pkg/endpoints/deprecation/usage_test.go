@@ -0,0 +1,95 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deprecation
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestUsageTrackerRecord(t *testing.T) {
+	tracker := NewUsageTracker()
+	key := UsageKey{
+		Group:     "policy",
+		Version:   "v1beta1",
+		Resource:  "podsecuritypolicies",
+		Username:  "system:serviceaccount:kube-system:legacy-operator",
+		UserAgent: "legacy-operator/v0.1",
+	}
+	t1 := time.Date(2022, time.June, 1, 0, 0, 0, 0, time.UTC)
+	t2 := t1.Add(time.Hour)
+
+	tracker.Record(key, "1.25", t1)
+	tracker.Record(key, "1.25", t2)
+	otherKey := key
+	otherKey.UserAgent = "kubectl/v1.24.0"
+	tracker.Record(otherKey, "1.25", t1)
+
+	report := tracker.Report()
+	if len(report) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(report))
+	}
+	// most recent first
+	if report[0].UserAgent != key.UserAgent {
+		t.Errorf("expected most recent entry first, got %q", report[0].UserAgent)
+	}
+	if report[0].Count != 2 {
+		t.Errorf("expected count 2, got %d", report[0].Count)
+	}
+	if !report[0].LastOccurrence.Equal(t2) {
+		t.Errorf("expected last occurrence %v, got %v", t2, report[0].LastOccurrence)
+	}
+	if report[0].RemovedRelease != "1.25" {
+		t.Errorf("expected removed release 1.25, got %q", report[0].RemovedRelease)
+	}
+
+	tracker.Reset()
+	if len(tracker.Report()) != 0 {
+		t.Errorf("expected empty report after reset")
+	}
+}
+
+func TestUsageTrackerBounded(t *testing.T) {
+	tracker := NewUsageTracker()
+	now := time.Now()
+	for i := 0; i < maxUsageEntries+10; i++ {
+		tracker.Record(UsageKey{Username: "user", UserAgent: string(rune(i))}, "", now)
+	}
+	if got := len(tracker.Report()); got != maxUsageEntries {
+		t.Errorf("expected tracker to be capped at %d entries, got %d", maxUsageEntries, got)
+	}
+}
+
+func TestUsageTrackerServeHTTP(t *testing.T) {
+	tracker := NewUsageTracker()
+	tracker.Record(UsageKey{Group: "batch", Version: "v1beta1", Resource: "cronjobs", Username: "admin", UserAgent: "kubectl"}, "1.25", time.Now())
+
+	w := httptest.NewRecorder()
+	tracker.ServeHTTP(w, httptest.NewRequest("GET", "/debug/api_deprecation/report", nil))
+	if w.Code != 200 {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	entries := []UsageEntry{}
+	if err := json.Unmarshal(w.Body.Bytes(), &entries); err != nil {
+		t.Fatalf("failed to decode report: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Resource != "cronjobs" {
+		t.Errorf("unexpected report: %+v", entries)
+	}
+}
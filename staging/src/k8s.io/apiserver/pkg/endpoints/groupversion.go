@@ -30,6 +30,7 @@ import (
 	"k8s.io/apiserver/pkg/admission"
 	"k8s.io/apiserver/pkg/authorization/authorizer"
 	"k8s.io/apiserver/pkg/endpoints/discovery"
+	"k8s.io/apiserver/pkg/endpoints/handlers"
 	"k8s.io/apiserver/pkg/endpoints/handlers/fieldmanager"
 	"k8s.io/apiserver/pkg/registry/rest"
 	"k8s.io/apiserver/pkg/storageversion"
@@ -100,6 +101,16 @@ type APIGroupVersion struct {
 	// The limit on the request body size that would be accepted and decoded in a write request.
 	// 0 means no limit.
 	MaxRequestBodyBytes int64
+
+	// StreamListThreshold is the minimum number of items before a list
+	// response requested with "stream=list" is encoded incrementally.
+	// 0 means use the built-in default.
+	StreamListThreshold int
+
+	// ResourceBodyLimits holds optional per-resource overrides of the request
+	// body size limit and limits on decode-time complexity, keyed by resource
+	// name. Resources without an entry use the server-wide defaults.
+	ResourceBodyLimits map[string]handlers.ResourceBodyLimits
 }
 
 // InstallREST registers the REST handlers (storage, watch, proxy and redirect) into a restful Container.
@@ -0,0 +1,114 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handlers
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestMaxRequestBodyBytesPerResource(t *testing.T) {
+	scope := &RequestScope{
+		Resource:            schema.GroupVersionResource{Version: "v1", Resource: "configmaps"},
+		MaxRequestBodyBytes: 1000,
+	}
+	if got := scope.maxRequestBodyBytes(); got != 1000 {
+		t.Errorf("expected server-wide default 1000, got %d", got)
+	}
+
+	scope.BodyLimits = ResourceBodyLimits{MaxRequestBodyBytes: 100}
+	if got := scope.maxRequestBodyBytes(); got != 100 {
+		t.Errorf("expected per-resource override 100, got %d", got)
+	}
+
+	// limits without a body override keep the server-wide default
+	scope.BodyLimits = ResourceBodyLimits{MaxManagedFieldsEntries: 10}
+	if got := scope.maxRequestBodyBytes(); got != 1000 {
+		t.Errorf("expected server-wide default 1000, got %d", got)
+	}
+}
+
+func TestCheckDecodedBodyLimits(t *testing.T) {
+	manyManagedFields := make([]metav1.ManagedFieldsEntry, 5)
+	for i := range manyManagedFields {
+		manyManagedFields[i] = metav1.ManagedFieldsEntry{Manager: "test", Operation: metav1.ManagedFieldsOperationUpdate}
+	}
+
+	tests := []struct {
+		name        string
+		limits      ResourceBodyLimits
+		obj         *metav1.PartialObjectMetadata
+		wantInvalid bool
+	}{
+		{
+			name: "no limits configured",
+			obj: &metav1.PartialObjectMetadata{
+				ObjectMeta: metav1.ObjectMeta{Name: "cm", Annotations: map[string]string{"k": strings.Repeat("v", 1000)}},
+			},
+		},
+		{
+			name:   "annotations within limit",
+			limits: ResourceBodyLimits{MaxAnnotationBytes: 100},
+			obj: &metav1.PartialObjectMetadata{
+				ObjectMeta: metav1.ObjectMeta{Name: "cm", Annotations: map[string]string{"k": "v"}},
+			},
+		},
+		{
+			name:   "annotations over limit",
+			limits: ResourceBodyLimits{MaxAnnotationBytes: 100},
+			obj: &metav1.PartialObjectMetadata{
+				ObjectMeta: metav1.ObjectMeta{Name: "cm", Annotations: map[string]string{"k": strings.Repeat("v", 1000)}},
+			},
+			wantInvalid: true,
+		},
+		{
+			name:   "managed fields over limit",
+			limits: ResourceBodyLimits{MaxManagedFieldsEntries: 3},
+			obj: &metav1.PartialObjectMetadata{
+				ObjectMeta: metav1.ObjectMeta{Name: "cm", ManagedFields: manyManagedFields},
+			},
+			wantInvalid: true,
+		},
+		{
+			name:   "unlimited dimensions are not checked",
+			limits: ResourceBodyLimits{MaxAnnotationBytes: 100},
+			obj: &metav1.PartialObjectMetadata{
+				ObjectMeta: metav1.ObjectMeta{Name: "cm", ManagedFields: manyManagedFields},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			scope := &RequestScope{
+				Resource:   schema.GroupVersionResource{Version: "v1", Resource: "configmaps"},
+				Kind:       schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"},
+				BodyLimits: tc.limits,
+			}
+			err := checkDecodedBodyLimits(tc.obj, scope)
+			if tc.wantInvalid && !errors.IsInvalid(err) {
+				t.Errorf("expected an invalid error, got %v", err)
+			}
+			if !tc.wantInvalid && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
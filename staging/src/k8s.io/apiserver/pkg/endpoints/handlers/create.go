@@ -93,7 +93,7 @@ func createHandler(r rest.NamedCreater, scope *RequestScope, admit admission.Int
 			return
 		}
 
-		body, err := limitedReadBody(req, scope.MaxRequestBodyBytes)
+		body, err := limitedReadBody(req, scope.maxRequestBodyBytes())
 		trace.Step("limitedReadBody done", utiltrace.Field{"len", len(body)}, utiltrace.Field{"err", err})
 		if err != nil {
 			scope.err(err, w, req)
@@ -147,6 +147,10 @@ func createHandler(r rest.NamedCreater, scope *RequestScope, admit admission.Int
 			scope.err(err, w, req)
 			return
 		}
+		if err := checkDecodedBodyLimits(obj, scope); err != nil {
+			scope.err(err, w, req)
+			return
+		}
 		trace.Step("Conversion done")
 
 		// On create, get name from new object if unset
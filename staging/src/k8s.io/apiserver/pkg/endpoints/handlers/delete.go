@@ -77,7 +77,7 @@ func DeleteResource(r rest.GracefulDeleter, allowsOptions bool, scope *RequestSc
 
 		options := &metav1.DeleteOptions{}
 		if allowsOptions {
-			body, err := limitedReadBody(req, scope.MaxRequestBodyBytes)
+			body, err := limitedReadBody(req, scope.maxRequestBodyBytes())
 			if err != nil {
 				scope.err(err, w, req)
 				return
@@ -225,7 +225,7 @@ func DeleteCollection(r rest.CollectionDeleter, checkBody bool, scope *RequestSc
 
 		options := &metav1.DeleteOptions{}
 		if checkBody {
-			body, err := limitedReadBody(req, scope.MaxRequestBodyBytes)
+			body, err := limitedReadBody(req, scope.maxRequestBodyBytes())
 			if err != nil {
 				scope.err(err, w, req)
 				return
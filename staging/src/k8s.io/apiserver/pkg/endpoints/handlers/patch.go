@@ -105,7 +105,7 @@ func PatchResource(r rest.Patcher, scope *RequestScope, admit admission.Interfac
 			return
 		}
 
-		patchBytes, err := limitedReadBody(req, scope.MaxRequestBodyBytes)
+		patchBytes, err := limitedReadBody(req, scope.maxRequestBodyBytes())
 		trace.Step("limitedReadBody done", utiltrace.Field{"len", len(patchBytes)}, utiltrace.Field{"err", err})
 		if err != nil {
 			scope.err(err, w, req)
@@ -207,6 +207,7 @@ func PatchResource(r rest.Patcher, scope *RequestScope, admit admission.Interfac
 			subresource:         scope.Subresource,
 			dryRun:              dryrun.IsDryRun(options.DryRun),
 			validationDirective: validationDirective,
+			bodyLimits:          scope.BodyLimits,
 
 			objectInterfaces: scope,
 
@@ -266,6 +267,7 @@ type patcher struct {
 	subresource         string
 	dryRun              bool
 	validationDirective string
+	bodyLimits          ResourceBodyLimits
 
 	objectInterfaces admission.ObjectInterfaces
 
@@ -564,6 +566,13 @@ func (p *patcher) applyPatch(ctx context.Context, _, currentObject runtime.Objec
 		return nil, patchErr
 	}
 
+	// The raw patch body is typically small; the decoded result of applying
+	// it is what can exceed the per-resource limits, so check it here before
+	// admission and storage, mirroring create and update.
+	if err := checkDecodedBodyLimitsAgainst(objToUpdate, p.bodyLimits, p.kind); err != nil {
+		return nil, err
+	}
+
 	objToUpdateHasUID, err := hasUID(objToUpdate)
 	if err != nil {
 		return nil, err
@@ -127,6 +127,12 @@ func targetEncodingForTransform(scope *RequestScope, mediaType negotiation.Media
 	return scope.Kind, scope.Serializer, false
 }
 
+// defaultStreamListThreshold is the minimum number of list items before the
+// response is written incrementally instead of being serialized in a single
+// buffer, used when the server config does not set a threshold. Small lists
+// gain nothing from streaming and stay on the cheaper buffered path.
+const defaultStreamListThreshold = 1000
+
 // transformResponseObject takes an object loaded from storage and performs any necessary transformations.
 // Will write the complete response object.
 func transformResponseObject(ctx context.Context, scope *RequestScope, trace *utiltrace.Trace, req *http.Request, w http.ResponseWriter, statusCode int, mediaType negotiation.MediaTypeOptions, result runtime.Object) {
@@ -147,6 +153,25 @@ func transformResponseObject(ctx context.Context, scope *RequestScope, trace *ut
 		return
 	}
 	kind, serializer, _ := targetEncodingForTransform(scope, mediaType, req)
+	streamListThreshold := scope.StreamListThreshold
+	if streamListThreshold == 0 {
+		streamListThreshold = defaultStreamListThreshold
+	}
+	// Streaming changes the items' wire format (each item carries its own
+	// apiVersion/kind, like objects in watch events), so it is used only for
+	// clients that asked for it with "stream=list" in the Accept header. The
+	// negotiation layer only accepts that parameter when the
+	// StreamingListEncoding feature gate is enabled.
+	if mediaType.Stream == "list" && mediaType.Convert == nil && meta.IsListType(obj) && meta.LenList(obj) >= streamListThreshold {
+		if obj.GetObjectKind().GroupVersionKind().Empty() {
+			// Stamp the envelope kind the way the versioning codec would;
+			// lists are never cached, so mutating in place is safe.
+			obj.GetObjectKind().SetGroupVersionKind(kind.GroupVersion().WithKind(kind.Kind + "List"))
+		}
+		if responsewriters.StreamListNegotiated(serializer, scope, kind.GroupVersion(), w, req, statusCode, obj) {
+			return
+		}
+	}
 	responsewriters.WriteObjectNegotiated(serializer, scope, kind.GroupVersion(), w, req, statusCode, obj)
 }
 
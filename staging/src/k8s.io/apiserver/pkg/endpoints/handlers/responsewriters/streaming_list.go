@@ -0,0 +1,132 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package responsewriters
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apiserver/pkg/audit"
+	"k8s.io/apiserver/pkg/endpoints/handlers/negotiation"
+)
+
+// listStreamFlushInterval is the number of items encoded between flushes when
+// streaming a list to an http.Flusher. Flushing after every item would defeat
+// connection-level buffering for small objects.
+const listStreamFlushInterval = 100
+
+// StreamList encodes a list object to w incrementally: the list envelope is
+// written first, then each item is encoded and written in turn. This lets
+// clients begin decoding items before the full response has been marshaled
+// and avoids buffering the entire serialized list in apiserver memory, which
+// matters during full relists of large collections (e.g. pods or
+// persistentvolumes by informers).
+//
+// The output is a regular JSON list document, so only JSON encoders may be
+// passed; callers negotiating other media types must fall back to
+// SerializeObject.
+func StreamList(list runtime.Object, itemEncoder runtime.Encoder, w io.Writer) error {
+	listMeta, err := meta.ListAccessor(list)
+	if err != nil {
+		return fmt.Errorf("the object is not a list: %v", err)
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return err
+	}
+	typeAccessor, err := meta.TypeAccessor(list)
+	if err != nil {
+		return err
+	}
+
+	envelope := map[string]interface{}{
+		"resourceVersion": listMeta.GetResourceVersion(),
+	}
+	if c := listMeta.GetContinue(); len(c) > 0 {
+		envelope["continue"] = c
+	}
+	if r := listMeta.GetRemainingItemCount(); r != nil {
+		envelope["remainingItemCount"] = *r
+	}
+	metaJSON, err := json.Marshal(envelope)
+	if err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintf(w, `{"kind":%q,"apiVersion":%q,"metadata":%s,"items":[`,
+		typeAccessor.GetKind(), typeAccessor.GetAPIVersion(), metaJSON); err != nil {
+		return err
+	}
+
+	flusher, _ := w.(http.Flusher)
+	for i, item := range items {
+		if i > 0 {
+			if _, err := io.WriteString(w, ","); err != nil {
+				return err
+			}
+		}
+		if err := itemEncoder.Encode(item, w); err != nil {
+			return err
+		}
+		if flusher != nil && (i+1)%listStreamFlushInterval == 0 {
+			flusher.Flush()
+		}
+	}
+
+	_, err = io.WriteString(w, "]}")
+	return err
+}
+
+// StreamListNegotiated writes a list response incrementally via StreamList
+// when the client negotiated plain, uncompressed JSON. It returns false
+// without writing anything when the negotiated encoding cannot be streamed,
+// in which case the caller should fall back to WriteObjectNegotiated.
+//
+// Items are encoded with the negotiated codec and therefore carry their own
+// apiVersion/kind, the same way objects in watch events do.
+func StreamListNegotiated(s runtime.NegotiatedSerializer, restrictions negotiation.EndpointRestrictions, gv schema.GroupVersion, w http.ResponseWriter, req *http.Request, statusCode int, list runtime.Object) bool {
+	_, serializer, err := negotiation.NegotiateOutputMediaType(req, s, restrictions)
+	if err != nil || serializer.MediaType != runtime.ContentTypeJSON {
+		return false
+	}
+	if negotiateContentEncoding(req) != "" {
+		// Compressed responses go through the buffered path so the whole
+		// payload is available to the gzip writer.
+		return false
+	}
+
+	audit.LogResponseObject(req.Context(), list, gv, s)
+
+	encoder := s.EncoderForVersion(serializer.Serializer, gv)
+	w.Header().Set("Content-Type", serializer.MediaType)
+	w.WriteHeader(statusCode)
+	if err := StreamList(list, encoder, w); err != nil {
+		// The status line has already been written, so the error cannot be
+		// surfaced as a response. Abort the connection instead of completing
+		// the response, so the client sees a transport error rather than a
+		// truncated 200.
+		utilruntime.HandleError(fmt.Errorf("apiserver was unable to stream a list response: %v", err))
+		panic(http.ErrAbortHandler)
+	}
+	return true
+}
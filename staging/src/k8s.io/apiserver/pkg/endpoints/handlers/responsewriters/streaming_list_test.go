@@ -0,0 +1,163 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package responsewriters
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apiserver/pkg/endpoints/handlers/negotiation"
+)
+
+func TestStreamList(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := v1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	codecs := serializer.NewCodecFactory(scheme)
+	info, _ := runtime.SerializerInfoForMediaType(codecs.SupportedMediaTypes(), "application/json")
+	encoder := codecs.EncoderForVersion(info.Serializer, v1.SchemeGroupVersion)
+
+	remaining := int64(5)
+	list := &v1.PodList{
+		TypeMeta: metav1.TypeMeta{Kind: "PodList", APIVersion: "v1"},
+		ListMeta: metav1.ListMeta{
+			ResourceVersion:    "100",
+			Continue:           "next-token",
+			RemainingItemCount: &remaining,
+		},
+		Items: []v1.Pod{
+			{ObjectMeta: metav1.ObjectMeta{Name: "pod-a", Namespace: "ns1"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "pod-b", Namespace: "ns1"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "pod-c", Namespace: "ns2"}},
+		},
+	}
+
+	buf := &bytes.Buffer{}
+	if err := StreamList(list, encoder, buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the streamed output must decode back into an equivalent list
+	decoded := &v1.PodList{}
+	if err := json.Unmarshal(buf.Bytes(), decoded); err != nil {
+		t.Fatalf("streamed output is not valid JSON: %v\n%s", err, buf.String())
+	}
+	if decoded.ResourceVersion != "100" || decoded.Continue != "next-token" {
+		t.Errorf("unexpected list metadata: %+v", decoded.ListMeta)
+	}
+	if decoded.RemainingItemCount == nil || *decoded.RemainingItemCount != 5 {
+		t.Errorf("unexpected remainingItemCount: %v", decoded.RemainingItemCount)
+	}
+	if len(decoded.Items) != 3 {
+		t.Fatalf("expected 3 items, got %d", len(decoded.Items))
+	}
+	for i, name := range []string{"pod-a", "pod-b", "pod-c"} {
+		if decoded.Items[i].Name != name {
+			t.Errorf("item %d: expected name %q, got %q", i, name, decoded.Items[i].Name)
+		}
+	}
+}
+
+func TestStreamListEmpty(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := v1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	codecs := serializer.NewCodecFactory(scheme)
+	info, _ := runtime.SerializerInfoForMediaType(codecs.SupportedMediaTypes(), "application/json")
+	encoder := codecs.EncoderForVersion(info.Serializer, v1.SchemeGroupVersion)
+
+	buf := &bytes.Buffer{}
+	list := &v1.PodList{TypeMeta: metav1.TypeMeta{Kind: "PodList", APIVersion: "v1"}}
+	if err := StreamList(list, encoder, buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	decoded := &v1.PodList{}
+	if err := json.Unmarshal(buf.Bytes(), decoded); err != nil {
+		t.Fatalf("streamed output is not valid JSON: %v\n%s", err, buf.String())
+	}
+	if len(decoded.Items) != 0 {
+		t.Errorf("expected no items, got %d", len(decoded.Items))
+	}
+}
+
+func TestStreamListRejectsNonLists(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := v1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	codecs := serializer.NewCodecFactory(scheme)
+	info, _ := runtime.SerializerInfoForMediaType(codecs.SupportedMediaTypes(), "application/json")
+	encoder := codecs.EncoderForVersion(info.Serializer, v1.SchemeGroupVersion)
+
+	if err := StreamList(&v1.Pod{}, encoder, &bytes.Buffer{}); err == nil {
+		t.Errorf("expected an error for a non-list object")
+	}
+}
+
+func TestStreamListNegotiated(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := v1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	codecs := serializer.NewCodecFactory(scheme)
+
+	list := &v1.PodList{
+		TypeMeta: metav1.TypeMeta{Kind: "PodList", APIVersion: "v1"},
+		ListMeta: metav1.ListMeta{ResourceVersion: "100"},
+		Items: []v1.Pod{
+			{ObjectMeta: metav1.ObjectMeta{Name: "pod-a", Namespace: "ns1"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "pod-b", Namespace: "ns1"}},
+		},
+	}
+
+	req := httptest.NewRequest("GET", "/api/v1/pods", nil)
+	req.Header.Set("Accept", "application/json")
+	w := httptest.NewRecorder()
+	if !StreamListNegotiated(codecs, negotiation.DefaultEndpointRestrictions, v1.SchemeGroupVersion, w, req, http.StatusOK, list) {
+		t.Fatal("expected a JSON request to be streamed")
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("unexpected content type %q", ct)
+	}
+	decoded := &v1.PodList{}
+	if err := json.Unmarshal(w.Body.Bytes(), decoded); err != nil {
+		t.Fatalf("streamed output is not valid JSON: %v\n%s", err, w.Body.String())
+	}
+	if decoded.ResourceVersion != "100" || len(decoded.Items) != 2 {
+		t.Errorf("unexpected decoded list: %+v", decoded)
+	}
+
+	// non-JSON encodings must fall back to the buffered path
+	req = httptest.NewRequest("GET", "/api/v1/pods", nil)
+	req.Header.Set("Accept", "application/vnd.kubernetes.protobuf")
+	w = httptest.NewRecorder()
+	if StreamListNegotiated(codecs, negotiation.DefaultEndpointRestrictions, v1.SchemeGroupVersion, w, req, http.StatusOK, list) {
+		t.Error("expected a protobuf request not to be streamed")
+	}
+	if w.Body.Len() != 0 {
+		t.Errorf("expected nothing written on fallback, got %q", w.Body.String())
+	}
+}
@@ -108,6 +108,16 @@ type RequestScope struct {
 	HubGroupVersion schema.GroupVersion
 
 	MaxRequestBodyBytes int64
+
+	// StreamListThreshold is the minimum number of items before a list
+	// response requested with "stream=list" is encoded incrementally.
+	// 0 means use the built-in default.
+	StreamListThreshold int
+
+	// BodyLimits holds per-resource overrides of the request body size limit
+	// and limits on decode-time complexity. The zero value applies no
+	// per-resource limits.
+	BodyLimits ResourceBodyLimits
 }
 
 func (scope *RequestScope) err(err error, w http.ResponseWriter, req *http.Request) {
@@ -162,6 +172,9 @@ func (scope *RequestScope) AllowsServerVersion(version string) bool {
 }
 
 func (scope *RequestScope) AllowsStreamSchema(s string) bool {
+	if s == "list" {
+		return utilfeature.DefaultFeatureGate.Enabled(features.StreamingListEncoding)
+	}
 	return s == "watch"
 }
 
@@ -595,6 +595,8 @@ func (a *APIInstaller) registerResourceHandlers(path string, storage rest.Storag
 		MetaGroupVersion: metav1.SchemeGroupVersion,
 
 		MaxRequestBodyBytes: a.group.MaxRequestBodyBytes,
+		StreamListThreshold: a.group.StreamListThreshold,
+		BodyLimits:          a.group.ResourceBodyLimits[resource],
 	}
 	if a.group.MetaGroupVersion != nil {
 		reqScope.MetaGroupVersion = *a.group.MetaGroupVersion
@@ -28,9 +28,11 @@ import (
 	restful "github.com/emicklei/go-restful"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/types"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
 	utilsets "k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apiserver/pkg/audit"
 	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/endpoints/deprecation"
 	"k8s.io/apiserver/pkg/endpoints/request"
 	"k8s.io/apiserver/pkg/endpoints/responsewriter"
 	"k8s.io/apiserver/pkg/features"
@@ -518,6 +520,18 @@ func MonitorRequest(req *http.Request, verb, group, version, resource, subresour
 	}
 	if deprecated {
 		deprecatedRequestGauge.WithContext(req.Context()).WithLabelValues(group, version, resource, subresource, removedRelease).Set(1)
+		username := ""
+		if ok {
+			username = info.GetName()
+		}
+		deprecation.DefaultUsageTracker.Record(deprecation.UsageKey{
+			Group:       group,
+			Version:     version,
+			Resource:    resource,
+			Subresource: subresource,
+			Username:    username,
+			UserAgent:   utilnet.GetHTTPClient(req),
+		}, removedRelease, time.Now())
 		audit.AddAuditAnnotation(req.Context(), deprecatedAnnotationKey, "true")
 		if len(removedRelease) > 0 {
 			audit.AddAuditAnnotation(req.Context(), removedReleaseAnnotationKey, removedRelease)
@@ -176,6 +176,14 @@ const (
 	// document.
 	StorageVersionHash featuregate.Feature = "StorageVersionHash"
 
+	// owner: @wojtek-t
+	// alpha: v1.25
+	//
+	// Allows clients that accept "application/json;stream=list" to receive
+	// large list responses encoded incrementally instead of from a single
+	// buffer, reducing apiserver memory spikes during full relists.
+	StreamingListEncoding featuregate.Feature = "StreamingListEncoding"
+
 	// owner: @wojtek-t
 	// alpha: v1.15
 	// beta: v1.16
@@ -229,5 +237,7 @@ var defaultKubernetesFeatureGates = map[featuregate.Feature]featuregate.FeatureS
 
 	StorageVersionHash: {Default: true, PreRelease: featuregate.Beta},
 
+	StreamingListEncoding: {Default: false, PreRelease: featuregate.Alpha},
+
 	WatchBookmark: {Default: true, PreRelease: featuregate.GA, LockToDefault: true},
 }
@@ -203,6 +203,10 @@ type Config struct {
 	// The limit on the request size that would be accepted and decoded in a write request
 	// 0 means no limit.
 	MaxRequestBodyBytes int64
+	// StreamListThreshold is the minimum number of items before a list
+	// response requested with "stream=list" is encoded incrementally.
+	// 0 means use the built-in default.
+	StreamListThreshold int
 	// MaxRequestsInFlight is the maximum number of parallel non-long-running requests. Every further
 	// request has to wait. Applies only to non-mutating requests.
 	MaxRequestsInFlight int
@@ -646,6 +650,7 @@ func (c completedConfig) New(name string, delegationTarget DelegationTarget) (*G
 		DiscoveryGroupManager: discovery.NewRootAPIsHandler(c.DiscoveryAddresses, c.Serializer),
 
 		maxRequestBodyBytes: c.MaxRequestBodyBytes,
+		streamListThreshold: c.StreamListThreshold,
 		livezClock:          clock.RealClock{},
 
 		lifecycleSignals:       c.lifecycleSignals,
@@ -39,6 +39,7 @@ import (
 	"k8s.io/apiserver/pkg/authorization/authorizer"
 	genericapi "k8s.io/apiserver/pkg/endpoints"
 	"k8s.io/apiserver/pkg/endpoints/discovery"
+	"k8s.io/apiserver/pkg/endpoints/handlers"
 	"k8s.io/apiserver/pkg/endpoints/handlers/fieldmanager"
 	"k8s.io/apiserver/pkg/features"
 	"k8s.io/apiserver/pkg/registry/rest"
@@ -87,6 +88,11 @@ type APIGroupInfo struct {
 	// StaticOpenAPISpec is the spec derived from the definitions of all resources installed together.
 	// It is set during InstallAPIGroups, InstallAPIGroup, and InstallLegacyAPIGroup.
 	StaticOpenAPISpec *spec.Swagger
+
+	// ResourceBodyLimits holds optional per-resource overrides of the request
+	// body size limit and limits on decode-time complexity, keyed by resource
+	// name. Resources without an entry use the server-wide defaults.
+	ResourceBodyLimits map[string]handlers.ResourceBodyLimits
 }
 
 func (a *APIGroupInfo) destroyStorage() {
@@ -218,6 +224,10 @@ type GenericAPIServer struct {
 	// 0 means no limit.
 	maxRequestBodyBytes int64
 
+	// The minimum number of items before a list response requested with
+	// "stream=list" is encoded incrementally. 0 means use the built-in default.
+	streamListThreshold int
+
 	// APIServerID is the ID of this API server
 	APIServerID string
 
@@ -654,6 +664,8 @@ func (s *GenericAPIServer) installAPIResources(apiPrefix string, apiGroupInfo *A
 		}
 
 		apiGroupVersion.MaxRequestBodyBytes = s.maxRequestBodyBytes
+		apiGroupVersion.StreamListThreshold = s.streamListThreshold
+		apiGroupVersion.ResourceBodyLimits = apiGroupInfo.ResourceBodyLimits
 
 		r, err := apiGroupVersion.InstallREST(s.Handler.GoRestfulContainer)
 		if err != nil {
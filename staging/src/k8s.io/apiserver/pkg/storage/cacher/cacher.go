@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"net/http"
 	"reflect"
+	"sort"
 	"sync"
 	"time"
 
@@ -1163,6 +1164,13 @@ type cacheWatcher struct {
 	// human readable identifier that helps assigning cacheWatcher
 	// instance with request
 	identifier string
+
+	// compressed holds the newest undelivered event per key for a slow
+	// consumer that understands bookmarks. While non-empty, the watcher is
+	// degraded: intermediate events per key are dropped and the remembered
+	// ones are flushed into the input channel, in resource version order, as
+	// it drains. Accessed only from the dispatching goroutine.
+	compressed map[string]*watchCacheEvent
 }
 
 func newCacheWatcher(chanSize int, filter filterWithAttrsFunc, forget func(), versioner storage.Versioner, deadline time.Time, allowWatchBookmarks bool, objectType reflect.Type, identifier string) *cacheWatcher {
@@ -1201,6 +1209,11 @@ func (c *cacheWatcher) stopLocked() {
 }
 
 func (c *cacheWatcher) nonblockingAdd(event *watchCacheEvent) bool {
+	// Flush events remembered while the consumer was slow first, so that
+	// events are delivered in order.
+	if !c.drainCompressed() {
+		return false
+	}
 	select {
 	case c.input <- event:
 		return true
@@ -1209,6 +1222,58 @@ func (c *cacheWatcher) nonblockingAdd(event *watchCacheEvent) bool {
 	}
 }
 
+// compress remembers an event that couldn't be delivered to a slow consumer,
+// keeping one event per key. Events for the same key are merged: the current
+// state comes from the newest event, while the previous state is preserved
+// from the oldest undelivered one. The consumer misses intermediate changes,
+// but still observes the transition from the last state it saw to the latest
+// state of every object, the same guarantee a relist would give it. In
+// particular, a filtered watcher whose object stopped matching the selector
+// mid-compression still receives the synthesized Deleted event. Bookmark
+// events are not remembered - they are regenerated periodically anyway.
+func (c *cacheWatcher) compress(event *watchCacheEvent) {
+	if event.Type == watch.Bookmark {
+		return
+	}
+	if len(c.compressed) == 0 {
+		klog.V(1).Infof("Degrading %v watcher to compressed event delivery due to unresponsiveness: %v. len(c.input) = %v, len(c.result) = %v", c.objectType.String(), c.identifier, len(c.input), len(c.result))
+		metrics.DegradedWatchersCounter.WithLabelValues(c.objectType.String()).Inc()
+		c.compressed = map[string]*watchCacheEvent{}
+	}
+	if previous, ok := c.compressed[event.Key]; ok {
+		merged := *event
+		merged.PrevObject = previous.PrevObject
+		merged.PrevObjLabels = previous.PrevObjLabels
+		merged.PrevObjFields = previous.PrevObjFields
+		c.compressed[event.Key] = &merged
+		return
+	}
+	c.compressed[event.Key] = event
+}
+
+// drainCompressed flushes events remembered for a slow consumer into the
+// input channel in resource version order, without blocking. It returns true
+// if the watcher is not degraded (anymore).
+func (c *cacheWatcher) drainCompressed() bool {
+	if len(c.compressed) == 0 {
+		return true
+	}
+	batch := make([]*watchCacheEvent, 0, len(c.compressed))
+	for _, event := range c.compressed {
+		batch = append(batch, event)
+	}
+	sort.Slice(batch, func(i, j int) bool { return batch[i].ResourceVersion < batch[j].ResourceVersion })
+	for _, event := range batch {
+		select {
+		case c.input <- event:
+			delete(c.compressed, event.Key)
+		default:
+			return false
+		}
+	}
+	return true
+}
+
 // Nil timer means that add will not block (if it can't send event immediately, it will break the watcher)
 func (c *cacheWatcher) add(event *watchCacheEvent, timer *time.Timer) bool {
 	// Try to send the event immediately, without blocking.
@@ -1216,9 +1281,17 @@ func (c *cacheWatcher) add(event *watchCacheEvent, timer *time.Timer) bool {
 		return true
 	}
 
+	// This means that we couldn't send event to that watcher.
+	// If the consumer understands bookmarks, degrade it to compressed event
+	// delivery instead of terminating it - periodic bookmarks keep its
+	// resource version moving even while it is behind.
+	if c.allowWatchBookmarks {
+		c.compress(event)
+		return true
+	}
+
 	closeFunc := func() {
-		// This means that we couldn't send event to that watcher.
-		// Since we don't want to block on it infinitely,
+		// Since we don't want to block on the watcher infinitely,
 		// we simply terminate it.
 		klog.V(1).Infof("Forcing %v watcher close due to unresponsiveness: %v. len(c.input) = %v, len(c.result) = %v", c.objectType.String(), c.identifier, len(c.input), len(c.result))
 		metrics.TerminatedWatchersCounter.WithLabelValues(c.objectType.String()).Inc()
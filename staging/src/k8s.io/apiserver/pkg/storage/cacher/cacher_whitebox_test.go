@@ -560,6 +560,89 @@ func TestCacheWatcherStoppedInAnotherGoroutine(t *testing.T) {
 	}
 }
 
+func TestCacheWatcherCompressedDelivery(t *testing.T) {
+	filter := func(string, labels.Set, fields.Set) bool { return true }
+	forgotten := false
+	forget := func() { forgotten = true }
+
+	// A watcher with input buffer of size 1 that nobody drains, so that the
+	// second add already finds the channel full.
+	w := newCacheWatcher(1, filter, forget, testVersioner{}, time.Now().Add(time.Hour), true, objectType, "")
+	event := func(key string, rv uint64) *watchCacheEvent {
+		return &watchCacheEvent{Type: watch.Added, Object: &v1.Pod{}, Key: key, ResourceVersion: rv}
+	}
+
+	if !w.add(event("pods/ns/a", 1), nil) {
+		t.Fatal("expected the first event to be accepted")
+	}
+	// The channel is full now; subsequent events must degrade the watcher
+	// instead of terminating it, keeping only the newest event per key.
+	for _, e := range []*watchCacheEvent{event("pods/ns/a", 2), event("pods/ns/a", 3), event("pods/ns/b", 4)} {
+		if !w.add(e, nil) {
+			t.Fatalf("expected event %v to be accepted by the degraded watcher", e.ResourceVersion)
+		}
+	}
+	if forgotten {
+		t.Fatal("slow watcher with bookmarks enabled should not be terminated")
+	}
+	if len(w.compressed) != 2 {
+		t.Fatalf("expected 2 compressed events, got %d", len(w.compressed))
+	}
+
+	// As the consumer drains the input channel, the compressed events are
+	// flushed in resource version order.
+	expectedRVs := []uint64{1, 3, 4}
+	for i, expectedRV := range expectedRVs {
+		got := <-w.input
+		if got.ResourceVersion != expectedRV {
+			t.Errorf("event %d: expected resource version %d, got %d", i, expectedRV, got.ResourceVersion)
+		}
+		// Trigger a flush the way the dispatcher would.
+		w.drainCompressed()
+	}
+	if len(w.compressed) != 0 {
+		t.Fatalf("expected the watcher to recover, %d compressed events left", len(w.compressed))
+	}
+	if !w.add(event("pods/ns/c", 5), nil) {
+		t.Fatal("expected the recovered watcher to accept events directly")
+	}
+	if got := <-w.input; got.ResourceVersion != 5 {
+		t.Errorf("expected resource version 5, got %d", got.ResourceVersion)
+	}
+}
+
+func TestCacheWatcherCompressPreservesOldestPrevObject(t *testing.T) {
+	filter := func(string, labels.Set, fields.Set) bool { return true }
+	w := newCacheWatcher(0, filter, emptyFunc, testVersioner{}, time.Now().Add(time.Hour), true, objectType, "")
+
+	oldest := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "oldest"}}
+	middle := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "middle"}}
+	newest := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "newest"}}
+
+	w.compress(&watchCacheEvent{
+		Type: watch.Modified, Key: "pods/ns/a", ResourceVersion: 2,
+		Object: middle, PrevObject: oldest, PrevObjLabels: labels.Set{"phase": "old"},
+	})
+	w.compress(&watchCacheEvent{
+		Type: watch.Modified, Key: "pods/ns/a", ResourceVersion: 3,
+		Object: newest, PrevObject: middle, PrevObjLabels: labels.Set{"phase": "middle"},
+	})
+
+	merged := w.compressed["pods/ns/a"]
+	if merged == nil {
+		t.Fatal("expected a compressed event for the key")
+	}
+	if merged.ResourceVersion != 3 || merged.Object != newest {
+		t.Errorf("expected the newest current state to survive, got rv %d", merged.ResourceVersion)
+	}
+	// The previous state must come from the oldest undelivered event, so a
+	// filtered consumer still observes the transition away from the state it
+	// last saw (e.g. a synthesized Deleted event).
+	if merged.PrevObject != oldest || merged.PrevObjLabels["phase"] != "old" {
+		t.Errorf("expected the oldest previous state to be preserved, got %v", merged.PrevObject)
+	}
+}
+
 func TestCacheWatcherStoppedOnDestroy(t *testing.T) {
 	backingStorage := &dummyStorage{}
 	cacher, _, err := newTestCacher(backingStorage)
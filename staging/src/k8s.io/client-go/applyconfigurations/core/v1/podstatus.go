@@ -39,6 +39,7 @@ type PodStatusApplyConfiguration struct {
 	ContainerStatuses          []ContainerStatusApplyConfiguration `json:"containerStatuses,omitempty"`
 	QOSClass                   *v1.PodQOSClass                     `json:"qosClass,omitempty"`
 	EphemeralContainerStatuses []ContainerStatusApplyConfiguration `json:"ephemeralContainerStatuses,omitempty"`
+	VolumeStatuses             []PodVolumeStatusApplyConfiguration `json:"volumeStatuses,omitempty"`
 }
 
 // PodStatusApplyConfiguration constructs an declarative configuration of the PodStatus type for use with
@@ -175,3 +176,16 @@ func (b *PodStatusApplyConfiguration) WithEphemeralContainerStatuses(values ...*
 	}
 	return b
 }
+
+// WithVolumeStatuses adds the given value to the VolumeStatuses field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the VolumeStatuses field.
+func (b *PodStatusApplyConfiguration) WithVolumeStatuses(values ...*PodVolumeStatusApplyConfiguration) *PodStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithVolumeStatuses")
+		}
+		b.VolumeStatuses = append(b.VolumeStatuses, *values[i])
+	}
+	return b
+}
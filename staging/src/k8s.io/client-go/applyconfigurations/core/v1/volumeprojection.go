@@ -21,10 +21,11 @@ package v1
 // VolumeProjectionApplyConfiguration represents an declarative configuration of the VolumeProjection type for use
 // with apply.
 type VolumeProjectionApplyConfiguration struct {
-	Secret              *SecretProjectionApplyConfiguration              `json:"secret,omitempty"`
-	DownwardAPI         *DownwardAPIProjectionApplyConfiguration         `json:"downwardAPI,omitempty"`
-	ConfigMap           *ConfigMapProjectionApplyConfiguration           `json:"configMap,omitempty"`
-	ServiceAccountToken *ServiceAccountTokenProjectionApplyConfiguration `json:"serviceAccountToken,omitempty"`
+	Secret               *SecretProjectionApplyConfiguration               `json:"secret,omitempty"`
+	DownwardAPI          *DownwardAPIProjectionApplyConfiguration          `json:"downwardAPI,omitempty"`
+	ConfigMap            *ConfigMapProjectionApplyConfiguration            `json:"configMap,omitempty"`
+	ServiceAccountToken  *ServiceAccountTokenProjectionApplyConfiguration  `json:"serviceAccountToken,omitempty"`
+	ServiceAccountIssuer *ServiceAccountIssuerProjectionApplyConfiguration `json:"serviceAccountIssuer,omitempty"`
 }
 
 // VolumeProjectionApplyConfiguration constructs an declarative configuration of the VolumeProjection type for use with
@@ -64,3 +65,11 @@ func (b *VolumeProjectionApplyConfiguration) WithServiceAccountToken(value *Serv
 	b.ServiceAccountToken = value
 	return b
 }
+
+// WithServiceAccountIssuer sets the ServiceAccountIssuer field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ServiceAccountIssuer field is set to the value of the last call.
+func (b *VolumeProjectionApplyConfiguration) WithServiceAccountIssuer(value *ServiceAccountIssuerProjectionApplyConfiguration) *VolumeProjectionApplyConfiguration {
+	b.ServiceAccountIssuer = value
+	return b
+}
@@ -3041,6 +3041,9 @@ var schemaYAML = typed.YAMLObject(`types:
     - name: parallelism
       type:
         scalar: numeric
+    - name: podReplacementPolicy
+      type:
+        scalar: string
     - name: selector
       type:
         namedType: io.k8s.apimachinery.pkg.apis.meta.v1.LabelSelector
@@ -5927,6 +5930,14 @@ var schemaYAML = typed.YAMLObject(`types:
     - name: startTime
       type:
         namedType: io.k8s.apimachinery.pkg.apis.meta.v1.Time
+    - name: volumeStatuses
+      type:
+        list:
+          elementType:
+            namedType: io.k8s.api.core.v1.PodVolumeStatus
+          elementRelationship: associative
+          keys:
+          - name
 - name: io.k8s.api.core.v1.PodTemplate
   map:
     fields:
@@ -5955,6 +5966,25 @@ var schemaYAML = typed.YAMLObject(`types:
       type:
         namedType: io.k8s.api.core.v1.PodSpec
       default: {}
+- name: io.k8s.api.core.v1.PodVolumeStatus
+  map:
+    fields:
+    - name: message
+      type:
+        scalar: string
+    - name: mounted
+      type:
+        scalar: boolean
+    - name: name
+      type:
+        scalar: string
+      default: ""
+    - name: resolvedSubPaths
+      type:
+        list:
+          elementType:
+            scalar: string
+          elementRelationship: atomic
 - name: io.k8s.api.core.v1.PortStatus
   map:
     fields:
@@ -6598,6 +6628,13 @@ var schemaYAML = typed.YAMLObject(`types:
           elementRelationship: associative
           keys:
           - name
+- name: io.k8s.api.core.v1.ServiceAccountIssuerProjection
+  map:
+    fields:
+    - name: path
+      type:
+        scalar: string
+      default: ""
 - name: io.k8s.api.core.v1.ServiceAccountTokenProjection
   map:
     fields:
@@ -7040,6 +7077,9 @@ var schemaYAML = typed.YAMLObject(`types:
     - name: secret
       type:
         namedType: io.k8s.api.core.v1.SecretProjection
+    - name: serviceAccountIssuer
+      type:
+        namedType: io.k8s.api.core.v1.ServiceAccountIssuerProjection
     - name: serviceAccountToken
       type:
         namedType: io.k8s.api.core.v1.ServiceAccountTokenProjection
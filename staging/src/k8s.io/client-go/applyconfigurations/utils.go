@@ -733,6 +733,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &applyconfigurationscorev1.PodTemplateApplyConfiguration{}
 	case corev1.SchemeGroupVersion.WithKind("PodTemplateSpec"):
 		return &applyconfigurationscorev1.PodTemplateSpecApplyConfiguration{}
+	case corev1.SchemeGroupVersion.WithKind("PodVolumeStatus"):
+		return &applyconfigurationscorev1.PodVolumeStatusApplyConfiguration{}
 	case corev1.SchemeGroupVersion.WithKind("PortStatus"):
 		return &applyconfigurationscorev1.PortStatusApplyConfiguration{}
 	case corev1.SchemeGroupVersion.WithKind("PortworxVolumeSource"):
@@ -799,6 +801,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &applyconfigurationscorev1.ServiceApplyConfiguration{}
 	case corev1.SchemeGroupVersion.WithKind("ServiceAccount"):
 		return &applyconfigurationscorev1.ServiceAccountApplyConfiguration{}
+	case corev1.SchemeGroupVersion.WithKind("ServiceAccountIssuerProjection"):
+		return &applyconfigurationscorev1.ServiceAccountIssuerProjectionApplyConfiguration{}
 	case corev1.SchemeGroupVersion.WithKind("ServiceAccountTokenProjection"):
 		return &applyconfigurationscorev1.ServiceAccountTokenProjectionApplyConfiguration{}
 	case corev1.SchemeGroupVersion.WithKind("ServicePort"):
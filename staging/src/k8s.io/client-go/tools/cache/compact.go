@@ -0,0 +1,121 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// CompactObject holds an object in its encoded form, together with just
+// enough object metadata for key functions, indexers and label selection to
+// work without decoding. It is produced by NewCompactTransformer and is what
+// ends up in the informer cache: for high-cardinality objects such as pods,
+// a protobuf-encoded body is considerably smaller than the decoded structs it
+// replaces, cutting resident memory of components that cache whole
+// collections (e.g. kube-controller-manager's pod and persistentvolume
+// informers in very large clusters).
+//
+// Consumers that need the full object must call Object, which decodes on
+// every invocation; the decoded form is deliberately not cached so the
+// savings are not lost to retained pointers.
+type CompactObject struct {
+	// PartialObjectMetadata keeps name, namespace, labels, resourceVersion
+	// and friends accessible without a decode.
+	*metav1.PartialObjectMetadata
+
+	codec runtime.Codec
+	data  []byte
+}
+
+// Object decodes and returns the full object.
+func (c *CompactObject) Object() (runtime.Object, error) {
+	obj, err := runtime.Decode(c.codec, c.data)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode cached object %s/%s: %v", c.GetNamespace(), c.GetName(), err)
+	}
+	return obj, nil
+}
+
+// NewCompactTransformer returns a TransformFunc that stores objects in
+// encoded form. The codec decides the wire format; protobuf gives the best
+// compaction for types that support it. Tombstones are handled by compacting
+// the object they carry.
+func NewCompactTransformer(codec runtime.Codec) TransformFunc {
+	var transform TransformFunc
+	transform = func(obj interface{}) (interface{}, error) {
+		if tombstone, ok := obj.(DeletedFinalStateUnknown); ok {
+			inner, err := transform(tombstone.Obj)
+			if err != nil {
+				return nil, err
+			}
+			tombstone.Obj = inner
+			return tombstone, nil
+		}
+		if _, ok := obj.(*CompactObject); ok {
+			// already compacted (e.g. a resync replaying cache content)
+			return obj, nil
+		}
+		runtimeObj, ok := obj.(runtime.Object)
+		if !ok {
+			return nil, fmt.Errorf("object of type %T cannot be compacted", obj)
+		}
+		accessor, err := meta.Accessor(runtimeObj)
+		if err != nil {
+			return nil, err
+		}
+		data, err := runtime.Encode(codec, runtimeObj)
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode object %s/%s for compact storage: %v", accessor.GetNamespace(), accessor.GetName(), err)
+		}
+		partial := &metav1.PartialObjectMetadata{}
+		partial.Name = accessor.GetName()
+		partial.Namespace = accessor.GetNamespace()
+		partial.UID = accessor.GetUID()
+		partial.ResourceVersion = accessor.GetResourceVersion()
+		partial.Labels = accessor.GetLabels()
+		partial.DeletionTimestamp = accessor.GetDeletionTimestamp()
+		return &CompactObject{
+			PartialObjectMetadata: partial,
+			codec:                 codec,
+			data:                  data,
+		}, nil
+	}
+	return transform
+}
+
+// Decompact returns the decoded object if obj is a CompactObject (or a
+// tombstone wrapping one) and obj unchanged otherwise, so consumers can be
+// written independently of whether the informer uses compact storage.
+func Decompact(obj interface{}) (interface{}, error) {
+	switch t := obj.(type) {
+	case *CompactObject:
+		return t.Object()
+	case DeletedFinalStateUnknown:
+		inner, err := Decompact(t.Obj)
+		if err != nil {
+			return nil, err
+		}
+		t.Obj = inner
+		return t, nil
+	default:
+		return obj, nil
+	}
+}
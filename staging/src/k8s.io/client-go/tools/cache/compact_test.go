@@ -0,0 +1,136 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+)
+
+func testCompactCodec(t *testing.T) runtime.Codec {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := v1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	codecs := serializer.NewCodecFactory(scheme)
+	return codecs.LegacyCodec(v1.SchemeGroupVersion)
+}
+
+func TestCompactTransformerRoundTrip(t *testing.T) {
+	transform := NewCompactTransformer(testCompactCodec(t))
+
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "pod1",
+			Namespace:       "ns1",
+			ResourceVersion: "42",
+			Labels:          map[string]string{"app": "db"},
+		},
+		Spec: v1.PodSpec{NodeName: "node-1"},
+	}
+
+	out, err := transform(pod)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	compact, ok := out.(*CompactObject)
+	if !ok {
+		t.Fatalf("expected *CompactObject, got %T", out)
+	}
+
+	// key functions and label access work without decoding
+	key, err := MetaNamespaceKeyFunc(compact)
+	if err != nil {
+		t.Fatalf("unexpected key error: %v", err)
+	}
+	if key != "ns1/pod1" {
+		t.Errorf("unexpected key %q", key)
+	}
+	if compact.GetLabels()["app"] != "db" || compact.GetResourceVersion() != "42" {
+		t.Errorf("unexpected metadata: %+v", compact.PartialObjectMetadata)
+	}
+
+	decoded, err := compact.Object()
+	if err != nil {
+		t.Fatalf("unexpected decode error: %v", err)
+	}
+	decodedPod, ok := decoded.(*v1.Pod)
+	if !ok {
+		t.Fatalf("expected *v1.Pod, got %T", decoded)
+	}
+	if decodedPod.Spec.NodeName != "node-1" {
+		t.Errorf("expected full object content to round-trip, got %+v", decodedPod.Spec)
+	}
+
+	// transforming twice is a no-op
+	again, err := transform(compact)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if again != out {
+		t.Errorf("expected already-compacted object to pass through unchanged")
+	}
+}
+
+func TestCompactTransformerTombstone(t *testing.T) {
+	transform := NewCompactTransformer(testCompactCodec(t))
+
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "ns1"}}
+	out, err := transform(DeletedFinalStateUnknown{Key: "ns1/pod1", Obj: pod})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tombstone, ok := out.(DeletedFinalStateUnknown)
+	if !ok {
+		t.Fatalf("expected tombstone to be preserved, got %T", out)
+	}
+	if _, ok := tombstone.Obj.(*CompactObject); !ok {
+		t.Errorf("expected tombstone content to be compacted, got %T", tombstone.Obj)
+	}
+}
+
+func TestDecompact(t *testing.T) {
+	transform := NewCompactTransformer(testCompactCodec(t))
+
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "ns1"}}
+	compact, err := transform(pod)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	obj, err := Decompact(compact)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := obj.(*v1.Pod); !ok {
+		t.Errorf("expected *v1.Pod, got %T", obj)
+	}
+
+	// non-compact objects pass through untouched
+	obj, err = Decompact(pod)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if obj != interface{}(pod) {
+		t.Errorf("expected pass-through for non-compact objects")
+	}
+}
@@ -311,6 +311,25 @@ func (le *LeaderElector) release() bool {
 	return true
 }
 
+// stepDown gives up the lease in favor of the preferred holder. The
+// preferred holder is kept on the record so that other candidates yield to
+// it while the transfer is in flight.
+func (le *LeaderElector) stepDown(ctx context.Context, preferredHolder string) {
+	now := metav1.Now()
+	leaderElectionRecord := rl.LeaderElectionRecord{
+		LeaderTransitions:    le.observedRecord.LeaderTransitions,
+		LeaseDurationSeconds: 1,
+		RenewTime:            now,
+		AcquireTime:          now,
+		PreferredHolder:      preferredHolder,
+	}
+	if err := le.config.Lock.Update(ctx, leaderElectionRecord); err != nil {
+		klog.Errorf("Failed to step down: %v", err)
+		return
+	}
+	le.setObservedRecord(&leaderElectionRecord)
+}
+
 // tryAcquireOrRenew tries to acquire a leader lease if it is not already acquired,
 // else it tries to renew the lease if it has already been acquired. Returns true
 // on success else returns false.
@@ -353,6 +372,22 @@ func (le *LeaderElector) tryAcquireOrRenew(ctx context.Context) bool {
 		return false
 	}
 
+	// A preferred holder was nominated for a graceful leadership transfer
+	// (see TransferLeadership). The current leader steps down without waiting
+	// for the lease to expire, and other candidates yield to the nominee for
+	// one lease duration before regular contention resumes.
+	if ph := oldLeaderElectionRecord.PreferredHolder; ph != "" && ph != le.config.Lock.Identity() {
+		if le.IsLeader() {
+			klog.Infof("lease %v has preferred holder %v, stepping down", le.config.Lock.Describe(), ph)
+			le.stepDown(ctx, ph)
+			return false
+		}
+		if le.observedTime.Add(le.config.LeaseDuration).After(now.Time) {
+			klog.V(4).Infof("lock is reserved for preferred holder %v", ph)
+			return false
+		}
+	}
+
 	// 3. We're going to try to update. The leaderElectionRecord is set to it's default
 	// here. Let's correct it before updating.
 	if le.IsLeader() {
@@ -31,9 +31,13 @@ import (
 
 const (
 	LeaderElectionRecordAnnotationKey = "control-plane.alpha.kubernetes.io/leader"
-	endpointsResourceLock             = "endpoints"
-	configMapsResourceLock            = "configmaps"
-	LeasesResourceLock                = "leases"
+	// PreferredHolderAnnotationKey is set on the lock object to nominate a
+	// candidate for a graceful leadership transfer; see
+	// leaderelection.TransferLeadership. Only supported by LeaseLock.
+	PreferredHolderAnnotationKey = "leaderelection.k8s.io/preferred-holder"
+	endpointsResourceLock        = "endpoints"
+	configMapsResourceLock       = "configmaps"
+	LeasesResourceLock           = "leases"
 	// When using EndpointsLeasesResourceLock, you need to ensure that
 	// API Priority & Fairness is configured with non-default flow-schema
 	// that will catch the necessary operations on leader-election related
@@ -119,6 +123,11 @@ type LeaderElectionRecord struct {
 	AcquireTime          metav1.Time `json:"acquireTime"`
 	RenewTime            metav1.Time `json:"renewTime"`
 	LeaderTransitions    int         `json:"leaderTransitions"`
+	// PreferredHolder is the identity nominated to take over the lease. When
+	// set, the current holder steps down without waiting for the lease to
+	// expire and other candidates yield to the nominee for one lease
+	// duration. Empty means no transfer was requested.
+	PreferredHolder string `json:"preferredHolder,omitempty"`
 }
 
 // EventRecorder records a change in the ResourceLock.
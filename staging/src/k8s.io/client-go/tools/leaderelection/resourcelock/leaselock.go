@@ -45,6 +45,7 @@ func (ll *LeaseLock) Get(ctx context.Context) (*LeaderElectionRecord, []byte, er
 		return nil, nil, err
 	}
 	record := LeaseSpecToLeaderElectionRecord(&ll.lease.Spec)
+	record.PreferredHolder = ll.lease.Annotations[PreferredHolderAnnotationKey]
 	recordByte, err := json.Marshal(*record)
 	if err != nil {
 		return nil, nil, err
@@ -71,6 +72,14 @@ func (ll *LeaseLock) Update(ctx context.Context, ler LeaderElectionRecord) error
 		return errors.New("lease not initialized, call get or create first")
 	}
 	ll.lease.Spec = LeaderElectionRecordToLeaseSpec(&ler)
+	if ler.PreferredHolder == "" {
+		delete(ll.lease.Annotations, PreferredHolderAnnotationKey)
+	} else {
+		if ll.lease.Annotations == nil {
+			ll.lease.Annotations = map[string]string{}
+		}
+		ll.lease.Annotations[PreferredHolderAnnotationKey] = ler.PreferredHolder
+	}
 
 	lease, err := ll.Client.Leases(ll.LeaseMeta.Namespace).Update(ctx, ll.lease, metav1.UpdateOptions{})
 	if err != nil {
@@ -0,0 +1,54 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package leaderelection
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	coordinationv1client "k8s.io/client-go/kubernetes/typed/coordination/v1"
+	rl "k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+// TransferLeadership nominates candidate as the preferred holder of the
+// lease, for example before planned maintenance of the current leader. The
+// current holder steps down without waiting for the lease to expire, and
+// other candidates yield to the nominee for one lease duration before
+// regular contention resumes. The transfer is best effort: if the nominee
+// does not take the lease in time, any candidate may acquire it.
+//
+// An empty candidate cancels a pending transfer. Only lease-based locks
+// support leadership transfer.
+func TransferLeadership(ctx context.Context, client coordinationv1client.LeasesGetter, namespace, name, candidate string) error {
+	lease, err := client.Leases(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if candidate == "" {
+		if _, ok := lease.Annotations[rl.PreferredHolderAnnotationKey]; !ok {
+			return nil
+		}
+		delete(lease.Annotations, rl.PreferredHolderAnnotationKey)
+	} else {
+		if lease.Annotations == nil {
+			lease.Annotations = map[string]string{}
+		}
+		lease.Annotations[rl.PreferredHolderAnnotationKey] = candidate
+	}
+	_, err = client.Leases(namespace).Update(ctx, lease, metav1.UpdateOptions{})
+	return err
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package leaderelection
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	rl "k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+func TestTransferLeadership(t *testing.T) {
+	holder := "leader-1"
+	client := fake.NewSimpleClientset(&coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-lease", Namespace: "default"},
+		Spec:       coordinationv1.LeaseSpec{HolderIdentity: &holder},
+	})
+
+	if err := TransferLeadership(context.TODO(), client.CoordinationV1(), "default", "test-lease", "leader-2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The nominee must be visible through the lock's election record.
+	lock := &rl.LeaseLock{
+		LeaseMeta:  metav1.ObjectMeta{Name: "test-lease", Namespace: "default"},
+		Client:     client.CoordinationV1(),
+		LockConfig: rl.ResourceLockConfig{Identity: holder},
+	}
+	record, _, err := lock.Get(context.TODO())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if record.PreferredHolder != "leader-2" {
+		t.Errorf("expected preferred holder %q, got %q", "leader-2", record.PreferredHolder)
+	}
+
+	// Cancelling the transfer removes the nomination.
+	if err := TransferLeadership(context.TODO(), client.CoordinationV1(), "default", "test-lease", ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	record, _, err = lock.Get(context.TODO())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if record.PreferredHolder != "" {
+		t.Errorf("expected no preferred holder, got %q", record.PreferredHolder)
+	}
+}
+
+func TestStepDownKeepsPreferredHolder(t *testing.T) {
+	holder := "leader-1"
+	client := fake.NewSimpleClientset(&coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-lease", Namespace: "default"},
+		Spec:       coordinationv1.LeaseSpec{HolderIdentity: &holder},
+	})
+	lock := &rl.LeaseLock{
+		LeaseMeta:  metav1.ObjectMeta{Name: "test-lease", Namespace: "default"},
+		Client:     client.CoordinationV1(),
+		LockConfig: rl.ResourceLockConfig{Identity: holder},
+	}
+	le, err := NewLeaderElector(LeaderElectionConfig{
+		Lock:          lock,
+		LeaseDuration: 15 * time.Second,
+		RenewDeadline: 10 * time.Second,
+		RetryPeriod:   2 * time.Second,
+		Callbacks: LeaderCallbacks{
+			OnStartedLeading: func(context.Context) {},
+			OnStoppedLeading: func() {},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := TransferLeadership(context.TODO(), client.CoordinationV1(), "default", "test-lease", "leader-2"); err != nil {
+		t.Fatal(err)
+	}
+
+	// The leader observes the nomination and steps down instead of renewing.
+	record, _, err := lock.Get(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	le.setObservedRecord(record)
+	if le.tryAcquireOrRenew(context.TODO()) {
+		t.Error("expected the leader to step down instead of renewing")
+	}
+
+	// The released lease keeps the nomination for the other candidates.
+	record, _, err = lock.Get(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if record.HolderIdentity != "" {
+		t.Errorf("expected the lease to be released, still held by %q", record.HolderIdentity)
+	}
+	if record.PreferredHolder != "leader-2" {
+		t.Errorf("expected preferred holder %q, got %q", "leader-2", record.PreferredHolder)
+	}
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package transport
+
+import (
+	"context"
+	"net/http"
+
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+)
+
+// RequestPriorityHeader carries a client-supplied priority hint for a single
+// request so that API Priority and Fairness can distinguish latency-sensitive
+// calls (e.g. a volume bind status update) from bulk background traffic
+// (e.g. an informer relist) coming from the same client identity.
+const RequestPriorityHeader = "X-Kubernetes-Request-Priority"
+
+// Well-known request priority values. The server treats unknown values as
+// unset, so clients may only rely on these.
+const (
+	// RequestPriorityLow marks background or bulk traffic that can tolerate
+	// queueing behind other work.
+	RequestPriorityLow = "low"
+	// RequestPriorityHigh marks latency-sensitive traffic that should not be
+	// queued behind background work from the same client.
+	RequestPriorityHigh = "high"
+)
+
+type requestPriorityKey struct{}
+
+// WithRequestPriority returns a copy of ctx carrying a priority hint for
+// requests issued with it. The hint is attached as a request header by the
+// round tripper installed via HTTPWrappersForConfig.
+func WithRequestPriority(ctx context.Context, priority string) context.Context {
+	return context.WithValue(ctx, requestPriorityKey{}, priority)
+}
+
+// RequestPriorityFrom returns the priority hint carried by ctx, if any.
+func RequestPriorityFrom(ctx context.Context) (string, bool) {
+	priority, ok := ctx.Value(requestPriorityKey{}).(string)
+	return priority, ok
+}
+
+type requestPriorityRoundTripper struct {
+	rt http.RoundTripper
+}
+
+var _ utilnet.RoundTripperWrapper = &requestPriorityRoundTripper{}
+
+// NewRequestPriorityRoundTripper adds the priority header from the request
+// context to a request unless it has already been set.
+func NewRequestPriorityRoundTripper(rt http.RoundTripper) http.RoundTripper {
+	return &requestPriorityRoundTripper{rt}
+}
+
+func (rt *requestPriorityRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	priority, ok := RequestPriorityFrom(req.Context())
+	if !ok || len(req.Header.Get(RequestPriorityHeader)) != 0 {
+		return rt.rt.RoundTrip(req)
+	}
+	req = utilnet.CloneRequest(req)
+	req.Header.Set(RequestPriorityHeader, priority)
+	return rt.rt.RoundTrip(req)
+}
+
+func (rt *requestPriorityRoundTripper) CancelRequest(req *http.Request) {
+	tryCancelRequest(rt.WrappedRoundTripper(), req)
+}
+
+func (rt *requestPriorityRoundTripper) WrappedRoundTripper() http.RoundTripper { return rt.rt }
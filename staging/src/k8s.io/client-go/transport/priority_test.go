@@ -0,0 +1,85 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package transport
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestRequestPriorityRoundTripper(t *testing.T) {
+	tests := []struct {
+		name           string
+		ctxPriority    string
+		existingHeader string
+		expectHeader   string
+	}{
+		{
+			name:         "no hint leaves header unset",
+			expectHeader: "",
+		},
+		{
+			name:         "low priority hint",
+			ctxPriority:  RequestPriorityLow,
+			expectHeader: RequestPriorityLow,
+		},
+		{
+			name:         "high priority hint",
+			ctxPriority:  RequestPriorityHigh,
+			expectHeader: RequestPriorityHigh,
+		},
+		{
+			name:           "explicit header wins over context",
+			ctxPriority:    RequestPriorityLow,
+			existingHeader: RequestPriorityHigh,
+			expectHeader:   RequestPriorityHigh,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rt := &testRoundTripper{}
+			ctx := context.Background()
+			if len(tc.ctxPriority) > 0 {
+				ctx = WithRequestPriority(ctx, tc.ctxPriority)
+			}
+			req, err := http.NewRequestWithContext(ctx, "GET", "http://localhost", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(tc.existingHeader) > 0 {
+				req.Header.Set(RequestPriorityHeader, tc.existingHeader)
+			}
+			if _, err := NewRequestPriorityRoundTripper(rt).RoundTrip(req); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := rt.Request.Header.Get(RequestPriorityHeader); got != tc.expectHeader {
+				t.Errorf("expected header %q, got %q", tc.expectHeader, got)
+			}
+		})
+	}
+}
+
+func TestRequestPriorityFrom(t *testing.T) {
+	if _, ok := RequestPriorityFrom(context.Background()); ok {
+		t.Errorf("expected no priority on a fresh context")
+	}
+	priority, ok := RequestPriorityFrom(WithRequestPriority(context.Background(), RequestPriorityHigh))
+	if !ok || priority != RequestPriorityHigh {
+		t.Errorf("expected %q, got %q (ok=%v)", RequestPriorityHigh, priority, ok)
+	}
+}
@@ -59,6 +59,7 @@ func HTTPWrappersForConfig(config *Config, rt http.RoundTripper) (http.RoundTrip
 	if len(config.UserAgent) > 0 {
 		rt = NewUserAgentRoundTripper(config.UserAgent, rt)
 	}
+	rt = NewRequestPriorityRoundTripper(rt)
 	if len(config.Impersonate.UserName) > 0 ||
 		len(config.Impersonate.UID) > 0 ||
 		len(config.Impersonate.Groups) > 0 ||
@@ -53,6 +53,10 @@ type KubeCloudSharedConfiguration struct {
 	// useServiceAccountCredentials indicates whether controllers should be run with
 	// individual service account credentials.
 	UseServiceAccountCredentials bool
+	// enforceServiceAccountCredentials causes the controller manager to refuse to
+	// start controllers with the shared controller-manager credential; it requires
+	// useServiceAccountCredentials to be enabled.
+	EnforceServiceAccountCredentials bool
 	// run with untagged cloud instances
 	AllowUntaggedCloud bool
 	// routeReconciliationPeriod is the period for reconciling routes created for Nodes by cloud provider..
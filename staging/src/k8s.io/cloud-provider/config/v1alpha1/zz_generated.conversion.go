@@ -126,6 +126,7 @@ func autoConvert_v1alpha1_KubeCloudSharedConfiguration_To_config_KubeCloudShared
 	}
 	out.ExternalCloudVolumePlugin = in.ExternalCloudVolumePlugin
 	out.UseServiceAccountCredentials = in.UseServiceAccountCredentials
+	out.EnforceServiceAccountCredentials = in.EnforceServiceAccountCredentials
 	out.AllowUntaggedCloud = in.AllowUntaggedCloud
 	out.RouteReconciliationPeriod = in.RouteReconciliationPeriod
 	out.NodeMonitorPeriod = in.NodeMonitorPeriod
@@ -146,6 +147,7 @@ func autoConvert_config_KubeCloudSharedConfiguration_To_v1alpha1_KubeCloudShared
 	}
 	out.ExternalCloudVolumePlugin = in.ExternalCloudVolumePlugin
 	out.UseServiceAccountCredentials = in.UseServiceAccountCredentials
+	out.EnforceServiceAccountCredentials = in.EnforceServiceAccountCredentials
 	out.AllowUntaggedCloud = in.AllowUntaggedCloud
 	out.RouteReconciliationPeriod = in.RouteReconciliationPeriod
 	out.NodeMonitorPeriod = in.NodeMonitorPeriod
@@ -17,6 +17,8 @@ limitations under the License.
 package options
 
 import (
+	"fmt"
+
 	"github.com/spf13/pflag"
 	cpconfig "k8s.io/cloud-provider/config"
 )
@@ -51,6 +53,7 @@ func (o *KubeCloudSharedOptions) AddFlags(fs *pflag.FlagSet) {
 	o.CloudProvider.AddFlags(fs)
 	fs.StringVar(&o.ExternalCloudVolumePlugin, "external-cloud-volume-plugin", o.ExternalCloudVolumePlugin, "The plugin to use when cloud provider is set to external. Can be empty, should only be set when cloud-provider is external. Currently used to allow node and volume controllers to work for in tree cloud providers.")
 	fs.BoolVar(&o.UseServiceAccountCredentials, "use-service-account-credentials", o.UseServiceAccountCredentials, "If true, use individual service account credentials for each controller.")
+	fs.BoolVar(&o.EnforceServiceAccountCredentials, "enforce-service-account-credentials", o.EnforceServiceAccountCredentials, "If true, refuse to start controllers with the shared controller-manager credential. Requires --use-service-account-credentials.")
 	fs.BoolVar(&o.AllowUntaggedCloud, "allow-untagged-cloud", false, "Allow the cluster to run without the cluster-id on cloud instances. This is a legacy mode of operation and a cluster-id will be required in the future.")
 	fs.MarkDeprecated("allow-untagged-cloud", "This flag is deprecated and will be removed in a future release. A cluster-id will be required on cloud instances.")
 	fs.DurationVar(&o.RouteReconciliationPeriod.Duration, "route-reconciliation-period", o.RouteReconciliationPeriod.Duration, "The period for reconciling routes created for Nodes by cloud provider.")
@@ -80,6 +83,7 @@ func (o *KubeCloudSharedOptions) ApplyTo(cfg *cpconfig.KubeCloudSharedConfigurat
 
 	cfg.ExternalCloudVolumePlugin = o.ExternalCloudVolumePlugin
 	cfg.UseServiceAccountCredentials = o.UseServiceAccountCredentials
+	cfg.EnforceServiceAccountCredentials = o.EnforceServiceAccountCredentials
 	cfg.AllowUntaggedCloud = o.AllowUntaggedCloud
 	cfg.RouteReconciliationPeriod = o.RouteReconciliationPeriod
 	cfg.NodeMonitorPeriod = o.NodeMonitorPeriod
@@ -101,6 +105,9 @@ func (o *KubeCloudSharedOptions) Validate() []error {
 
 	errs := []error{}
 	errs = append(errs, o.CloudProvider.Validate()...)
+	if o.EnforceServiceAccountCredentials && !o.UseServiceAccountCredentials {
+		errs = append(errs, fmt.Errorf("--enforce-service-account-credentials requires --use-service-account-credentials to be true"))
+	}
 
 	return errs
 }
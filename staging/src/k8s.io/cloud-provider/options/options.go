@@ -186,6 +186,9 @@ func (o *CloudControllerManagerOptions) ApplyTo(c *config.Config, userAgent stri
 			c.Client.CoreV1(),
 			metav1.NamespaceSystem)
 	} else {
+		if c.ComponentConfig.KubeCloudShared.EnforceServiceAccountCredentials {
+			return fmt.Errorf("--enforce-service-account-credentials requires --use-service-account-credentials, refusing to run controllers with the shared cloud-controller-manager credential")
+		}
 		c.ClientBuilder = rootClientBuilder
 	}
 	c.VersionedClient = rootClientBuilder.ClientOrDie("shared-informers")
@@ -0,0 +1,93 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package configstatus publishes the effective (post-default) configuration
+// of a component to a well-known ConfigMap on startup. The live
+// configuration is served by the authenticated read-only /configz endpoint
+// of each instance; the ConfigMap gives configuration management tools a
+// single place to detect drift between the desired and the running
+// configuration without scraping every instance.
+package configstatus
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/component-base/version"
+)
+
+const (
+	// ConfigKey is the ConfigMap key holding the effective configuration in
+	// JSON form.
+	ConfigKey = "config"
+	// ConfigHashKey is the ConfigMap key holding the SHA-256 hash of the
+	// effective configuration, for cheap drift comparison.
+	ConfigHashKey = "configHash"
+	// BinaryVersionKey is the ConfigMap key holding the version of the
+	// binary that published the configuration.
+	BinaryVersionKey = "binaryVersion"
+	// LastUpdatedKey is the ConfigMap key holding the RFC 3339 time of the
+	// last publication.
+	LastUpdatedKey = "lastUpdated"
+)
+
+// ConfigMapName returns the name of the status ConfigMap for the component.
+func ConfigMapName(component string) string {
+	return component + "-config-status"
+}
+
+// Publish writes the effective configuration of the component to its status
+// ConfigMap in the kube-system namespace, creating or updating it as needed.
+func Publish(ctx context.Context, client clientset.Interface, component string, config interface{}) error {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("cannot marshal configuration of %s: %v", component, err)
+	}
+
+	configMap := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ConfigMapName(component),
+			Namespace: metav1.NamespaceSystem,
+		},
+		Data: map[string]string{
+			ConfigKey:        string(data),
+			ConfigHashKey:    fmt.Sprintf("%x", sha256.Sum256(data)),
+			BinaryVersionKey: version.Get().GitVersion,
+			LastUpdatedKey:   time.Now().UTC().Format(time.RFC3339),
+		},
+	}
+
+	_, err = client.CoreV1().ConfigMaps(metav1.NamespaceSystem).Create(ctx, configMap, metav1.CreateOptions{})
+	if apierrors.IsAlreadyExists(err) {
+		existing, getErr := client.CoreV1().ConfigMaps(metav1.NamespaceSystem).Get(ctx, configMap.Name, metav1.GetOptions{})
+		if getErr != nil {
+			return fmt.Errorf("cannot get configuration status of %s: %v", component, getErr)
+		}
+		existing.Data = configMap.Data
+		_, err = client.CoreV1().ConfigMaps(metav1.NamespaceSystem).Update(ctx, existing, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		return fmt.Errorf("cannot publish configuration status of %s: %v", component, err)
+	}
+	return nil
+}
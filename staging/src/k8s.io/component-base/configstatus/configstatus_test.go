@@ -0,0 +1,77 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package configstatus
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+type testConfig struct {
+	Workers int `json:"workers"`
+}
+
+func TestPublishCreatesConfigMap(t *testing.T) {
+	client := fake.NewSimpleClientset()
+
+	if err := Publish(context.TODO(), client, "test-component", testConfig{Workers: 5}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	configMap, err := client.CoreV1().ConfigMaps(metav1.NamespaceSystem).Get(context.TODO(), ConfigMapName("test-component"), metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected the status ConfigMap to exist: %v", err)
+	}
+	if configMap.Data[ConfigKey] != `{"workers":5}` {
+		t.Errorf("unexpected config: %q", configMap.Data[ConfigKey])
+	}
+	if configMap.Data[ConfigHashKey] == "" {
+		t.Error("expected a config hash")
+	}
+	if configMap.Data[LastUpdatedKey] == "" {
+		t.Error("expected a last updated timestamp")
+	}
+}
+
+func TestPublishUpdatesExistingConfigMap(t *testing.T) {
+	client := fake.NewSimpleClientset()
+
+	if err := Publish(context.TODO(), client, "test-component", testConfig{Workers: 5}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	firstConfigMap, err := client.CoreV1().ConfigMaps(metav1.NamespaceSystem).Get(context.TODO(), ConfigMapName("test-component"), metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Publish(context.TODO(), client, "test-component", testConfig{Workers: 10}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	configMap, err := client.CoreV1().ConfigMaps(metav1.NamespaceSystem).Get(context.TODO(), ConfigMapName("test-component"), metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if configMap.Data[ConfigKey] != `{"workers":10}` {
+		t.Errorf("unexpected config: %q", configMap.Data[ConfigKey])
+	}
+	if configMap.Data[ConfigHashKey] == firstConfigMap.Data[ConfigHashKey] {
+		t.Error("expected the config hash to change with the config")
+	}
+}
@@ -31,6 +31,7 @@ type Options struct {
 	ShowHiddenMetricsForVersion string
 	DisabledMetrics             []string
 	AllowListMapping            map[string]string
+	DebugMetricsFile            string
 }
 
 // NewOptions returns default metrics options
@@ -73,6 +74,12 @@ func (o *Options) AddFlags(fs *pflag.FlagSet) {
 		"This flag provides an escape hatch for misbehaving metrics. "+
 			"You must provide the fully qualified metric name in order to disable it. "+
 			"Disclaimer: disabling metrics is higher in precedence than showing hidden metrics.")
+	fs.StringVar(&o.DebugMetricsFile,
+		"debug-metrics-file",
+		o.DebugMetricsFile,
+		"Path to a file listing fully qualified metric names, one per line, that should be "+
+			"enabled even if they were disabled with --disabled-metrics. "+
+			"The file is re-read on SIGHUP, so debugging metrics can be enabled without a restart.")
 	fs.StringToStringVar(&o.AllowListMapping, "allow-metric-labels", o.AllowListMapping,
 		"The map from metric-label to value allow-list of this label. The key's format is <MetricName>,<LabelName>. "+
 			"The value's format is <allowed_value>,<allowed_value>..."+
@@ -94,6 +101,9 @@ func (o *Options) Apply() {
 	if o.AllowListMapping != nil {
 		SetLabelAllowListFromCLI(o.AllowListMapping)
 	}
+	if len(o.DebugMetricsFile) > 0 {
+		EnableDebugMetricsFromFile(o.DebugMetricsFile)
+	}
 }
 
 func validateShowHiddenMetricsVersion(currentVersion semver.Version, targetVersionStr string) error {
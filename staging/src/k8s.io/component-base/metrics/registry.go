@@ -69,6 +69,25 @@ func SetDisabledMetric(name string) {
 	disabledMetrics[name] = struct{}{}
 }
 
+// SetEnabledMetric removes the metric from the disabled-metrics list and
+// re-registers the collectors that were hidden because of it, so a metric
+// disabled at startup can be turned back on at runtime for debugging.
+func SetEnabledMetric(name string) {
+	disabledMetricsLock.Lock()
+	_, wasDisabled := disabledMetrics[name]
+	delete(disabledMetrics, name)
+	disabledMetricsLock.Unlock()
+	if !wasDisabled {
+		return
+	}
+
+	registriesLock.RLock()
+	defer registriesLock.RUnlock()
+	for _, r := range registries {
+		r.enableHiddenCollector(name)
+	}
+}
+
 // SetShowHidden will enable showing hidden metrics. This will no-opt
 // after the initial call
 func SetShowHidden() {
@@ -279,6 +298,22 @@ func (kr *kubeRegistry) enableHiddenCollectors() {
 	kr.MustRegister(cs...)
 }
 
+// enableHiddenCollector re-registers the hidden collector with the given
+// fully qualified name, if the registry tracks one.
+func (kr *kubeRegistry) enableHiddenCollector(fqName string) {
+	kr.hiddenCollectorsLock.Lock()
+	c, ok := kr.hiddenCollectors[fqName]
+	if ok {
+		delete(kr.hiddenCollectors, fqName)
+		c.ClearState()
+	}
+	kr.hiddenCollectorsLock.Unlock()
+
+	if ok {
+		kr.MustRegister(c)
+	}
+}
+
 // enableHiddenStableCollectors will re-register the stable collectors if there is one or more hidden metrics in it.
 // Since we can not register a metrics twice, so we have to unregister first then register again.
 func (kr *kubeRegistry) enableHiddenStableCollectors() {
@@ -0,0 +1,83 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"k8s.io/klog/v2"
+)
+
+// EnableDebugMetricsFromFile enables the metrics listed in the given file and
+// re-reads the file on every SIGHUP the process receives, so metrics that
+// were disabled at startup can be turned on for debugging without a restart.
+// A missing file is not an error; it simply enables nothing.
+func EnableDebugMetricsFromFile(path string) {
+	if err := applyDebugMetricsFile(path); err != nil {
+		klog.Errorf("Failed to apply debug metrics file %q: %v", path, err)
+	}
+
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, syscall.SIGHUP)
+	go func() {
+		for range ch {
+			if err := applyDebugMetricsFile(path); err != nil {
+				klog.Errorf("Failed to apply debug metrics file %q: %v", path, err)
+				continue
+			}
+			klog.V(2).Infof("Reloaded debug metrics file %q", path)
+		}
+	}()
+}
+
+// applyDebugMetricsFile enables every metric listed in the file.
+func applyDebugMetricsFile(path string) error {
+	names, err := readDebugMetricsFile(path)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		SetEnabledMetric(name)
+	}
+	return nil
+}
+
+// readDebugMetricsFile parses a debug metrics file: one fully qualified
+// metric name per line, blank lines and '#' comments ignored. A missing file
+// yields an empty list.
+func readDebugMetricsFile(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read debug metrics file: %v", err)
+	}
+	var names []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		names = append(names, line)
+	}
+	return names, nil
+}
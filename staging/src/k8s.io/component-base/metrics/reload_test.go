@@ -0,0 +1,90 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	apimachineryversion "k8s.io/apimachinery/pkg/version"
+	"k8s.io/component-base/metrics/testutil"
+)
+
+func TestSetEnabledMetric(t *testing.T) {
+	SetDisabledMetric("should_be_toggled")
+	currentVersion := apimachineryversion.Info{
+		Major:      "1",
+		Minor:      "17",
+		GitVersion: "v1.17.1-alpha-1.12345",
+	}
+	registry := newKubeRegistry(currentVersion)
+	toggledMetric := NewCounter(&CounterOpts{
+		Name: "should_be_toggled",
+		Help: "this metric starts out disabled",
+	})
+
+	registry.MustRegister(toggledMetric)
+	toggledMetric.Inc()
+
+	if err := testutil.GatherAndCompare(registry, strings.NewReader(""), "should_be_toggled"); err != nil {
+		t.Fatal(err)
+	}
+
+	SetEnabledMetric("should_be_toggled")
+	toggledMetric.Inc()
+
+	expectedOutput := `
+        # HELP should_be_toggled [ALPHA] this metric starts out disabled
+        # TYPE should_be_toggled counter
+        should_be_toggled 1
+`
+	if err := testutil.GatherAndCompare(registry, strings.NewReader(expectedOutput), "should_be_toggled"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReadDebugMetricsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "debug-metrics")
+	content := `
+# debugging metrics
+first_metric
+
+  second_metric
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	names, err := readDebugMetricsFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := []string{"first_metric", "second_metric"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected %v, got %v", expected, names)
+	}
+
+	names, err = readDebugMetricsFile(filepath.Join(t.TempDir(), "does-not-exist"))
+	if err != nil {
+		t.Fatalf("unexpected error for missing file: %v", err)
+	}
+	if names != nil {
+		t.Errorf("expected no names for missing file, got %v", names)
+	}
+}
@@ -63,6 +63,12 @@ type controller struct {
 	// latestLease is the latest lease which the controller updated or created
 	latestLease *coordinationv1.Lease
 
+	// overloadSkips is the number of upcoming sync intervals to skip because
+	// the apiserver signaled overload (429/Retry-After) on the last renewal
+	// attempt. Skipping intervals stretches the effective renewal interval
+	// without abandoning the lease.
+	overloadSkips int
+
 	// newLeasePostProcessFunc allows customizing a lease object (e.g. setting OwnerReference)
 	// before every time the lease is created/refreshed(updated). Note that an error will block
 	// a lease CREATE, causing the controller to retry next time, but an error won't block a
@@ -99,6 +105,11 @@ func (c *controller) Run(stopCh <-chan struct{}) {
 }
 
 func (c *controller) sync() {
+	if c.overloadSkips > 0 {
+		c.overloadSkips--
+		klog.V(2).Infof("apiserver signaled overload, skipping lease renewal, %d skipped intervals remaining", c.overloadSkips)
+		return
+	}
 	if c.latestLease != nil {
 		// As long as the lease is not (or very rarely) updated by any other agent than the component itself,
 		// we can optimistically assume it didn't change since our last update and try updating
@@ -185,6 +196,12 @@ func (c *controller) retryUpdateLease(base *coordinationv1.Lease) error {
 			return nil
 		}
 		klog.Errorf("failed to update lease, error: %v", err)
+		// The apiserver asked us to back off; retrying immediately would only
+		// add to its load, so defer renewal to a later interval instead.
+		if apierrors.IsTooManyRequests(err) {
+			c.registerOverload(err)
+			return fmt.Errorf("apiserver overloaded, deferring lease renewal: %v", err)
+		}
 		// OptimisticLockError requires getting the newer version of lease to proceed.
 		if apierrors.IsConflict(err) {
 			base, _ = c.backoffEnsureLease()
@@ -197,6 +214,29 @@ func (c *controller) retryUpdateLease(base *coordinationv1.Lease) error {
 	return fmt.Errorf("failed %d attempts to update lease", maxUpdateRetries)
 }
 
+// registerOverload stretches the effective renewal interval in response to an
+// overload signal from the apiserver, honoring a server-provided Retry-After
+// when present. The effective interval is kept within half the lease duration
+// so that consumers of the lease (e.g. the node lifecycle controller) still
+// observe a renewal well before the lease expires.
+func (c *controller) registerOverload(err error) {
+	if c.renewInterval <= 0 {
+		return
+	}
+	skips := 1
+	if seconds, ok := apierrors.SuggestsClientDelay(err); ok {
+		skips = int(time.Duration(seconds) * time.Second / c.renewInterval)
+	}
+	maxSkips := int((time.Duration(c.leaseDurationSeconds)*time.Second/2)/c.renewInterval) - 1
+	if skips > maxSkips {
+		skips = maxSkips
+	}
+	if skips < 0 {
+		skips = 0
+	}
+	c.overloadSkips = skips
+}
+
 // newLease constructs a new lease if base is nil, or returns a copy of base
 // with desired state asserted on the copy.
 // Note that an error will block lease CREATE, causing the CREATE to be retried in
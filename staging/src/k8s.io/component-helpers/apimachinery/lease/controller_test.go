@@ -465,3 +465,58 @@ func setNodeOwnerFunc(c clientset.Interface, nodeName string) func(lease *coordi
 		return nil
 	}
 }
+
+func TestRegisterOverload(t *testing.T) {
+	newTooManyRequests := func(retryAfterSeconds int) error {
+		err := apierrors.NewTooManyRequests("slow down", retryAfterSeconds)
+		return err
+	}
+	cases := []struct {
+		desc                 string
+		renewInterval        time.Duration
+		leaseDurationSeconds int32
+		err                  error
+		expectSkips          int
+	}{
+		{
+			desc:                 "no retry-after stretches by one interval",
+			renewInterval:        10 * time.Second,
+			leaseDurationSeconds: 40,
+			err:                  apierrors.NewTooManyRequestsError("slow down"),
+			expectSkips:          1,
+		},
+		{
+			desc:                 "retry-after within the lease duration is honored",
+			renewInterval:        2 * time.Second,
+			leaseDurationSeconds: 40,
+			err:                  newTooManyRequests(10),
+			expectSkips:          5,
+		},
+		{
+			desc:                 "retry-after is capped at half the lease duration",
+			renewInterval:        10 * time.Second,
+			leaseDurationSeconds: 40,
+			err:                  newTooManyRequests(120),
+			expectSkips:          1,
+		},
+		{
+			desc:                 "renewal close to the lease duration is never stretched",
+			renewInterval:        30 * time.Second,
+			leaseDurationSeconds: 40,
+			err:                  newTooManyRequests(120),
+			expectSkips:          0,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			c := &controller{
+				renewInterval:        tc.renewInterval,
+				leaseDurationSeconds: tc.leaseDurationSeconds,
+			}
+			c.registerOverload(tc.err)
+			if c.overloadSkips != tc.expectSkips {
+				t.Errorf("expected %d skipped intervals, got %d", tc.expectSkips, c.overloadSkips)
+			}
+		})
+	}
+}
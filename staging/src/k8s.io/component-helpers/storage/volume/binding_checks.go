@@ -0,0 +1,122 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package volume
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// Criteria a volume must satisfy to bind to a claim, in the order they are
+// evaluated by CheckVolumeBinding.
+const (
+	BindingCriterionClaimRef     = "claimRef"
+	BindingCriterionPhase        = "phase"
+	BindingCriterionStorageClass = "storageClass"
+	BindingCriterionVolumeMode   = "volumeMode"
+	BindingCriterionAccessModes  = "accessModes"
+	BindingCriterionCapacity     = "capacity"
+	BindingCriterionSelector     = "selector"
+	BindingCriterionNodeAffinity = "nodeAffinity"
+)
+
+// BindingCheck is the outcome of evaluating one binding criterion for a
+// claim/volume pair.
+type BindingCheck struct {
+	// Criterion is one of the BindingCriterion constants.
+	Criterion string
+	// Passed is whether the volume satisfies the criterion.
+	Passed bool
+	// Reason says why the criterion failed; empty when Passed is true.
+	Reason string
+}
+
+// CheckVolumeBinding evaluates every binding criterion of the given volume
+// against the claim and returns one result per criterion, so diagnostics can
+// show exactly which requirements a volume misses instead of only the first
+// one. The criteria mirror FindMatchingVolume. When node is nil, node
+// affinity is not evaluated.
+func CheckVolumeBinding(claim *v1.PersistentVolumeClaim, volume *v1.PersistentVolume, node *v1.Node) []BindingCheck {
+	checks := []BindingCheck{}
+	addCheck := func(criterion, reason string) {
+		checks = append(checks, BindingCheck{Criterion: criterion, Passed: reason == "", Reason: reason})
+	}
+
+	reason := ""
+	if volume.Spec.ClaimRef != nil && !IsVolumeBoundToClaim(volume, claim) {
+		reason = fmt.Sprintf("bound to another claim %q", volume.Spec.ClaimRef.Namespace+"/"+volume.Spec.ClaimRef.Name)
+	}
+	addCheck(BindingCriterionClaimRef, reason)
+
+	reason = ""
+	if volume.Spec.ClaimRef == nil && volume.Status.Phase != v1.VolumeAvailable {
+		reason = fmt.Sprintf("volume is %s", volume.Status.Phase)
+	}
+	addCheck(BindingCriterionPhase, reason)
+
+	reason = ""
+	volumeClass := GetPersistentVolumeClass(volume)
+	claimClass := GetPersistentVolumeClaimClass(claim)
+	if volumeClass != claimClass {
+		reason = fmt.Sprintf("storage class %q does not match the requested %q", volumeClass, claimClass)
+	}
+	addCheck(BindingCriterionStorageClass, reason)
+
+	reason = ""
+	if CheckVolumeModeMismatches(&claim.Spec, &volume.Spec) {
+		reason = "volume mode does not match the requested mode"
+	}
+	addCheck(BindingCriterionVolumeMode, reason)
+
+	reason = ""
+	if !CheckAccessModes(claim, volume) {
+		reason = "volume does not support the requested access modes"
+	}
+	addCheck(BindingCriterionAccessModes, reason)
+
+	reason = ""
+	volumeSize := volume.Spec.Capacity[v1.ResourceStorage]
+	requestedSize := claim.Spec.Resources.Requests[v1.ResourceStorage]
+	if volumeSize.Cmp(requestedSize) < 0 {
+		reason = fmt.Sprintf("capacity %s is smaller than the requested %s", volumeSize.String(), requestedSize.String())
+	}
+	addCheck(BindingCriterionCapacity, reason)
+
+	reason = ""
+	if claim.Spec.Selector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(claim.Spec.Selector)
+		if err != nil {
+			reason = fmt.Sprintf("claim selector is invalid: %v", err)
+		} else if !selector.Matches(labels.Set(volume.Labels)) {
+			reason = "volume labels do not match the claim selector"
+		}
+	}
+	addCheck(BindingCriterionSelector, reason)
+
+	if node != nil {
+		reason = ""
+		if err := CheckNodeAffinity(volume, node.Labels); err != nil {
+			reason = fmt.Sprintf("volume node affinity does not match the node: %v", err)
+		}
+		addCheck(BindingCriterionNodeAffinity, reason)
+	}
+
+	return checks
+}
@@ -49,6 +49,13 @@ const (
 	// be dynamically provisioned. Its value is the name of the selected node.
 	AnnSelectedNode = "volume.kubernetes.io/selected-node"
 
+	// AnnBindRollback annotation is added by the scheduler to a not yet bound
+	// PVC whose pod-wide volume binding transaction failed. The PV controller
+	// reacts by removing both this annotation and AnnSelectedNode, returning
+	// the claim to waiting for its next consumer. Value of this annotation
+	// does not matter.
+	AnnBindRollback = "volume.kubernetes.io/bind-rollback"
+
 	// NotSupportedProvisioner is a special provisioner name which can be set
 	// in storage class to indicate dynamic provisioning is not supported by
 	// the storage.
@@ -17,6 +17,8 @@ limitations under the License.
 package clientbuilder
 
 import (
+	"net/http"
+
 	"k8s.io/client-go/discovery"
 	clientset "k8s.io/client-go/kubernetes"
 	restclient "k8s.io/client-go/rest"
@@ -44,7 +46,11 @@ type SimpleControllerClientBuilder struct {
 // Config returns a client config for a fixed client
 func (b SimpleControllerClientBuilder) Config(name string) (*restclient.Config, error) {
 	clientConfig := *b.ClientConfig
-	return restclient.AddUserAgent(&clientConfig, name), nil
+	restclient.AddUserAgent(&clientConfig, name)
+	clientConfig.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return wrapWithUsageMetrics(name, rt)
+	})
+	return &clientConfig, nil
 }
 
 // ConfigOrDie returns a client config if no error from previous config func.
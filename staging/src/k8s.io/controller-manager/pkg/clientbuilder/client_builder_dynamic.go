@@ -129,6 +129,10 @@ func (t *DynamicControllerClientBuilder) Config(saName string) (*restclient.Conf
 		t.roundTripperFuncMap[saName] = configCopy.WrapTransport
 	}
 
+	configCopy.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return wrapWithUsageMetrics(saName, rt)
+	})
+
 	return &configCopy, nil
 }
 
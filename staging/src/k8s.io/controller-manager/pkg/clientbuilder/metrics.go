@@ -0,0 +1,70 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clientbuilder
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+
+	"k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/legacyregistry"
+)
+
+// requestsTotal counts API requests per controller identity, so operators can
+// see which controller is responsible for which API load and verify that
+// per-controller credentials are in use.
+var requestsTotal = metrics.NewCounterVec(
+	&metrics.CounterOpts{
+		Name:           "controller_manager_client_requests_total",
+		Help:           "Number of API requests issued through per-controller clients, partitioned by controller identity and HTTP response code.",
+		StabilityLevel: metrics.ALPHA,
+	},
+	[]string{"controller", "code"},
+)
+
+var registerMetricsOnce sync.Once
+
+func registerMetrics() {
+	registerMetricsOnce.Do(func() {
+		legacyregistry.MustRegister(requestsTotal)
+	})
+}
+
+// countingRoundTripper increments requestsTotal for every response received
+// through a controller's client.
+type countingRoundTripper struct {
+	controller string
+	delegate   http.RoundTripper
+}
+
+func (c *countingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := c.delegate.RoundTrip(req)
+	code := "<error>"
+	if resp != nil {
+		code = strconv.Itoa(resp.StatusCode)
+	}
+	requestsTotal.WithLabelValues(c.controller, code).Inc()
+	return resp, err
+}
+
+// wrapWithUsageMetrics decorates rt so requests are attributed to the named
+// controller in controller_manager_client_requests_total.
+func wrapWithUsageMetrics(controller string, rt http.RoundTripper) http.RoundTripper {
+	registerMetrics()
+	return &countingRoundTripper{controller: controller, delegate: rt}
+}
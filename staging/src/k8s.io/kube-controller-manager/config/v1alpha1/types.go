@@ -176,6 +176,10 @@ type AttachDetachControllerConfiguration struct {
 	// ReconcilerSyncLoopPeriod is the amount of time the reconciler sync states loop
 	// wait between successive executions. Is set to 5 sec by default.
 	ReconcilerSyncLoopPeriod metav1.Duration
+	// MaximumParallelDetachesPerNode is the maximum number of detach operations
+	// that may run in parallel against a single node. Is 0 by default, which
+	// falls back to in-tree per volume plugin defaults.
+	MaximumParallelDetachesPerNode int32
 }
 
 // CSRSigningControllerConfiguration contains elements describing CSRSigningController.
@@ -250,6 +254,12 @@ type EndpointControllerConfiguration struct {
 	// Processing of pod changes will be delayed by this duration to join them with potential
 	// upcoming updates and reduce the overall number of endpoints updates.
 	EndpointUpdatesBatchPeriod metav1.Duration
+
+	// EndpointSliceOnlyServiceSize is the number of endpoints above which the
+	// controller stops maintaining the legacy Endpoints object of a service,
+	// leaving EndpointSlices as its only endpoint representation. A value of
+	// 0 means that Endpoints objects are maintained for all services.
+	EndpointSliceOnlyServiceSize int32
 }
 
 // EndpointSliceControllerConfiguration contains elements describing
@@ -402,6 +412,16 @@ type NodeLifecycleControllerConfiguration struct {
 	// Zone is treated as unhealthy in nodeEvictionRate and secondaryNodeEvictionRate when at least
 	// unhealthyZoneThreshold (no less than 3) of Nodes in the zone are NotReady
 	UnhealthyZoneThreshold float32
+	// nodeOutOfServiceFencingWebhookURL is the URL of a fencing webhook that
+	// confirms a NotReady node is powered off. When set, the out-of-service
+	// taint controller is started; when empty the controller stays disabled.
+	NodeOutOfServiceFencingWebhookURL string
+	// nodeOutOfServiceNotReadyThreshold is how long a node must be NotReady
+	// before the out-of-service taint controller consults the fencing webhook.
+	NodeOutOfServiceNotReadyThreshold metav1.Duration
+	// nodeOutOfServiceFencingTimeout is the per-request timeout for calls to
+	// the fencing webhook.
+	NodeOutOfServiceFencingTimeout metav1.Duration
 }
 
 // PersistentVolumeBinderControllerConfiguration contains elements describing
@@ -410,6 +430,59 @@ type PersistentVolumeBinderControllerConfiguration struct {
 	// pvClaimBinderSyncPeriod is the period for syncing persistent volumes
 	// and persistent volume claims.
 	PVClaimBinderSyncPeriod metav1.Duration
+	// pvBinderWorkers is the number of workers binding persistent volume
+	// claims concurrently. Claims are sharded across workers, so each claim
+	// is always handled by the same worker. Values below 1 are treated as 1.
+	PVBinderWorkers int32
+	// pvProvisionRetryBackoffBase is the initial delay between retries of
+	// failed dynamic provisioning of a claim. Retries back off exponentially
+	// with jitter up to pvProvisionRetryBackoffMax. Zero disables the
+	// backoff and retries provisioning on every sync.
+	PVProvisionRetryBackoffBase metav1.Duration
+	// pvProvisionRetryBackoffMax is the upper bound of the provisioning
+	// retry backoff.
+	PVProvisionRetryBackoffMax metav1.Duration
+	// pvProvisionMaxRetries is the number of provisioning attempts per claim
+	// before the claim is marked with a terminal ProvisioningFailed
+	// condition. Zero means no limit. Only effective with the backoff
+	// enabled.
+	PVProvisionMaxRetries int32
+	// pvWipeJobTemplateFile is the path of a batch/v1 Job manifest whose
+	// spec is used as the template for wipe jobs that replace the legacy
+	// recycler pods for volumes with the Recycle reclaim policy. Empty
+	// keeps the legacy recycler pods.
+	PVWipeJobTemplateFile string
+	// pvCoalesceStatusUpdates makes the controller batch PV and PVC status
+	// updates through a write-coalescing layer that deduplicates redundant
+	// updates, reducing the API server write load in large clusters.
+	PVCoalesceStatusUpdates bool
+	// pvShardCount splits the volume and claim key space into this many
+	// shards and makes this replica sync only the shards it owns,
+	// coordinated through Lease objects, so the PV controller can run
+	// active in several manager replicas at once. It must be identical in
+	// all replicas. Zero keeps the classic single-active behavior.
+	PVShardCount int32
+	// pvShardIdentity uniquely names this replica in the shard leases,
+	// e.g. the pod name. Empty defaults to the hostname.
+	PVShardIdentity string
+	// pvStorageSummaryInterval is the interval at which the controller
+	// publishes its storage summary to the pv-storage-summary ConfigMap in
+	// kube-system, so dashboards can watch one object instead of listing
+	// all volumes. Zero disables publishing.
+	PVStorageSummaryInterval metav1.Duration
+	// pvBindApprovalWebhookConfigFile is the path of a kubeconfig file
+	// describing an external bind approval webhook consulted before binds
+	// between claims and candidate volumes are finalized. Empty disables
+	// the check.
+	PVBindApprovalWebhookConfigFile string
+	// pvBindApprovalFailOpen allows binds to proceed when the bind
+	// approval webhook cannot be reached; false blocks them until the
+	// webhook recovers.
+	PVBindApprovalFailOpen bool
+	// pvBindApprovalCacheTTL bounds how long a webhook decision for a
+	// particular claim/volume pair is reused without consulting the
+	// webhook again. Zero disables caching.
+	PVBindApprovalCacheTTL metav1.Duration
 	// volumeConfiguration holds configuration for volume related features.
 	VolumeConfiguration VolumeConfiguration
 	// VolumeHostCIDRDenylist is a list of CIDRs that should not be reachable by the
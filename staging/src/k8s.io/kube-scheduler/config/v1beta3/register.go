@@ -45,6 +45,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&PodTopologySpreadArgs{},
 		&VolumeBindingArgs{},
 		&NodeAffinityArgs{},
+		&PrioritySortArgs{},
+		&NodeCSIOverheadArgs{},
 	)
 	return nil
 }
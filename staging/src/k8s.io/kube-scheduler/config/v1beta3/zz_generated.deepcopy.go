@@ -23,6 +23,7 @@ package v1beta3
 
 import (
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -290,6 +291,38 @@ func (in *NodeAffinityArgs) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeCSIOverheadArgs) DeepCopyInto(out *NodeCSIOverheadArgs) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.PerDriverReserved != nil {
+		in, out := &in.PerDriverReserved, &out.PerDriverReserved
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeCSIOverheadArgs.
+func (in *NodeCSIOverheadArgs) DeepCopy() *NodeCSIOverheadArgs {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeCSIOverheadArgs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NodeCSIOverheadArgs) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeResourcesBalancedAllocationArgs) DeepCopyInto(out *NodeResourcesBalancedAllocationArgs) {
 	*out = *in
@@ -488,6 +521,41 @@ func (in *PodTopologySpreadArgs) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrioritySortArgs) DeepCopyInto(out *PrioritySortArgs) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.NonPreemptingAgingInterval != nil {
+		in, out := &in.NonPreemptingAgingInterval, &out.NonPreemptingAgingInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.NonPreemptingAgingMaxBoost != nil {
+		in, out := &in.NonPreemptingAgingMaxBoost, &out.NonPreemptingAgingMaxBoost
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrioritySortArgs.
+func (in *PrioritySortArgs) DeepCopy() *PrioritySortArgs {
+	if in == nil {
+		return nil
+	}
+	out := new(PrioritySortArgs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PrioritySortArgs) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RequestedToCapacityRatioParam) DeepCopyInto(out *RequestedToCapacityRatioParam) {
 	*out = *in
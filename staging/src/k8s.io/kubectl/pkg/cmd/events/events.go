@@ -29,7 +29,6 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/duration"
@@ -65,7 +64,11 @@ var (
 	kubectl alpha events --all-namespaces
 
 	# List recent events for the specified pod, then wait for more events and list them as they arrive.
-	kubectl alpha events --for pod/web-pod-13je7 --watch`))
+	kubectl alpha events --for pod/web-pod-13je7 --watch
+
+	# List recent events for the specified pod together with events for its persistent volume
+	# claims, their bound volumes and volume attachments, merged into one time-sorted view.
+	kubectl alpha events --for pod/web-pod-13je7 --related`))
 )
 
 // EventsFlags directly reflect the information that CLI is gathering via flags.  They will be converted to Options, which
@@ -77,6 +80,7 @@ type EventsFlags struct {
 	AllNamespaces bool
 	Watch         bool
 	ForObject     string
+	Related       bool
 	ChunkSize     int64
 	genericclioptions.IOStreams
 }
@@ -96,6 +100,7 @@ type EventsOptions struct {
 	Namespace     string
 	AllNamespaces bool
 	Watch         bool
+	Related       bool
 
 	forGVK  schema.GroupVersionKind
 	forName string
@@ -131,6 +136,7 @@ func (o *EventsFlags) AddFlags(cmd *cobra.Command) {
 	cmd.Flags().BoolVarP(&o.Watch, "watch", "w", o.Watch, "After listing the requested events, watch for more events.")
 	cmd.Flags().BoolVarP(&o.AllNamespaces, "all-namespaces", "A", o.AllNamespaces, "If present, list the requested object(s) across all namespaces. Namespace in current context is ignored even if specified with --namespace.")
 	cmd.Flags().StringVar(&o.ForObject, "for", o.ForObject, "Filter events to only those pertaining to the specified resource.")
+	cmd.Flags().BoolVar(&o.Related, "related", o.Related, "Include events for objects referenced by the resource specified with --for, e.g. a pod's persistent volume claims, their bound volumes and volume attachments.")
 	cmdutil.AddChunkSizeFlag(cmd, &o.ChunkSize)
 }
 
@@ -140,8 +146,12 @@ func (flags *EventsFlags) ToOptions(ctx context.Context, args []string) (*Events
 		ctx:           ctx,
 		AllNamespaces: flags.AllNamespaces,
 		Watch:         flags.Watch,
+		Related:       flags.Related,
 		IOStreams:     flags.IOStreams,
 	}
+	if flags.Related && flags.ForObject == "" {
+		return nil, fmt.Errorf("--related requires --for")
+	}
 	var err error
 	o.Namespace, _, err = flags.RESTClientGetter.ToRawKubeConfigLoader().Namespace()
 	if err != nil {
@@ -181,30 +191,33 @@ func (o EventsOptions) Run() error {
 	if o.AllNamespaces {
 		namespace = ""
 	}
-	listOptions := metav1.ListOptions{Limit: cmdutil.DefaultChunkSize}
+	targets := []objectRef{}
 	if o.forName != "" {
-		listOptions.FieldSelector = fields.AndSelectors(
-			fields.OneTermEqualSelector("involvedObject.kind", o.forGVK.Kind),
-			fields.OneTermEqualSelector("involvedObject.name", o.forName)).String()
+		targets = append(targets, objectRef{Kind: o.forGVK.Kind, Name: o.forName})
+		if o.Related {
+			targets = append(targets, relatedObjectRefs(o.ctx, o.client, o.Namespace, targets[0])...)
+		}
 	}
 	if o.Watch {
-		return o.runWatch(namespace, listOptions)
+		return o.runWatch(namespace, targets)
 	}
 
-	e := o.client.CoreV1().Events(namespace)
 	el := &corev1.EventList{}
-	err := runtimeresource.FollowContinue(&listOptions,
-		func(options metav1.ListOptions) (runtime.Object, error) {
-			newEvents, err := e.List(o.ctx, options)
-			if err != nil {
-				return nil, runtimeresource.EnhanceListError(err, options, "events")
-			}
-			el.Items = append(el.Items, newEvents.Items...)
-			return newEvents, nil
-		})
-
-	if err != nil {
-		return err
+	for _, query := range targetEventQueries(targets, namespace) {
+		e := o.client.CoreV1().Events(query.namespace)
+		listOptions := query.listOptions
+		err := runtimeresource.FollowContinue(&listOptions,
+			func(options metav1.ListOptions) (runtime.Object, error) {
+				newEvents, err := e.List(o.ctx, options)
+				if err != nil {
+					return nil, runtimeresource.EnhanceListError(err, options, "events")
+				}
+				el.Items = append(el.Items, newEvents.Items...)
+				return newEvents, nil
+			})
+		if err != nil {
+			return err
+		}
 	}
 
 	if len(el.Items) == 0 {
@@ -228,7 +241,29 @@ func (o EventsOptions) Run() error {
 	return nil
 }
 
-func (o EventsOptions) runWatch(namespace string, listOptions metav1.ListOptions) error {
+func (o EventsOptions) runWatch(namespace string, targets []objectRef) error {
+	// A single target can be watched server-side through a field selector.
+	// Multiple targets need the unfiltered event stream, filtered client-side,
+	// because event field selectors can only match one involved object. When
+	// the targets' events span namespaces (cluster-scoped objects record
+	// events in "default"), the watch must span them too.
+	listOptions := metav1.ListOptions{}
+	queries := targetEventQueries(targets, namespace)
+	if len(targets) == 1 {
+		listOptions = queries[0].listOptions
+		namespace = queries[0].namespace
+	} else {
+		for _, query := range queries {
+			if query.namespace != namespace {
+				namespace = metav1.NamespaceAll
+				break
+			}
+		}
+	}
+	targetSet := map[objectRef]bool{}
+	for _, target := range targets {
+		targetSet[target] = true
+	}
 	eventWatch, err := o.client.CoreV1().Events(namespace).Watch(o.ctx, listOptions)
 	if err != nil {
 		return err
@@ -245,6 +280,9 @@ func (o EventsOptions) runWatch(namespace string, listOptions metav1.ListOptions
 				return false, nil
 			}
 			event := e.Object.(*corev1.Event)
+			if len(targets) > 1 && !targetSet[objectRef{Kind: event.InvolvedObject.Kind, Name: event.InvolvedObject.Name}] {
+				return false, nil
+			}
 			if !headingsPrinted {
 				printHeadings(w, o.AllNamespaces)
 				headingsPrinted = true
@@ -0,0 +1,144 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+)
+
+// objectRef identifies a single object whose events should be displayed.
+// Only kind and name are tracked because the core v1 events field selectors
+// match on involvedObject.kind and involvedObject.name.
+type objectRef struct {
+	Kind string
+	Name string
+}
+
+// relatedObjectRefs walks references from the object identified by ref and
+// returns the transitive set of objects whose events are relevant when
+// displaying events "for" ref. The walk is intentionally shallow and
+// best-effort: missing or forbidden objects are skipped so that events for
+// the objects we could resolve are still shown.
+//
+// Currently resolved chains:
+//
+//	Pod -> PersistentVolumeClaim -> PersistentVolume -> VolumeAttachment
+//	PersistentVolumeClaim -> PersistentVolume -> VolumeAttachment
+//	PersistentVolume -> VolumeAttachment
+func relatedObjectRefs(ctx context.Context, client kubernetes.Interface, namespace string, ref objectRef) []objectRef {
+	refs := []objectRef{}
+	switch ref.Kind {
+	case "Pod":
+		pod, err := client.CoreV1().Pods(namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			break
+		}
+		for _, volume := range pod.Spec.Volumes {
+			if volume.PersistentVolumeClaim == nil {
+				continue
+			}
+			claimRef := objectRef{Kind: "PersistentVolumeClaim", Name: volume.PersistentVolumeClaim.ClaimName}
+			refs = append(refs, claimRef)
+			refs = append(refs, relatedObjectRefs(ctx, client, namespace, claimRef)...)
+		}
+	case "PersistentVolumeClaim":
+		pvc, err := client.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil || pvc.Spec.VolumeName == "" {
+			break
+		}
+		pvRef := objectRef{Kind: "PersistentVolume", Name: pvc.Spec.VolumeName}
+		refs = append(refs, pvRef)
+		refs = append(refs, relatedObjectRefs(ctx, client, namespace, pvRef)...)
+	case "PersistentVolume":
+		attachments, err := client.StorageV1().VolumeAttachments().List(ctx, metav1.ListOptions{})
+		if err != nil && !apierrors.IsForbidden(err) {
+			break
+		}
+		if attachments != nil {
+			for _, attachment := range attachments.Items {
+				if attachment.Spec.Source.PersistentVolumeName != nil && *attachment.Spec.Source.PersistentVolumeName == ref.Name {
+					refs = append(refs, objectRef{Kind: "VolumeAttachment", Name: attachment.Name})
+				}
+			}
+		}
+	}
+	return dedupeObjectRefs(refs)
+}
+
+// eventQuery pairs the list options selecting one target's events with the
+// namespace those events are recorded in.
+type eventQuery struct {
+	namespace   string
+	listOptions metav1.ListOptions
+}
+
+// eventNamespace returns the namespace in which events for target are
+// recorded. Events for cluster-scoped objects such as PersistentVolumes and
+// VolumeAttachments are recorded in the "default" namespace rather than the
+// namespace the command runs against. An empty namespace (all namespaces)
+// already covers every target and is returned unchanged.
+func eventNamespace(target objectRef, namespace string) string {
+	if namespace == "" {
+		return namespace
+	}
+	switch target.Kind {
+	case "PersistentVolume", "VolumeAttachment":
+		return metav1.NamespaceDefault
+	}
+	return namespace
+}
+
+// targetEventQueries returns one query per target, or a single unfiltered
+// query in namespace when no targets were requested.
+func targetEventQueries(targets []objectRef, namespace string) []eventQuery {
+	if len(targets) == 0 {
+		return []eventQuery{{namespace: namespace, listOptions: metav1.ListOptions{Limit: cmdutil.DefaultChunkSize}}}
+	}
+	queries := make([]eventQuery, 0, len(targets))
+	for _, target := range targets {
+		queries = append(queries, eventQuery{
+			namespace: eventNamespace(target, namespace),
+			listOptions: metav1.ListOptions{
+				Limit: cmdutil.DefaultChunkSize,
+				FieldSelector: fields.AndSelectors(
+					fields.OneTermEqualSelector("involvedObject.kind", target.Kind),
+					fields.OneTermEqualSelector("involvedObject.name", target.Name)).String(),
+			},
+		})
+	}
+	return queries
+}
+
+// dedupeObjectRefs returns refs with duplicates removed, preserving order.
+func dedupeObjectRefs(refs []objectRef) []objectRef {
+	seen := map[objectRef]bool{}
+	deduped := make([]objectRef, 0, len(refs))
+	for _, ref := range refs {
+		if seen[ref] {
+			continue
+		}
+		seen[ref] = true
+		deduped = append(deduped, ref)
+	}
+	return deduped
+}
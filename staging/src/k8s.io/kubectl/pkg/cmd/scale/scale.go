@@ -17,6 +17,7 @@ limitations under the License.
 package scale
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -24,11 +25,19 @@ import (
 	"k8s.io/klog/v2"
 
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/cli-runtime/pkg/printers"
 	"k8s.io/cli-runtime/pkg/resource"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	watchtools "k8s.io/client-go/tools/watch"
 	cmdutil "k8s.io/kubectl/pkg/cmd/util"
 	"k8s.io/kubectl/pkg/scale"
 	"k8s.io/kubectl/pkg/util/completion"
@@ -44,7 +53,12 @@ var (
 
 		If --current-replicas or --resource-version is specified, it is validated before the
 		scale is attempted, and it is guaranteed that the precondition holds true when the
-		scale is sent to the server.`))
+		scale is sent to the server.
+
+		With --wait, scale waits until the new size has been reached before returning.
+		Combined with --min-available, scale-down happens in batches, waiting for the
+		remaining replicas to become available between steps and refusing to continue if
+		fewer than the requested number of replicas are available.`))
 
 	scaleExample = templates.Examples(i18n.T(`
 		# Scale a replica set named 'foo' to 3
@@ -60,7 +74,10 @@ var (
 		kubectl scale --replicas=5 rc/foo rc/bar rc/baz
 
 		# Scale stateful set named 'web' to 3
-		kubectl scale --replicas=3 statefulset/web`))
+		kubectl scale --replicas=3 statefulset/web
+
+		# Scale stateful set named 'web' down to 3 in batches, keeping at least 2 replicas available at each step
+		kubectl scale --replicas=3 --wait --min-available=2 statefulset/web`))
 )
 
 type ScaleOptions struct {
@@ -75,6 +92,8 @@ type ScaleOptions struct {
 	ResourceVersion string
 	CurrentReplicas int
 	Timeout         time.Duration
+	Wait            bool
+	MinAvailable    int
 
 	Recorder                     genericclioptions.Recorder
 	builder                      *resource.Builder
@@ -83,6 +102,7 @@ type ScaleOptions struct {
 	args                         []string
 	shortOutput                  bool
 	clientSet                    kubernetes.Interface
+	dynamicClient                dynamic.Interface
 	scaler                       scale.Scaler
 	unstructuredClientForMapping func(mapping *meta.RESTMapping) (resource.RESTClient, error)
 	parent                       string
@@ -131,6 +151,8 @@ func NewCmdScale(f cmdutil.Factory, ioStreams genericclioptions.IOStreams) *cobr
 	cmd.Flags().IntVar(&o.Replicas, "replicas", o.Replicas, "The new desired number of replicas. Required.")
 	cmd.MarkFlagRequired("replicas")
 	cmd.Flags().DurationVar(&o.Timeout, "timeout", 0, "The length of time to wait before giving up on a scale operation, zero means don't wait. Any other values should contain a corresponding time unit (e.g. 1s, 2m, 3h).")
+	cmd.Flags().BoolVar(&o.Wait, "wait", o.Wait, "If true, wait for the new size to be reached before returning. Defaults to the --timeout duration, or 5m when --timeout is not set.")
+	cmd.Flags().IntVar(&o.MinAvailable, "min-available", o.MinAvailable, "Minimum number of replicas that must remain available while scaling down. Scale-down happens in batches, verifying availability between steps. Requires --wait. 0 (default) disables the availability guardrail.")
 	cmdutil.AddFilenameOptionFlags(cmd, &o.FilenameOptions, "identifying the resource to set a new size")
 	cmdutil.AddDryRunFlag(cmd)
 	cmdutil.AddLabelSelectorFlagVar(cmd, &o.Selector)
@@ -158,6 +180,7 @@ func (o *ScaleOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []st
 	if err != nil {
 		return err
 	}
+	o.dynamicClient = dynamicClient
 	o.dryRunVerifier = resource.NewQueryParamVerifier(dynamicClient, f.OpenAPIGetter(), resource.QueryParamDryRun)
 
 	o.namespace, o.enforceNamespace, err = f.ToRawKubeConfigLoader().Namespace()
@@ -190,6 +213,18 @@ func (o *ScaleOptions) Validate() error {
 		return fmt.Errorf("The --current-replicas must specify an integer of -1 or greater")
 	}
 
+	if o.MinAvailable < 0 {
+		return fmt.Errorf("The --min-available must specify an integer of 0 or greater")
+	}
+
+	if o.MinAvailable > 0 && !o.Wait {
+		return fmt.Errorf("--min-available requires --wait")
+	}
+
+	if o.MinAvailable > o.Replicas {
+		return fmt.Errorf("--min-available=%d must not be greater than --replicas=%d", o.MinAvailable, o.Replicas)
+	}
+
 	return nil
 }
 
@@ -233,6 +268,9 @@ func (o *ScaleOptions) RunScale() error {
 	if o.Timeout != 0 && o.dryRunStrategy == cmdutil.DryRunNone {
 		waitForReplicas = scale.NewRetryParams(1*time.Second, o.Timeout)
 	}
+	if o.Wait && waitForReplicas == nil && o.dryRunStrategy == cmdutil.DryRunNone {
+		waitForReplicas = scale.NewRetryParams(1*time.Second, defaultWaitTimeout)
+	}
 
 	counter := 0
 	err = r.Visit(func(info *resource.Info, err error) error {
@@ -245,7 +283,11 @@ func (o *ScaleOptions) RunScale() error {
 		if o.dryRunStrategy == cmdutil.DryRunClient {
 			return o.PrintObj(info.Object, o.Out)
 		}
-		if err := o.scaler.Scale(info.Namespace, info.Name, uint(o.Replicas), precondition, retry, waitForReplicas, mapping.Resource, o.dryRunStrategy == cmdutil.DryRunServer); err != nil {
+		if o.MinAvailable > 0 && o.dryRunStrategy == cmdutil.DryRunNone {
+			if err := o.scaleDownInBatches(info, precondition, retry, waitForReplicas); err != nil {
+				return err
+			}
+		} else if err := o.scaler.Scale(info.Namespace, info.Name, uint(o.Replicas), precondition, retry, waitForReplicas, mapping.Resource, o.dryRunStrategy == cmdutil.DryRunServer); err != nil {
 			return err
 		}
 
@@ -274,6 +316,123 @@ func (o *ScaleOptions) RunScale() error {
 	return nil
 }
 
+// defaultWaitTimeout bounds how long --wait watches for the new size to be
+// reached when --timeout is not set.
+const defaultWaitTimeout = 5 * time.Minute
+
+// scaleDownInBatches scales the resource down to o.Replicas in steps, never
+// removing more replicas in one step than the current availability surplus
+// over --min-available, and waiting for the controller to converge between
+// steps. Scaling up needs no guardrails and is delegated to the scaler.
+func (o *ScaleOptions) scaleDownInBatches(info *resource.Info, precondition *scale.ScalePrecondition, retry, waitForReplicas *scale.RetryParams) error {
+	gvr := info.ResourceMapping().Resource
+	timeout := o.Timeout
+	if timeout == 0 {
+		timeout = defaultWaitTimeout
+	}
+	deadline := time.Now().Add(timeout)
+	for {
+		obj, err := o.dynamicClient.Resource(gvr).Namespace(info.Namespace).Get(context.TODO(), info.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		current, found, err := unstructured.NestedInt64(obj.Object, "spec", "replicas")
+		if err != nil {
+			return err
+		}
+		if !found {
+			return fmt.Errorf("cannot determine the current number of replicas of %s", info.Name)
+		}
+		if current <= int64(o.Replicas) {
+			return o.scaler.Scale(info.Namespace, info.Name, uint(o.Replicas), precondition, retry, waitForReplicas, gvr, false)
+		}
+
+		available := availableReplicas(obj)
+		surplus := available - int64(o.MinAvailable)
+		if surplus <= 0 {
+			return fmt.Errorf("refusing to scale %s down: %d replicas available, --min-available=%d", info.Name, available, o.MinAvailable)
+		}
+		next := current - surplus
+		if next < int64(o.Replicas) {
+			next = int64(o.Replicas)
+		}
+		if err := o.scaler.Scale(info.Namespace, info.Name, uint(next), precondition, retry, nil, gvr, false); err != nil {
+			return err
+		}
+		// The preconditions only apply to the first step.
+		precondition = nil
+
+		if err := o.waitForAvailability(info, next, time.Until(deadline)); err != nil {
+			return err
+		}
+		if next == int64(o.Replicas) {
+			return nil
+		}
+	}
+}
+
+// waitForAvailability watches the resource until its controller has converged
+// on the given number of replicas and all of them are available, failing as
+// soon as availability drops below --min-available.
+func (o *ScaleOptions) waitForAvailability(info *resource.Info, replicas int64, timeout time.Duration) error {
+	if timeout <= 0 {
+		return fmt.Errorf("timed out waiting for %s to scale down", info.Name)
+	}
+	gvr := info.ResourceMapping().Resource
+	fieldSelector := fields.OneTermEqualSelector("metadata.name", info.Name).String()
+	lw := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			options.FieldSelector = fieldSelector
+			return o.dynamicClient.Resource(gvr).Namespace(info.Namespace).List(context.TODO(), options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			options.FieldSelector = fieldSelector
+			return o.dynamicClient.Resource(gvr).Namespace(info.Namespace).Watch(context.TODO(), options)
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	_, err := watchtools.UntilWithSync(ctx, lw, &unstructured.Unstructured{}, nil, func(e watch.Event) (bool, error) {
+		switch e.Type {
+		case watch.Added, watch.Modified:
+			obj, ok := e.Object.(*unstructured.Unstructured)
+			if !ok {
+				return true, fmt.Errorf("internal error: unexpected object %#v", e.Object)
+			}
+			available := availableReplicas(obj)
+			if available < int64(o.MinAvailable) {
+				return true, fmt.Errorf("refusing to continue scaling %s down: %d replicas available, --min-available=%d", info.Name, available, o.MinAvailable)
+			}
+			statusReplicas, _, err := unstructured.NestedInt64(obj.Object, "status", "replicas")
+			if err != nil {
+				return true, err
+			}
+			return statusReplicas == replicas && available >= replicas, nil
+
+		case watch.Deleted:
+			return true, fmt.Errorf("object has been deleted")
+
+		default:
+			return true, fmt.Errorf("internal error: unexpected event %#v", e)
+		}
+	})
+	return err
+}
+
+// availableReplicas returns the number of available replicas recorded in the
+// object's status, falling back to ready replicas for resources that do not
+// report availability.
+func availableReplicas(obj *unstructured.Unstructured) int64 {
+	if available, found, err := unstructured.NestedInt64(obj.Object, "status", "availableReplicas"); err == nil && found {
+		return available
+	}
+	if ready, found, err := unstructured.NestedInt64(obj.Object, "status", "readyReplicas"); err == nil && found {
+		return ready
+	}
+	return 0
+}
+
 func scaler(f cmdutil.Factory) (scale.Scaler, error) {
 	scalesGetter, err := cmdutil.ScaleClientFn(f)
 	if err != nil {
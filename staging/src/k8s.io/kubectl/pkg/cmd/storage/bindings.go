@@ -0,0 +1,191 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/client-go/kubernetes"
+	storagehelpers "k8s.io/component-helpers/storage/volume"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	bindingsLong = templates.LongDesc(i18n.T(`
+		Explain why a persistent volume claim is or is not bound.
+
+		For the given claim, every persistent volume in the cluster is
+		evaluated against the same binding criteria the volume binder uses
+		(claim reference, phase, storage class, volume mode, access modes,
+		capacity and label selector), and the result of each criterion is
+		shown per volume. With --node, the volume's node affinity is checked
+		against that node as well.`))
+
+	bindingsExample = templates.Examples(i18n.T(`
+		# Explain why claim "data" in the current namespace is Pending
+		kubectl storage bindings data
+
+		# Include each volume's node affinity check against node "node-1"
+		kubectl storage bindings data --node node-1`))
+)
+
+// BindingsOptions holds everything needed to run the bindings diagnostic.
+type BindingsOptions struct {
+	Namespace string
+	ClaimName string
+	NodeName  string
+
+	Client kubernetes.Interface
+
+	genericclioptions.IOStreams
+}
+
+// NewCmdStorageBindings returns the "storage bindings" command.
+func NewCmdStorageBindings(f cmdutil.Factory, streams genericclioptions.IOStreams) *cobra.Command {
+	o := &BindingsOptions{IOStreams: streams}
+	cmd := &cobra.Command{
+		Use:                   "bindings CLAIM_NAME",
+		DisableFlagsInUseLine: true,
+		Short:                 i18n.T("Explain why a persistent volume claim is or is not bound"),
+		Long:                  bindingsLong,
+		Example:               bindingsExample,
+		Args:                  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete(f, args))
+			cmdutil.CheckErr(o.Run(cmd.Context()))
+		},
+	}
+	cmd.Flags().StringVar(&o.NodeName, "node", o.NodeName, "Check each volume's node affinity against this node.")
+	return cmd
+}
+
+// Complete fills in the options from the factory and arguments.
+func (o *BindingsOptions) Complete(f cmdutil.Factory, args []string) error {
+	o.ClaimName = args[0]
+	var err error
+	o.Namespace, _, err = f.ToRawKubeConfigLoader().Namespace()
+	if err != nil {
+		return err
+	}
+	o.Client, err = f.KubernetesClientSet()
+	return err
+}
+
+// Run evaluates every volume against the claim and prints one line per
+// volume with the outcome of each binding criterion.
+func (o *BindingsOptions) Run(ctx context.Context) error {
+	claim, err := o.Client.CoreV1().PersistentVolumeClaims(o.Namespace).Get(ctx, o.ClaimName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	requestedSize := claim.Spec.Resources.Requests[corev1.ResourceStorage]
+	fmt.Fprintf(o.Out, "Claim:\t%s/%s\n", claim.Namespace, claim.Name)
+	fmt.Fprintf(o.Out, "Phase:\t%s\n", claim.Status.Phase)
+	fmt.Fprintf(o.Out, "Requested:\t%s\n", requestedSize.String())
+	fmt.Fprintf(o.Out, "StorageClass:\t%q\n", storagehelpers.GetPersistentVolumeClaimClass(claim))
+	if claim.Spec.VolumeName != "" {
+		fmt.Fprintf(o.Out, "Bound to:\t%s\n", claim.Spec.VolumeName)
+		return nil
+	}
+
+	var node *corev1.Node
+	if o.NodeName != "" {
+		node, err = o.Client.CoreV1().Nodes().Get(ctx, o.NodeName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+	}
+
+	volumes, err := o.Client.CoreV1().PersistentVolumes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	if len(volumes.Items) == 0 {
+		fmt.Fprintln(o.Out, "No persistent volumes exist in the cluster.")
+		return nil
+	}
+
+	criteria := []string{
+		storagehelpers.BindingCriterionClaimRef,
+		storagehelpers.BindingCriterionPhase,
+		storagehelpers.BindingCriterionStorageClass,
+		storagehelpers.BindingCriterionVolumeMode,
+		storagehelpers.BindingCriterionAccessModes,
+		storagehelpers.BindingCriterionCapacity,
+		storagehelpers.BindingCriterionSelector,
+	}
+	if node != nil {
+		criteria = append(criteria, storagehelpers.BindingCriterionNodeAffinity)
+	}
+
+	fmt.Fprintln(o.Out)
+	w := printers.GetNewTabWriter(o.Out)
+	fmt.Fprintf(w, "VOLUME\tCAPACITY\t%s\n", strings.ToUpper(strings.Join(criteria, "\t")))
+	candidates := 0
+	failures := []string{}
+	for i := range volumes.Items {
+		volume := &volumes.Items[i]
+		checks := storagehelpers.CheckVolumeBinding(claim, volume, node)
+		outcomeByCriterion := map[string]bool{}
+		matches := true
+		for _, check := range checks {
+			outcomeByCriterion[check.Criterion] = check.Passed
+			if !check.Passed {
+				matches = false
+				failures = append(failures, fmt.Sprintf("%s: %s", volume.Name, check.Reason))
+			}
+		}
+		if matches {
+			candidates++
+		}
+		capacity := volume.Spec.Capacity[corev1.ResourceStorage]
+		columns := []string{volume.Name, capacity.String()}
+		for _, criterion := range criteria {
+			outcome := "ok"
+			if !outcomeByCriterion[criterion] {
+				outcome = "fail"
+			}
+			columns = append(columns, outcome)
+		}
+		fmt.Fprintln(w, strings.Join(columns, "\t"))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if len(failures) > 0 {
+		fmt.Fprintln(o.Out)
+		fmt.Fprintln(o.Out, "Failures:")
+		for _, failure := range failures {
+			fmt.Fprintf(o.Out, "  %s\n", failure)
+		}
+	}
+	fmt.Fprintln(o.Out)
+	fmt.Fprintf(o.Out, "%d of %d volumes can bind this claim.\n", candidates, len(volumes.Items))
+	return nil
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newTestClaim(name, namespace, size, class string) *corev1.PersistentVolumeClaim {
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			StorageClassName: &class,
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(size)},
+			},
+		},
+		Status: corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimPending},
+	}
+}
+
+func newTestVolume(name, size, class string) *corev1.PersistentVolume {
+	return &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: corev1.PersistentVolumeSpec{
+			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			StorageClassName: class,
+			Capacity:         corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(size)},
+		},
+		Status: corev1.PersistentVolumeStatus{Phase: corev1.VolumeAvailable},
+	}
+}
+
+func TestBindingsRun(t *testing.T) {
+	claim := newTestClaim("data", "ns", "5Gi", "gold")
+	matching := newTestVolume("pv-match", "10Gi", "gold")
+	tooSmall := newTestVolume("pv-small", "1Gi", "gold")
+	wrongClass := newTestVolume("pv-silver", "10Gi", "silver")
+
+	streams, _, out, _ := genericclioptions.NewTestIOStreams()
+	o := &BindingsOptions{
+		Namespace: "ns",
+		ClaimName: "data",
+		Client:    fake.NewSimpleClientset(claim, matching, tooSmall, wrongClass),
+		IOStreams: streams,
+	}
+	if err := o.Run(context.TODO()); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	output := out.String()
+	if !strings.Contains(output, "1 of 3 volumes can bind this claim.") {
+		t.Errorf("expected exactly one candidate volume, got output:\n%s", output)
+	}
+	if !strings.Contains(output, "pv-small: capacity 1Gi is smaller than the requested 5Gi") {
+		t.Errorf("expected a capacity failure for pv-small, got output:\n%s", output)
+	}
+	if !strings.Contains(output, `pv-silver: storage class "silver" does not match the requested "gold"`) {
+		t.Errorf("expected a storage class failure for pv-silver, got output:\n%s", output)
+	}
+}
+
+func TestBindingsRunBoundClaim(t *testing.T) {
+	claim := newTestClaim("data", "ns", "5Gi", "gold")
+	claim.Spec.VolumeName = "pv-match"
+	claim.Status.Phase = corev1.ClaimBound
+
+	streams, _, out, _ := genericclioptions.NewTestIOStreams()
+	o := &BindingsOptions{
+		Namespace: "ns",
+		ClaimName: "data",
+		Client:    fake.NewSimpleClientset(claim),
+		IOStreams: streams,
+	}
+	if err := o.Run(context.TODO()); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "Bound to:\tpv-match") {
+		t.Errorf("expected the bound volume to be reported, got output:\n%s", out.String())
+	}
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package storage holds diagnostic subcommands for cluster storage, answering
+// the support questions `kubectl get`/`kubectl describe` output alone cannot,
+// such as why a claim stays Pending.
+package storage
+
+import (
+	"github.com/spf13/cobra"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var storageLong = templates.LongDesc(i18n.T(`
+	Diagnose cluster storage.
+
+	The subcommands explain storage decisions the cluster has made, such as
+	why a persistent volume claim does not bind.`))
+
+// NewCmdStorage returns the "storage" command with its diagnostic
+// subcommands.
+func NewCmdStorage(f cmdutil.Factory, streams genericclioptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "storage SUBCOMMAND",
+		DisableFlagsInUseLine: true,
+		Short:                 i18n.T("Diagnose cluster storage"),
+		Long:                  storageLong,
+		Run:                   cmdutil.DefaultSubCommandRun(streams.ErrOut),
+	}
+	cmd.AddCommand(NewCmdStorageBindings(f, streams))
+	return cmd
+}
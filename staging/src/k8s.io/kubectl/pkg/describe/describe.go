@@ -3541,6 +3541,17 @@ func (d *NodeDescriber) Describe(namespace, name string, describerSettings Descr
 		canViewPods = false
 	}
 
+	// VolumeAttachments are cluster-scoped; in a policy aware setting the
+	// user may have access to the node but not to attachments.
+	var attachments []storagev1.VolumeAttachment
+	if attachmentList, err := d.StorageV1().VolumeAttachments().List(context.TODO(), metav1.ListOptions{}); err == nil {
+		for _, attachment := range attachmentList.Items {
+			if attachment.Spec.NodeName == name {
+				attachments = append(attachments, attachment)
+			}
+		}
+	}
+
 	var events *corev1.EventList
 	if describerSettings.ShowEvents {
 		if ref, err := reference.GetReference(scheme.Scheme, node); err != nil {
@@ -3561,7 +3572,7 @@ func (d *NodeDescriber) Describe(namespace, name string, describerSettings Descr
 		}
 	}
 
-	return describeNode(node, nodeNonTerminatedPodsList, events, canViewPods, &LeaseDescriber{d})
+	return describeNode(node, nodeNonTerminatedPodsList, events, canViewPods, attachments, &LeaseDescriber{d})
 }
 
 type LeaseDescriber struct {
@@ -3569,7 +3580,7 @@ type LeaseDescriber struct {
 }
 
 func describeNode(node *corev1.Node, nodeNonTerminatedPodsList *corev1.PodList, events *corev1.EventList,
-	canViewPods bool, ld *LeaseDescriber) (string, error) {
+	canViewPods bool, attachments []storagev1.VolumeAttachment, ld *LeaseDescriber) (string, error) {
 	return tabbedString(func(out io.Writer) error {
 		w := NewPrefixWriter(out)
 		w.Write(LEVEL_0, "Name:\t%s\n", node.Name)
@@ -3655,6 +3666,7 @@ func describeNode(node *corev1.Node, nodeNonTerminatedPodsList *corev1.PodList,
 		if len(node.Spec.ProviderID) > 0 {
 			w.Write(LEVEL_0, "ProviderID:\t%s\n", node.Spec.ProviderID)
 		}
+		describeNodeVolumes(node, attachments, w)
 		if canViewPods && nodeNonTerminatedPodsList != nil {
 			describeNodeResource(nodeNonTerminatedPodsList, node, w)
 		} else {
@@ -3667,6 +3679,46 @@ func describeNode(node *corev1.Node, nodeNonTerminatedPodsList *corev1.PodList,
 	})
 }
 
+// describeNodeVolumes prints the volumes attached to and in use on the node.
+// The attaching driver, attach age and last operation error come from the
+// node's VolumeAttachment objects.
+func describeNodeVolumes(node *corev1.Node, attachments []storagev1.VolumeAttachment, w PrefixWriter) {
+	if len(node.Status.VolumesAttached) > 0 {
+		inUse := sets.NewString()
+		for _, volumeName := range node.Status.VolumesInUse {
+			inUse.Insert(string(volumeName))
+		}
+		w.Write(LEVEL_0, "Attached Volumes:\n  Name\tDevicePath\tInUse\n")
+		w.Write(LEVEL_1, "----\t----------\t-----\n")
+		for _, volume := range node.Status.VolumesAttached {
+			w.Write(LEVEL_1, "%s\t%s\t%v\n", volume.Name, volume.DevicePath, inUse.Has(string(volume.Name)))
+		}
+	}
+	if len(attachments) > 0 {
+		w.Write(LEVEL_0, "Volume Attachments:\n  Driver\tPV\tAttached\tAge\tError\n")
+		w.Write(LEVEL_1, "------\t--\t--------\t---\t-----\n")
+		for _, attachment := range attachments {
+			pvName := "<none>"
+			if attachment.Spec.Source.PersistentVolumeName != nil {
+				pvName = *attachment.Spec.Source.PersistentVolumeName
+			}
+			lastError := "<none>"
+			if attachment.Status.AttachError != nil {
+				lastError = attachment.Status.AttachError.Message
+			}
+			if attachment.Status.DetachError != nil {
+				lastError = attachment.Status.DetachError.Message
+			}
+			w.Write(LEVEL_1, "%s\t%s\t%v\t%s\t%s\n",
+				attachment.Spec.Attacher,
+				pvName,
+				attachment.Status.Attached,
+				translateTimestampSince(attachment.CreationTimestamp),
+				lastError)
+		}
+	}
+}
+
 func describeNodeLease(lease *coordinationv1.Lease, w PrefixWriter) {
 	w.Write(LEVEL_0, "Lease:\n")
 	holderIdentity := "<unset>"
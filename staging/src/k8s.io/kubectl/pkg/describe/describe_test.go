@@ -4953,6 +4953,7 @@ func mergeResourceLists(resourceLists ...corev1.ResourceList) corev1.ResourceLis
 
 func TestDescribeNode(t *testing.T) {
 	holderIdentity := "holder"
+	pvName := "pv-1"
 	nodeCapacity := mergeResourceLists(
 		getHugePageResourceList("2Mi", "4Gi"),
 		getResourceList("8", "24Gi"),
@@ -4976,6 +4977,34 @@ func TestDescribeNode(t *testing.T) {
 			Status: corev1.NodeStatus{
 				Capacity:    nodeCapacity,
 				Allocatable: nodeAllocatable,
+				VolumesInUse: []corev1.UniqueVolumeName{
+					"kubernetes.io/csi/csi.example.com^vol-1",
+				},
+				VolumesAttached: []corev1.AttachedVolume{
+					{
+						Name:       "kubernetes.io/csi/csi.example.com^vol-1",
+						DevicePath: "",
+					},
+				},
+			},
+		},
+		&storagev1.VolumeAttachment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "csi-attachment-1",
+				CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Hour)),
+			},
+			Spec: storagev1.VolumeAttachmentSpec{
+				Attacher: "csi.example.com",
+				NodeName: "bar",
+				Source: storagev1.VolumeAttachmentSource{
+					PersistentVolumeName: &pvName,
+				},
+			},
+			Status: storagev1.VolumeAttachmentStatus{
+				Attached: true,
+				DetachError: &storagev1.VolumeError{
+					Message: "volume is still mounted",
+				},
 			},
 		},
 		&coordinationv1.Lease{
@@ -5075,6 +5104,14 @@ func TestDescribeNode(t *testing.T) {
   ephemeral-storage  0 (0%)       0 (0%)
   hugepages-1Gi      0 (0%)       0 (0%)
   hugepages-2Mi      512Mi (25%)  512Mi (25%)`,
+		`Attached Volumes:
+  Name                                     DevicePath  InUse
+  ----                                     ----------  -----
+  kubernetes.io/csi/csi.example.com^vol-1              true`,
+		`Volume Attachments:
+  Driver           PV    Attached  Age  Error
+  ------           --    --------  ---  -----
+  csi.example.com  pv-1  true      60m  volume is still mounted`,
 		`Node bar status is now: NodeHasNoDiskPressure`,
 		`Node bar status is now: NodeReady`}
 	for _, expected := range expectedOut {
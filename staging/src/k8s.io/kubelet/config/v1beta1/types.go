@@ -309,6 +309,23 @@ type KubeletConfiguration struct {
 	// Default: "1m"
 	// +optional
 	VolumeStatsAggPeriod metav1.Duration `json:"volumeStatsAggPeriod,omitempty"`
+	// maxVolumeUnmountDuration is the maximum amount of time an unmount of a
+	// volume of a terminating pod may take before the kubelet force-unmounts
+	// it (falling back to a lazy detach), records a node event and marks the
+	// pod with a VolumesForceUnmounted condition. A zero value disables
+	// forced unmounts.
+	// Default: "0s" (disabled)
+	// +optional
+	MaxVolumeUnmountDuration metav1.Duration `json:"maxVolumeUnmountDuration,omitempty"`
+	// defaultFSGroupChangePolicy is the fsGroupChangePolicy applied to volumes
+	// of pods that do not specify one in their security context. Valid values
+	// are "Always", "OnRootMismatch" and "OnRootMismatchUsingXattr".
+	// "OnRootMismatchUsingXattr" behaves like "OnRootMismatch", but only skips
+	// the recursive permission change when a marker xattr written after a
+	// previous change records the same fsGroup.
+	// Default: ""
+	// +optional
+	DefaultFSGroupChangePolicy string `json:"defaultFSGroupChangePolicy,omitempty"`
 	// kubeletCgroups is the absolute name of cgroups to isolate the kubelet in
 	// Default: ""
 	// +optional
@@ -275,6 +275,31 @@ type VolumeStats struct {
 	// VolumeHealthStats contains data about volume health
 	// +optional
 	VolumeHealthStats *VolumeHealthStats `json:"volumeHealthStats,omitempty"`
+
+	// IOStats contains IO statistics for the volume, attributed from the
+	// pod cgroup to the volume's backing device.
+	// +optional
+	IOStats *VolumeIOStats `json:"ioStats,omitempty"`
+}
+
+// VolumeIOStats contains cumulative IO statistics for a Volume. The counters
+// are read from the pod cgroup and mapped back to the volume's backing device,
+// so they only account for IO issued by containers of the owning pod.
+type VolumeIOStats struct {
+	// The time at which these stats were updated.
+	Time metav1.Time `json:"time"`
+	// ReadBytes is the cumulative number of bytes read from the volume's device.
+	// +optional
+	ReadBytes *uint64 `json:"readBytes,omitempty"`
+	// WriteBytes is the cumulative number of bytes written to the volume's device.
+	// +optional
+	WriteBytes *uint64 `json:"writeBytes,omitempty"`
+	// ReadOperations is the cumulative number of read IOs issued to the volume's device.
+	// +optional
+	ReadOperations *uint64 `json:"readOperations,omitempty"`
+	// WriteOperations is the cumulative number of write IOs issued to the volume's device.
+	// +optional
+	WriteOperations *uint64 `json:"writeOperations,omitempty"`
 }
 
 // VolumeHealthStats contains data about volume health.
@@ -0,0 +1,168 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package perftype
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// CurrentPVBindMetricsVersion is the current PV binding perf data version.
+const CurrentPVBindMetricsVersion = "v1"
+
+// ClaimBindingRecorder collects PV binding and provisioning SLIs. It is
+// driven from a PersistentVolumeClaim watch: RecordClaimCreated is called for
+// every new claim and RecordClaimBound when the claim transitions to Bound.
+// The recorded latencies can be turned into PerfData and gated against SLOs
+// with VerifySLIs. All methods are safe for concurrent use.
+type ClaimBindingRecorder struct {
+	lock sync.Mutex
+	// created maps claim keys to their creation time until they are bound.
+	created map[string]time.Time
+	// latencies holds the creation-to-bound latency of every bound claim.
+	latencies []time.Duration
+	// firstBound and lastBound delimit the window used for throughput.
+	firstBound time.Time
+	lastBound  time.Time
+}
+
+// NewClaimBindingRecorder returns an empty ClaimBindingRecorder.
+func NewClaimBindingRecorder() *ClaimBindingRecorder {
+	return &ClaimBindingRecorder{
+		created: map[string]time.Time{},
+	}
+}
+
+// RecordClaimCreated records that the claim with the given key was created at
+// the given time.
+func (r *ClaimBindingRecorder) RecordClaimCreated(key string, created time.Time) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if _, ok := r.created[key]; !ok {
+		r.created[key] = created
+	}
+}
+
+// RecordClaimBound records that the claim with the given key became Bound at
+// the given time. Claims whose creation was not recorded are ignored, as are
+// repeated Bound events for the same claim.
+func (r *ClaimBindingRecorder) RecordClaimBound(key string, bound time.Time) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	created, ok := r.created[key]
+	if !ok {
+		return
+	}
+	delete(r.created, key)
+	r.latencies = append(r.latencies, bound.Sub(created))
+	if r.firstBound.IsZero() || bound.Before(r.firstBound) {
+		r.firstBound = bound
+	}
+	if bound.After(r.lastBound) {
+		r.lastBound = bound
+	}
+}
+
+// BoundCount returns the number of claims bound so far.
+func (r *ClaimBindingRecorder) BoundCount() int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return len(r.latencies)
+}
+
+// PendingCount returns the number of claims created but not yet bound.
+func (r *ClaimBindingRecorder) PendingCount() int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return len(r.created)
+}
+
+// BindLatencyPercentile returns the given percentile (e.g. 99) of the
+// recorded bind latencies, or zero if no claim was bound.
+func (r *ClaimBindingRecorder) BindLatencyPercentile(percentile int) time.Duration {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return latencyPercentile(r.latencies, percentile)
+}
+
+// Throughput returns the number of claims bound per second between the first
+// and the last recorded Bound transition, or zero if fewer than two claims
+// were bound.
+func (r *ClaimBindingRecorder) Throughput() float64 {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	window := r.lastBound.Sub(r.firstBound)
+	if len(r.latencies) < 2 || window <= 0 {
+		return 0
+	}
+	return float64(len(r.latencies)) / window.Seconds()
+}
+
+// PerfData returns the recorded SLIs in the generic perf data format, one
+// data item with the bind latency percentiles in milliseconds and one with
+// the provisioning throughput in claims per second.
+func (r *ClaimBindingRecorder) PerfData() PerfData {
+	latencyItem := DataItem{
+		Data: map[string]float64{
+			"Perc50": float64(r.BindLatencyPercentile(50)) / float64(time.Millisecond),
+			"Perc90": float64(r.BindLatencyPercentile(90)) / float64(time.Millisecond),
+			"Perc99": float64(r.BindLatencyPercentile(99)) / float64(time.Millisecond),
+		},
+		Unit:   "ms",
+		Labels: map[string]string{"Metric": "pv_bind_latency"},
+	}
+	throughputItem := DataItem{
+		Data:   map[string]float64{"Throughput": r.Throughput()},
+		Unit:   "claims/s",
+		Labels: map[string]string{"Metric": "pv_provision_throughput"},
+	}
+	return PerfData{
+		Version:   CurrentPVBindMetricsVersion,
+		DataItems: []DataItem{latencyItem, throughputItem},
+	}
+}
+
+// VerifySLIs returns an error if the 99th percentile of the bind latency
+// exceeds maxBindLatencyP99 or if the provisioning throughput is below
+// minThroughput claims per second. A zero threshold disables its check.
+func (r *ClaimBindingRecorder) VerifySLIs(maxBindLatencyP99 time.Duration, minThroughput float64) error {
+	if p99 := r.BindLatencyPercentile(99); maxBindLatencyP99 > 0 && p99 > maxBindLatencyP99 {
+		return fmt.Errorf("PV bind latency P99 %v exceeds %v", p99, maxBindLatencyP99)
+	}
+	if throughput := r.Throughput(); minThroughput > 0 && throughput < minThroughput {
+		return fmt.Errorf("PV provision throughput %.2f claims/s is below %.2f claims/s", throughput, minThroughput)
+	}
+	return nil
+}
+
+// latencyPercentile returns the given percentile of the latencies, or zero
+// for an empty slice.
+func latencyPercentile(latencies []time.Duration, percentile int) time.Duration {
+	if len(latencies) == 0 {
+		return 0
+	}
+	sorted := make([]time.Duration, len(latencies))
+	copy(sorted, latencies)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	index := len(sorted)*percentile/100 - 1
+	if index < 0 {
+		index = 0
+	}
+	return sorted[index]
+}
@@ -64,6 +64,7 @@ var BaseSuites = []func() storageframework.TestSuite{
 	InitMultiVolumeTestSuite,
 	InitVolumeExpandTestSuite,
 	InitDisruptiveTestSuite,
+	InitDataIntegrityTestSuite,
 	InitVolumeLimitsTestSuite,
 	InitTopologyTestSuite,
 	InitVolumeStressTestSuite,
@@ -0,0 +1,211 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testsuites
+
+import (
+	"context"
+	"time"
+
+	"github.com/onsi/ginkgo"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	errors "k8s.io/apimachinery/pkg/util/errors"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/kubernetes/test/e2e/framework"
+	e2epod "k8s.io/kubernetes/test/e2e/framework/pod"
+	e2eskipper "k8s.io/kubernetes/test/e2e/framework/skipper"
+	storageframework "k8s.io/kubernetes/test/e2e/storage/framework"
+	"k8s.io/kubernetes/test/e2e/storage/utils"
+	storageutils "k8s.io/kubernetes/test/e2e/storage/utils"
+	admissionapi "k8s.io/pod-security-admission/api"
+)
+
+type dataIntegrityTestSuite struct {
+	tsInfo storageframework.TestSuiteInfo
+}
+
+// InitCustomDataIntegrityTestSuite returns dataIntegrityTestSuite that implements TestSuite interface
+// using custom test patterns
+func InitCustomDataIntegrityTestSuite(patterns []storageframework.TestPattern) storageframework.TestSuite {
+	return &dataIntegrityTestSuite{
+		tsInfo: storageframework.TestSuiteInfo{
+			Name:         "data-integrity",
+			FeatureTag:   "[LinuxOnly]",
+			TestPatterns: patterns,
+		},
+	}
+}
+
+// InitDataIntegrityTestSuite returns dataIntegrityTestSuite that implements TestSuite interface
+// using test suite default patterns
+func InitDataIntegrityTestSuite() storageframework.TestSuite {
+	testPatterns := []storageframework.TestPattern{
+		storageframework.FsVolModeDynamicPV,
+		storageframework.BlockVolModeDynamicPV,
+	}
+	return InitCustomDataIntegrityTestSuite(testPatterns)
+}
+
+func (s *dataIntegrityTestSuite) GetTestSuiteInfo() storageframework.TestSuiteInfo {
+	return s.tsInfo
+}
+
+func (s *dataIntegrityTestSuite) SkipUnsupportedTests(driver storageframework.TestDriver, pattern storageframework.TestPattern) {
+	if pattern.VolMode == v1.PersistentVolumeBlock && !driver.GetDriverInfo().Capabilities[storageframework.CapBlock] {
+		e2eskipper.Skipf("Driver %s doesn't support %v -- skipping", driver.GetDriverInfo().Name, pattern.VolMode)
+	}
+}
+
+func (s *dataIntegrityTestSuite) DefineTests(driver storageframework.TestDriver, pattern storageframework.TestPattern) {
+	type local struct {
+		config        *storageframework.PerTestConfig
+		driverCleanup func()
+
+		cs clientset.Interface
+		ns *v1.Namespace
+
+		// VolumeResource contains pv, pvc, sc, etc., owns cleaning that up
+		resource *storageframework.VolumeResource
+		pod      *v1.Pod
+	}
+	var (
+		dInfo = driver.GetDriverInfo()
+		l     local
+	)
+
+	// Beware that it also registers an AfterEach which renders f unusable. Any code using
+	// f must run inside an It or Context callback.
+	f := framework.NewFrameworkWithCustomTimeouts("data-integrity", storageframework.GetDriverTimeouts(driver))
+	f.NamespacePodSecurityEnforceLevel = admissionapi.LevelPrivileged
+
+	init := func() {
+		l = local{}
+		l.ns = f.Namespace
+		l.cs = f.ClientSet
+
+		// Now do the more expensive test initialization.
+		l.config, l.driverCleanup = driver.PrepareTest(f)
+
+		testVolumeSizeRange := s.GetTestSuiteInfo().SupportedSizeRange
+		l.resource = storageframework.CreateVolumeResource(driver, l.config, pattern, testVolumeSizeRange)
+	}
+
+	cleanup := func() {
+		var errs []error
+		if l.pod != nil {
+			ginkgo.By("Deleting pod")
+			errs = append(errs, e2epod.DeletePodWithWait(f.ClientSet, l.pod))
+			l.pod = nil
+		}
+
+		if l.resource != nil {
+			errs = append(errs, l.resource.CleanupResource())
+			l.resource = nil
+		}
+
+		errs = append(errs, storageutils.TryFunc(l.driverCleanup))
+		l.driverCleanup = nil
+		framework.ExpectNoError(errors.NewAggregate(errs), "while cleaning up resource")
+	}
+
+	// createPodWithVolume creates a pod mounting the test volume on the given
+	// node selection and returns it with its NodeName filled in.
+	createPodWithVolume := func(node e2epod.NodeSelection) *v1.Pod {
+		podConfig := e2epod.Config{
+			NS:            l.ns.Name,
+			PVCs:          []*v1.PersistentVolumeClaim{l.resource.Pvc},
+			SeLinuxLabel:  e2epod.GetLinuxLabel(),
+			NodeSelection: node,
+			ImageID:       e2epod.GetDefaultTestImageID(),
+		}
+		pod, err := e2epod.CreateSecPodWithNodeSelection(l.cs, &podConfig, f.Timeouts.PodStart)
+		framework.ExpectNoError(err, "while creating pod with volume")
+		pod, err = l.cs.CoreV1().Pods(pod.Namespace).Get(context.TODO(), pod.Name, metav1.GetOptions{})
+		framework.ExpectNoError(err, "while getting scheduled pod")
+		return pod
+	}
+
+	// The volume is made accessible via /mnt/volume1 by CreateSecPodWithNodeSelection.
+	const path = "/mnt/volume1"
+	// Write enough data to span several blocks, so corruption of a single
+	// block is caught. The data is passed to the pod on its command line, so
+	// it has to stay well below the kernel's per-argument limit.
+	const byteLen = 32 * 1024
+
+	ginkgo.It("should keep checksummed data intact across pod recreation on a different node", func() {
+		init()
+		defer cleanup()
+
+		// Check different-node test requirement
+		if dInfo.Capabilities[storageframework.CapSingleNodeVolume] {
+			e2eskipper.Skipf("Driver %s only supports %v -- skipping", dInfo.Name, storageframework.CapSingleNodeVolume)
+		}
+		if l.config.ClientNodeSelection.Name != "" {
+			e2eskipper.Skipf("Driver %q requires to deploy on a specific node - skipping", dInfo.Name)
+		}
+		if err := ensureTopologyRequirements(&l.config.ClientNodeSelection, l.cs, dInfo, 2); err != nil {
+			framework.Failf("Error setting topology requirements: %v", err)
+		}
+
+		node := l.config.ClientNodeSelection
+		l.pod = createPodWithVolume(node)
+		nodeName := l.pod.Spec.NodeName
+
+		seed := time.Now().UTC().UnixNano()
+		ginkgo.By("Writing checksummed data to the volume")
+		utils.CheckWriteToPath(f, l.pod, pattern.VolMode, false, path, byteLen, seed)
+		ginkgo.By("Verifying the checksum before the volume is detached")
+		utils.CheckReadFromPath(f, l.pod, pattern.VolMode, false, path, byteLen, seed)
+
+		ginkgo.By("Deleting the pod to detach the volume")
+		framework.ExpectNoError(e2epod.DeletePodWithWait(l.cs, l.pod), "while deleting pod")
+		l.pod = nil
+
+		ginkgo.By("Recreating the pod on a different node")
+		e2epod.SetAntiAffinity(&node, nodeName)
+		l.pod = createPodWithVolume(node)
+
+		ginkgo.By("Verifying the checksum after the volume was reattached")
+		utils.CheckReadFromPath(f, l.pod, pattern.VolMode, false, path, byteLen, seed)
+	})
+
+	ginkgo.It("should keep checksummed data intact after the pod is force deleted and the volume reattached", func() {
+		init()
+		defer cleanup()
+
+		node := l.config.ClientNodeSelection
+		l.pod = createPodWithVolume(node)
+		nodeName := l.pod.Spec.NodeName
+
+		seed := time.Now().UTC().UnixNano()
+		// Write through the page cache so the data survives the force kill.
+		ginkgo.By("Writing checksummed data to the volume, bypassing the page cache")
+		utils.CheckWriteToPath(f, l.pod, pattern.VolMode, true, path, byteLen, seed)
+
+		ginkgo.By("Force deleting the pod")
+		framework.ExpectNoError(e2epod.DeletePodWithGracePeriod(l.cs, l.pod, 0), "while force deleting pod")
+		l.pod = nil
+
+		ginkgo.By("Recreating the pod on the same node")
+		e2epod.SetAffinity(&node, nodeName)
+		l.pod = createPodWithVolume(node)
+
+		ginkgo.By("Verifying the checksum after the volume was reattached")
+		utils.CheckReadFromPath(f, l.pod, pattern.VolMode, false, path, byteLen, seed)
+	})
+}
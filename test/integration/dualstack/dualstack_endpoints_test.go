@@ -103,7 +103,8 @@ func TestDualStackEndpoints(t *testing.T) {
 		informers.Core().V1().Services(),
 		informers.Core().V1().Endpoints(),
 		client,
-		1*time.Second)
+		1*time.Second,
+		0)
 
 	epsController := endpointslice.NewController(
 		informers.Core().V1().Pods(),
@@ -53,6 +53,7 @@ func TestEndpointUpdates(t *testing.T) {
 		informers.Core().V1().Services(),
 		informers.Core().V1().Endpoints(),
 		client,
+		0,
 		0)
 
 	// Start informer and controllers
@@ -182,6 +183,7 @@ func TestEndpointWithTerminatingPod(t *testing.T) {
 		informers.Core().V1().Services(),
 		informers.Core().V1().Endpoints(),
 		client,
+		0,
 		0)
 
 	// Start informer and controllers
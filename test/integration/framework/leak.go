@@ -0,0 +1,131 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	clientset "k8s.io/client-go/kubernetes"
+)
+
+// GoroutineLeakCheck fails the test if it ends with more goroutines than it
+// started with. Goroutines that are still winding down are given a few
+// seconds to exit before the test is failed, which keeps the check from
+// flaking on runtime-managed goroutines.
+//
+// Register the check before starting any servers or controllers so their
+// shutdown is covered:
+//
+//	framework.GoroutineLeakCheck(t)
+func GoroutineLeakCheck(t *testing.T) {
+	before := runtime.NumGoroutine()
+	t.Cleanup(func() {
+		deadline := time.Now().Add(5 * time.Second)
+		var after int
+		for {
+			after = runtime.NumGoroutine()
+			if after <= before || time.Now().After(deadline) {
+				break
+			}
+			time.Sleep(100 * time.Millisecond)
+		}
+		if after > before {
+			buf := make([]byte, 1<<20)
+			buf = buf[:runtime.Stack(buf, true)]
+			t.Errorf("goroutine leak: started with %d goroutines, ended with %d; stacks:\n%s", before, after, buf)
+		}
+	})
+}
+
+// ObjectLeakCheck fails the test if it leaves cluster-scoped storage objects
+// (PersistentVolumes, StorageClasses) behind that did not exist when the check
+// was registered. Namespaced objects are cleaned up with their namespace, but
+// cluster-scoped leftovers silently bleed into later tests sharing the
+// apiserver.
+func ObjectLeakCheck(t *testing.T, client clientset.Interface) {
+	before := listClusterScopedStorageObjects(t, client)
+	t.Cleanup(func() {
+		after := listClusterScopedStorageObjects(t, client)
+		if leaked := after.Difference(before); leaked.Len() > 0 {
+			t.Errorf("leaked cluster-scoped objects: %v", leaked.List())
+		}
+	})
+}
+
+func listClusterScopedStorageObjects(t *testing.T, client clientset.Interface) sets.String {
+	objects := sets.NewString()
+	pvs, err := client.CoreV1().PersistentVolumes().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("failed to list persistent volumes: %v", err)
+	}
+	for _, pv := range pvs.Items {
+		objects.Insert("persistentvolume/" + pv.Name)
+	}
+	classes, err := client.StorageV1().StorageClasses().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("failed to list storage classes: %v", err)
+	}
+	for _, class := range classes.Items {
+		objects.Insert("storageclass/" + class.Name)
+	}
+	return objects
+}
+
+// EtcdKeyLeakCheck fails the test if keys under the given prefix exist at the
+// end of the test that did not exist when the check was registered. It catches
+// objects that are no longer reachable through the API (for example after a
+// failed migration or a bad finalizer removal) but still occupy etcd space.
+// The prefix is usually "/registry/" or a narrower slice of it such as
+// "/registry/persistentvolumes/".
+func EtcdKeyLeakCheck(t *testing.T, prefix string) {
+	client, err := clientv3.New(clientv3.Config{
+		Endpoints:   []string{GetEtcdURL()},
+		DialTimeout: 20 * time.Second,
+	})
+	if err != nil {
+		t.Fatalf("failed to create etcd client: %v", err)
+	}
+	before := listEtcdKeys(t, client, prefix)
+	t.Cleanup(func() {
+		defer client.Close()
+		after := listEtcdKeys(t, client, prefix)
+		if leaked := after.Difference(before); leaked.Len() > 0 {
+			t.Errorf("leaked etcd keys under %s: %v", prefix, leaked.List())
+		}
+	})
+}
+
+func listEtcdKeys(t *testing.T, client *clientv3.Client, prefix string) sets.String {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	resp, err := client.Get(ctx, prefix, clientv3.WithPrefix(), clientv3.WithKeysOnly())
+	if err != nil {
+		t.Fatalf("failed to list etcd keys under %s: %v", prefix, err)
+	}
+	keys := sets.NewString()
+	for _, kv := range resp.Kvs {
+		keys.Insert(string(kv.Key))
+	}
+	return keys
+}
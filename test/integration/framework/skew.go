@@ -0,0 +1,190 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"syscall"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+
+	"k8s.io/kubernetes/pkg/util/env"
+)
+
+// SkewVersion selects the prebuilt kube-apiserver binary a skew test runs
+// against.
+type SkewVersion string
+
+const (
+	// SkewOlder runs the test against an apiserver one minor version older
+	// than the code under test.
+	SkewOlder SkewVersion = "N-1"
+	// SkewNewer runs the test against an apiserver one minor version newer
+	// than the code under test.
+	SkewNewer SkewVersion = "N+1"
+
+	// skewedAPIServerOlderEnv and skewedAPIServerNewerEnv name the prebuilt
+	// kube-apiserver binaries. Skew tests are skipped when the variable for
+	// the requested version is not set, so regular integration runs are not
+	// affected.
+	skewedAPIServerOlderEnv = "KUBE_INTEGRATION_APISERVER_N_MINUS_1"
+	skewedAPIServerNewerEnv = "KUBE_INTEGRATION_APISERVER_N_PLUS_1"
+
+	// skewedAPIServerToken is the static bearer token the skewed apiserver
+	// is started with; the returned rest.Config authenticates with it.
+	skewedAPIServerToken = "skew-test-token"
+)
+
+// SkewedAPIServerPath returns the path to the prebuilt kube-apiserver binary
+// for the given skew version, or false when none is configured.
+func SkewedAPIServerPath(version SkewVersion) (string, bool) {
+	envName := skewedAPIServerOlderEnv
+	if version == SkewNewer {
+		envName = skewedAPIServerNewerEnv
+	}
+	path := env.GetEnvAsStringOrFallback(envName, "")
+	return path, path != ""
+}
+
+// StartSkewedAPIServer launches the prebuilt kube-apiserver binary for the
+// given skew version against the shared test etcd and returns a client config
+// for it. The test is skipped when no binary is configured for that version.
+// The server is stopped and its scratch directories are removed via t.Cleanup.
+//
+// Controllers under test connect to the returned config, which lets the
+// current controller code exercise create/update paths against an apiserver
+// that does not know about new fields (N-1) or already serves newer defaults
+// (N+1).
+func StartSkewedAPIServer(t *testing.T, version SkewVersion) *rest.Config {
+	apiServerPath, found := SkewedAPIServerPath(version)
+	if !found {
+		t.Skipf("no %s kube-apiserver binary configured, skipping skew test", version)
+	}
+	if _, err := os.Stat(apiServerPath); err != nil {
+		t.Fatalf("configured %s kube-apiserver binary not usable: %v", version, err)
+	}
+
+	securePort, err := getAvailablePort()
+	if err != nil {
+		t.Fatalf("could not get a port for the skewed apiserver: %v", err)
+	}
+	certDir, err := os.MkdirTemp(os.TempDir(), "skew-apiserver-certs")
+	if err != nil {
+		t.Fatalf("unable to make temp cert dir: %v", err)
+	}
+	tokenFile, err := os.CreateTemp(os.TempDir(), "skew-apiserver-tokens")
+	if err != nil {
+		t.Fatalf("unable to make temp token file: %v", err)
+	}
+	if _, err := fmt.Fprintf(tokenFile, "%s,admin,admin,system:masters\n", skewedAPIServerToken); err != nil {
+		t.Fatalf("unable to write token file: %v", err)
+	}
+	if err := tokenFile.Close(); err != nil {
+		t.Fatalf("unable to close token file: %v", err)
+	}
+
+	args := []string{
+		"--etcd-servers", GetEtcdURL(),
+		// Keep the skewed server's keys apart from servers started by the
+		// test itself, so both can run against the shared etcd.
+		"--etcd-prefix", fmt.Sprintf("/registry-skew-%d", securePort),
+		"--secure-port", fmt.Sprintf("%d", securePort),
+		"--cert-dir", certDir,
+		"--token-auth-file", tokenFile.Name(),
+		"--authorization-mode", "AlwaysAllow",
+		"--service-cluster-ip-range", "10.0.0.0/24",
+		"--allow-privileged",
+	}
+	klog.Infof("starting %s kube-apiserver %s on port %d", version, apiServerPath, securePort)
+	cmd := exec.Command(apiServerPath, args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("failed to run %s kube-apiserver: %v", version, err)
+	}
+
+	t.Cleanup(func() {
+		cmd.Process.Signal(syscall.SIGTERM)
+		done := make(chan struct{})
+		go func() {
+			cmd.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			klog.Infof("skewed apiserver didn't exit in 5 seconds, killing it")
+			cmd.Process.Kill()
+			<-done
+		}
+		os.RemoveAll(certDir)
+		os.Remove(tokenFile.Name())
+	})
+
+	config := &rest.Config{
+		Host:        fmt.Sprintf("https://127.0.0.1:%d", securePort),
+		BearerToken: skewedAPIServerToken,
+		// The skewed server generates a self-signed serving certificate in
+		// its cert dir; the test client does not verify it.
+		TLSClientConfig: rest.TLSClientConfig{Insecure: true},
+		QPS:             -1,
+	}
+
+	if err := waitForSkewedAPIServer(config); err != nil {
+		t.Fatalf("%s kube-apiserver did not become healthy: %v", version, err)
+	}
+	return config
+}
+
+// waitForSkewedAPIServer polls the server's /healthz until it responds or the
+// timeout expires.
+func waitForSkewedAPIServer(config *rest.Config) error {
+	client := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		},
+		Timeout: time.Second,
+	}
+	url := config.Host + "/healthz"
+	var lastErr error
+	for i := 0; i < 300; i++ {
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Authorization", "Bearer "+config.BearerToken)
+		resp, err := client.Do(req)
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				return nil
+			}
+			lastErr = fmt.Errorf("healthz returned %d", resp.StatusCode)
+		} else {
+			lastErr = err
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	return fmt.Errorf("timed out waiting for healthz: %v", lastErr)
+}
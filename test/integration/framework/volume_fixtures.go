@@ -0,0 +1,164 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PersistentVolumeBuilder builds PersistentVolume fixtures for integration
+// and e2e tests. Start with MakePersistentVolume, chain the With* options
+// and finish with Obj.
+type PersistentVolumeBuilder struct {
+	volume *v1.PersistentVolume
+}
+
+// MakePersistentVolume returns a builder prefilled with a HostPath-backed
+// volume, the shape the volume test suites used to copy-paste as createPV.
+func MakePersistentVolume(name, path, capacity string, accessModes []v1.PersistentVolumeAccessMode, reclaimPolicy v1.PersistentVolumeReclaimPolicy) *PersistentVolumeBuilder {
+	return &PersistentVolumeBuilder{
+		volume: &v1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: v1.PersistentVolumeSpec{
+				PersistentVolumeSource:        v1.PersistentVolumeSource{HostPath: &v1.HostPathVolumeSource{Path: path}},
+				Capacity:                      v1.ResourceList{v1.ResourceName(v1.ResourceStorage): resource.MustParse(capacity)},
+				AccessModes:                   accessModes,
+				PersistentVolumeReclaimPolicy: reclaimPolicy,
+			},
+		},
+	}
+}
+
+// WithNodeAffinity restricts the volume to nodes whose label under key has
+// one of the given values.
+func (b *PersistentVolumeBuilder) WithNodeAffinity(key string, values ...string) *PersistentVolumeBuilder {
+	b.volume.Spec.NodeAffinity = &v1.VolumeNodeAffinity{
+		Required: &v1.NodeSelector{
+			NodeSelectorTerms: []v1.NodeSelectorTerm{
+				{
+					MatchExpressions: []v1.NodeSelectorRequirement{
+						{
+							Key:      key,
+							Operator: v1.NodeSelectorOpIn,
+							Values:   values,
+						},
+					},
+				},
+			},
+		},
+	}
+	return b
+}
+
+// WithVolumeMode sets the volume mode, e.g. for Block mode coverage.
+func (b *PersistentVolumeBuilder) WithVolumeMode(mode v1.PersistentVolumeMode) *PersistentVolumeBuilder {
+	b.volume.Spec.VolumeMode = &mode
+	return b
+}
+
+// WithClass sets the storage class name.
+func (b *PersistentVolumeBuilder) WithClass(class string) *PersistentVolumeBuilder {
+	b.volume.Spec.StorageClassName = class
+	return b
+}
+
+// WithAnnotations adds the given annotations, keeping any already set.
+func (b *PersistentVolumeBuilder) WithAnnotations(annotations map[string]string) *PersistentVolumeBuilder {
+	for name, value := range annotations {
+		metav1.SetMetaDataAnnotation(&b.volume.ObjectMeta, name, value)
+	}
+	return b
+}
+
+// WithLabels adds the given labels, keeping any already set.
+func (b *PersistentVolumeBuilder) WithLabels(labels map[string]string) *PersistentVolumeBuilder {
+	if b.volume.Labels == nil {
+		b.volume.Labels = map[string]string{}
+	}
+	for name, value := range labels {
+		b.volume.Labels[name] = value
+	}
+	return b
+}
+
+// WithFinalizers appends the given finalizers.
+func (b *PersistentVolumeBuilder) WithFinalizers(finalizers ...string) *PersistentVolumeBuilder {
+	b.volume.Finalizers = append(b.volume.Finalizers, finalizers...)
+	return b
+}
+
+// Obj returns the built volume.
+func (b *PersistentVolumeBuilder) Obj() *v1.PersistentVolume {
+	return b.volume
+}
+
+// PersistentVolumeClaimBuilder builds PersistentVolumeClaim fixtures for
+// integration and e2e tests. Start with MakePersistentVolumeClaim, chain
+// the With* options and finish with Obj.
+type PersistentVolumeClaimBuilder struct {
+	claim *v1.PersistentVolumeClaim
+}
+
+// MakePersistentVolumeClaim returns a builder prefilled with the shape the
+// volume test suites used to copy-paste as createPVC.
+func MakePersistentVolumeClaim(name, namespace, capacity string, accessModes []v1.PersistentVolumeAccessMode) *PersistentVolumeClaimBuilder {
+	return &PersistentVolumeClaimBuilder{
+		claim: &v1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Spec: v1.PersistentVolumeClaimSpec{
+				Resources:   v1.ResourceRequirements{Requests: v1.ResourceList{v1.ResourceName(v1.ResourceStorage): resource.MustParse(capacity)}},
+				AccessModes: accessModes,
+			},
+		},
+	}
+}
+
+// WithVolumeMode sets the volume mode, e.g. for Block mode coverage.
+func (b *PersistentVolumeClaimBuilder) WithVolumeMode(mode v1.PersistentVolumeMode) *PersistentVolumeClaimBuilder {
+	b.claim.Spec.VolumeMode = &mode
+	return b
+}
+
+// WithClass sets the storage class name.
+func (b *PersistentVolumeClaimBuilder) WithClass(class string) *PersistentVolumeClaimBuilder {
+	b.claim.Spec.StorageClassName = &class
+	return b
+}
+
+// WithAnnotations adds the given annotations, keeping any already set.
+func (b *PersistentVolumeClaimBuilder) WithAnnotations(annotations map[string]string) *PersistentVolumeClaimBuilder {
+	for name, value := range annotations {
+		metav1.SetMetaDataAnnotation(&b.claim.ObjectMeta, name, value)
+	}
+	return b
+}
+
+// WithFinalizers appends the given finalizers.
+func (b *PersistentVolumeClaimBuilder) WithFinalizers(finalizers ...string) *PersistentVolumeClaimBuilder {
+	b.claim.Finalizers = append(b.claim.Finalizers, finalizers...)
+	return b
+}
+
+// Obj returns the built claim.
+func (b *PersistentVolumeClaimBuilder) Obj() *v1.PersistentVolumeClaim {
+	return b.claim
+}
@@ -0,0 +1,209 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+)
+
+// PVPredicate evaluates a PersistentVolume. The volume wait helpers below
+// block until their predicate returns true.
+type PVPredicate func(volume *v1.PersistentVolume) bool
+
+// PVCPredicate evaluates a PersistentVolumeClaim. The claim wait helpers
+// below block until their predicate returns true.
+type PVCPredicate func(claim *v1.PersistentVolumeClaim) bool
+
+// PVPhase returns a predicate that matches volumes in the given phase.
+func PVPhase(phase v1.PersistentVolumePhase) PVPredicate {
+	return func(volume *v1.PersistentVolume) bool {
+		return volume.Status.Phase == phase
+	}
+}
+
+// PVCPhase returns a predicate that matches claims in the given phase.
+func PVCPhase(phase v1.PersistentVolumeClaimPhase) PVCPredicate {
+	return func(claim *v1.PersistentVolumeClaim) bool {
+		return claim.Status.Phase == phase
+	}
+}
+
+// PVAnnotation returns a predicate that matches volumes carrying the given
+// annotation with the given value.
+func PVAnnotation(name, value string) PVPredicate {
+	return func(volume *v1.PersistentVolume) bool {
+		return volume.Annotations[name] == value
+	}
+}
+
+// PVCAnnotation returns a predicate that matches claims carrying the given
+// annotation with the given value.
+func PVCAnnotation(name, value string) PVCPredicate {
+	return func(claim *v1.PersistentVolumeClaim) bool {
+		return claim.Annotations[name] == value
+	}
+}
+
+// PVCCondition returns a predicate that matches claims that have a condition
+// of the given type with the given status.
+func PVCCondition(conditionType v1.PersistentVolumeClaimConditionType, status v1.ConditionStatus) PVCPredicate {
+	return func(claim *v1.PersistentVolumeClaim) bool {
+		for _, condition := range claim.Status.Conditions {
+			if condition.Type == conditionType && condition.Status == status {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// WaitForPersistentVolume blocks until the named volume satisfies the
+// predicate or the context expires. On expiry it dumps the current PV/PVC
+// state and fails the test.
+func WaitForPersistentVolume(ctx context.Context, t *testing.T, client clientset.Interface, w watch.Interface, pvName string, predicate PVPredicate) {
+	t.Helper()
+	// Check if the volume already satisfies the predicate.
+	volume, err := client.CoreV1().PersistentVolumes().Get(ctx, pvName, metav1.GetOptions{})
+	if err == nil && predicate(volume) {
+		return
+	}
+
+	for {
+		select {
+		case event := <-w.ResultChan():
+			volume, ok := event.Object.(*v1.PersistentVolume)
+			if !ok {
+				continue
+			}
+			if volume.Name == pvName && predicate(volume) {
+				klog.V(2).Infof("volume %q reached the expected state", volume.Name)
+				return
+			}
+		case <-ctx.Done():
+			dumpVolumeState(t, client)
+			t.Fatalf("timed out waiting for volume %q: %v", pvName, ctx.Err())
+		}
+	}
+}
+
+// WaitForAnyPersistentVolume blocks until any volume on the watch satisfies
+// the predicate or the context expires. On expiry it dumps the current PV/PVC
+// state and fails the test.
+func WaitForAnyPersistentVolume(ctx context.Context, t *testing.T, client clientset.Interface, w watch.Interface, predicate PVPredicate) {
+	t.Helper()
+	for {
+		select {
+		case event := <-w.ResultChan():
+			volume, ok := event.Object.(*v1.PersistentVolume)
+			if !ok {
+				continue
+			}
+			if predicate(volume) {
+				klog.V(2).Infof("volume %q reached the expected state", volume.Name)
+				return
+			}
+		case <-ctx.Done():
+			dumpVolumeState(t, client)
+			t.Fatalf("timed out waiting for any volume: %v", ctx.Err())
+		}
+	}
+}
+
+// WaitForPersistentVolumeClaim blocks until the named claim satisfies the
+// predicate or the context expires. On expiry it dumps the current PV/PVC
+// state and fails the test.
+func WaitForPersistentVolumeClaim(ctx context.Context, t *testing.T, client clientset.Interface, w watch.Interface, namespace, claimName string, predicate PVCPredicate) {
+	t.Helper()
+	// Check if the claim already satisfies the predicate.
+	claim, err := client.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, claimName, metav1.GetOptions{})
+	if err == nil && predicate(claim) {
+		return
+	}
+
+	for {
+		select {
+		case event := <-w.ResultChan():
+			claim, ok := event.Object.(*v1.PersistentVolumeClaim)
+			if !ok {
+				continue
+			}
+			if claim.Name == claimName && predicate(claim) {
+				klog.V(2).Infof("claim %q reached the expected state", claim.Name)
+				return
+			}
+		case <-ctx.Done():
+			dumpVolumeState(t, client)
+			t.Fatalf("timed out waiting for claim %q: %v", claimName, ctx.Err())
+		}
+	}
+}
+
+// WaitForAnyPersistentVolumeClaim blocks until any claim on the watch
+// satisfies the predicate or the context expires. On expiry it dumps the
+// current PV/PVC state and fails the test.
+func WaitForAnyPersistentVolumeClaim(ctx context.Context, t *testing.T, client clientset.Interface, w watch.Interface, predicate PVCPredicate) {
+	t.Helper()
+	for {
+		select {
+		case event := <-w.ResultChan():
+			claim, ok := event.Object.(*v1.PersistentVolumeClaim)
+			if !ok {
+				continue
+			}
+			if predicate(claim) {
+				klog.V(2).Infof("claim %q reached the expected state", claim.Name)
+				return
+			}
+		case <-ctx.Done():
+			dumpVolumeState(t, client)
+			t.Fatalf("timed out waiting for any claim: %v", ctx.Err())
+		}
+	}
+}
+
+// dumpVolumeState logs the current state of all PVs and PVCs to help debug
+// which object got stuck.
+func dumpVolumeState(t *testing.T, client clientset.Interface) {
+	t.Helper()
+	volumes, err := client.CoreV1().PersistentVolumes().List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Logf("error listing volumes: %v", err)
+	} else {
+		for _, volume := range volumes.Items {
+			claimref := ""
+			if volume.Spec.ClaimRef != nil {
+				claimref = volume.Spec.ClaimRef.Namespace + "/" + volume.Spec.ClaimRef.Name
+			}
+			t.Logf("PV %q: phase %q, bound to %q, annotations %v", volume.Name, volume.Status.Phase, claimref, volume.Annotations)
+		}
+	}
+	claims, err := client.CoreV1().PersistentVolumeClaims(metav1.NamespaceAll).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Logf("error listing claims: %v", err)
+	} else {
+		for _, claim := range claims.Items {
+			t.Logf("PVC %s/%s: phase %q, bound to %q, annotations %v", claim.Namespace, claim.Name, claim.Status.Phase, claim.Spec.VolumeName, claim.Annotations)
+		}
+	}
+}
@@ -138,7 +138,10 @@ func TestTTLAnnotations(t *testing.T) {
 	defer closeFn()
 
 	testClient, informers := createClientAndInformers(t, server)
-	nodeInformer := informers.Core().V1().Nodes()
+	nodeInformer, err := informers.ForResource(v1.SchemeGroupVersion.WithResource("nodes"))
+	if err != nil {
+		t.Fatalf("Failed to construct node informer: %v", err)
+	}
 	ttlc := ttl.NewTTLController(nodeInformer, testClient)
 
 	ctx, cancel := context.WithCancel(context.Background())
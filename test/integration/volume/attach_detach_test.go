@@ -437,6 +437,7 @@ func createAdClients(ns *v1.Namespace, t *testing.T, server *httptest.Server, sy
 		nil, /* prober */
 		false,
 		5*time.Second,
+		0, /* maxParallelDetachesPerNode */
 		timers,
 		nil, /* filteredDialOptions */
 	)
@@ -141,9 +141,9 @@ func TestPersistentVolumeRecycler(t *testing.T) {
 	klog.V(2).Infof("TestPersistentVolumeRecycler pvc created")
 
 	// wait until the controller pairs the volume and claim
-	waitForPersistentVolumePhase(testClient, pv.Name, watchPV, v1.VolumeBound)
+	waitForPersistentVolumePhase(t, testClient, pv.Name, watchPV, v1.VolumeBound)
 	klog.V(2).Infof("TestPersistentVolumeRecycler pv bound")
-	waitForPersistentVolumeClaimPhase(testClient, pvc.Name, ns.Name, watchPVC, v1.ClaimBound)
+	waitForPersistentVolumeClaimPhase(t, testClient, pvc.Name, ns.Name, watchPVC, v1.ClaimBound)
 	klog.V(2).Infof("TestPersistentVolumeRecycler pvc bound")
 
 	// deleting a claim releases the volume, after which it can be recycled
@@ -152,9 +152,9 @@ func TestPersistentVolumeRecycler(t *testing.T) {
 	}
 	klog.V(2).Infof("TestPersistentVolumeRecycler pvc deleted")
 
-	waitForPersistentVolumePhase(testClient, pv.Name, watchPV, v1.VolumeReleased)
+	waitForPersistentVolumePhase(t, testClient, pv.Name, watchPV, v1.VolumeReleased)
 	klog.V(2).Infof("TestPersistentVolumeRecycler pv released")
-	waitForPersistentVolumePhase(testClient, pv.Name, watchPV, v1.VolumeAvailable)
+	waitForPersistentVolumePhase(t, testClient, pv.Name, watchPV, v1.VolumeAvailable)
 	klog.V(2).Infof("TestPersistentVolumeRecycler pv available")
 }
 
@@ -193,9 +193,9 @@ func TestPersistentVolumeDeleter(t *testing.T) {
 		t.Errorf("Failed to create PersistentVolumeClaim: %v", err)
 	}
 	klog.V(2).Infof("TestPersistentVolumeDeleter pvc created")
-	waitForPersistentVolumePhase(testClient, pv.Name, watchPV, v1.VolumeBound)
+	waitForPersistentVolumePhase(t, testClient, pv.Name, watchPV, v1.VolumeBound)
 	klog.V(2).Infof("TestPersistentVolumeDeleter pv bound")
-	waitForPersistentVolumeClaimPhase(testClient, pvc.Name, ns.Name, watchPVC, v1.ClaimBound)
+	waitForPersistentVolumeClaimPhase(t, testClient, pvc.Name, ns.Name, watchPVC, v1.ClaimBound)
 	klog.V(2).Infof("TestPersistentVolumeDeleter pvc bound")
 
 	// deleting a claim releases the volume, after which it can be recycled
@@ -204,7 +204,7 @@ func TestPersistentVolumeDeleter(t *testing.T) {
 	}
 	klog.V(2).Infof("TestPersistentVolumeDeleter pvc deleted")
 
-	waitForPersistentVolumePhase(testClient, pv.Name, watchPV, v1.VolumeReleased)
+	waitForPersistentVolumePhase(t, testClient, pv.Name, watchPV, v1.VolumeReleased)
 	klog.V(2).Infof("TestPersistentVolumeDeleter pv released")
 
 	for {
@@ -271,9 +271,9 @@ func TestPersistentVolumeBindRace(t *testing.T) {
 	}
 	klog.V(2).Infof("TestPersistentVolumeBindRace pv created, pre-bound to %s", claim.Name)
 
-	waitForPersistentVolumePhase(testClient, pv.Name, watchPV, v1.VolumeBound)
+	waitForPersistentVolumePhase(t, testClient, pv.Name, watchPV, v1.VolumeBound)
 	klog.V(2).Infof("TestPersistentVolumeBindRace pv bound")
-	waitForAnyPersistentVolumeClaimPhase(watchPVC, v1.ClaimBound)
+	waitForAnyPersistentVolumeClaimPhase(t, testClient, watchPVC, v1.ClaimBound)
 	klog.V(2).Infof("TestPersistentVolumeBindRace pvc bound")
 
 	pv, err = testClient.CoreV1().PersistentVolumes().Get(context.TODO(), pv.Name, metav1.GetOptions{})
@@ -344,9 +344,9 @@ func TestPersistentVolumeClaimLabelSelector(t *testing.T) {
 	}
 	t.Log("claim created")
 
-	waitForAnyPersistentVolumePhase(watchPV, v1.VolumeBound)
+	waitForAnyPersistentVolumePhase(t, testClient, watchPV, v1.VolumeBound)
 	t.Log("volume bound")
-	waitForPersistentVolumeClaimPhase(testClient, pvc.Name, ns.Name, watchPVC, v1.ClaimBound)
+	waitForPersistentVolumeClaimPhase(t, testClient, pvc.Name, ns.Name, watchPVC, v1.ClaimBound)
 	t.Log("claim bound")
 
 	pv, err := testClient.CoreV1().PersistentVolumes().Get(context.TODO(), "pv-false", metav1.GetOptions{})
@@ -444,9 +444,9 @@ func TestPersistentVolumeClaimLabelSelectorMatchExpressions(t *testing.T) {
 	}
 	t.Log("claim created")
 
-	waitForAnyPersistentVolumePhase(watchPV, v1.VolumeBound)
+	waitForAnyPersistentVolumePhase(t, testClient, watchPV, v1.VolumeBound)
 	t.Log("volume bound")
-	waitForPersistentVolumeClaimPhase(testClient, pvc.Name, ns.Name, watchPVC, v1.ClaimBound)
+	waitForPersistentVolumeClaimPhase(t, testClient, pvc.Name, ns.Name, watchPVC, v1.ClaimBound)
 	t.Log("claim bound")
 
 	pv, err := testClient.CoreV1().PersistentVolumes().Get(context.TODO(), "pv-false", metav1.GetOptions{})
@@ -505,7 +505,7 @@ func TestPersistentVolumeMultiPVs(t *testing.T) {
 		if err != nil {
 			t.Errorf("Failed to create PersistentVolume %d: %v", i, err)
 		}
-		waitForPersistentVolumePhase(testClient, pvs[i].Name, watchPV, v1.VolumeAvailable)
+		waitForPersistentVolumePhase(t, testClient, pvs[i].Name, watchPV, v1.VolumeAvailable)
 	}
 	t.Log("volumes created")
 
@@ -516,9 +516,9 @@ func TestPersistentVolumeMultiPVs(t *testing.T) {
 	t.Log("claim created")
 
 	// wait until the binder pairs the claim with a volume
-	waitForAnyPersistentVolumePhase(watchPV, v1.VolumeBound)
+	waitForAnyPersistentVolumePhase(t, testClient, watchPV, v1.VolumeBound)
 	t.Log("volume bound")
-	waitForPersistentVolumeClaimPhase(testClient, pvc.Name, ns.Name, watchPVC, v1.ClaimBound)
+	waitForPersistentVolumeClaimPhase(t, testClient, pvc.Name, ns.Name, watchPVC, v1.ClaimBound)
 	t.Log("claim bound")
 
 	// only one PV is bound
@@ -554,7 +554,7 @@ func TestPersistentVolumeMultiPVs(t *testing.T) {
 	}
 	t.Log("claim deleted")
 
-	waitForAnyPersistentVolumePhase(watchPV, v1.VolumeReleased)
+	waitForAnyPersistentVolumePhase(t, testClient, watchPV, v1.VolumeReleased)
 	t.Log("volumes released")
 }
 
@@ -603,7 +603,7 @@ func TestPersistentVolumeMultiPVsPVCs(t *testing.T) {
 	}()
 	// Wait for them to get Available
 	for i := 0; i < objCount; i++ {
-		waitForAnyPersistentVolumePhase(watchPV, v1.VolumeAvailable)
+		waitForAnyPersistentVolumePhase(t, testClient, watchPV, v1.VolumeAvailable)
 		klog.V(1).Infof("%d volumes available", i+1)
 	}
 	klog.V(2).Infof("TestPersistentVolumeMultiPVsPVCs: volumes are Available")
@@ -683,12 +683,12 @@ func TestPersistentVolumeMultiPVsPVCs(t *testing.T) {
 
 	// wait until the binder pairs all claims
 	for i := 0; i < objCount; i++ {
-		waitForAnyPersistentVolumeClaimPhase(watchPVC, v1.ClaimBound)
+		waitForAnyPersistentVolumeClaimPhase(t, testClient, watchPVC, v1.ClaimBound)
 		klog.V(1).Infof("%d claims bound", i+1)
 	}
 	// wait until the binder pairs all volumes
 	for i := 0; i < objCount; i++ {
-		waitForPersistentVolumePhase(testClient, pvs[i].Name, watchPV, v1.VolumeBound)
+		waitForPersistentVolumePhase(t, testClient, pvs[i].Name, watchPV, v1.VolumeBound)
 		klog.V(1).Infof("%d claims bound", i+1)
 	}
 
@@ -760,7 +760,7 @@ func TestPersistentVolumeControllerStartup(t *testing.T) {
 		// Drain watchPVC with all events generated by the PVC until it's bound
 		// We don't want to catch "PVC created with Status.Phase == Pending"
 		// later in this test.
-		waitForAnyPersistentVolumeClaimPhase(watchPVC, v1.ClaimBound)
+		waitForAnyPersistentVolumeClaimPhase(t, testClient, watchPVC, v1.ClaimBound)
 
 		pv := createPV(pvName, "/tmp/foo"+strconv.Itoa(i), "1G",
 			[]v1.PersistentVolumeAccessMode{v1.ReadWriteOnce}, v1.PersistentVolumeReclaimRetain)
@@ -784,7 +784,7 @@ func TestPersistentVolumeControllerStartup(t *testing.T) {
 		// Drain watchPV with all events generated by the PV until it's bound
 		// We don't want to catch "PV created with Status.Phase == Pending"
 		// later in this test.
-		waitForAnyPersistentVolumePhase(watchPV, v1.VolumeBound)
+		waitForAnyPersistentVolumePhase(t, testClient, watchPV, v1.VolumeBound)
 	}
 
 	// Start the controller when all PVs and PVCs are already saved in etcd
@@ -899,7 +899,7 @@ func TestPersistentVolumeProvisionMultiPVCs(t *testing.T) {
 
 	// Wait until the controller provisions and binds all of them
 	for i := 0; i < objCount; i++ {
-		waitForAnyPersistentVolumeClaimPhase(watchPVC, v1.ClaimBound)
+		waitForAnyPersistentVolumeClaimPhase(t, testClient, watchPVC, v1.ClaimBound)
 		klog.V(1).Infof("%d claims bound", i+1)
 	}
 	klog.V(2).Infof("TestPersistentVolumeProvisionMultiPVCs: claims are bound")
@@ -989,9 +989,9 @@ func TestPersistentVolumeMultiPVsDiffAccessModes(t *testing.T) {
 	t.Log("claim created")
 
 	// wait until the controller pairs the volume and claim
-	waitForAnyPersistentVolumePhase(watchPV, v1.VolumeBound)
+	waitForAnyPersistentVolumePhase(t, testClient, watchPV, v1.VolumeBound)
 	t.Log("volume bound")
-	waitForPersistentVolumeClaimPhase(testClient, pvc.Name, ns.Name, watchPVC, v1.ClaimBound)
+	waitForPersistentVolumeClaimPhase(t, testClient, pvc.Name, ns.Name, watchPVC, v1.ClaimBound)
 	t.Log("claim bound")
 
 	// only RWM PV is bound
@@ -1019,78 +1019,41 @@ func TestPersistentVolumeMultiPVsDiffAccessModes(t *testing.T) {
 	}
 	t.Log("claim deleted")
 
-	waitForAnyPersistentVolumePhase(watchPV, v1.VolumeReleased)
+	waitForAnyPersistentVolumePhase(t, testClient, watchPV, v1.VolumeReleased)
 	t.Log("volume released")
 }
 
-func waitForPersistentVolumePhase(client *clientset.Clientset, pvName string, w watch.Interface, phase v1.PersistentVolumePhase) {
-	// Check if the volume is already in requested phase
-	volume, err := client.CoreV1().PersistentVolumes().Get(context.TODO(), pvName, metav1.GetOptions{})
-	if err == nil && volume.Status.Phase == phase {
-		return
-	}
+// phaseWaitTimeout bounds the phase waits below. When it triggers, the
+// framework helpers dump the current PV/PVC state and fail the test instead
+// of hanging until the suite timeout.
+const phaseWaitTimeout = 2 * time.Minute
 
-	// Wait for the phase
-	for {
-		event := <-w.ResultChan()
-		volume, ok := event.Object.(*v1.PersistentVolume)
-		if !ok {
-			continue
-		}
-		if volume.Status.Phase == phase && volume.Name == pvName {
-			klog.V(2).Infof("volume %q is %s", volume.Name, phase)
-			break
-		}
-	}
+func waitForPersistentVolumePhase(t *testing.T, client *clientset.Clientset, pvName string, w watch.Interface, phase v1.PersistentVolumePhase) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), phaseWaitTimeout)
+	defer cancel()
+	framework.WaitForPersistentVolume(ctx, t, client, w, pvName, framework.PVPhase(phase))
 }
 
-func waitForPersistentVolumeClaimPhase(client *clientset.Clientset, claimName, namespace string, w watch.Interface, phase v1.PersistentVolumeClaimPhase) {
-	// Check if the claim is already in requested phase
-	claim, err := client.CoreV1().PersistentVolumeClaims(namespace).Get(context.TODO(), claimName, metav1.GetOptions{})
-	if err == nil && claim.Status.Phase == phase {
-		return
-	}
-
-	// Wait for the phase
-	for {
-		event := <-w.ResultChan()
-		claim, ok := event.Object.(*v1.PersistentVolumeClaim)
-		if !ok {
-			continue
-		}
-		if claim.Status.Phase == phase && claim.Name == claimName {
-			klog.V(2).Infof("claim %q is %s", claim.Name, phase)
-			break
-		}
-	}
+func waitForPersistentVolumeClaimPhase(t *testing.T, client *clientset.Clientset, claimName, namespace string, w watch.Interface, phase v1.PersistentVolumeClaimPhase) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), phaseWaitTimeout)
+	defer cancel()
+	framework.WaitForPersistentVolumeClaim(ctx, t, client, w, namespace, claimName, framework.PVCPhase(phase))
 }
 
-func waitForAnyPersistentVolumePhase(w watch.Interface, phase v1.PersistentVolumePhase) {
-	for {
-		event := <-w.ResultChan()
-		volume, ok := event.Object.(*v1.PersistentVolume)
-		if !ok {
-			continue
-		}
-		if volume.Status.Phase == phase {
-			klog.V(2).Infof("volume %q is %s", volume.Name, phase)
-			break
-		}
-	}
+func waitForAnyPersistentVolumePhase(t *testing.T, client *clientset.Clientset, w watch.Interface, phase v1.PersistentVolumePhase) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), phaseWaitTimeout)
+	defer cancel()
+	framework.WaitForAnyPersistentVolume(ctx, t, client, w, framework.PVPhase(phase))
 }
 
-func waitForAnyPersistentVolumeClaimPhase(w watch.Interface, phase v1.PersistentVolumeClaimPhase) {
-	for {
-		event := <-w.ResultChan()
-		claim, ok := event.Object.(*v1.PersistentVolumeClaim)
-		if !ok {
-			continue
-		}
-		if claim.Status.Phase == phase {
-			klog.V(2).Infof("claim %q is %s", claim.Name, phase)
-			break
-		}
-	}
+func waitForAnyPersistentVolumeClaimPhase(t *testing.T, client *clientset.Clientset, w watch.Interface, phase v1.PersistentVolumeClaimPhase) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), phaseWaitTimeout)
+	defer cancel()
+	framework.WaitForAnyPersistentVolumeClaim(ctx, t, client, w, framework.PVCPhase(phase))
 }
 
 func createClients(ns *v1.Namespace, t *testing.T, s *httptest.Server, syncPeriod time.Duration) (*clientset.Clientset, *persistentvolumecontroller.PersistentVolumeController, informers.SharedInformerFactory, watch.Interface, watch.Interface) {
@@ -1155,27 +1118,9 @@ func createClients(ns *v1.Namespace, t *testing.T, s *httptest.Server, syncPerio
 }
 
 func createPV(name, path, cap string, mode []v1.PersistentVolumeAccessMode, reclaim v1.PersistentVolumeReclaimPolicy) *v1.PersistentVolume {
-	return &v1.PersistentVolume{
-		ObjectMeta: metav1.ObjectMeta{Name: name},
-		Spec: v1.PersistentVolumeSpec{
-			PersistentVolumeSource:        v1.PersistentVolumeSource{HostPath: &v1.HostPathVolumeSource{Path: path}},
-			Capacity:                      v1.ResourceList{v1.ResourceName(v1.ResourceStorage): resource.MustParse(cap)},
-			AccessModes:                   mode,
-			PersistentVolumeReclaimPolicy: reclaim,
-		},
-	}
+	return framework.MakePersistentVolume(name, path, cap, mode, reclaim).Obj()
 }
 
 func createPVC(name, namespace, cap string, mode []v1.PersistentVolumeAccessMode, class string) *v1.PersistentVolumeClaim {
-	return &v1.PersistentVolumeClaim{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      name,
-			Namespace: namespace,
-		},
-		Spec: v1.PersistentVolumeClaimSpec{
-			Resources:        v1.ResourceRequirements{Requests: v1.ResourceList{v1.ResourceName(v1.ResourceStorage): resource.MustParse(cap)}},
-			AccessModes:      mode,
-			StorageClassName: &class,
-		},
-	}
+	return framework.MakePersistentVolumeClaim(name, namespace, cap, mode).WithClass(class).Obj()
 }
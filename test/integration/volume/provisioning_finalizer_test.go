@@ -0,0 +1,107 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package volume
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	storage "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	persistentvolumecontroller "k8s.io/kubernetes/pkg/controller/volume/persistentvolume"
+	"k8s.io/kubernetes/test/integration/framework"
+)
+
+// TestPersistentVolumeProvisioningFinalizer verifies that the provisioning
+// protection finalizer does not linger on a successfully provisioned claim
+// and therefore does not block the claim's deletion.
+func TestPersistentVolumeProvisioningFinalizer(t *testing.T) {
+	_, s, closeFn := framework.RunAnAPIServer(nil)
+	defer closeFn()
+
+	ns := framework.CreateTestingNamespace("pv-provisioning-finalizer", t)
+	defer framework.DeleteTestingNamespace(ns, t)
+
+	testClient, binder, informers, watchPV, watchPVC := createClients(ns, t, s, defaultSyncPeriod)
+	defer watchPV.Stop()
+	defer watchPVC.Stop()
+
+	// NOTE: This test cannot run in parallel, because it is creating and deleting
+	// non-namespaced objects (PersistenceVolumes and StorageClasses).
+	defer testClient.CoreV1().PersistentVolumes().DeleteCollection(context.TODO(), metav1.DeleteOptions{}, metav1.ListOptions{})
+	defer testClient.StorageV1().StorageClasses().DeleteCollection(context.TODO(), metav1.DeleteOptions{}, metav1.ListOptions{})
+
+	storageClass := storage.StorageClass{
+		TypeMeta: metav1.TypeMeta{
+			Kind: "StorageClass",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "gold",
+		},
+		Provisioner: provisionerPluginName,
+	}
+	testClient.StorageV1().StorageClasses().Create(context.TODO(), &storageClass, metav1.CreateOptions{})
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	informers.Start(ctx.Done())
+	go binder.Run(ctx)
+	defer cancel()
+
+	pvc := createPVC("pvc-provision-finalizer", ns.Name, "1G", []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce}, "gold")
+	if _, err := testClient.CoreV1().PersistentVolumeClaims(ns.Name).Create(context.TODO(), pvc, metav1.CreateOptions{}); err != nil {
+		t.Errorf("Failed to create PersistentVolumeClaim: %v", err)
+	}
+
+	waitForPersistentVolumeClaimPhase(t, testClient, pvc.Name, ns.Name, watchPVC, v1.ClaimBound)
+
+	// The finalizer is removed after the PV object is saved, which may be a
+	// moment after the claim turns Bound.
+	err := wait.Poll(100*time.Millisecond, wait.ForeverTestTimeout, func() (bool, error) {
+		claim, err := testClient.CoreV1().PersistentVolumeClaims(ns.Name).Get(context.TODO(), pvc.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		for _, finalizer := range claim.Finalizers {
+			if finalizer == persistentvolumecontroller.ProvisioningProtectionFinalizer {
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+	if err != nil {
+		t.Errorf("expected finalizer %q to be removed from the bound claim: %v", persistentvolumecontroller.ProvisioningProtectionFinalizer, err)
+	}
+
+	// The claim must be deletable without manual finalizer surgery.
+	if err := testClient.CoreV1().PersistentVolumeClaims(ns.Name).Delete(context.TODO(), pvc.Name, metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Failed to delete PersistentVolumeClaim: %v", err)
+	}
+	err = wait.Poll(100*time.Millisecond, wait.ForeverTestTimeout, func() (bool, error) {
+		_, err := testClient.CoreV1().PersistentVolumeClaims(ns.Name).Get(context.TODO(), pvc.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	})
+	if err != nil {
+		t.Errorf("expected the claim to be deleted: %v", err)
+	}
+}
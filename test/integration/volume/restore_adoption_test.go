@@ -0,0 +1,92 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package volume
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	persistentvolumecontroller "k8s.io/kubernetes/pkg/controller/volume/persistentvolume"
+	"k8s.io/kubernetes/test/integration/framework"
+)
+
+// TestPersistentVolumeRestoreAdoption verifies that a claim carrying a
+// restore ID annotation is bound to the out-of-band volume labeled with
+// the same restore ID, even though the claim requests a storage class the
+// restored volume does not have.
+func TestPersistentVolumeRestoreAdoption(t *testing.T) {
+	_, s, closeFn := framework.RunAnAPIServer(nil)
+	defer closeFn()
+
+	ns := framework.CreateTestingNamespace("pv-restore-adoption", t)
+	defer framework.DeleteTestingNamespace(ns, t)
+
+	testClient, ctrl, informers, watchPV, watchPVC := createClients(ns, t, s, defaultSyncPeriod)
+	defer watchPV.Stop()
+	defer watchPVC.Stop()
+
+	// NOTE: This test cannot run in parallel, because it is creating and deleting
+	// non-namespaced objects (PersistenceVolumes).
+	defer testClient.CoreV1().PersistentVolumes().DeleteCollection(context.TODO(), metav1.DeleteOptions{}, metav1.ListOptions{})
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	informers.Start(ctx.Done())
+	go ctrl.Run(ctx)
+	defer cancel()
+
+	// Two restored volumes from different restore runs; only the one
+	// carrying the claim's restore ID may be adopted.
+	pvRestored := createPV("pv-restored", "/tmp/restored", "10G", []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce}, v1.PersistentVolumeReclaimRetain)
+	pvRestored.Labels = map[string]string{persistentvolumecontroller.LabelRestoreID: "restore-1"}
+	pvOther := createPV("pv-other-restore", "/tmp/other", "10G", []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce}, v1.PersistentVolumeReclaimRetain)
+	pvOther.Labels = map[string]string{persistentvolumecontroller.LabelRestoreID: "restore-2"}
+
+	// The class does not exist; without adoption the claim would wait for
+	// provisioning forever.
+	pvc := createPVC("pvc-restored", ns.Name, "5G", []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce}, "restored-class")
+	pvc.Annotations = map[string]string{persistentvolumecontroller.AnnRestoreID: "restore-1"}
+
+	for _, pv := range []*v1.PersistentVolume{pvRestored, pvOther} {
+		if _, err := testClient.CoreV1().PersistentVolumes().Create(context.TODO(), pv, metav1.CreateOptions{}); err != nil {
+			t.Errorf("Failed to create PersistentVolume: %v", err)
+		}
+	}
+	if _, err := testClient.CoreV1().PersistentVolumeClaims(ns.Name).Create(context.TODO(), pvc, metav1.CreateOptions{}); err != nil {
+		t.Errorf("Failed to create PersistentVolumeClaim: %v", err)
+	}
+
+	waitForPersistentVolumePhase(t, testClient, pvRestored.Name, watchPV, v1.VolumeBound)
+	waitForPersistentVolumeClaimPhase(t, testClient, pvc.Name, ns.Name, watchPVC, v1.ClaimBound)
+
+	claim, err := testClient.CoreV1().PersistentVolumeClaims(ns.Name).Get(context.TODO(), pvc.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error getting pvc: %v", err)
+	}
+	if claim.Spec.VolumeName != pvRestored.Name {
+		t.Errorf("expected the claim to adopt %q, got %q", pvRestored.Name, claim.Spec.VolumeName)
+	}
+
+	otherPV, err := testClient.CoreV1().PersistentVolumes().Get(context.TODO(), pvOther.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error getting pv: %v", err)
+	}
+	if otherPV.Status.Phase != v1.VolumeAvailable {
+		t.Errorf("expected the other restore's volume to stay Available, got %v", otherPV.Status.Phase)
+	}
+}
@@ -0,0 +1,184 @@
+#!/usr/bin/env bash
+
+# Copyright 2022 The Kubernetes Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# Script that runs a storage churn load profile against a running Kubemark
+# cluster: waves of StatefulSets with volumeClaimTemplates are created, waited
+# for and deleted, and the pv binder throughput and pod startup (volume
+# attach/mount) latency are measured against configurable SLOs.
+#
+# The kubemark master must run the kube-controller-manager with
+# --enable-hostpath-provisioner so that the claims can be dynamically
+# provisioned, and hollow nodes should be started with volume latencies
+# (e.g. HOLLOW_KUBELET_TEST_ARGS="--volume-attach-latency=10s
+# --volume-mount-latency=1s") for realistic attach/mount timing.
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+KUBE_ROOT=$(dirname "${BASH_SOURCE[0]}")/../..
+
+RESOURCE_DIRECTORY="${KUBE_ROOT}/test/kubemark/resources"
+export KUBECONFIG="${KUBECONFIG:-${RESOURCE_DIRECTORY}/kubeconfig.kubemark}"
+
+# Shape of the load.
+STATEFULSETS="${STORAGE_CHURN_STATEFULSETS:-50}"
+REPLICAS="${STORAGE_CHURN_REPLICAS:-20}"
+WAVES="${STORAGE_CHURN_WAVES:-3}"
+STORAGE_CLASS="${STORAGE_CHURN_STORAGE_CLASS:-storage-churn}"
+NAMESPACE="${STORAGE_CHURN_NAMESPACE:-storage-churn}"
+WAVE_TIMEOUT="${STORAGE_CHURN_WAVE_TIMEOUT:-1800}"
+
+# SLOs. The run fails if the pv binder binds fewer claims per second than
+# MIN_BINDER_THROUGHPUT or if the 99th percentile of pod startup latency
+# (which includes the emulated attach and mount delays) exceeds
+# MAX_POD_STARTUP_SECONDS.
+MIN_BINDER_THROUGHPUT="${STORAGE_CHURN_MIN_BINDER_THROUGHPUT:-5}"
+MAX_POD_STARTUP_SECONDS="${STORAGE_CHURN_MAX_POD_STARTUP_SECONDS:-120}"
+
+CLAIMS_PER_WAVE=$((STATEFULSETS * REPLICAS))
+
+function retry() {
+  for attempt in $(seq 1 3); do
+    if "$@"; then
+      return 0
+    fi
+    echo "== Failed to run '$*' (attempt ${attempt}), retrying =="
+    sleep 5
+  done
+  "$@"
+}
+
+function setup() {
+  retry kubectl create namespace "${NAMESPACE}"
+  kubectl apply -f - <<EOF
+apiVersion: storage.k8s.io/v1
+kind: StorageClass
+metadata:
+  name: ${STORAGE_CLASS}
+provisioner: kubernetes.io/host-path
+EOF
+}
+
+function teardown() {
+  kubectl delete namespace "${NAMESPACE}" --ignore-not-found=true --wait=false
+  kubectl delete storageclass "${STORAGE_CLASS}" --ignore-not-found=true
+}
+
+function create-statefulsets() {
+  for i in $(seq 1 "${STATEFULSETS}"); do
+    sed -e "s/{{name}}/churn-${i}/g" \
+        -e "s/{{replicas}}/${REPLICAS}/g" \
+        -e "s/{{storage_class}}/${STORAGE_CLASS}/g" \
+      "${RESOURCE_DIRECTORY}/storage-churn_template.yaml" | \
+      kubectl apply --namespace "${NAMESPACE}" -f - > /dev/null
+  done
+}
+
+function delete-statefulsets() {
+  kubectl delete statefulsets --namespace "${NAMESPACE}" -l app=storage-churn --wait=false > /dev/null
+  kubectl delete pvc --namespace "${NAMESPACE}" --all --wait=false > /dev/null
+  # Wait for the pods to go away so the next wave starts from a clean slate.
+  local deadline=$(($(date +%s) + WAVE_TIMEOUT))
+  while [[ "$(kubectl get pods --namespace "${NAMESPACE}" -l app=storage-churn --no-headers 2> /dev/null | wc -l)" -gt 0 ]]; do
+    if [[ "$(date +%s)" -ge "${deadline}" ]]; then
+      echo "== Timed out waiting for churn pods to be deleted =="
+      exit 1
+    fi
+    sleep 5
+  done
+}
+
+# Waits until the given number of objects of the given kind are in the given
+# state, where the state is checked by counting the lines of `kubectl get`
+# output matching the given pattern.
+function wait-for-count() {
+  local kind="$1" pattern="$2" want="$3"
+  local deadline=$(($(date +%s) + WAVE_TIMEOUT))
+  local got=0
+  while true; do
+    got="$(kubectl get "${kind}" --namespace "${NAMESPACE}" --no-headers 2> /dev/null | grep -c "${pattern}" || true)"
+    if [[ "${got}" -ge "${want}" ]]; then
+      return 0
+    fi
+    if [[ "$(date +%s)" -ge "${deadline}" ]]; then
+      echo "== Timed out waiting for ${want} ${kind} matching '${pattern}', got ${got} =="
+      exit 1
+    fi
+    sleep 5
+  done
+}
+
+# Prints the given percentile of pod startup latency in seconds, computed as
+# the delay between pod creation and the PodScheduled->Ready transition, which
+# on hollow nodes is dominated by the emulated volume attach/mount latencies.
+function pod-startup-percentile() {
+  local percentile="$1"
+  kubectl get pods --namespace "${NAMESPACE}" -l app=storage-churn \
+      -o jsonpath='{range .items[*]}{.metadata.creationTimestamp} {.status.conditions[?(@.type=="Ready")].lastTransitionTime}{"\n"}{end}' | \
+    awk '{ cmd = "date -d " $1 " +%s"; cmd | getline created; close(cmd);
+           cmd = "date -d " $2 " +%s"; cmd | getline ready; close(cmd);
+           print ready - created }' | \
+    sort -n | \
+    awk -v p="${percentile}" '{ v[NR] = $1 } END { print v[int(NR * p / 100 + 0.5)] }'
+}
+
+function run-wave() {
+  local wave="$1"
+  echo "== Wave ${wave}: creating ${STATEFULSETS} statefulsets with ${CLAIMS_PER_WAVE} claims =="
+  local start
+  start="$(date +%s)"
+  create-statefulsets
+  wait-for-count pvc "Bound" "${CLAIMS_PER_WAVE}"
+  local bound
+  bound="$(date +%s)"
+  wait-for-count pods "Running" "${CLAIMS_PER_WAVE}"
+
+  local elapsed=$((bound - start))
+  if [[ "${elapsed}" -lt 1 ]]; then
+    elapsed=1
+  fi
+  local throughput=$((CLAIMS_PER_WAVE / elapsed))
+  local p50 p99
+  p50="$(pod-startup-percentile 50)"
+  p99="$(pod-startup-percentile 99)"
+  echo "== Wave ${wave}: ${CLAIMS_PER_WAVE} claims bound in ${elapsed}s (${throughput} claims/s), pod startup p50=${p50}s p99=${p99}s =="
+
+  if [[ "${throughput}" -lt "${MIN_BINDER_THROUGHPUT}" ]]; then
+    echo "== Wave ${wave}: FAILED pv binder throughput SLO: ${throughput} < ${MIN_BINDER_THROUGHPUT} claims/s =="
+    FAILED=1
+  fi
+  if [[ "${p99}" -gt "${MAX_POD_STARTUP_SECONDS}" ]]; then
+    echo "== Wave ${wave}: FAILED pod startup latency SLO: p99 ${p99}s > ${MAX_POD_STARTUP_SECONDS}s =="
+    FAILED=1
+  fi
+
+  echo "== Wave ${wave}: deleting statefulsets =="
+  delete-statefulsets
+}
+
+FAILED=0
+trap teardown EXIT
+setup
+for wave in $(seq 1 "${WAVES}"); do
+  run-wave "${wave}"
+done
+
+if [[ "${FAILED}" != 0 ]]; then
+  echo "== Storage churn: SLO violations detected =="
+  exit 1
+fi
+echo "== Storage churn: all SLOs met =="